@@ -5,10 +5,17 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/config"
+	"github.com/hashicorp/tfci/internal/logging"
+	"github.com/hashicorp/tfci/internal/notify"
 	"github.com/hashicorp/tfci/internal/writer"
 	"github.com/hashicorp/tfci/version"
 
@@ -17,19 +24,93 @@ import (
 )
 
 var (
-	hostnameFlag     = flag.String("hostname", "", "The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to HCP Terraform (app.terraform.io)")
-	tokenFlag        = flag.String("token", "", "The token used to authenticate with HCP Terraform. Defaults to reading `TF_API_TOKEN` environment variable")
-	organizationFlag = flag.String("organization", "", "HCP Terraform Organization Name")
+	hostnameFlag      = flag.String("hostname", "", "The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to HCP Terraform (app.terraform.io)")
+	tokenFlag         = flag.String("token", "", "The token used to authenticate with HCP Terraform. Defaults to reading `TF_API_TOKEN` environment variable")
+	organizationFlag  = flag.String("organization", "", "HCP Terraform Organization Name")
+	outputFileFlag    = flag.String("output-file", "", "Writes the final result of the command to the provided file path, in addition to stdout and platform output")
+	outputFormatFlag  = flag.String("output-format", "json", "Format of the result written to --output-file: json or yaml")
+	artifactsDirFlag  = flag.String("artifacts-dir", "", "Directory to write full payloads to as separate files, such as run.json, plan.json and plan.log, instead of including them with platform output")
+	logFileFlag       = flag.String("log-file", "", "Writes the plan logs streamed while monitoring a run to the provided file path, in addition to stdout")
+	applyLogFileFlag  = flag.String("apply-log-file", "", "Writes the apply logs streamed while monitoring a run to the provided file path, in addition to stdout")
+	formatFlag        = flag.String("format", "json", "Format of the result written to stdout: json, yaml or table")
+	columnsFlag       = flag.String("columns", "", "Comma separated columns of list results rendered with --format=table, ex: name,status")
+	formatTmplFlag    = flag.String("format-template", "", "Go template to render the result written to stdout instead of json, ex: '{{ .run_id }}'. Prefix with @ to read the template from a file")
+	printOutputFlag   = flag.Bool("print-platform-output", false, "Prints what would be written to the CI platform output instead of writing it")
+	notifySlackFlag   = flag.String("notify-slack-webhook", "", "Slack incoming webhook URL to post a message to on command completion. Defaults to reading `TF_NOTIFY_SLACK_WEBHOOK` environment variable")
+	notifyURLFlag     = flag.String("notify-webhook-url", "", "URL to POST a JSON notification to on command completion. Defaults to reading `TF_NOTIFY_WEBHOOK_URL` environment variable")
+	oidcFlag          = flag.Bool("oidc", false, "Authenticate using the CI platform's workload identity (OIDC) token instead of an API token")
+	oidcAudienceFlag  = flag.String("oidc-audience", "", "Audience requested for the workload identity token. Defaults to the hostname")
+	oidcExchangeFlag  = flag.String("oidc-exchange-url", "", "Token exchange endpoint that returns an API token for the workload identity token. Defaults to reading `TF_OIDC_EXCHANGE_URL` environment variable")
+	proxyURLFlag      = flag.String("proxy-url", "", "Proxy URL used for requests to HCP Terraform, in addition to the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables")
+	maxRetriesFlag    = flag.Int("max-retries", 30, "Maximum number of retries for requests to HCP Terraform that result in a server error")
+	retryInitialFlag  = flag.Duration("retry-initial-backoff", 0, "Initial wait between request retries and status polling, ex: 500ms, 2s")
+	retryMaxFlag      = flag.Duration("retry-max-backoff", 0, "Maximum wait between request retries and status polling, ex: 5s, 30s")
+	pollIntervalFlag  = flag.Duration("poll-interval", 0, "Fixed interval to poll for status changes, instead of backing off, ex: 10s")
+	logTimeoutFlag    = flag.Duration("log-timeout", 0, "Maximum time to read plan and apply logs, ex: 30m. Defaults to `TF_MAX_TIMEOUT`")
+	stateTimeoutFlag  = flag.Duration("state-processing-timeout", 0, "Maximum time to wait for the current state version to be processed before reading workspace outputs, ex: 15m. Defaults to 5m")
+	backoffJitterFlag = flag.Uint64("backoff-jitter", 20, "Randomizes each wait between status polling attempts by +/- the percentage, so parallel jobs do not poll in lockstep. 0 disables jitter")
+	pollProfileFlag   = flag.String("poll-profile", "default", "Preset wait between status polling attempts and maximum timeout: fast, default or relaxed")
+	strictFlag        = flag.Bool("strict", false, "Fails the command on soft failures that are otherwise reported as warnings, such as failing to read logs")
+	pageSizeFlag      = flag.Int("page-size", 100, "Number of items requested per page when listing resources, between 1 and 100")
+	profileFlag       = flag.String("profile", "", "Named profile from the config file or `TFCI_PROFILE_<NAME>_*` environment variables, setting the hostname, organization and token. Defaults to reading `TFCI_PROFILE` environment variable")
+	exitCodeModeFlag  = flag.String("exit-code-mode", "simple", "Exit codes returned on failure: simple, detailed or strict")
+	configFlag        = flag.String("config", "", "Path to a tfci.yaml file with default options. Defaults to reading `TFCI_CONFIG` environment variable, then tfci.yaml in the working directory")
+	logFormatFlag     = flag.String("log-format", "", "Format of diagnostic logs enabled with `TF_LOG`: text or json. Defaults to reading `TF_LOG_FORMAT` environment variable")
+	eventURLFlag      = flag.String("event-webhook-url", "", "URL to POST NDJSON run lifecycle events to while monitoring. Defaults to reading `TF_EVENT_WEBHOOK_URL` environment variable")
+	recordFlag        = flag.String("record", "", "Writes the API requests and responses of the command to the provided file path, with tokens and sensitive values redacted, to attach to bug reports")
+	replayFlag        = flag.String("replay", "", "Runs the command against the API responses of a session written with --record instead of HCP Terraform")
+	mockServerFlag    = flag.String("mock-server", "", "Directory of fixtures to serve canned API responses from instead of HCP Terraform, for testing pipelines. Defaults to reading `TFCI_MOCK_SERVER` environment variable")
+	// multiple patterns can be provided with repeated flags
+	redactPatternFlags []string
+	// hidden, for diagnosing slow commands
+	debugProfileFlag = flag.Bool("debug-profile", false, "Writes CPU and heap profiles and API call timings to the runner temp directory")
 )
 
+// started with --debug-profile, stopped once the command completes
+var profile *debugProfile
+
+// started with --record, saved once the command completes
+var recorder *cloud.Recorder
+
+func init() {
+	flag.Func("redact-pattern", "Regular expression matching values to redact from streamed plan and apply logs, in addition to run variable values. Can be repeated", func(pattern string) error {
+		redactPatternFlags = append(redactPatternFlags, pattern)
+		return nil
+	})
+}
+
 func newCliRunner() (*cli.CLI, error) {
 	args := os.Args[1:]
 	log.Printf("[DEBUG] Command argument count: %d", len(args))
 
-	err := flag.CommandLine.Parse(args)
+	err := flag.CommandLine.Parse(hoistGlobalFlags(flag.CommandLine, args))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(*configFlag)
 	if err != nil {
 		return nil, err
 	}
+	if err := applyConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	if *logFormatFlag != "" {
+		if err := logging.SetupLogger(&logging.LoggerOptions{
+			PlatformType: env.PlatformType,
+			Format:       *logFormatFlag,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if *debugProfileFlag {
+		profile, err = startDebugProfile()
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	newArgs := flag.CommandLine.Args()
 
@@ -42,15 +123,116 @@ func newCliRunner() (*cli.CLI, error) {
 	if *organizationFlag == "" && orgEnv != "" {
 		*organizationFlag = orgEnv
 	}
+	if *notifySlackFlag == "" {
+		*notifySlackFlag = os.Getenv("TF_NOTIFY_SLACK_WEBHOOK")
+	}
+	if *notifyURLFlag == "" {
+		*notifyURLFlag = os.Getenv("TF_NOTIFY_WEBHOOK_URL")
+	}
+	if *eventURLFlag == "" {
+		*eventURLFlag = os.Getenv("TF_EVENT_WEBHOOK_URL")
+	}
+	if *mockServerFlag == "" {
+		*mockServerFlag = os.Getenv("TFCI_MOCK_SERVER")
+	}
 	log.Printf("[DEBUG] Subcommand arg count: %d for organization: %s", len(newArgs), orgEnv)
 
-	tfe, err := cloud.NewTfeClient(*hostnameFlag, *tokenFlag, string(env.PlatformType))
+	if err := cloud.ConfigureRetry(&cloud.RetryOptions{
+		MaxRetries:             *maxRetriesFlag,
+		InitialBackoff:         *retryInitialFlag,
+		MaxBackoff:             *retryMaxFlag,
+		PollInterval:           *pollIntervalFlag,
+		LogTimeout:             *logTimeoutFlag,
+		StateProcessingTimeout: *stateTimeoutFlag,
+		BackoffJitter:          *backoffJitterFlag,
+		PollProfile:            *pollProfileFlag,
+	}); err != nil {
+		return nil, err
+	}
+	if err := cloud.ConfigurePageSize(*pageSizeFlag); err != nil {
+		return nil, err
+	}
+
+	redactor, err := logging.NewRedactor(redactPatternFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimits := cloud.NewRateLimitTracker()
+	clientOpts := &cloud.ClientOptions{
+		Hostname:   *hostnameFlag,
+		Token:      *tokenFlag,
+		Platform:   string(env.PlatformType),
+		ProxyURL:   *proxyURLFlag,
+		RateLimits: rateLimits,
+		// offline testing, no credentials are required
+		MockFixtures: *mockServerFlag,
+		Replay:       *replayFlag,
+		Credentials:  &cloud.Credentials{},
+	}
+	if *recordFlag != "" {
+		recorder = cloud.NewRecorder(redactor)
+		clientOpts.Recorder = recorder
+	}
+	if profile != nil {
+		clientOpts.Timings = profile.timings
+	}
+	if *oidcFlag && *mockServerFlag == "" && *replayFlag == "" {
+		if *oidcExchangeFlag == "" {
+			*oidcExchangeFlag = os.Getenv("TF_OIDC_EXCHANGE_URL")
+		}
+		clientOpts.OIDC = &cloud.OIDCOptions{
+			Audience:    *oidcAudienceFlag,
+			ExchangeURL: *oidcExchangeFlag,
+		}
+	}
+
+	tfe, err := cloud.NewTfeClient(clientOpts)
 	if err != nil {
 		log.Printf("[ERROR] Could not initialize HCP Terraform client, error: %#v", err)
 		return nil, err
 	}
 
+	outputFormat, err := cmd.ParseOutputFormat(*outputFormatFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := cmd.ParseFormat(*formatFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	formatTemplate, err := cmd.ParseFormatTemplate(*formatTmplFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	exitCodeMode, err := cmd.ParseExitCodeMode(*exitCodeModeFlag)
+	if err != nil {
+		return nil, err
+	}
+
 	cloudService := cloud.NewCloud(tfe, writer)
+	cloudService.UseRateLimits(rateLimits)
+	if *eventURLFlag != "" {
+		cloudService.UseEvents(notify.NewEventWebhook(*eventURLFlag))
+	}
+	if *artifactsDirFlag != "" {
+		if err := os.MkdirAll(*artifactsDirFlag, 0755); err != nil {
+			return nil, fmt.Errorf("error creating artifacts directory: %w", err)
+		}
+		cloudService.UseLogFile(cloud.PlanLog, filepath.Join(*artifactsDirFlag, "plan.log"))
+		cloudService.UseLogFile(cloud.ApplyLog, filepath.Join(*artifactsDirFlag, "apply.log"))
+	}
+	cloudService.UseRedactor(redactor)
+	// explicit log files take precedence over the artifacts directory
+	if *logFileFlag != "" {
+		cloudService.UseLogFile(cloud.PlanLog, *logFileFlag)
+	}
+	if *applyLogFileFlag != "" {
+		cloudService.UseLogFile(cloud.ApplyLog, *applyLogFileFlag)
+	}
 
 	meta := cmd.NewMetaOpts(
 		appCtx,
@@ -58,6 +240,18 @@ func newCliRunner() (*cli.CLI, error) {
 		env,
 		cmd.WithOrg(*organizationFlag),
 		cmd.WithWriter(writer),
+		cmd.WithConfig(cfg),
+		cmd.WithExitCodeMode(exitCodeMode),
+		cmd.WithStrict(*strictFlag),
+		cmd.WithOutputFile(*outputFileFlag, outputFormat),
+		cmd.WithArtifactsDir(*artifactsDirFlag),
+		cmd.WithFormat(format, parseColumns(*columnsFlag)),
+		cmd.WithFormatTemplate(formatTemplate),
+		cmd.WithPrintPlatformOutput(*printOutputFlag),
+		cmd.WithNotifiers(notify.NewNotifiers(notify.Options{
+			SlackWebhook: *notifySlackFlag,
+			WebhookURL:   *notifyURLFlag,
+		})),
 	)
 
 	cliRunner.Commands = map[string]cli.CommandFactory{
@@ -67,6 +261,9 @@ func newCliRunner() (*cli.CLI, error) {
 		"run create": func() (cli.Command, error) {
 			return &cmd.CreateRunCommand{Meta: meta}, nil
 		},
+		"run create-batch": func() (cli.Command, error) {
+			return &cmd.CreateBatchRunCommand{Meta: meta}, nil
+		},
 		"run apply": func() (cli.Command, error) {
 			return &cmd.ApplyRunCommand{Meta: meta}, nil
 		},
@@ -76,16 +273,291 @@ func newCliRunner() (*cli.CLI, error) {
 		"run discard": func() (cli.Command, error) {
 			return &cmd.DiscardRunCommand{Meta: meta}, nil
 		},
+		"run watch": func() (cli.Command, error) {
+			return &cmd.WatchRunCommand{Meta: meta}, nil
+		},
+		"run comment add": func() (cli.Command, error) {
+			return &cmd.RunCommentAddCommand{Meta: meta}, nil
+		},
+		"run comment list": func() (cli.Command, error) {
+			return &cmd.RunCommentListCommand{Meta: meta}, nil
+		},
+		"agentpool list": func() (cli.Command, error) {
+			return &cmd.AgentPoolListCommand{Meta: meta}, nil
+		},
+		"agentpool create": func() (cli.Command, error) {
+			return &cmd.AgentPoolCreateCommand{Meta: meta}, nil
+		},
+		"agent token create": func() (cli.Command, error) {
+			return &cmd.AgentTokenCreateCommand{Meta: meta}, nil
+		},
+		"agent token revoke": func() (cli.Command, error) {
+			return &cmd.AgentTokenRevokeCommand{Meta: meta}, nil
+		},
+		"team list": func() (cli.Command, error) {
+			return &cmd.TeamListCommand{Meta: meta}, nil
+		},
+		"team create": func() (cli.Command, error) {
+			return &cmd.TeamCreateCommand{Meta: meta}, nil
+		},
+		"team access add": func() (cli.Command, error) {
+			return &cmd.TeamAccessAddCommand{Meta: meta}, nil
+		},
+		"team token regenerate": func() (cli.Command, error) {
+			return &cmd.TeamTokenRegenerateCommand{Meta: meta}, nil
+		},
+		"explorer query": func() (cli.Command, error) {
+			return &cmd.ExplorerQueryCommand{Meta: meta}, nil
+		},
+		"audit export": func() (cli.Command, error) {
+			return &cmd.AuditExportCommand{Meta: meta}, nil
+		},
+		"module list": func() (cli.Command, error) {
+			return &cmd.ModuleListCommand{Meta: meta}, nil
+		},
+		"module show": func() (cli.Command, error) {
+			return &cmd.ModuleShowCommand{Meta: meta}, nil
+		},
+		"module publish": func() (cli.Command, error) {
+			return &cmd.ModulePublishCommand{Meta: meta}, nil
+		},
+		"cost show": func() (cli.Command, error) {
+			return &cmd.CostShowCommand{Meta: meta}, nil
+		},
+		"policy compare": func() (cli.Command, error) {
+			return &cmd.PolicyCompareCommand{Meta: meta}, nil
+		},
+		"policy show": func() (cli.Command, error) {
+			return &cmd.PolicyShowCommand{Meta: meta}, nil
+		},
+		"policy override": func() (cli.Command, error) {
+			return &cmd.PolicyOverrideCommand{Meta: meta}, nil
+		},
+		"runtask list": func() (cli.Command, error) {
+			return &cmd.RunTaskListCommand{Meta: meta}, nil
+		},
+		"runtask create": func() (cli.Command, error) {
+			return &cmd.RunTaskCreateCommand{Meta: meta}, nil
+		},
+		"runtask attach": func() (cli.Command, error) {
+			return &cmd.RunTaskAttachCommand{Meta: meta}, nil
+		},
+		"runtask serve": func() (cli.Command, error) {
+			return &cmd.RunTaskServeCommand{Meta: meta}, nil
+		},
+		"taskstage show": func() (cli.Command, error) {
+			return &cmd.TaskStageShowCommand{Meta: meta}, nil
+		},
+		"run report": func() (cli.Command, error) {
+			return &cmd.RunReportCommand{Meta: meta}, nil
+		},
 		"run cancel": func() (cli.Command, error) {
 			return &cmd.CancelRunCommand{Meta: meta}, nil
 		},
+		"pipeline run": func() (cli.Command, error) {
+			return &cmd.PipelineRunCommand{Meta: meta}, nil
+		},
 		"plan output": func() (cli.Command, error) {
 			return &cmd.OutputPlanCommand{Meta: meta}, nil
 		},
 		"workspace output list": func() (cli.Command, error) {
 			return &cmd.WorkspaceOutputCommand{Meta: meta}, nil
 		},
+		"workspace state push": func() (cli.Command, error) {
+			return &cmd.WorkspaceStatePushCommand{Meta: meta}, nil
+		},
+		"workspace resources list": func() (cli.Command, error) {
+			return &cmd.WorkspaceResourceListCommand{Meta: meta}, nil
+		},
+		"workspace set-version": func() (cli.Command, error) {
+			return &cmd.WorkspaceSetVersionCommand{Meta: meta}, nil
+		},
+		"terraform-versions list": func() (cli.Command, error) {
+			return &cmd.TerraformVersionListCommand{Meta: meta}, nil
+		},
+		"tagbinding list": func() (cli.Command, error) {
+			return &cmd.TagBindingListCommand{Meta: meta}, nil
+		},
+		"tagbinding set": func() (cli.Command, error) {
+			return &cmd.TagBindingSetCommand{Meta: meta}, nil
+		},
+		"admin user list": func() (cli.Command, error) {
+			return &cmd.AdminUserListCommand{Meta: meta}, nil
+		},
+		"admin user suspend": func() (cli.Command, error) {
+			return &cmd.AdminUserSuspendCommand{Meta: meta}, nil
+		},
+		"admin user unsuspend": func() (cli.Command, error) {
+			return &cmd.AdminUserUnsuspendCommand{Meta: meta}, nil
+		},
+		"admin organization list": func() (cli.Command, error) {
+			return &cmd.AdminOrganizationListCommand{Meta: meta}, nil
+		},
+		"admin terraform-version list": func() (cli.Command, error) {
+			return &cmd.AdminTerraformVersionListCommand{Meta: meta}, nil
+		},
+		"admin terraform-version update": func() (cli.Command, error) {
+			return &cmd.AdminTerraformVersionUpdateCommand{Meta: meta}, nil
+		},
+		"reconcile": func() (cli.Command, error) {
+			return &cmd.ReconcileCommand{Meta: meta}, nil
+		},
+		"detect-changes": func() (cli.Command, error) {
+			return &cmd.DetectChangesCommand{Meta: meta}, nil
+		},
+		"whoami": func() (cli.Command, error) {
+			return &cmd.WhoamiCommand{Meta: meta}, nil
+		},
+		"validate": func() (cli.Command, error) {
+			return &cmd.ValidateCommand{Meta: meta}, nil
+		},
+		"organization list": func() (cli.Command, error) {
+			return &cmd.OrganizationListCommand{Meta: meta}, nil
+		},
+		"organization show": func() (cli.Command, error) {
+			return &cmd.OrganizationShowCommand{Meta: meta}, nil
+		},
+		"organization queue": func() (cli.Command, error) {
+			return &cmd.OrganizationQueueCommand{Meta: meta}, nil
+		},
+	}
+
+	for name, factory := range cliRunner.Commands {
+		cliRunner.Commands[name] = cmd.WithExitCodes(meta, factory)
+	}
+
+	// tfci-<name> executables on PATH extend the available commands, built-in commands take precedence
+	for name, path := range cmd.DiscoverPlugins(os.Getenv("PATH")) {
+		if isBuiltinCommand(cliRunner.Commands, name) {
+			log.Printf("[DEBUG] plugin %s is shadowed by a built-in command", path)
+			continue
+		}
+		cliRunner.Commands[name] = func() (cli.Command, error) {
+			return &cmd.PluginCommand{Meta: meta, Name: name, Path: path, Credentials: clientOpts.Credentials}, nil
+		}
 	}
 
 	return cliRunner, nil
 }
+
+// options of subcommands that share the name of a global option, these are parsed by the subcommand
+var subcommandFlags = map[string][]string{
+	"audit export":   {"format"},
+	"explorer query": {"format"},
+}
+
+// moves global options provided after the subcommand before it, so global options can be provided anywhere.
+// arguments after "--" are left for the subcommand
+func hoistGlobalFlags(global *flag.FlagSet, args []string) []string {
+	hoisted := []string{}
+	rest := []string{}
+	command := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			// the subcommand is the words before its first option
+			if len(rest) == len(command) {
+				command = append(command, arg)
+			}
+			rest = append(rest, arg)
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		f := global.Lookup(name)
+		if f == nil || slices.Contains(subcommandFlags[strings.Join(command, " ")], name) {
+			rest = append(rest, arg)
+			continue
+		}
+		hoisted = append(hoisted, arg)
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); hasValue || (ok && boolFlag.IsBoolFlag()) {
+			continue
+		}
+		if i+1 < len(args) {
+			i++
+			hoisted = append(hoisted, args[i])
+		}
+	}
+	return append(hoisted, rest...)
+}
+
+func parseColumns(value string) []string {
+	columns := []string{}
+	for _, column := range strings.Split(value, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// plugins cannot replace a command or the parent of nested commands, such as run
+func isBuiltinCommand(commands map[string]cli.CommandFactory, name string) bool {
+	for command := range commands {
+		if command == name || strings.HasPrefix(command, name+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// environment variables read by global options, these take precedence over the config file
+var globalFlagEnv = map[string]string{
+	"hostname":             "TF_HOSTNAME",
+	"token":                "TF_API_TOKEN",
+	"organization":         "TF_CLOUD_ORGANIZATION",
+	"notify-slack-webhook": "TF_NOTIFY_SLACK_WEBHOOK",
+	"notify-webhook-url":   "TF_NOTIFY_WEBHOOK_URL",
+	"event-webhook-url":    "TF_EVENT_WEBHOOK_URL",
+	"oidc-exchange-url":    "TF_OIDC_EXCHANGE_URL",
+	"log-format":           "TF_LOG_FORMAT",
+	"mock-server":          "TFCI_MOCK_SERVER",
+}
+
+// applies config file defaults with precedence: flags > profile > environment > file
+func applyConfig(cfg *config.Config) error {
+	for k, v := range cfg.Env() {
+		if _, ok := os.LookupEnv(k); !ok {
+			os.Setenv(k, v)
+		}
+	}
+
+	profileName := *profileFlag
+	if profileName == "" {
+		profileName = os.Getenv("TFCI_PROFILE")
+	}
+	if profileName != "" {
+		profile, err := cfg.Profile(profileName, os.Getenv)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] using profile: %s", profileName)
+		// the selected profile takes precedence over environment variables
+		profileFlags := map[string]string{}
+		for name, value := range map[string]string{
+			"hostname":     profile.Hostname,
+			"organization": profile.Organization,
+			"token":        profile.Token(profileName, os.Getenv),
+		} {
+			if value != "" {
+				profileFlags[name] = value
+			}
+		}
+		if err := config.ApplyFlags(flag.CommandLine, profileFlags); err != nil {
+			return err
+		}
+	}
+
+	globals := map[string]string{}
+	for name, value := range cfg.Global {
+		if env, ok := globalFlagEnv[name]; ok && os.Getenv(env) != "" {
+			continue
+		}
+		globals[name] = value
+	}
+	return config.ApplyFlags(flag.CommandLine, globals)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// writes cpu and heap profiles and api call timings, enabled with the hidden --debug-profile flag
+type debugProfile struct {
+	dir     string
+	start   time.Time
+	cpu     *os.File
+	timings *cloud.APITimings
+}
+
+type debugTimings struct {
+	Command         string            `json:"command"`
+	TotalDurationMs int64             `json:"total_duration_ms"`
+	APIDurationMs   int64             `json:"api_duration_ms"`
+	APICalls        []debugTimingCall `json:"api_calls"`
+}
+
+type debugTimingCall struct {
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Count     int    `json:"count"`
+	Errors    int    `json:"errors"`
+	TotalMs   int64  `json:"total_ms"`
+	MaxMs     int64  `json:"max_ms"`
+	AverageMs int64  `json:"average_ms"`
+}
+
+// profiles are written to the CI runner temp directory when available
+func debugProfileDir() string {
+	base := os.Getenv("RUNNER_TEMP")
+	if base == "" {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, fmt.Sprintf("tfci-profile-%d", time.Now().Unix()))
+}
+
+func startDebugProfile() (*debugProfile, error) {
+	p := &debugProfile{
+		dir:     debugProfileDir(),
+		start:   time.Now(),
+		timings: cloud.NewAPITimings(),
+	}
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating debug profile directory: %w", err)
+	}
+
+	cpu, err := os.Create(filepath.Join(p.dir, "cpu.pprof"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating cpu profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		cpu.Close()
+		return nil, fmt.Errorf("error starting cpu profile: %w", err)
+	}
+	p.cpu = cpu
+	log.Printf("[DEBUG] writing debug profiles to: %s", p.dir)
+	return p, nil
+}
+
+// stops the cpu profile and writes the heap profile and timings, returning the profile directory
+func (p *debugProfile) stop(command string) (string, error) {
+	pprof.StopCPUProfile()
+	if err := p.cpu.Close(); err != nil {
+		return p.dir, err
+	}
+
+	heap, err := os.Create(filepath.Join(p.dir, "heap.pprof"))
+	if err != nil {
+		return p.dir, err
+	}
+	defer heap.Close()
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(heap); err != nil {
+		return p.dir, err
+	}
+
+	timings := debugTimings{
+		Command:         command,
+		TotalDurationMs: time.Since(p.start).Milliseconds(),
+		APICalls:        []debugTimingCall{},
+	}
+	for _, call := range p.timings.Summary() {
+		timings.APIDurationMs += call.Total.Milliseconds()
+		timings.APICalls = append(timings.APICalls, debugTimingCall{
+			Method:    call.Method,
+			Path:      call.Path,
+			Count:     call.Count,
+			Errors:    call.Errors,
+			TotalMs:   call.Total.Milliseconds(),
+			MaxMs:     call.Max.Milliseconds(),
+			AverageMs: (call.Total / time.Duration(call.Count)).Milliseconds(),
+		})
+	}
+	data, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return p.dir, err
+	}
+	return p.dir, os.WriteFile(filepath.Join(p.dir, "timings.json"), data, 0644)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type AccountService interface {
+	ReadCurrentUser(context.Context) (*tfe.User, error)
+	ReadEntitlements(context.Context, string) (map[string]bool, error)
+	ReadWorkspacePermissions(context.Context, string, string) (map[string]bool, error)
+}
+
+type accountService struct {
+	*cloudMeta
+}
+
+// returns the user or service account the token belongs to
+func (s *accountService) ReadCurrentUser(ctx context.Context) (*tfe.User, error) {
+	user, err := s.tfe.Users.ReadCurrent(ctx)
+	if err != nil {
+		log.Printf("[ERROR] error reading current account: %s", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *accountService) ReadEntitlements(ctx context.Context, orgName string) (map[string]bool, error) {
+	e, err := s.tfe.Organizations.ReadEntitlements(ctx, orgName)
+	if err != nil {
+		log.Printf("[ERROR] error reading entitlements for organization: %q error: %s", orgName, err)
+		return nil, err
+	}
+	return entitlementsMap(e), nil
+}
+
+// returns the effective permissions of the token on the workspace, keyed by api attribute name
+func (s *accountService) ReadWorkspacePermissions(ctx context.Context, orgName string, wName string) (map[string]bool, error) {
+	w, err := s.readWorkspace(ctx, orgName, wName)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q error: %s", wName, orgName, err)
+		return nil, err
+	}
+
+	p := w.Permissions
+	if p == nil {
+		p = &tfe.WorkspacePermissions{}
+	}
+	return map[string]bool{
+		"can-destroy":          p.CanDestroy,
+		"can-force-unlock":     p.CanForceUnlock,
+		"can-lock":             p.CanLock,
+		"can-manage-run-tasks": p.CanManageRunTasks,
+		"can-queue-apply":      p.CanQueueApply,
+		"can-queue-destroy":    p.CanQueueDestroy,
+		"can-queue-run":        p.CanQueueRun,
+		"can-read-settings":    p.CanReadSettings,
+		"can-unlock":           p.CanUnlock,
+		"can-update":           p.CanUpdate,
+		"can-update-variable":  p.CanUpdateVariable,
+	}, nil
+}
+
+func NewAccountService(meta *cloudMeta) *accountService {
+	return &accountService{meta}
+}
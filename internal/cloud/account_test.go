@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountService_ReadWorkspacePermissions(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mWorkspace := mocks.NewMockWorkspaces(ctrl)
+	mWorkspace.EXPECT().Read(ctx, "abc-company", "my-workspace").Return(&tfe.Workspace{
+		ID: "ws-***",
+		Permissions: &tfe.WorkspacePermissions{
+			CanQueueRun:   true,
+			CanQueueApply: false,
+		},
+	}, nil)
+
+	service := NewAccountService(&cloudMeta{
+		tfe:    &tfe.Client{Workspaces: mWorkspace},
+		writer: &defaultWriter{},
+	})
+
+	permissions, err := service.ReadWorkspacePermissions(ctx, "abc-company", "my-workspace")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !permissions["can-queue-run"] {
+		t.Errorf("expected can-queue-run permission")
+	}
+	if permissions["can-queue-apply"] {
+		t.Errorf("expected can-queue-apply permission to be missing")
+	}
+}
+
+func TestAccountService_ReadCurrentUser(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mUsers := mocks.NewMockUsers(ctrl)
+	mUsers.EXPECT().ReadCurrent(ctx).Return(nil, tfe.ErrUnauthorized)
+
+	service := NewAccountService(&cloudMeta{
+		tfe:    &tfe.Client{Users: mUsers},
+		writer: &defaultWriter{},
+	})
+
+	if _, err := service.ReadCurrentUser(ctx); err != tfe.ErrUnauthorized {
+		t.Errorf("expected unauthorized error but received %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+var ErrAdminUnsupported = errors.New("the admin API is only available in Terraform Enterprise")
+
+type AdminUserDetails struct {
+	ID               string `json:"id"`
+	Username         string `json:"username"`
+	Email            string `json:"email"`
+	IsAdmin          bool   `json:"is_admin"`
+	IsSuspended      bool   `json:"is_suspended"`
+	IsServiceAccount bool   `json:"is_service_account"`
+}
+
+func newAdminUserDetails(u *tfe.AdminUser) *AdminUserDetails {
+	return &AdminUserDetails{
+		ID:               u.ID,
+		Username:         u.Username,
+		Email:            u.Email,
+		IsAdmin:          u.IsAdmin,
+		IsSuspended:      u.IsSuspended,
+		IsServiceAccount: u.IsServiceAccount,
+	}
+}
+
+type AdminOrganizationDetails struct {
+	Name              string `json:"name"`
+	NotificationEmail string `json:"notification_email"`
+	IsDisabled        bool   `json:"is_disabled"`
+	SSOEnabled        bool   `json:"sso_enabled"`
+}
+
+type TerraformVersionDetails struct {
+	ID         string `json:"id"`
+	Version    string `json:"version"`
+	Enabled    bool   `json:"enabled"`
+	Deprecated bool   `json:"deprecated"`
+	Official   bool   `json:"official"`
+	Beta       bool   `json:"beta"`
+	Usage      int    `json:"usage"`
+}
+
+func newTerraformVersionDetails(v *tfe.AdminTerraformVersion) *TerraformVersionDetails {
+	return &TerraformVersionDetails{
+		ID:         v.ID,
+		Version:    v.Version,
+		Enabled:    v.Enabled,
+		Deprecated: v.Deprecated,
+		Official:   v.Official,
+		Beta:       v.Beta,
+		Usage:      v.Usage,
+	}
+}
+
+type AdminUserListOptions struct {
+	// matches usernames and email addresses
+	Query         string
+	SuspendedOnly bool
+}
+
+// availability of a Terraform version, unset fields are left unchanged
+type TerraformVersionUpdateOptions struct {
+	Enabled          *bool
+	Deprecated       *bool
+	DeprecatedReason string
+}
+
+type AdminService interface {
+	ListUsers(ctx context.Context, options AdminUserListOptions) ([]*AdminUserDetails, error)
+	SetUserSuspended(ctx context.Context, user string, suspended bool) (*AdminUserDetails, error)
+	ListAdminOrganizations(ctx context.Context, query string) ([]*AdminOrganizationDetails, error)
+	ListTerraformVersions(ctx context.Context, search string) ([]*TerraformVersionDetails, error)
+	UpdateTerraformVersion(ctx context.Context, version string, options TerraformVersionUpdateOptions) (*TerraformVersionDetails, error)
+}
+
+type adminService struct {
+	*cloudMeta
+}
+
+// the admin API is not available in HCP Terraform and requires a site admin token
+func (s *adminService) requireEnterprise() error {
+	if s.capabilities != nil && s.capabilities.IsCloud {
+		return ErrAdminUnsupported
+	}
+	return nil
+}
+
+func (s *adminService) ListUsers(ctx context.Context, options AdminUserListOptions) ([]*AdminUserDetails, error) {
+	if err := s.requireEnterprise(); err != nil {
+		return nil, err
+	}
+	users, err := s.listUsers(ctx, options)
+	if err != nil {
+		log.Printf("[ERROR] error listing users: %s", err)
+		return nil, err
+	}
+	details := make([]*AdminUserDetails, 0, len(users))
+	for _, u := range users {
+		details = append(details, newAdminUserDetails(u))
+	}
+	return details, nil
+}
+
+func (s *adminService) listUsers(ctx context.Context, options AdminUserListOptions) ([]*tfe.AdminUser, error) {
+	listOptions := &tfe.AdminUserListOptions{Query: options.Query}
+	if options.SuspendedOnly {
+		listOptions.SuspendedUsers = "true"
+	}
+	return listAll(func(opts tfe.ListOptions) ([]*tfe.AdminUser, *tfe.Pagination, error) {
+		listOptions.ListOptions = opts
+		list, err := s.tfe.Admin.Users.List(ctx, listOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+// suspends or unsuspends a user by ID, username or email address
+func (s *adminService) SetUserSuspended(ctx context.Context, user string, suspended bool) (*AdminUserDetails, error) {
+	if err := s.requireEnterprise(); err != nil {
+		return nil, err
+	}
+	userID, err := s.findUserID(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var u *tfe.AdminUser
+	if suspended {
+		u, err = s.tfe.Admin.Users.Suspend(ctx, userID)
+	} else {
+		u, err = s.tfe.Admin.Users.Unsuspend(ctx, userID)
+	}
+	if err != nil {
+		log.Printf("[ERROR] error updating suspension of user: %q error: %s", userID, err)
+		return nil, err
+	}
+	return newAdminUserDetails(u), nil
+}
+
+func (s *adminService) findUserID(ctx context.Context, user string) (string, error) {
+	if strings.HasPrefix(user, "user-") {
+		return user, nil
+	}
+	users, err := s.listUsers(ctx, AdminUserListOptions{Query: user})
+	if err != nil {
+		log.Printf("[ERROR] error finding user: %q error: %s", user, err)
+		return "", err
+	}
+	for _, u := range users {
+		if u.Username == user || strings.EqualFold(u.Email, user) {
+			return u.ID, nil
+		}
+	}
+	return "", fmt.Errorf("user %q %w", user, tfe.ErrResourceNotFound)
+}
+
+func (s *adminService) ListAdminOrganizations(ctx context.Context, query string) ([]*AdminOrganizationDetails, error) {
+	if err := s.requireEnterprise(); err != nil {
+		return nil, err
+	}
+	orgs, err := listAll(func(opts tfe.ListOptions) ([]*tfe.AdminOrganization, *tfe.Pagination, error) {
+		list, err := s.tfe.Admin.Organizations.List(ctx, &tfe.AdminOrganizationListOptions{ListOptions: opts, Query: query})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing organizations: %s", err)
+		return nil, err
+	}
+	details := make([]*AdminOrganizationDetails, 0, len(orgs))
+	for _, o := range orgs {
+		details = append(details, &AdminOrganizationDetails{
+			Name:              o.Name,
+			NotificationEmail: o.NotificationEmail,
+			IsDisabled:        o.IsDisabled,
+			SSOEnabled:        o.SsoEnabled,
+		})
+	}
+	return details, nil
+}
+
+func (s *adminService) ListTerraformVersions(ctx context.Context, search string) ([]*TerraformVersionDetails, error) {
+	if err := s.requireEnterprise(); err != nil {
+		return nil, err
+	}
+	versions, err := listAll(func(opts tfe.ListOptions) ([]*tfe.AdminTerraformVersion, *tfe.Pagination, error) {
+		list, err := s.tfe.Admin.TerraformVersions.List(ctx, &tfe.AdminTerraformVersionsListOptions{ListOptions: opts, Search: search})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing terraform versions: %s", err)
+		return nil, err
+	}
+	details := make([]*TerraformVersionDetails, 0, len(versions))
+	for _, v := range versions {
+		details = append(details, newTerraformVersionDetails(v))
+	}
+	return details, nil
+}
+
+// updates the availability of a Terraform version, such as "1.9.5"
+func (s *adminService) UpdateTerraformVersion(ctx context.Context, version string, options TerraformVersionUpdateOptions) (*TerraformVersionDetails, error) {
+	if err := s.requireEnterprise(); err != nil {
+		return nil, err
+	}
+	list, err := s.tfe.Admin.TerraformVersions.List(ctx, &tfe.AdminTerraformVersionsListOptions{Filter: version})
+	if err != nil {
+		log.Printf("[ERROR] error reading terraform version: %q error: %s", version, err)
+		return nil, err
+	}
+	var versionID string
+	for _, v := range list.Items {
+		if v.Version == version {
+			versionID = v.ID
+		}
+	}
+	if versionID == "" {
+		return nil, fmt.Errorf("terraform version %q %w", version, tfe.ErrResourceNotFound)
+	}
+
+	update := tfe.AdminTerraformVersionUpdateOptions{
+		Enabled:    options.Enabled,
+		Deprecated: options.Deprecated,
+	}
+	if options.DeprecatedReason != "" {
+		update.DeprecatedReason = tfe.String(options.DeprecatedReason)
+	}
+	v, err := s.tfe.Admin.TerraformVersions.Update(ctx, versionID, update)
+	if err != nil {
+		log.Printf("[ERROR] error updating terraform version: %q error: %s", version, err)
+		return nil, err
+	}
+	return newTerraformVersionDetails(v), nil
+}
+
+func NewAdminService(meta *cloudMeta) *adminService {
+	return &adminService{meta}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminService_RequiresEnterprise(t *testing.T) {
+	service := NewAdminService(&cloudMeta{
+		tfe:          &tfe.Client{},
+		writer:       &defaultWriter{},
+		capabilities: &Capabilities{IsCloud: true},
+	})
+	ctx := context.Background()
+	if _, err := service.ListUsers(ctx, AdminUserListOptions{}); !errors.Is(err, ErrAdminUnsupported) {
+		t.Errorf("expected error %q, got %v", ErrAdminUnsupported, err)
+	}
+	if _, err := service.ListTerraformVersions(ctx, ""); !errors.Is(err, ErrAdminUnsupported) {
+		t.Errorf("expected error %q, got %v", ErrAdminUnsupported, err)
+	}
+}
+
+func TestAdminService_SetUserSuspended(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		user        string
+		suspended   bool
+		users       []*tfe.AdminUser
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name:       "suspends by id",
+			user:       "user-1",
+			suspended:  true,
+			expectedID: "user-1",
+		},
+		{
+			name:       "unsuspends by email",
+			user:       "Jane@example.com",
+			users:      []*tfe.AdminUser{{ID: "user-2", Username: "janet"}, {ID: "user-1", Username: "jane", Email: "jane@example.com"}},
+			expectedID: "user-1",
+		},
+		{
+			name:        "user not found",
+			user:        "jane",
+			suspended:   true,
+			users:       []*tfe.AdminUser{{ID: "user-2", Username: "janet"}},
+			expectedErr: tfe.ErrResourceNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mUsers := mocks.NewMockAdminUsers(ctrl)
+			if tc.users != nil {
+				mUsers.EXPECT().List(ctx, &tfe.AdminUserListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, Query: tc.user}).Return(&tfe.AdminUserList{
+					Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1},
+					Items:      tc.users,
+				}, nil)
+			}
+			if tc.expectedID != "" && tc.suspended {
+				mUsers.EXPECT().Suspend(ctx, tc.expectedID).Return(&tfe.AdminUser{ID: tc.expectedID, IsSuspended: true}, nil)
+			}
+			if tc.expectedID != "" && !tc.suspended {
+				mUsers.EXPECT().Unsuspend(ctx, tc.expectedID).Return(&tfe.AdminUser{ID: tc.expectedID}, nil)
+			}
+
+			service := NewAdminService(&cloudMeta{
+				tfe:    &tfe.Client{Admin: tfe.Admin{Users: mUsers}},
+				writer: &defaultWriter{},
+			})
+			user, err := service.SetUserSuspended(ctx, tc.user, tc.suspended)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if user.ID != tc.expectedID || user.IsSuspended != tc.suspended {
+				t.Errorf("unexpected user: %+v", user)
+			}
+		})
+	}
+}
+
+func TestAdminService_UpdateTerraformVersion(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mVersions := mocks.NewMockAdminTerraformVersions(ctrl)
+	mVersions.EXPECT().List(ctx, &tfe.AdminTerraformVersionsListOptions{Filter: "1.9.5"}).Return(&tfe.AdminTerraformVersionsList{
+		Items: []*tfe.AdminTerraformVersion{{ID: "tool-1", Version: "1.9.5", Enabled: true}},
+	}, nil)
+	mVersions.EXPECT().Update(ctx, "tool-1", tfe.AdminTerraformVersionUpdateOptions{
+		Deprecated:       tfe.Bool(true),
+		DeprecatedReason: tfe.String("upgrade to 1.10"),
+	}).Return(&tfe.AdminTerraformVersion{ID: "tool-1", Version: "1.9.5", Enabled: true, Deprecated: true}, nil)
+
+	service := NewAdminService(&cloudMeta{
+		tfe:    &tfe.Client{Admin: tfe.Admin{TerraformVersions: mVersions}},
+		writer: &defaultWriter{},
+	})
+	version, err := service.UpdateTerraformVersion(ctx, "1.9.5", TerraformVersionUpdateOptions{
+		Deprecated:       tfe.Bool(true),
+		DeprecatedReason: "upgrade to 1.10",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := &TerraformVersionDetails{ID: "tool-1", Version: "1.9.5", Enabled: true, Deprecated: true}
+	if !reflect.DeepEqual(version, expected) {
+		t.Errorf("expected version %+v, got %+v", expected, version)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type AgentPoolDetails struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	AgentCount         int    `json:"agent_count"`
+	OrganizationScoped bool   `json:"organization_scoped"`
+}
+
+func newAgentPoolDetails(pool *tfe.AgentPool) *AgentPoolDetails {
+	return &AgentPoolDetails{
+		ID:                 pool.ID,
+		Name:               pool.Name,
+		AgentCount:         pool.AgentCount,
+		OrganizationScoped: pool.OrganizationScoped,
+	}
+}
+
+type AgentPoolService interface {
+	ListAgentPools(ctx context.Context, organization string) ([]*AgentPoolDetails, error)
+	CreateAgentPool(ctx context.Context, organization, name string, organizationScoped bool) (*AgentPoolDetails, error)
+	CreateAgentToken(ctx context.Context, agentPoolID, description string) (*tfe.AgentToken, error)
+	RevokeAgentToken(ctx context.Context, agentTokenID string) error
+}
+
+type agentPoolService struct {
+	*cloudMeta
+}
+
+func (s *agentPoolService) ListAgentPools(ctx context.Context, organization string) ([]*AgentPoolDetails, error) {
+	pools, err := listAll(func(opts tfe.ListOptions) ([]*tfe.AgentPool, *tfe.Pagination, error) {
+		list, err := s.tfe.AgentPools.List(ctx, organization, &tfe.AgentPoolListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing agent pools of organization: %q error: %s", organization, err)
+		return nil, err
+	}
+	details := make([]*AgentPoolDetails, 0, len(pools))
+	for _, pool := range pools {
+		details = append(details, newAgentPoolDetails(pool))
+	}
+	return details, nil
+}
+
+func (s *agentPoolService) CreateAgentPool(ctx context.Context, organization, name string, organizationScoped bool) (*AgentPoolDetails, error) {
+	pool, err := s.tfe.AgentPools.Create(ctx, organization, tfe.AgentPoolCreateOptions{
+		Name:               tfe.String(name),
+		OrganizationScoped: tfe.Bool(organizationScoped),
+	})
+	if err != nil {
+		log.Printf("[ERROR] error creating agent pool: %q organization: %q error: %s", name, organization, err)
+		return nil, err
+	}
+	return newAgentPoolDetails(pool), nil
+}
+
+// the returned token holds the secret value, which can only be read when the token is created
+func (s *agentPoolService) CreateAgentToken(ctx context.Context, agentPoolID, description string) (*tfe.AgentToken, error) {
+	token, err := s.tfe.AgentTokens.Create(ctx, agentPoolID, tfe.AgentTokenCreateOptions{
+		Description: tfe.String(description),
+	})
+	if err != nil {
+		log.Printf("[ERROR] error creating agent token for agent pool: %q error: %s", agentPoolID, err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *agentPoolService) RevokeAgentToken(ctx context.Context, agentTokenID string) error {
+	if err := s.tfe.AgentTokens.Delete(ctx, agentTokenID); err != nil {
+		log.Printf("[ERROR] error revoking agent token: %q error: %s", agentTokenID, err)
+		return err
+	}
+	return nil
+}
+
+func NewAgentPoolService(meta *cloudMeta) *agentPoolService {
+	return &agentPoolService{meta}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAgentPoolService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mPools := mocks.NewMockAgentPools(ctrl)
+	mPools.EXPECT().List(ctx, "abc-company", &tfe.AgentPoolListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.AgentPoolList{
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+		Items:      []*tfe.AgentPool{{ID: "apool-1", Name: "autoscaled", AgentCount: 3, OrganizationScoped: true}},
+	}, nil)
+	mPools.EXPECT().Create(ctx, "abc-company", tfe.AgentPoolCreateOptions{Name: tfe.String("autoscaled"), OrganizationScoped: tfe.Bool(false)}).Return(&tfe.AgentPool{ID: "apool-2", Name: "autoscaled"}, nil)
+	mTokens := mocks.NewMockAgentTokens(ctrl)
+	mTokens.EXPECT().Create(ctx, "apool-1", tfe.AgentTokenCreateOptions{Description: tfe.String("autoscaler")}).Return(&tfe.AgentToken{ID: "at-1", Token: "secret"}, nil)
+	mTokens.EXPECT().Delete(ctx, "at-1").Return(nil)
+
+	service := NewAgentPoolService(&cloudMeta{tfe: &tfe.Client{AgentPools: mPools, AgentTokens: mTokens}, writer: &defaultWriter{}})
+
+	pools, err := service.ListAgentPools(ctx, "abc-company")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(pools) != 1 || pools[0].AgentCount != 3 || !pools[0].OrganizationScoped {
+		t.Errorf("unexpected agent pools %v", pools)
+	}
+
+	pool, err := service.CreateAgentPool(ctx, "abc-company", "autoscaled", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pool.ID != "apool-2" {
+		t.Errorf("expected agent pool apool-2 but received %s", pool.ID)
+	}
+
+	token, err := service.CreateAgentToken(ctx, "apool-1", "autoscaler")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.Token != "secret" {
+		t.Errorf("unexpected agent token %v", token)
+	}
+
+	if err := service.RevokeAgentToken(ctx, "at-1"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
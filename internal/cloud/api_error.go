@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// HCP Terraform identifies each request for support cases with this response header
+const requestIDHeader = "X-Request-Id"
+
+// APIError is a failed response from HCP Terraform, after retries.
+// go-tfe returns untyped errors for most statuses, so the transport returns
+// this error in place of the response and the status can be read from the error
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	RequestID  string
+	// error messages of the response payload, formatted the same as go-tfe
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		Method:     resp.Request.Method,
+		Path:       resp.Request.URL.Path,
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Message:    resp.Status,
+	}
+
+	var payload struct {
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil || len(payload.Errors) == 0 {
+		return apiErr
+	}
+	messages := make([]string, 0, len(payload.Errors))
+	for _, e := range payload.Errors {
+		if e.Detail == "" {
+			messages = append(messages, e.Title)
+		} else {
+			messages = append(messages, e.Title+"\n\n"+e.Detail)
+		}
+	}
+	apiErr.Message = strings.Join(messages, "\n")
+	return apiErr
+}
+
+// go-tfe returns sentinel errors for these responses, such as tfe.ErrResourceNotFound,
+// or retries them, so the response is passed through
+func hasTypedError(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusTooManyRequests:
+		return true
+	case http.StatusConflict:
+		for _, action := range []string{"actions/lock", "actions/unlock", "actions/force-unlock", "actions/safe-delete"} {
+			if strings.HasSuffix(resp.Request.URL.Path, action) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// returns the failed responses that go-tfe does not return a typed error for as an APIError
+func newAPIErrorTransport(base http.RoundTripper) http.RoundTripper {
+	return &apiErrorTransport{base: base}
+}
+
+type apiErrorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest || hasTypedError(resp) {
+		return resp, err
+	}
+
+	// the error is wrapped by the http client, and can be unwrapped with errors.As
+	defer resp.Body.Close()
+	return nil, newAPIError(resp)
+}
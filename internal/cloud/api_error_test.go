@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestAPIErrorTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", tfe.ContentTypeJSONAPI)
+		w.Header().Set("X-Request-Id", "req-abc123")
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":[{"status":"403","title":"forbidden","detail":"insufficient permissions"}]}`))
+		case "/api/v2/workspaces/ws-unavailable":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := tfe.NewClient(&tfe.Config{
+		Address:    server.URL,
+		Token:      "token",
+		HTTPClient: &http.Client{Transport: newAPIErrorTransport(http.DefaultTransport)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	testCases := []struct {
+		workspaceID string
+		status      int
+		message     string
+	}{
+		{workspaceID: "ws-forbidden", status: http.StatusForbidden, message: "forbidden\n\ninsufficient permissions"},
+		{workspaceID: "ws-unavailable", status: http.StatusBadGateway, message: "502 Bad Gateway"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.workspaceID, func(t *testing.T) {
+			_, err := client.Workspaces.ReadByID(ctx, tc.workspaceID)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an api error but received: %v", err)
+			}
+			if apiErr.StatusCode != tc.status || apiErr.RequestID != "req-abc123" || apiErr.Message != tc.message {
+				t.Errorf("unexpected api error: %+v", apiErr)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Workspaces.ReadByID(ctx, "ws-missing")
+		var apiErr *APIError
+		if !errors.Is(err, tfe.ErrResourceNotFound) || errors.As(err, &apiErr) {
+			t.Errorf("expected the go-tfe not found error but received: %#v", err)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// resource changes made by an apply, and the state version it created
+type ApplySummary struct {
+	ApplyID   string
+	Status    tfe.ApplyStatus
+	Added     int
+	Changed   int
+	Destroyed int
+	Imported  int
+	// empty when the current state version of the workspace was created by a later run
+	StateVersionID string
+}
+
+// reads the results of the run's apply, nil when the run has not been applied
+func (service *runService) GetApplySummary(ctx context.Context, run *tfe.Run) (*ApplySummary, error) {
+	if run == nil || run.Apply == nil || run.Apply.ID == "" {
+		return nil, nil
+	}
+	apply, err := service.tfe.Applies.Read(ctx, run.Apply.ID)
+	if err != nil {
+		log.Printf("[ERROR] error reading apply: %q error: %s", run.Apply.ID, err)
+		return nil, err
+	}
+	if apply.Status != tfe.ApplyFinished && apply.Status != tfe.ApplyErrored {
+		return nil, nil
+	}
+
+	summary := &ApplySummary{
+		ApplyID:   apply.ID,
+		Status:    apply.Status,
+		Added:     apply.ResourceAdditions,
+		Changed:   apply.ResourceChanges,
+		Destroyed: apply.ResourceDestructions,
+		Imported:  apply.ResourceImports,
+	}
+	if run.Workspace == nil || run.Workspace.ID == "" {
+		return summary, nil
+	}
+
+	// partially applied runs create a state version too
+	sv, err := service.tfe.StateVersions.ReadCurrent(ctx, run.Workspace.ID)
+	if err != nil {
+		log.Printf("[ERROR] error reading current state version of workspace: %q error: %s", run.Workspace.ID, err)
+		return summary, err
+	}
+	if sv.Run != nil && sv.Run.ID == run.ID {
+		summary.StateVersionID = sv.ID
+	} else {
+		log.Printf("[DEBUG] current state version: %q was not created by run: %q", sv.ID, run.ID)
+	}
+	return summary, nil
+}
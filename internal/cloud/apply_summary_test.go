@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_GetApplySummary(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   tfe.ApplyStatus
+		svRunID  string
+		expected *ApplySummary
+	}{
+		{
+			name:     "applied",
+			status:   tfe.ApplyFinished,
+			svRunID:  "run-1",
+			expected: &ApplySummary{ApplyID: "apply-1", Status: tfe.ApplyFinished, Added: 2, Changed: 1, Destroyed: 3, StateVersionID: "sv-1"},
+		},
+		{
+			name:     "state changed by a later run",
+			status:   tfe.ApplyFinished,
+			svRunID:  "run-2",
+			expected: &ApplySummary{ApplyID: "apply-1", Status: tfe.ApplyFinished, Added: 2, Changed: 1, Destroyed: 3},
+		},
+		{name: "not applied", status: tfe.ApplyUnreachable},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			mApplies := mocks.NewMockApplies(ctrl)
+			mApplies.EXPECT().Read(ctx, "apply-1").Return(&tfe.Apply{
+				ID:                   "apply-1",
+				Status:               tc.status,
+				ResourceAdditions:    2,
+				ResourceChanges:      1,
+				ResourceDestructions: 3,
+			}, nil)
+			mStateVersions := mocks.NewMockStateVersions(ctrl)
+			if tc.expected != nil {
+				mStateVersions.EXPECT().ReadCurrent(ctx, "ws-1").Return(&tfe.StateVersion{ID: "sv-1", Run: &tfe.Run{ID: tc.svRunID}}, nil)
+			}
+
+			service := &runService{&cloudMeta{tfe: &tfe.Client{Applies: mApplies, StateVersions: mStateVersions}, writer: &defaultWriter{}}}
+			summary, err := service.GetApplySummary(ctx, &tfe.Run{
+				ID:        "run-1",
+				Apply:     &tfe.Apply{ID: "apply-1"},
+				Workspace: &tfe.Workspace{ID: "ws-1"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(summary, tc.expected) {
+				t.Errorf("expected %+v but received %+v", tc.expected, summary)
+			}
+		})
+	}
+}
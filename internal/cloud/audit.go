@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+var ErrAuditTrailUnsupported = errors.New("audit trails are only available in HCP Terraform")
+
+type AuditService interface {
+	ListAuditTrails(context.Context, time.Time) ([]*tfe.AuditTrail, error)
+}
+
+type auditService struct {
+	*cloudMeta
+}
+
+// lists the audit trail events of the organization of the token since the given time, oldest first
+func (s *auditService) ListAuditTrails(ctx context.Context, since time.Time) ([]*tfe.AuditTrail, error) {
+	if s.capabilities != nil && !s.capabilities.IsCloud && s.capabilities.TFEVersion != "" {
+		return nil, ErrAuditTrailUnsupported
+	}
+	events, err := listAll(func(opts tfe.ListOptions) ([]*tfe.AuditTrail, *tfe.Pagination, error) {
+		page, err := s.tfe.AuditTrails.List(ctx, &tfe.AuditTrailListOptions{Since: since, ListOptions: &opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		// audit trails have their own pagination
+		if page.AuditTrailPagination == nil {
+			return page.Items, nil, nil
+		}
+		return page.Items, &tfe.Pagination{
+			CurrentPage: page.CurrentPage,
+			NextPage:    page.NextPage,
+			TotalPages:  page.TotalPages,
+			TotalCount:  page.TotalCount,
+		}, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing audit trails since: %s error: %s", since.Format(time.RFC3339), err)
+		return nil, err
+	}
+	return events, nil
+}
+
+func NewAuditService(meta *cloudMeta) *auditService {
+	return &auditService{meta}
+}
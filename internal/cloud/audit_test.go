@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_ListAuditTrails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mAuditTrails := mocks.NewMockAuditTrails(ctrl)
+	mAuditTrails.EXPECT().List(ctx, &tfe.AuditTrailListOptions{Since: since, ListOptions: &tfe.ListOptions{PageSize: 100}}).Return(&tfe.AuditTrailList{
+		AuditTrailPagination: &tfe.AuditTrailPagination{CurrentPage: 1, NextPage: 2, TotalPages: 2},
+		Items:                []*tfe.AuditTrail{{ID: "ae66e491-1"}},
+	}, nil)
+	mAuditTrails.EXPECT().List(ctx, &tfe.AuditTrailListOptions{Since: since, ListOptions: &tfe.ListOptions{PageNumber: 2, PageSize: 100}}).Return(&tfe.AuditTrailList{
+		AuditTrailPagination: &tfe.AuditTrailPagination{CurrentPage: 2, TotalPages: 2},
+		Items:                []*tfe.AuditTrail{{ID: "ae66e491-2"}},
+	}, nil)
+
+	service := NewAuditService(&cloudMeta{tfe: &tfe.Client{AuditTrails: mAuditTrails}, writer: &defaultWriter{}})
+	events, err := service.ListAuditTrails(ctx, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(events) != 2 || events[0].ID != "ae66e491-1" || events[1].ID != "ae66e491-2" {
+		t.Errorf("unexpected audit trails %v", events)
+	}
+}
+
+func TestAuditService_ListAuditTrailsUnsupported(t *testing.T) {
+	service := NewAuditService(&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}, capabilities: &Capabilities{TFEVersion: "v202401-1"}})
+	_, err := service.ListAuditTrails(context.Background(), time.Now())
+	if !errors.Is(err, ErrAuditTrailUnsupported) {
+		t.Errorf("expected unsupported error but received %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/sethvargo/go-retry"
+)
+
+// wait before the first retry of an errored run, doubling with each retry
+var autoRetryBackoff = struct {
+	start  time.Duration
+	capped time.Duration
+}{start: 30 * time.Second, capped: 5 * time.Minute}
+
+// log messages of errors that are likely to succeed when the run is retried
+var transientRunErrors = []struct {
+	reason  string
+	pattern *regexp.Regexp
+}{
+	{reason: "agent disconnected", pattern: regexp.MustCompile(`(?i)agent (has )?(disconnected|lost connection|went offline)|lost connection to agent`)},
+	{reason: "cloud provider throttling", pattern: regexp.MustCompile(`(?i)throttl|rate exceeded|requestlimitexceeded|ratelimitexceeded|toomanyrequests|slowdown|status code:? 429`)},
+	{reason: "network error", pattern: regexp.MustCompile(`(?i)connection reset by peer|i/o timeout|tls handshake timeout|unexpected eof`)},
+	{reason: "service unavailable", pattern: regexp.MustCompile(`(?i)status code:? (502|503|504)|service unavailable|internalfailure`)},
+}
+
+type RetryRunOptions struct {
+	CreateRunOptions
+	// the errored run being retried
+	ErroredRunID string
+	// retries of the original run, starting at 1
+	Attempt int
+}
+
+// reads the logs of an errored run, returning the cause of the error when it is transient
+func (service *runService) TransientRunError(ctx context.Context, run *tfe.Run) (string, error) {
+	logs, err := service.erroredRunLogs(ctx, run)
+	if err != nil || logs == nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for _, transient := range transientRunErrors {
+			if transient.pattern.MatchString(line) {
+				log.Printf("[DEBUG] run: %q errored with transient error: %q", run.ID, line)
+				return transient.reason, nil
+			}
+		}
+	}
+	return "", scanner.Err()
+}
+
+// waits for the backoff of the attempt, then creates a new run in place of an errored run
+func (service *runService) RetryRun(ctx context.Context, options RetryRunOptions) (*tfe.Run, error) {
+	wait := retryRunWait(options.Attempt)
+	service.writer.Output(fmt.Sprintf("Retrying errored Run ID: %q in %s, attempt %d", options.ErroredRunID, wait.Round(time.Second), options.Attempt))
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(wait):
+	}
+
+	createOpts := options.CreateRunOptions
+	createOpts.Message = fmt.Sprintf("%s (retry %d of errored run %s)", createOpts.Message, options.Attempt, options.ErroredRunID)
+	return service.CreateRun(ctx, createOpts)
+}
+
+func retryRunWait(attempt int) time.Duration {
+	backoff := retry.NewExponential(autoRetryBackoff.start)
+	backoff = retry.WithCappedDuration(autoRetryBackoff.capped, backoff)
+	if retryOptions.BackoffJitter > 0 {
+		backoff = retry.WithJitterPercent(retryOptions.BackoffJitter, backoff)
+	}
+	var wait time.Duration
+	for i := 0; i < attempt; i++ {
+		wait, _ = backoff.Next()
+	}
+	return wait
+}
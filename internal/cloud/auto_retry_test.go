@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_TransientRunError(t *testing.T) {
+	cases := []struct {
+		name     string
+		logs     string
+		expected string
+	}{
+		{name: "throttling", logs: "Error: creating EC2 Instance: operation error EC2: RunInstances, https response error StatusCode: 400, api error RequestLimitExceeded: Request limit exceeded.\n", expected: "cloud provider throttling"},
+		{name: "agent", logs: "Preparing the remote plan...\nThe agent has disconnected while the operation was in progress\n", expected: "agent disconnected"},
+		{name: "configuration error", logs: "Error: Reference to undeclared resource\n", expected: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+			mPlans := mocks.NewMockPlans(ctrl)
+			mPlans.EXPECT().Logs(ctx, "plan-1").Return(strings.NewReader(tc.logs), nil)
+
+			service := &runService{&cloudMeta{tfe: &tfe.Client{Plans: mPlans}, writer: &defaultWriter{}}}
+			reason, err := service.TransientRunError(ctx, &tfe.Run{
+				ID:     "run-1",
+				Status: tfe.RunErrored,
+				Plan:   &tfe.Plan{ID: "plan-1", Status: tfe.PlanErrored},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if reason != tc.expected {
+				t.Errorf("expected reason %q but received %q", tc.expected, reason)
+			}
+		})
+	}
+}
+
+func TestRunService_TransientRunError_NotErrored(t *testing.T) {
+	service := &runService{&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}}}
+	reason, err := service.TransientRunError(context.Background(), &tfe.Run{ID: "run-1", Status: tfe.RunPlanned})
+	if err != nil || reason != "" {
+		t.Errorf("expected no transient error but received %q, %v", reason, err)
+	}
+}
+
+func TestRetryRunWait(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{})
+	expected := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute}
+	for i, wait := range expected {
+		if actual := retryRunWait(i + 1); actual != wait {
+			t.Errorf("expected attempt %d to wait %s but received %s", i+1, wait, actual)
+		}
+	}
+}
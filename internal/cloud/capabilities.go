@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type Capability string
+
+const (
+	TaskStages        Capability = "task stages"
+	PolicyEvaluations Capability = "policy evaluations"
+	Projects          Capability = "projects"
+	SavedPlans        Capability = "saved plans"
+	TagBindings       Capability = "tag bindings"
+)
+
+// first Terraform Enterprise release supporting each capability, HCP Terraform supports all capabilities
+var minimumTFEVersion = map[Capability]string{
+	TaskStages:        "v202206-1",
+	PolicyEvaluations: "v202210-1",
+	Projects:          "v202302-1",
+	SavedPlans:        "v202311-1",
+	TagBindings:       "v202410-1",
+}
+
+// Terraform Enterprise releases are formatted as vYYYYMM-N
+var tfeVersionPattern = regexp.MustCompile(`^v(\d{6})-(\d+)$`)
+
+type UnsupportedError struct {
+	Capability     Capability
+	TFEVersion     string
+	MinimumVersion string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s are not supported by your Terraform Enterprise version (%s), requires %s or later", e.Capability, e.TFEVersion, e.MinimumVersion)
+}
+
+// gates features on the remote instance type and version
+type Capabilities struct {
+	IsCloud    bool
+	TFEVersion string
+	APIVersion string
+}
+
+func newCapabilities(c *tfe.Client) *Capabilities {
+	if c == nil {
+		return &Capabilities{}
+	}
+	caps := &Capabilities{
+		IsCloud:    c.IsCloud(),
+		TFEVersion: c.RemoteTFEVersion(),
+		APIVersion: c.RemoteAPIVersion(),
+	}
+	log.Printf("[DEBUG] HCP Terraform: %t, TFE version: %q, API version: %q", caps.IsCloud, caps.TFEVersion, caps.APIVersion)
+	return caps
+}
+
+func (c *Capabilities) Supports(capability Capability) bool {
+	return c.Require(capability) == nil
+}
+
+// returns an *UnsupportedError when the instance is known to be older than the first supporting release.
+// unknown versions are not gated, the API determines support.
+func (c *Capabilities) Require(capability Capability) error {
+	if c == nil || c.IsCloud {
+		return nil
+	}
+	minimum, ok := minimumTFEVersion[capability]
+	if !ok {
+		return nil
+	}
+	if compareTFEVersions(c.TFEVersion, minimum) >= 0 {
+		return nil
+	}
+	return &UnsupportedError{
+		Capability:     capability,
+		TFEVersion:     c.TFEVersion,
+		MinimumVersion: minimum,
+	}
+}
+
+// returns -1, 0 or 1, versions that can not be parsed compare as equal
+func compareTFEVersions(a, b string) int {
+	am, bm := tfeVersionPattern.FindStringSubmatch(a), tfeVersionPattern.FindStringSubmatch(b)
+	if am == nil || bm == nil {
+		return 0
+	}
+	for i := 1; i <= 2; i++ {
+		av, _ := strconv.Atoi(am[i])
+		bv, _ := strconv.Atoi(bm[i])
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCapabilities_Require(t *testing.T) {
+	testCases := []struct {
+		name         string
+		capabilities *Capabilities
+		capability   Capability
+		supported    bool
+	}{
+		{
+			name:         "hcp-terraform",
+			capabilities: &Capabilities{IsCloud: true},
+			capability:   SavedPlans,
+			supported:    true,
+		},
+		{
+			name:         "tfe-newer-release",
+			capabilities: &Capabilities{TFEVersion: "v202401-2"},
+			capability:   SavedPlans,
+			supported:    true,
+		},
+		{
+			name:         "tfe-minimum-release",
+			capabilities: &Capabilities{TFEVersion: "v202302-1"},
+			capability:   Projects,
+			supported:    true,
+		},
+		{
+			name:         "tfe-older-release",
+			capabilities: &Capabilities{TFEVersion: "v202209-3"},
+			capability:   PolicyEvaluations,
+			supported:    false,
+		},
+		{
+			name:         "tfe-unknown-release",
+			capabilities: &Capabilities{},
+			capability:   SavedPlans,
+			supported:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.capabilities.Require(tc.capability)
+			if tc.supported && err != nil {
+				t.Fatalf("expected %s to be supported but received: %s", tc.capability, err)
+			}
+			if !tc.supported {
+				var unsupported *UnsupportedError
+				if !errors.As(err, &unsupported) {
+					t.Fatalf("expected unsupported error but received: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunService_CreateRun_SavePlanUnsupported(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mWorkspace := mocks.NewMockWorkspaces(ctrl)
+	mWorkspace.EXPECT().Read(ctx, "abc-company", "my-workspace").Return(&tfe.Workspace{ID: "ws-***"}, nil)
+
+	service := NewRunService(&cloudMeta{
+		tfe:          &tfe.Client{Workspaces: mWorkspace},
+		writer:       &defaultWriter{},
+		capabilities: &Capabilities{TFEVersion: "v202307-1"},
+	})
+
+	_, err := service.CreateRun(ctx, CreateRunOptions{
+		Organization: "abc-company",
+		Workspace:    "my-workspace",
+		SavePlan:     true,
+	})
+
+	expected := "saved plans are not supported by your Terraform Enterprise version (v202307-1), requires v202311-1 or later"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q but received: %v", expected, err)
+	}
+}
@@ -4,7 +4,12 @@
 package cloud
 
 import (
+	"context"
+	"log"
+
 	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/logging"
+	"github.com/hashicorp/tfci/internal/notify"
 )
 
 type Writer interface {
@@ -22,6 +27,11 @@ func (d *defaultWriter) Error(msg string)  {}
 // compile time check
 var _ Writer = (*defaultWriter)(nil)
 
+// receives lifecycle events as monitoring loops poll
+type EventEmitter interface {
+	Emit(context.Context, *notify.Event) error
+}
+
 type Cloud struct {
 	*cloudMeta
 
@@ -29,29 +39,146 @@ type Cloud struct {
 	RunService
 	PlanService
 	WorkspaceService
+	AccountService
+	OrganizationService
+	ReconcileService
+	CommentService
+	AuditService
+	RegistryModuleService
+	AgentPoolService
+	TeamService
+	ExplorerService
+	RunTaskService
+	StateVersionService
+	WorkspaceResourceService
+	TagBindingService
+	AdminService
+	TerraformVersionService
 }
 
 func (c *Cloud) UseJson(json bool) {
 	c.writer.UseJson(json)
 }
 
+func (c *Cloud) UseEvents(e EventEmitter) {
+	c.events = e
+}
+
+func (c *Cloud) UseRateLimits(r *RateLimitTracker) {
+	c.rateLimits = r
+}
+
+// redacts values and patterns from streamed logs, replacing values previously added
+func (c *Cloud) UseRedactor(r *logging.Redactor) {
+	c.redactor = r
+}
+
+// redacts the values from logs streamed after they are added
+func (c *Cloud) RedactValues(values ...string) {
+	c.redactor.AddValues(values...)
+}
+
+// writes the logs of the kind to the file while they are streamed
+func (c *Cloud) UseLogFile(kind LogKind, path string) {
+	if c.logFiles == nil {
+		c.logFiles = map[LogKind]string{}
+	}
+	c.logFiles[kind] = path
+}
+
+func (c *Cloud) RateLimits() *RateLimitTracker {
+	return c.rateLimits
+}
+
+// features supported by the remote instance, detected when the client connected
+func (c *Cloud) Capabilities() *Capabilities {
+	return c.capabilities
+}
+
+// reports run task results, authenticated with the access token of each run task request
+func (c *Cloud) RunTaskCallbacks() tfe.RunTasksIntegration {
+	return c.tfe.RunTasksIntegration
+}
+
 // shared struct to embed
 type cloudMeta struct {
 	tfe    *tfe.Client
 	writer Writer
+	events EventEmitter
+	// shared with the tfe client http transport
+	rateLimits *RateLimitTracker
+	// features supported by the remote instance
+	capabilities *Capabilities
+	// workspaces read during the command invocation
+	workspaces *workspaceCache
+	// files plan and apply logs are also written to
+	logFiles map[LogKind]string
+	// redacts sensitive values from streamed logs
+	redactor *logging.Redactor
+}
+
+// event delivery is best effort and does not affect the operation
+func (m *cloudMeta) emit(ctx context.Context, e *notify.Event) {
+	if m.events == nil {
+		return
+	}
+	if err := m.events.Emit(ctx, e); err != nil {
+		log.Printf("[ERROR] problem emitting %s event: %s", e.Type, err.Error())
+	}
+}
+
+// emits a status change event when the run status differs from the last observed status
+func (m *cloudMeta) emitRunStatus(ctx context.Context, run *tfe.Run, lastStatus *tfe.RunStatus) {
+	if run == nil || run.Status == *lastStatus {
+		return
+	}
+	*lastStatus = run.Status
+	e := notify.NewEvent(notify.RunStatusChanged, run.ID, string(run.Status))
+	e.RunID = run.ID
+	m.emit(ctx, e)
+}
+
+func (m *cloudMeta) emitRunCompleted(ctx context.Context, runID string, run *tfe.Run, err error) {
+	e := notify.NewEvent(notify.RunCompleted, runID, "")
+	e.RunID = runID
+	if run != nil {
+		e.Status = string(run.Status)
+	}
+	if err != nil {
+		e.Message = err.Error()
+	}
+	m.emit(ctx, e)
 }
 
 func NewCloud(c *tfe.Client, w Writer) *Cloud {
 	meta := &cloudMeta{
-		tfe:    c,
-		writer: w,
+		tfe:          c,
+		writer:       w,
+		capabilities: newCapabilities(c),
+		workspaces:   newWorkspaceCache(),
+		redactor:     &logging.Redactor{},
 	}
 
 	return &Cloud{
-		cloudMeta:            meta,
-		ConfigVersionService: NewConfigVersionService(meta),
-		RunService:           NewRunService(meta),
-		PlanService:          NewPlanService(meta),
-		WorkspaceService:     NewWorkspaceService(meta),
+		cloudMeta:                meta,
+		ConfigVersionService:     NewConfigVersionService(meta),
+		RunService:               NewRunService(meta),
+		PlanService:              NewPlanService(meta),
+		WorkspaceService:         NewWorkspaceService(meta),
+		AccountService:           NewAccountService(meta),
+		OrganizationService:      NewOrganizationService(meta),
+		ReconcileService:         NewReconcileService(meta),
+		CommentService:           NewCommentService(meta),
+		AuditService:             NewAuditService(meta),
+		RegistryModuleService:    NewRegistryModuleService(meta),
+		AgentPoolService:         NewAgentPoolService(meta),
+		TeamService:              NewTeamService(meta),
+		ExplorerService:          NewExplorerService(meta),
+		RunTaskService:           NewRunTaskService(meta),
+		StateVersionService:      NewStateVersionService(meta),
+		WorkspaceResourceService: NewWorkspaceResourceService(meta),
+		TagBindingService:        NewTagBindingService(meta),
+		AdminService:             NewAdminService(meta),
+		TerraformVersionService:  NewTerraformVersionService(meta),
 	}
 }
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/notify"
+)
+
+type recordingEmitter struct {
+	events []*notify.Event
+}
+
+func (r *recordingEmitter) Emit(_ context.Context, e *notify.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func TestCloudMeta_EmitRunStatus(t *testing.T) {
+	emitter := &recordingEmitter{}
+	meta := &cloudMeta{writer: &defaultWriter{}, events: emitter}
+	ctx := context.Background()
+
+	var lastStatus tfe.RunStatus
+	for _, status := range []tfe.RunStatus{tfe.RunPending, tfe.RunPlanning, tfe.RunPlanning, tfe.RunPlanned} {
+		meta.emitRunStatus(ctx, &tfe.Run{ID: "run-123", Status: status}, &lastStatus)
+	}
+	meta.emitRunCompleted(ctx, "run-123", &tfe.Run{ID: "run-123", Status: tfe.RunPlanned}, errors.New("oops"))
+
+	expected := []string{"pending", "planning", "planned", "planned"}
+	if len(emitter.events) != len(expected) {
+		t.Fatalf("expected %d events but received %d", len(expected), len(emitter.events))
+	}
+	for i, e := range emitter.events {
+		if e.Status != expected[i] {
+			t.Errorf("expected %q but received %q", expected[i], e.Status)
+		}
+	}
+
+	completed := emitter.events[len(emitter.events)-1]
+	if completed.Type != notify.RunCompleted || completed.Message != "oops" {
+		t.Errorf("unexpected completion event: %+v", completed)
+	}
+}
+
+func TestCloudMeta_EmitWithoutEmitter(t *testing.T) {
+	meta := &cloudMeta{writer: &defaultWriter{}}
+	var lastStatus tfe.RunStatus
+	// should not panic when no emitter has been configured
+	meta.emitRunStatus(context.Background(), &tfe.Run{ID: "run-123", Status: tfe.RunPending}, &lastStatus)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type CommentService interface {
+	AddComment(context.Context, string, string) (*tfe.Comment, error)
+	ListComments(context.Context, string) ([]*tfe.Comment, error)
+}
+
+type commentService struct {
+	*cloudMeta
+}
+
+func (s *commentService) AddComment(ctx context.Context, runID string, body string) (*tfe.Comment, error) {
+	comment, err := s.tfe.Comments.Create(ctx, runID, tfe.CommentCreateOptions{Body: body})
+	if err != nil {
+		log.Printf("[ERROR] error adding comment to run: %q error: %s", runID, err)
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *commentService) ListComments(ctx context.Context, runID string) ([]*tfe.Comment, error) {
+	list, err := s.tfe.Comments.List(ctx, runID)
+	if err != nil {
+		log.Printf("[ERROR] error listing comments of run: %q error: %s", runID, err)
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func NewCommentService(meta *cloudMeta) *commentService {
+	return &commentService{meta}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommentService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mComments := mocks.NewMockComments(ctrl)
+	mComments.EXPECT().Create(ctx, "run-1", tfe.CommentCreateOptions{Body: "Approved in JIRA-123"}).Return(&tfe.Comment{ID: "wsc-1", Body: "Approved in JIRA-123"}, nil)
+	mComments.EXPECT().List(ctx, "run-1").Return(&tfe.CommentList{Items: []*tfe.Comment{{ID: "wsc-1", Body: "Approved in JIRA-123"}}}, nil)
+
+	service := NewCommentService(&cloudMeta{tfe: &tfe.Client{Comments: mComments}, writer: &defaultWriter{}})
+	comment, err := service.AddComment(ctx, "run-1", "Approved in JIRA-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if comment.ID != "wsc-1" {
+		t.Errorf("expected comment wsc-1 but received %s", comment.ID)
+	}
+
+	comments, err := service.ListComments(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "Approved in JIRA-123" {
+		t.Errorf("unexpected comments %v", comments)
+	}
+}
@@ -4,11 +4,13 @@
 package cloud
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
 
 	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/notify"
 	"github.com/sethvargo/go-retry"
 )
 
@@ -18,10 +20,23 @@ type UploadOptions struct {
 	ConfigurationDirectory string
 	Speculative            bool
 	Provisional            bool
+	// path to a cache of previously uploaded slugs, an unchanged configuration
+	// reuses the previous configuration version instead of uploading again
+	SlugCacheFile string
+}
+
+// options to upload the archive of an existing configuration version to another workspace
+type UploadArchiveOptions struct {
+	Organization string
+	Workspace    string
+	Archive      []byte
+	Speculative  bool
 }
 
 type ConfigVersionService interface {
 	UploadConfig(ctx context.Context, options UploadOptions) (*tfe.ConfigurationVersion, error)
+	DownloadConfig(ctx context.Context, configVersionID string) ([]byte, error)
+	UploadConfigArchive(ctx context.Context, options UploadArchiveOptions) (*tfe.ConfigurationVersion, error)
 }
 
 type configVersionService struct {
@@ -29,13 +44,23 @@ type configVersionService struct {
 }
 
 func (service *configVersionService) UploadConfig(ctx context.Context, options UploadOptions) (*tfe.ConfigurationVersion, error) {
-	workspace, wErr := service.tfe.Workspaces.Read(ctx, options.Organization, options.Workspace)
+	workspace, wErr := service.readWorkspace(ctx, options.Organization, options.Workspace)
 
 	if wErr != nil {
 		log.Printf("[ERROR] error reading workspace: %q organization: %q error: %s", options.Workspace, options.Organization, wErr)
 		return nil, wErr
 	}
 
+	var hash string
+	if options.SlugCacheFile != "" {
+		var reused *tfe.ConfigurationVersion
+		hash, reused = service.reusableConfigVersion(ctx, options)
+		if reused != nil {
+			service.writer.Output(fmt.Sprintf("Configuration is unchanged, reusing Configuration Version: %s", reused.ID))
+			return reused, nil
+		}
+	}
+
 	configVersion, cvErr := service.tfe.ConfigurationVersions.Create(ctx, workspace.ID, tfe.ConfigurationVersionCreateOptions{
 		Speculative:   &options.Speculative,
 		Provisional:   &options.Provisional,
@@ -58,6 +83,61 @@ func (service *configVersionService) UploadConfig(ctx context.Context, options U
 
 	service.writer.Output("Uploading configuration...")
 
+	configVersion, retryErr := service.waitForUpload(ctx, configVersion)
+	if retryErr != nil {
+		return configVersion, retryErr
+	}
+
+	if hash != "" && configVersion.Status == tfe.ConfigurationUploaded {
+		cache := readSlugCache(options.SlugCacheFile)
+		cache.Entries[slugCacheKey(options)] = &slugCacheEntry{Hash: hash, ConfigurationVersionID: configVersion.ID}
+		if cErr := cache.write(options.SlugCacheFile); cErr != nil {
+			log.Printf("[WARN] error writing slug cache: %q error: %s", options.SlugCacheFile, cErr)
+		}
+	}
+
+	return configVersion, err
+}
+
+// downloads the archive of an uploaded configuration version
+func (service *configVersionService) DownloadConfig(ctx context.Context, configVersionID string) ([]byte, error) {
+	archive, err := service.tfe.ConfigurationVersions.Download(ctx, configVersionID)
+	if err != nil {
+		log.Printf("[ERROR] error downloading configuration version: %q error: %s", configVersionID, err)
+		return nil, err
+	}
+	return archive, nil
+}
+
+// creates a configuration version in the workspace with an archive, as configuration versions
+// belong to a single workspace and cannot be used for runs in other workspaces
+func (service *configVersionService) UploadConfigArchive(ctx context.Context, options UploadArchiveOptions) (*tfe.ConfigurationVersion, error) {
+	workspace, err := service.readWorkspace(ctx, options.Organization, options.Workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q error: %s", options.Workspace, options.Organization, err)
+		return nil, err
+	}
+
+	configVersion, err := service.tfe.ConfigurationVersions.Create(ctx, workspace.ID, tfe.ConfigurationVersionCreateOptions{
+		Speculative:   &options.Speculative,
+		AutoQueueRuns: tfe.Bool(false),
+	})
+	if err != nil {
+		log.Printf("[ERROR] error creating configuration version: %s", err)
+		return nil, err
+	}
+	service.writer.Output(fmt.Sprintf("Configuration Version has been created: %s in workspace: %q", configVersion.ID, options.Workspace))
+
+	if err := service.tfe.ConfigurationVersions.UploadTarGzip(ctx, configVersion.UploadURL, bytes.NewReader(options.Archive)); err != nil {
+		log.Printf("[ERROR] error uploading configuration version: %s", err)
+		return configVersion, err
+	}
+	return service.waitForUpload(ctx, configVersion)
+}
+
+// polls the configuration version until it is uploaded or errored
+func (service *configVersionService) waitForUpload(ctx context.Context, configVersion *tfe.ConfigurationVersion) (*tfe.ConfigurationVersion, error) {
+	lastStatus := configVersion.Status
 	retryErr := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
 		log.Printf("[DEBUG] Monitoring Upload Status...")
 		cv, err := service.tfe.ConfigurationVersions.Read(ctx, configVersion.ID)
@@ -65,6 +145,10 @@ func (service *configVersionService) UploadConfig(ctx context.Context, options U
 			return err
 		}
 		service.writer.Output(fmt.Sprintf("Upload Status: %q", cv.Status))
+		if cv.Status != lastStatus {
+			lastStatus = cv.Status
+			service.emit(ctx, notify.NewEvent(notify.ConfigStatusChanged, cv.ID, string(cv.Status)))
+		}
 		if cv.Status == tfe.ConfigurationUploaded || cv.Status == tfe.ConfigurationErrored {
 			// update configVersion to latest results
 			configVersion = cv
@@ -75,10 +159,35 @@ func (service *configVersionService) UploadConfig(ctx context.Context, options U
 
 	if retryErr != nil {
 		log.Printf("[ERROR] error waiting for upload completion: %s", retryErr)
-		return configVersion, retryErr
 	}
+	return configVersion, retryErr
+}
 
-	return configVersion, err
+// returns the hash of the configuration and the configuration version previously uploaded with the
+// same hash, when it can still be used. cache errors are not fatal, the configuration is uploaded instead.
+func (service *configVersionService) reusableConfigVersion(ctx context.Context, options UploadOptions) (string, *tfe.ConfigurationVersion) {
+	hash, err := slugHash(options.ConfigurationDirectory)
+	if err != nil {
+		log.Printf("[WARN] error hashing configuration directory: %q error: %s", options.ConfigurationDirectory, err)
+		return "", nil
+	}
+	log.Printf("[DEBUG] configuration directory: %q slug hash: %s", options.ConfigurationDirectory, hash)
+
+	entry, ok := readSlugCache(options.SlugCacheFile).Entries[slugCacheKey(options)]
+	if !ok || entry.Hash != hash {
+		return hash, nil
+	}
+	cv, err := service.tfe.ConfigurationVersions.Read(ctx, entry.ConfigurationVersionID)
+	if err != nil {
+		log.Printf("[WARN] error reading cached configuration version: %q error: %s", entry.ConfigurationVersionID, err)
+		return hash, nil
+	}
+	// archived configuration versions can no longer be used for runs
+	if cv.Status != tfe.ConfigurationUploaded {
+		log.Printf("[DEBUG] cached configuration version: %q has status: %q, uploading configuration", cv.ID, cv.Status)
+		return hash, nil
+	}
+	return hash, cv
 }
 
 func NewConfigVersionService(meta *cloudMeta) ConfigVersionService {
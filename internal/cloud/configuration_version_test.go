@@ -6,8 +6,12 @@ package cloud
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/go-tfe/mocks"
@@ -153,3 +157,162 @@ func TestUpload(t *testing.T) {
 		})
 	}
 }
+
+func testSlugDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "null_resource" "a" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".terraformignore"), []byte("*.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSlugHash(t *testing.T) {
+	dir := testSlugDir(t)
+	hash, err := slugHash(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// modification times and ignored files do not change the hash
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "main.tf"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "debug.log"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if unchanged, _ := slugHash(dir); unchanged != hash {
+		t.Errorf("expected hash %s to be unchanged but received %s", hash, unchanged)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "null_resource" "b" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if changed, _ := slugHash(dir); changed == hash {
+		t.Error("expected hash to change with the configuration")
+	}
+}
+
+func TestUpload_SlugCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mConfigVersions := mocks.NewMockConfigurationVersions(ctrl)
+
+	dir := testSlugDir(t)
+	cacheFile := filepath.Join(t.TempDir(), ".tfci", "slug-cache.json")
+	options := UploadOptions{
+		Organization:           "abc-company",
+		Workspace:              "networking",
+		ConfigurationDirectory: dir,
+		SlugCacheFile:          cacheFile,
+	}
+	cv := &tfe.ConfigurationVersion{ID: "cv-1", UploadURL: "cv.com", Status: tfe.ConfigurationUploaded}
+
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil).Times(2)
+	// first upload, the cache is empty
+	mConfigVersions.EXPECT().Create(ctx, "ws-1", gomock.Any()).Return(cv, nil).Times(1)
+	mConfigVersions.EXPECT().Upload(ctx, "cv.com", dir).Return(nil).Times(1)
+	// polled after the upload, then read to check the cached configuration version can be reused
+	mConfigVersions.EXPECT().Read(gomock.Any(), "cv-1").Return(cv, nil).Times(2)
+
+	client := &tfe.Client{Workspaces: mWorkspaces, ConfigurationVersions: mConfigVersions}
+	service := NewConfigVersionService(&cloudMeta{tfe: client, writer: &defaultWriter{}})
+	for i := 0; i < 2; i++ {
+		uploaded, err := service.UploadConfig(ctx, options)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if uploaded.ID != "cv-1" {
+			t.Errorf("expected configuration version cv-1 but received %s", uploaded.ID)
+		}
+	}
+
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		t.Fatalf("expected slug cache to be written: %s", err)
+	}
+	if !strings.Contains(string(data), `"configuration_version_id": "cv-1"`) {
+		t.Errorf("expected slug cache to contain cv-1 but received %s", data)
+	}
+}
+
+func TestUpload_SlugCache_Archived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mConfigVersions := mocks.NewMockConfigurationVersions(ctrl)
+
+	dir := testSlugDir(t)
+	hash, err := slugHash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := UploadOptions{
+		Organization:           "abc-company",
+		Workspace:              "networking",
+		ConfigurationDirectory: dir,
+		SlugCacheFile:          filepath.Join(t.TempDir(), "slug-cache.json"),
+	}
+	cache := &slugCache{Entries: map[string]*slugCacheEntry{
+		slugCacheKey(options): {Hash: hash, ConfigurationVersionID: "cv-old"},
+	}}
+	if err := cache.write(options.SlugCacheFile); err != nil {
+		t.Fatal(err)
+	}
+
+	cv := &tfe.ConfigurationVersion{ID: "cv-new", UploadURL: "cv.com", Status: tfe.ConfigurationUploaded}
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+	mConfigVersions.EXPECT().Read(ctx, "cv-old").Return(&tfe.ConfigurationVersion{ID: "cv-old", Status: tfe.ConfigurationArchived}, nil)
+	mConfigVersions.EXPECT().Create(ctx, "ws-1", gomock.Any()).Return(cv, nil)
+	mConfigVersions.EXPECT().Upload(ctx, "cv.com", dir).Return(nil)
+	mConfigVersions.EXPECT().Read(gomock.Any(), "cv-new").Return(cv, nil)
+
+	client := &tfe.Client{Workspaces: mWorkspaces, ConfigurationVersions: mConfigVersions}
+	service := NewConfigVersionService(&cloudMeta{tfe: client, writer: &defaultWriter{}})
+	uploaded, err := service.UploadConfig(ctx, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if uploaded.ID != "cv-new" {
+		t.Errorf("expected archived configuration version to be replaced but received %s", uploaded.ID)
+	}
+	if entry := readSlugCache(options.SlugCacheFile).Entries[slugCacheKey(options)]; entry.ConfigurationVersionID != "cv-new" {
+		t.Errorf("expected slug cache to be updated with cv-new but received %s", entry.ConfigurationVersionID)
+	}
+}
+
+func TestUploadConfigArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mConfigVersions := mocks.NewMockConfigurationVersions(ctrl)
+
+	cv := &tfe.ConfigurationVersion{ID: "cv-2", UploadURL: "cv.com", Status: tfe.ConfigurationPending}
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "payments-db").Return(&tfe.Workspace{ID: "ws-2"}, nil)
+	mConfigVersions.EXPECT().Create(ctx, "ws-2", tfe.ConfigurationVersionCreateOptions{
+		Speculative:   tfe.Bool(true),
+		AutoQueueRuns: tfe.Bool(false),
+	}).Return(cv, nil)
+	mConfigVersions.EXPECT().UploadTarGzip(ctx, "cv.com", gomock.Any()).Return(nil)
+	mConfigVersions.EXPECT().Read(gomock.Any(), "cv-2").Return(&tfe.ConfigurationVersion{ID: "cv-2", Status: tfe.ConfigurationUploaded}, nil)
+
+	client := &tfe.Client{Workspaces: mWorkspaces, ConfigurationVersions: mConfigVersions}
+	service := NewConfigVersionService(&cloudMeta{tfe: client, writer: &defaultWriter{}})
+	uploaded, err := service.UploadConfigArchive(ctx, UploadArchiveOptions{
+		Organization: "abc-company",
+		Workspace:    "payments-db",
+		Archive:      []byte("archive"),
+		Speculative:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if uploaded.ID != "cv-2" || uploaded.Status != tfe.ConfigurationUploaded {
+		t.Errorf("expected uploaded configuration version cv-2 but received %+v", uploaded)
+	}
+}
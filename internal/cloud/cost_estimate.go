@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type CostEstimateDetails struct {
+	ID                      string                  `json:"id"`
+	Status                  string                  `json:"status"`
+	ErrorMessage            string                  `json:"error_message,omitempty"`
+	PriorMonthlyCost        string                  `json:"prior_monthly_cost"`
+	ProposedMonthlyCost     string                  `json:"proposed_monthly_cost"`
+	DeltaMonthlyCost        string                  `json:"delta_monthly_cost"`
+	ResourcesCount          int                     `json:"resources_count"`
+	MatchedResourcesCount   int                     `json:"matched_resources_count"`
+	UnmatchedResourcesCount int                     `json:"unmatched_resources_count"`
+	Resources               []*CostEstimateResource `json:"resources,omitempty"`
+}
+
+// cost of a single resource, unmatched resources are not supported by cost estimation and have no cost
+type CostEstimateResource struct {
+	Address             string `json:"address"`
+	Type                string `json:"type"`
+	Matched             bool   `json:"matched"`
+	PriorMonthlyCost    string `json:"prior_monthly_cost,omitempty"`
+	ProposedMonthlyCost string `json:"proposed_monthly_cost,omitempty"`
+	DeltaMonthlyCost    string `json:"delta_monthly_cost,omitempty"`
+}
+
+func newCostEstimateDetails(ce *tfe.CostEstimate) *CostEstimateDetails {
+	return &CostEstimateDetails{
+		ID:                      ce.ID,
+		Status:                  string(ce.Status),
+		ErrorMessage:            ce.ErrorMessage,
+		PriorMonthlyCost:        ce.PriorMonthlyCost,
+		ProposedMonthlyCost:     ce.ProposedMonthlyCost,
+		DeltaMonthlyCost:        ce.DeltaMonthlyCost,
+		ResourcesCount:          ce.ResourcesCount,
+		MatchedResourcesCount:   ce.MatchedResourcesCount,
+		UnmatchedResourcesCount: ce.UnmatchedResourcesCount,
+	}
+}
+
+// reads the cost estimate of a run, without its per-resource breakdown
+func (s *runService) GetCostEstimate(ctx context.Context, runID string) (*CostEstimateDetails, error) {
+	run, err := s.GetRun(ctx, GetRunOptions{RunID: runID})
+	if err != nil {
+		return nil, err
+	}
+	if run.CostEstimate == nil {
+		return nil, fmt.Errorf("run %s does not have a cost estimate, cost estimation may be disabled for the organization", runID)
+	}
+	return newCostEstimateDetails(run.CostEstimate), nil
+}
+
+// reads the per-resource breakdown of a finished cost estimate from its logs
+func (s *runService) GetCostEstimateResources(ctx context.Context, costEstimateID string) ([]*CostEstimateResource, error) {
+	logs, err := s.tfe.CostEstimates.Logs(ctx, costEstimateID)
+	if err != nil {
+		log.Printf("[ERROR] error reading cost estimate logs: %q error: %s", costEstimateID, err)
+		return nil, err
+	}
+	return parseCostEstimateResources(logs)
+}
+
+// cost estimate logs are a json document with the matched and unmatched resources
+type costEstimateLog struct {
+	Resources struct {
+		Matched   []*costEstimateLogResource `json:"matched"`
+		Unmatched []*costEstimateLogResource `json:"unmatched"`
+	} `json:"resources"`
+}
+
+type costEstimateLogResource struct {
+	Address             string `json:"address"`
+	Type                string `json:"type"`
+	PriorMonthlyCost    string `json:"prior-monthly-cost"`
+	ProposedMonthlyCost string `json:"proposed-monthly-cost"`
+	DeltaMonthlyCost    string `json:"delta-monthly-cost"`
+}
+
+func parseCostEstimateResources(logs io.Reader) ([]*CostEstimateResource, error) {
+	var doc costEstimateLog
+	if err := json.NewDecoder(logs).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error parsing cost estimate logs: %w", err)
+	}
+	resources := []*CostEstimateResource{}
+	for _, r := range doc.Resources.Matched {
+		resources = append(resources, &CostEstimateResource{
+			Address:             r.Address,
+			Type:                r.Type,
+			Matched:             true,
+			PriorMonthlyCost:    r.PriorMonthlyCost,
+			ProposedMonthlyCost: r.ProposedMonthlyCost,
+			DeltaMonthlyCost:    r.DeltaMonthlyCost,
+		})
+	}
+	for _, r := range doc.Resources.Unmatched {
+		resources = append(resources, &CostEstimateResource{Address: r.Address, Type: r.Type})
+	}
+	return resources, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const testCostEstimateLogs = `{
+  "delta-monthly-cost": "8.35",
+  "prior-monthly-cost": "0.0",
+  "proposed-monthly-cost": "8.35",
+  "resources": {
+    "matched": [
+      {"address": "aws_instance.web", "type": "aws_instance", "name": "web", "prior-monthly-cost": "0.0", "proposed-monthly-cost": "8.35", "delta-monthly-cost": "8.35"}
+    ],
+    "unmatched": [
+      {"address": "aws_s3_bucket.logs", "type": "aws_s3_bucket", "name": "logs"}
+    ]
+  }
+}`
+
+func TestRunService_GetCostEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mRuns := mocks.NewMockRuns(ctrl)
+	mCostEstimates := mocks.NewMockCostEstimates(ctrl)
+
+	mRuns.EXPECT().ReadWithOptions(ctx, "run-1", gomock.Any()).Return(&tfe.Run{
+		ID: "run-1",
+		CostEstimate: &tfe.CostEstimate{
+			ID:                      "ce-1",
+			Status:                  tfe.CostEstimateFinished,
+			DeltaMonthlyCost:        "8.35",
+			ResourcesCount:          2,
+			MatchedResourcesCount:   1,
+			UnmatchedResourcesCount: 1,
+		},
+	}, nil)
+	mCostEstimates.EXPECT().Logs(ctx, "ce-1").Return(strings.NewReader(testCostEstimateLogs), nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Runs: mRuns, CostEstimates: mCostEstimates}, writer: &testLogWriter{}}}
+	estimate, err := service.GetCostEstimate(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if estimate.DeltaMonthlyCost != "8.35" || estimate.MatchedResourcesCount != 1 || estimate.UnmatchedResourcesCount != 1 {
+		t.Errorf("unexpected cost estimate %+v", estimate)
+	}
+
+	resources, err := service.GetCostEstimateResources(ctx, estimate.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources but received %d", len(resources))
+	}
+	if r := resources[0]; r.Address != "aws_instance.web" || !r.Matched || r.ProposedMonthlyCost != "8.35" {
+		t.Errorf("unexpected matched resource %+v", r)
+	}
+	if r := resources[1]; r.Address != "aws_s3_bucket.logs" || r.Matched {
+		t.Errorf("unexpected unmatched resource %+v", r)
+	}
+}
+
+func TestRunService_GetCostEstimate_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mRuns := mocks.NewMockRuns(ctrl)
+	mRuns.EXPECT().ReadWithOptions(gomock.Any(), "run-1", gomock.Any()).Return(&tfe.Run{ID: "run-1"}, nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Runs: mRuns}, writer: &testLogWriter{}}}
+	if _, err := service.GetCostEstimate(context.Background(), "run-1"); err == nil || !strings.Contains(err.Error(), "does not have a cost estimate") {
+		t.Errorf("expected missing cost estimate error but received %v", err)
+	}
+}
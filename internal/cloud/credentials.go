@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+)
+
+// credentials written by `terraform login`, relative to the home directory
+const credentialsFile = ".terraform.d/credentials.tfrc.json"
+
+// subset of the Terraform CLI configuration file used to resolve credentials
+// https://developer.hashicorp.com/terraform/cli/config/config-file#credentials
+type cliConfig struct {
+	Credentials        map[string]map[string]interface{} `hcl:"credentials"`
+	CredentialsHelpers map[string]*cliCredentialsHelper  `hcl:"credentials_helper"`
+}
+
+type cliCredentialsHelper struct {
+	Args []string `hcl:"args"`
+}
+
+// resolves api tokens in the same order as the Terraform CLI
+type cliCredentials struct {
+	homeDir string
+	getenv  func(string) string
+}
+
+func newCLICredentials() *cliCredentials {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		log.Printf("[DEBUG] unable to resolve home directory for Terraform CLI credentials: %s", err)
+	}
+	return &cliCredentials{
+		homeDir: home,
+		getenv:  os.Getenv,
+	}
+}
+
+func (c *cliCredentials) Token(host string) (string, error) {
+	// TF_TOKEN_app_terraform_io
+	envName := "TF_TOKEN_" + strings.ReplaceAll(strings.ReplaceAll(host, "-", "__"), ".", "_")
+	if token := c.getenv(envName); token != "" {
+		log.Printf("[DEBUG] token read from %s", envName)
+		return token, nil
+	}
+
+	config, err := c.readConfig()
+	if err != nil {
+		return "", err
+	}
+
+	if creds, ok := config.Credentials[host]; ok {
+		if token, ok := creds["token"].(string); ok && token != "" {
+			log.Printf("[DEBUG] token read from Terraform CLI credentials for: %s", host)
+			return token, nil
+		}
+	}
+
+	// only a single credentials helper is supported by the Terraform CLI
+	for name, helper := range config.CredentialsHelpers {
+		log.Printf("[DEBUG] token read from Terraform CLI credentials helper: %s", name)
+		return c.helperToken(name, helper, host)
+	}
+
+	return "", nil
+}
+
+func (c *cliCredentials) readConfig() (*cliConfig, error) {
+	config := &cliConfig{
+		Credentials:        make(map[string]map[string]interface{}),
+		CredentialsHelpers: make(map[string]*cliCredentialsHelper),
+	}
+
+	configFile := c.getenv("TF_CLI_CONFIG_FILE")
+	if configFile == "" && c.homeDir != "" {
+		configFile = filepath.Join(c.homeDir, ".terraformrc")
+	}
+	if err := decodeCLIConfig(configFile, func(src []byte) (*cliConfig, error) {
+		var rc cliConfig
+		err := hcl.Decode(&rc, string(src))
+		return &rc, err
+	}, config); err != nil {
+		return nil, err
+	}
+
+	if c.homeDir != "" {
+		if err := decodeCLIConfig(filepath.Join(c.homeDir, credentialsFile), func(src []byte) (*cliConfig, error) {
+			var rc cliConfig
+			err := json.Unmarshal(src, &rc)
+			return &rc, err
+		}, config); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// merges the config file into config, missing files are ignored
+func decodeCLIConfig(path string, decode func([]byte) (*cliConfig, error), config *cliConfig) error {
+	if path == "" {
+		return nil
+	}
+
+	src, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rc, err := decode(src)
+	if err != nil {
+		return fmt.Errorf("error reading Terraform CLI configuration %s: %w", path, err)
+	}
+
+	for host, creds := range rc.Credentials {
+		config.Credentials[host] = creds
+	}
+	for name, helper := range rc.CredentialsHelpers {
+		config.CredentialsHelpers[name] = helper
+	}
+	return nil
+}
+
+// https://developer.hashicorp.com/terraform/internals/credentials-helpers
+func (c *cliCredentials) helperToken(name string, helper *cliCredentialsHelper, host string) (string, error) {
+	program := "terraform-credentials-" + name
+	path, err := exec.LookPath(program)
+	if err != nil && c.homeDir != "" {
+		path, err = exec.LookPath(filepath.Join(c.homeDir, ".terraform.d", "plugins", program))
+	}
+	if err != nil {
+		return "", fmt.Errorf("credentials helper %q not found: %w", name, err)
+	}
+
+	var args []string
+	if helper != nil {
+		args = append(args, helper.Args...)
+	}
+	args = append(args, "get", host)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("credentials helper %q failed: %s %w", name, strings.TrimSpace(stderr.String()), err)
+	}
+
+	var creds map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return "", fmt.Errorf("credentials helper %q returned invalid json: %w", name, err)
+	}
+	token, _ := creds["token"].(string)
+	return token, nil
+}
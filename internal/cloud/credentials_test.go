@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path string, contents string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("error creating directory: %s", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), perm); err != nil {
+		t.Fatalf("error writing file: %s", err)
+	}
+}
+
+func TestCLICredentials_Token(t *testing.T) {
+	testCases := []struct {
+		name     string
+		host     string
+		env      map[string]string
+		rc       string
+		json     string
+		expected string
+	}{
+		{
+			name:     "env-token",
+			host:     "tfe.my-company.com",
+			env:      map[string]string{"TF_TOKEN_tfe_my__company_com": "env-token"},
+			expected: "env-token",
+		},
+		{
+			name:     "terraformrc",
+			host:     "app.terraform.io",
+			rc:       `credentials "app.terraform.io" { token = "rc-token" }`,
+			expected: "rc-token",
+		},
+		{
+			name:     "credentials-json",
+			host:     "app.terraform.io",
+			json:     `{"credentials": {"app.terraform.io": {"token": "json-token"}}}`,
+			expected: "json-token",
+		},
+		{
+			name:     "unknown-host",
+			host:     "tfe.example.com",
+			json:     `{"credentials": {"app.terraform.io": {"token": "json-token"}}}`,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			home := t.TempDir()
+			if tc.rc != "" {
+				writeTestFile(t, filepath.Join(home, ".terraformrc"), tc.rc, 0644)
+			}
+			if tc.json != "" {
+				writeTestFile(t, filepath.Join(home, credentialsFile), tc.json, 0644)
+			}
+
+			creds := &cliCredentials{
+				homeDir: home,
+				getenv:  func(k string) string { return tc.env[k] },
+			}
+
+			token, err := creds.Token(tc.host)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if token != tc.expected {
+				t.Errorf("expected %q but received %q", tc.expected, token)
+			}
+		})
+	}
+}
+
+func TestCLICredentials_Helper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credentials helper test requires a posix shell")
+	}
+
+	home := t.TempDir()
+	writeTestFile(t, filepath.Join(home, ".terraformrc"), `credentials_helper "test" { args = ["--flag"] }`, 0644)
+	writeTestFile(t, filepath.Join(home, ".terraform.d", "plugins", "terraform-credentials-test"), "#!/bin/sh\necho '{\"token\": \"helper-token-'$3'\"}'\n", 0755)
+
+	creds := &cliCredentials{
+		homeDir: home,
+		getenv:  func(k string) string { return "" },
+	}
+
+	token, err := creds.Token("app.terraform.io")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "helper-token-app.terraform.io"; token != expected {
+		t.Errorf("expected %q but received %q", expected, token)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// an error or warning reported by Terraform
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+	// resource address the diagnostic applies to, including its module
+	Address  string `json:"address,omitempty"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// structured run output, one json message per log line
+type jsonLogMessage struct {
+	Type       string `json:"type"`
+	Diagnostic *struct {
+		Severity string `json:"severity"`
+		Summary  string `json:"summary"`
+		Detail   string `json:"detail"`
+		Address  string `json:"address"`
+		Range    *struct {
+			Filename string `json:"filename"`
+			Start    struct {
+				Line int `json:"line"`
+			} `json:"start"`
+		} `json:"range"`
+	} `json:"diagnostic"`
+}
+
+var (
+	diagnosticStartPattern    = regexp.MustCompile(`^(Error|Warning): (.*)$`)
+	diagnosticAddressPattern  = regexp.MustCompile(`^\s+with (\S+?),?$`)
+	diagnosticLocationPattern = regexp.MustCompile(`^\s+on (\S+) line (\d+)`)
+	moduleAddressPattern      = regexp.MustCompile(`^(module\.[^.\[]+(\[[^\]]*\])?\.)+`)
+)
+
+// reads the diagnostics of the plan or apply that caused the run to error
+func (service *runService) GetRunDiagnostics(ctx context.Context, run *tfe.Run) ([]*Diagnostic, error) {
+	logs, err := service.erroredRunLogs(ctx, run)
+	if err != nil || logs == nil {
+		return nil, err
+	}
+	return parseDiagnostics(logs)
+}
+
+// opens the logs of the errored plan, or the apply when the plan succeeded
+func (service *runService) erroredRunLogs(ctx context.Context, run *tfe.Run) (io.Reader, error) {
+	if run == nil || run.Status != tfe.RunErrored {
+		return nil, nil
+	}
+	if run.Apply != nil && run.Apply.ID != "" && (run.Plan == nil || run.Plan.Status != tfe.PlanErrored) {
+		return service.tfe.Applies.Logs(ctx, run.Apply.ID)
+	}
+	if run.Plan != nil && run.Plan.ID != "" {
+		return service.tfe.Plans.Logs(ctx, run.Plan.ID)
+	}
+	return nil, nil
+}
+
+// parses diagnostics from structured json logs, or the human readable output of older Terraform versions
+func parseDiagnostics(logs io.Reader) ([]*Diagnostic, error) {
+	diagnostics := []*Diagnostic{}
+	var current *Diagnostic
+	var detail []string
+	finish := func() {
+		if current != nil {
+			current.Detail = strings.TrimSpace(strings.Join(detail, "\n"))
+			diagnostics = append(diagnostics, current)
+		}
+		current, detail = nil, nil
+	}
+
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.HasPrefix(line, "{") {
+			var msg jsonLogMessage
+			if err := json.Unmarshal([]byte(line), &msg); err == nil {
+				if msg.Type == "diagnostic" && msg.Diagnostic != nil {
+					d := &Diagnostic{
+						Severity: msg.Diagnostic.Severity,
+						Summary:  msg.Diagnostic.Summary,
+						Detail:   msg.Diagnostic.Detail,
+						Address:  msg.Diagnostic.Address,
+						Module:   moduleAddress(msg.Diagnostic.Address),
+					}
+					if msg.Diagnostic.Range != nil {
+						d.Filename = msg.Diagnostic.Range.Filename
+						d.Line = msg.Diagnostic.Range.Start.Line
+					}
+					diagnostics = append(diagnostics, d)
+				}
+				continue
+			}
+		}
+
+		// diagnostics are framed by box drawing characters
+		switch {
+		case strings.HasPrefix(line, "╷"):
+			finish()
+			continue
+		case strings.HasPrefix(line, "╵"):
+			finish()
+			continue
+		}
+		line = strings.TrimPrefix(strings.TrimPrefix(line, "│"), " ")
+
+		if m := diagnosticStartPattern.FindStringSubmatch(line); m != nil {
+			finish()
+			current = &Diagnostic{Severity: strings.ToLower(m[1]), Summary: strings.TrimSpace(m[2])}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if m := diagnosticAddressPattern.FindStringSubmatch(line); m != nil && current.Address == "" {
+			current.Address = m[1]
+			current.Module = moduleAddress(m[1])
+			continue
+		}
+		if m := diagnosticLocationPattern.FindStringSubmatch(line); m != nil && current.Filename == "" {
+			current.Filename = strings.TrimSuffix(m[1], ",")
+			current.Line, _ = strconv.Atoi(m[2])
+			continue
+		}
+		// source snippets are indented, the detail is not
+		if line == "" || !strings.HasPrefix(line, " ") {
+			detail = append(detail, line)
+		}
+	}
+	finish()
+	return diagnostics, scanner.Err()
+}
+
+// the module path of a resource address, empty for the root module
+func moduleAddress(address string) string {
+	return strings.TrimSuffix(moduleAddressPattern.FindString(address), ".")
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDiagnostics_JSON(t *testing.T) {
+	logs := `{"@level":"info","@message":"Terraform 1.9.0","type":"version"}
+{"@level":"error","@message":"Error: Unsupported argument","type":"diagnostic","diagnostic":{"severity":"error","summary":"Unsupported argument","detail":"An argument named \"foo\" is not expected here.","address":"module.vpc[\"east\"].aws_vpc.main","range":{"filename":"modules/vpc/main.tf","start":{"line":3,"column":3}}}}
+{"@level":"warn","@message":"Warning: Deprecated attribute","type":"diagnostic","diagnostic":{"severity":"warning","summary":"Deprecated attribute"}}
+`
+	diagnostics, err := parseDiagnostics(strings.NewReader(logs))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []*Diagnostic{
+		{
+			Severity: "error",
+			Summary:  "Unsupported argument",
+			Detail:   `An argument named "foo" is not expected here.`,
+			Address:  `module.vpc["east"].aws_vpc.main`,
+			Module:   `module.vpc["east"]`,
+			Filename: "modules/vpc/main.tf",
+			Line:     3,
+		},
+		{Severity: "warning", Summary: "Deprecated attribute"},
+	}
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("expected %+v but received %+v", expected, diagnostics)
+	}
+}
+
+func TestParseDiagnostics_Text(t *testing.T) {
+	logs := `Terraform v1.9.0
+aws_instance.web: Refreshing state...
+╷
+│ Error: creating EC2 Instance: InvalidAMIID.NotFound
+│ 
+│   with module.app.aws_instance.web,
+│   on modules/app/main.tf line 12, in resource "aws_instance" "web":
+│   12: resource "aws_instance" "web" {
+│ 
+│ The image id does not exist.
+│ Check the ami variable.
+╵
+`
+	diagnostics, err := parseDiagnostics(strings.NewReader(logs))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []*Diagnostic{{
+		Severity: "error",
+		Summary:  "creating EC2 Instance: InvalidAMIID.NotFound",
+		Detail:   "The image id does not exist.\nCheck the ami variable.",
+		Address:  "module.app.aws_instance.web",
+		Module:   "module.app",
+		Filename: "modules/app/main.tf",
+		Line:     12,
+	}}
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("expected %+v but received %+v", expected[0], diagnostics)
+	}
+}
+
+func TestModuleAddress(t *testing.T) {
+	cases := map[string]string{
+		"aws_vpc.main":                         "",
+		"module.a.aws_vpc.main":                "module.a",
+		"module.a[0].module.b.aws_vpc.main[1]": "module.a[0].module.b",
+		`module.a["x.y"].aws_vpc.main`:         `module.a["x.y"]`,
+		"module.a.data.aws_region.current":     "module.a",
+		"":                                     "",
+	}
+	for address, expected := range cases {
+		if actual := moduleAddress(address); actual != expected {
+			t.Errorf("expected module %q for %q but received %q", expected, address, actual)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// environment variable set on the workspace before a run is created, as run variables
+// only support the terraform category
+type EnvVariable struct {
+	Key       string
+	Value     string
+	Sensitive bool
+}
+
+// creates or updates environment variables of the workspace, returning true when a variable changed.
+// sensitive values cannot be read, so they are always updated and reported as changed
+func (service *runService) setEnvVariables(ctx context.Context, workspaceID string, vars []*EnvVariable) (bool, error) {
+	if len(vars) == 0 {
+		return false, nil
+	}
+	existing, err := service.listVariables(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, v := range vars {
+		var current *tfe.Variable
+		for _, e := range existing {
+			if e.Key == v.Key && e.Category == tfe.CategoryEnv {
+				current = e
+				break
+			}
+		}
+
+		if current == nil {
+			if _, err := service.tfe.Variables.Create(ctx, workspaceID, tfe.VariableCreateOptions{
+				Key:       tfe.String(v.Key),
+				Value:     tfe.String(v.Value),
+				Category:  tfe.Category(tfe.CategoryEnv),
+				Sensitive: tfe.Bool(v.Sensitive),
+			}); err != nil {
+				log.Printf("[ERROR] error creating environment variable: %q error: %s", v.Key, err)
+				return changed, err
+			}
+			service.writer.Output(fmt.Sprintf("Created environment variable: %q", v.Key))
+			changed = true
+			continue
+		}
+
+		if current.Value == v.Value && current.Sensitive == v.Sensitive && !v.Sensitive {
+			continue
+		}
+		if _, err := service.tfe.Variables.Update(ctx, workspaceID, current.ID, tfe.VariableUpdateOptions{
+			Key:       tfe.String(v.Key),
+			Value:     tfe.String(v.Value),
+			Sensitive: tfe.Bool(v.Sensitive),
+		}); err != nil {
+			log.Printf("[ERROR] error updating environment variable: %q error: %s", v.Key, err)
+			return changed, err
+		}
+		service.writer.Output(fmt.Sprintf("Updated environment variable: %q", v.Key))
+		changed = true
+	}
+	return changed, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_SetEnvVariables(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mVariables := mocks.NewMockVariables(ctrl)
+	mVariables.EXPECT().List(ctx, "ws-1", &tfe.VariableListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.VariableList{
+		Items: []*tfe.Variable{
+			{ID: "var-1", Key: "AWS_REGION", Value: "us-east-1", Category: tfe.CategoryEnv},
+			{ID: "var-2", Key: "LOG_LEVEL", Value: "info", Category: tfe.CategoryEnv},
+			{ID: "var-3", Key: "TOKEN", Category: tfe.CategoryTerraform},
+		},
+		Pagination: &tfe.Pagination{},
+	}, nil)
+	mVariables.EXPECT().Update(ctx, "ws-1", "var-2", tfe.VariableUpdateOptions{
+		Key:       tfe.String("LOG_LEVEL"),
+		Value:     tfe.String("debug"),
+		Sensitive: tfe.Bool(false),
+	}).Return(&tfe.Variable{}, nil)
+	// a terraform variable with the same key is not updated
+	mVariables.EXPECT().Create(ctx, "ws-1", tfe.VariableCreateOptions{
+		Key:       tfe.String("TOKEN"),
+		Value:     tfe.String("secret"),
+		Category:  tfe.Category(tfe.CategoryEnv),
+		Sensitive: tfe.Bool(true),
+	}).Return(&tfe.Variable{}, nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Variables: mVariables}, writer: &defaultWriter{}}}
+	changed, err := service.setEnvVariables(ctx, "ws-1", []*EnvVariable{
+		{Key: "AWS_REGION", Value: "us-east-1"},
+		{Key: "LOG_LEVEL", Value: "debug"},
+		{Key: "TOKEN", Value: "secret", Sensitive: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !changed {
+		t.Error("expected environment variables to be changed")
+	}
+}
+
+func TestRunService_SetEnvVariables_Unchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mVariables := mocks.NewMockVariables(ctrl)
+	mVariables.EXPECT().List(ctx, "ws-1", gomock.Any()).Return(&tfe.VariableList{
+		Items:      []*tfe.Variable{{ID: "var-1", Key: "AWS_REGION", Value: "us-east-1", Category: tfe.CategoryEnv}},
+		Pagination: &tfe.Pagination{},
+	}, nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Variables: mVariables}, writer: &defaultWriter{}}}
+	changed, err := service.setEnvVariables(ctx, "ws-1", []*EnvVariable{{Key: "AWS_REGION", Value: "us-east-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if changed {
+		t.Error("expected environment variables to be unchanged")
+	}
+}
+
+func TestRunService_CreateRun_EnvVariablesLockedWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "my-workspace").Return(&tfe.Workspace{ID: "ws-1", Locked: true}, nil)
+	// variables of a locked workspace are not changed
+	mVariables := mocks.NewMockVariables(ctrl)
+
+	service := NewRunService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces, Variables: mVariables}, writer: &defaultWriter{}})
+	_, err := service.CreateRun(ctx, CreateRunOptions{
+		Organization: "abc-company",
+		Workspace:    "my-workspace",
+		EnvVariables: []*EnvVariable{{Key: "AWS_REGION", Value: "us-east-1"}},
+	})
+
+	expected := "run has been specified as non-speculative and the workspace is currently locked"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q but received: %v", expected, err)
+	}
+}
+
+func TestRunService_CreateRun_EnvVariablesPlanOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	service := NewRunService(&cloudMeta{tfe: &tfe.Client{Workspaces: mocks.NewMockWorkspaces(ctrl), Variables: mocks.NewMockVariables(ctrl)}, writer: &defaultWriter{}})
+	_, err := service.CreateRun(context.Background(), CreateRunOptions{
+		Organization: "abc-company",
+		Workspace:    "my-workspace",
+		PlanOnly:     true,
+		EnvVariables: []*EnvVariable{{Key: "AWS_REGION", Value: "us-east-1"}},
+	})
+
+	expected := "environment variables cannot be set for plan only runs, they remain set on the workspace for later runs"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q but received: %v", expected, err)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// explorer views that can be queried, see https://developer.hashicorp.com/terraform/cloud-docs/api-docs/explorer
+var ExplorerTypes = []string{"workspaces", "tf_versions", "providers", "modules"}
+
+type ExplorerFilter struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+type ExplorerQueryOptions struct {
+	Organization string
+	Type         string
+	Filters      []*ExplorerFilter
+	Sort         string
+}
+
+// attributes of a row of an explorer view, keyed by field name
+type ExplorerRow map[string]interface{}
+
+type ExplorerService interface {
+	QueryExplorer(context.Context, ExplorerQueryOptions) ([]ExplorerRow, error)
+}
+
+type explorerService struct {
+	*cloudMeta
+}
+
+type explorerResponse struct {
+	Data []struct {
+		Attributes ExplorerRow `json:"attributes"`
+	} `json:"data"`
+	Meta struct {
+		Pagination *struct {
+			CurrentPage int `json:"current-page"`
+			NextPage    int `json:"next-page"`
+			TotalPages  int `json:"total-pages"`
+			TotalCount  int `json:"total-count"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// go-tfe has no explorer client, so the query is sent with the client's raw requests
+func (s *explorerService) QueryExplorer(ctx context.Context, options ExplorerQueryOptions) ([]ExplorerRow, error) {
+	path := fmt.Sprintf("organizations/%s/explorer", url.PathEscape(options.Organization))
+	rows, err := listAll(func(opts tfe.ListOptions) ([]ExplorerRow, *tfe.Pagination, error) {
+		params := explorerQueryParams(options, opts)
+		req, err := s.tfe.NewRequestWithAdditionalQueryParams("GET", path, nil, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		var body bytes.Buffer
+		if err := req.Do(ctx, &body); err != nil {
+			return nil, nil, err
+		}
+		var resp explorerResponse
+		if err := json.Unmarshal(body.Bytes(), &resp); err != nil {
+			return nil, nil, fmt.Errorf("error parsing explorer response: %w", err)
+		}
+		page := make([]ExplorerRow, 0, len(resp.Data))
+		for _, d := range resp.Data {
+			page = append(page, d.Attributes)
+		}
+		if resp.Meta.Pagination == nil {
+			return page, nil, nil
+		}
+		return page, &tfe.Pagination{
+			CurrentPage: resp.Meta.Pagination.CurrentPage,
+			NextPage:    resp.Meta.Pagination.NextPage,
+			TotalPages:  resp.Meta.Pagination.TotalPages,
+			TotalCount:  resp.Meta.Pagination.TotalCount,
+		}, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error querying explorer type: %q organization: %q error: %s", options.Type, options.Organization, err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+func explorerQueryParams(options ExplorerQueryOptions, opts tfe.ListOptions) map[string][]string {
+	params := map[string][]string{
+		"type":       {options.Type},
+		"page[size]": {strconv.Itoa(opts.PageSize)},
+	}
+	if opts.PageNumber > 0 {
+		params["page[number]"] = []string{strconv.Itoa(opts.PageNumber)}
+	}
+	if options.Sort != "" {
+		params["sort"] = []string{options.Sort}
+	}
+	for i, f := range options.Filters {
+		key := fmt.Sprintf("filter[%d][%s][%s][0]", i, f.Field, f.Operator)
+		params[key] = []string{f.Value}
+	}
+	return params
+}
+
+func NewExplorerService(meta *cloudMeta) *explorerService {
+	return &explorerService{meta}
+}
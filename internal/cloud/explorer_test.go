@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestExplorerService_QueryExplorer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/organizations/abc-company/explorer" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("type") != "workspaces" || q.Get("filter[0][terraform_version][lt][0]") != "1.6" || q.Get("sort") != "-workspace_name" {
+			t.Errorf("unexpected explorer query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if q.Get("page[number]") == "" {
+			fmt.Fprint(w, `{"data":[{"type":"visibility-workspace","attributes":{"workspace_name":"networking","terraform_version":"1.5.7","drifted":false}}],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"type":"visibility-workspace","attributes":{"workspace_name":"compute","terraform_version":"1.4.0","drifted":true}}],"meta":{"pagination":{"current-page":2,"next-page":null,"total-pages":2}}}`)
+	}))
+	defer server.Close()
+
+	client, err := tfe.NewClient(&tfe.Config{Address: server.URL, Token: "token"})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	service := NewExplorerService(&cloudMeta{tfe: client, writer: &defaultWriter{}})
+	rows, err := service.QueryExplorer(context.Background(), ExplorerQueryOptions{
+		Organization: "abc-company",
+		Type:         "workspaces",
+		Filters:      []*ExplorerFilter{{Field: "terraform_version", Operator: "lt", Value: "1.6"}},
+		Sort:         "-workspace_name",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rows) != 2 || rows[0]["workspace_name"] != "networking" || rows[1]["drifted"] != true {
+		t.Errorf("unexpected explorer rows %v", rows)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// runs that will not change status
+var terminalRunStatuses = []tfe.RunStatus{
+	tfe.RunApplied,
+	tfe.RunPlannedAndFinished,
+	tfe.RunPlannedAndSaved,
+	tfe.RunErrored,
+	tfe.RunDiscarded,
+	tfe.RunCanceled,
+	"force_canceled",
+}
+
+// the key is recorded in the run message, the only run attribute set by the client that can be searched
+func idempotencyTag(key string) string {
+	return fmt.Sprintf("[tfci-idempotency-key: %s]", key)
+}
+
+func idempotencyMessage(message string, key string) string {
+	tag := idempotencyTag(key)
+	if strings.Contains(message, tag) {
+		return message
+	}
+	if message == "" {
+		return tag
+	}
+	return fmt.Sprintf("%s %s", message, tag)
+}
+
+// returns the most recent unfinished run in the workspace created with the idempotency key
+func (service *runService) findRunByIdempotencyKey(ctx context.Context, workspaceID string, key string) (*tfe.Run, error) {
+	runs, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Run, *tfe.Pagination, error) {
+		page, err := service.tfe.Runs.List(ctx, workspaceID, &tfe.RunListOptions{ListOptions: opts, Search: key})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing runs of workspace: %q with idempotency key: %q error: %s", workspaceID, key, err)
+		return nil, err
+	}
+
+	// runs are listed newest first
+	tag := idempotencyTag(key)
+	for _, run := range runs {
+		if strings.Contains(run.Message, tag) && !slices.Contains(terminalRunStatuses, run.Status) {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
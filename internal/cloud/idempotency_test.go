@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyMessage(t *testing.T) {
+	cases := []struct {
+		message  string
+		expected string
+	}{
+		{message: "", expected: "[tfci-idempotency-key: deploy-42]"},
+		{message: "Triggered from CI", expected: "Triggered from CI [tfci-idempotency-key: deploy-42]"},
+		{message: "Retry [tfci-idempotency-key: deploy-42]", expected: "Retry [tfci-idempotency-key: deploy-42]"},
+	}
+	for _, tc := range cases {
+		if actual := idempotencyMessage(tc.message, "deploy-42"); actual != tc.expected {
+			t.Errorf("expected message %q but received %q", tc.expected, actual)
+		}
+	}
+}
+
+func testIdempotencyMocks(ctrl *gomock.Controller, runs []*tfe.Run) (*mocks.MockWorkspaces, *mocks.MockRuns) {
+	ctx := context.Background()
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+
+	mRuns := mocks.NewMockRuns(ctrl)
+	mRuns.EXPECT().List(ctx, "ws-1", &tfe.RunListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+		Search:      "deploy-42",
+	}).Return(&tfe.RunList{Items: runs, Pagination: &tfe.Pagination{CurrentPage: 1}}, nil)
+	return mWorkspaces, mRuns
+}
+
+func TestRunService_CreateRun_IdempotencyKeyAttaches(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mWorkspaces, mRuns := testIdempotencyMocks(ctrl, []*tfe.Run{
+		{ID: "run-other", Status: tfe.RunPlanning, Message: "Triggered from CI"},
+		{ID: "run-retry", Status: tfe.RunPlanning, Message: "Triggered from CI [tfci-idempotency-key: deploy-42]"},
+		{ID: "run-done", Status: tfe.RunApplied, Message: "Triggered from CI [tfci-idempotency-key: deploy-42]"},
+	})
+	mRuns.EXPECT().ReadWithOptions(ctx, "run-retry", gomock.Any()).Return(&tfe.Run{ID: "run-retry", Status: tfe.RunPlannedAndFinished}, nil)
+
+	service := NewRunService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces, Runs: mRuns}, writer: &defaultWriter{}})
+	run, err := service.CreateRun(ctx, CreateRunOptions{
+		Organization:   "abc-company",
+		Workspace:      "networking",
+		Message:        "Triggered from CI",
+		IdempotencyKey: "deploy-42",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if run.ID != "run-retry" {
+		t.Errorf("expected to attach to run-retry but received %s", run.ID)
+	}
+}
+
+func TestRunService_CreateRun_IdempotencyKeyCreates(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	// a finished run with the same key does not prevent a new run
+	mWorkspaces, mRuns := testIdempotencyMocks(ctrl, []*tfe.Run{
+		{ID: "run-done", Status: tfe.RunErrored, Message: "Triggered from CI [tfci-idempotency-key: deploy-42]"},
+	})
+	mRuns.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, opts tfe.RunCreateOptions) (*tfe.Run, error) {
+		if !strings.HasSuffix(*opts.Message, "[tfci-idempotency-key: deploy-42]") {
+			t.Errorf("expected idempotency key in run message but received %q", *opts.Message)
+		}
+		return &tfe.Run{ID: "run-new", Status: tfe.RunPending}, nil
+	})
+	mRuns.EXPECT().ReadWithOptions(ctx, "run-new", gomock.Any()).Return(&tfe.Run{ID: "run-new", Status: tfe.RunPlannedAndFinished}, nil)
+
+	service := NewRunService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces, Runs: mRuns}, writer: &defaultWriter{}})
+	run, err := service.CreateRun(ctx, CreateRunOptions{
+		Organization:   "abc-company",
+		Workspace:      "networking",
+		Message:        "Triggered from CI",
+		IdempotencyKey: "deploy-42",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if run.ID != "run-new" {
+		t.Errorf("expected new run but received %s", run.ID)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// attempts to resume reading logs after a read error
+const maxLogResumes = 3
+
+// logs that can be persisted to files while they are streamed
+type LogKind string
+
+const (
+	PlanLog  LogKind = "Plan Log"
+	ApplyLog LogKind = "Apply Log"
+)
+
+// opens the file the logs are also written to, returns nil when the logs are not persisted.
+// resumed logs are appended, since the lines already written are skipped.
+func (m *cloudMeta) openLogFile(kind LogKind, resume bool) (*os.File, error) {
+	path := m.logFiles[kind]
+	if path == "" {
+		return nil, nil
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	return os.OpenFile(path, flags, 0644)
+}
+
+// maximum time to read plan and apply logs
+func logTimeout() time.Duration {
+	if retryOptions.LogTimeout > 0 {
+		return retryOptions.LogTimeout
+	}
+	return Timeout()
+}
+
+// writes logs until the operation has finished or the log timeout elapses.
+// the log reader polls the operation status while waiting for more output, when reading
+// fails, logs are reopened and resume after the lines that have already been written.
+func (service *runService) streamLogs(ctx context.Context, kind LogKind, open func(context.Context) (io.Reader, error)) error {
+	title := string(kind)
+	timeout := logTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := pollBackoff(timeout)
+	var offset int64
+	for attempt := 0; ; attempt++ {
+		reader, err := open(ctx)
+		if err == nil && offset > 0 {
+			// skip lines that have already been written
+			_, err = io.CopyN(io.Discard, reader, offset)
+		}
+		if err == nil {
+			if offset == 0 {
+				service.writer.Output(fmt.Sprintf("-------------- %s --------------", title))
+			}
+			var written int64
+			written, err = service.writeLogLines(kind, reader, offset > 0)
+			offset += written
+			if err == nil {
+				fmt.Println()
+				return nil
+			}
+		}
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s exceeded the log timeout of %s, increase it with --log-timeout", title, timeout)
+		}
+		if ctx.Err() != nil || attempt >= maxLogResumes {
+			return err
+		}
+		log.Printf("[WARN] error reading %s, resuming at offset %d: %s", title, offset, err)
+
+		wait, stop := backoff.Next()
+		if stop {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// writes log lines to the writer, and the log file when the logs are persisted
+func (service *runService) writeLogLines(kind LogKind, reader io.Reader, resume bool) (int64, error) {
+	file, err := service.openLogFile(kind, resume)
+	if err != nil {
+		log.Printf("[ERROR] error opening %s file: %s", kind, err)
+		service.writer.Error(fmt.Sprintf("Error writing %s to file: %s", kind, err))
+	}
+	if file == nil {
+		return service.outputRunLogLines(reader, nil)
+	}
+	defer file.Close()
+	return service.outputRunLogLines(reader, file)
+}
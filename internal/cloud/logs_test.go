@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/tfci/internal/logging"
+)
+
+type testLogWriter struct {
+	defaultWriter
+	lines []string
+}
+
+func (w *testLogWriter) Output(msg string) {
+	w.lines = append(w.lines, msg)
+}
+
+// returns the logs, then fails once the limit has been read
+type failingLogReader struct {
+	r     io.Reader
+	limit int
+}
+
+func (f *failingLogReader) Read(p []byte) (int, error) {
+	if f.limit <= 0 {
+		return 0, errors.New("connection reset by peer")
+	}
+	if len(p) > f.limit {
+		p = p[:f.limit]
+	}
+	n, err := f.r.Read(p)
+	f.limit -= n
+	return n, err
+}
+
+func testLogRetryOptions(t *testing.T, opts *RetryOptions) {
+	t.Helper()
+	previous := retryOptions
+	retryOptions = opts
+	t.Cleanup(func() { retryOptions = previous })
+}
+
+func TestRunService_StreamLogs_Resume(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	logs := "Terraform v1.9.0\nPlan: 1 to add\nPlan complete\n"
+	opened := 0
+	writer := &testLogWriter{}
+	service := &runService{&cloudMeta{writer: writer}}
+
+	err := service.streamLogs(context.Background(), PlanLog, func(ctx context.Context) (io.Reader, error) {
+		opened++
+		if opened == 1 {
+			// fails part way through the second line
+			return &failingLogReader{r: strings.NewReader(logs), limit: 20}, nil
+		}
+		return strings.NewReader(logs), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if opened != 2 {
+		t.Errorf("expected logs to be reopened once but were opened %d times", opened)
+	}
+
+	expected := []string{"-------------- Plan Log --------------", "Terraform v1.9.0", "Plan: 1 to add", "Plan complete"}
+	if strings.Join(writer.lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected lines:\n%s\nbut received:\n%s", strings.Join(expected, "\n"), strings.Join(writer.lines, "\n"))
+	}
+}
+
+func TestRunService_StreamLogs_MaxResumes(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	opened := 0
+	service := &runService{&cloudMeta{writer: &testLogWriter{}}}
+	err := service.streamLogs(context.Background(), ApplyLog, func(ctx context.Context) (io.Reader, error) {
+		opened++
+		return nil, errors.New("unavailable")
+	})
+	if err == nil || err.Error() != "unavailable" {
+		t.Errorf("expected unavailable error but received %v", err)
+	}
+	if opened != maxLogResumes+1 {
+		t.Errorf("expected %d attempts but received %d", maxLogResumes+1, opened)
+	}
+}
+
+func TestRunService_StreamLogs_Timeout(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{LogTimeout: 10 * time.Millisecond})
+
+	service := &runService{&cloudMeta{writer: &testLogWriter{}}}
+	err := service.streamLogs(context.Background(), PlanLog, func(ctx context.Context) (io.Reader, error) {
+		// the log reader blocks while waiting for the operation to finish
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err == nil || !strings.Contains(err.Error(), "Plan Log exceeded the log timeout of 10ms") {
+		t.Errorf("expected log timeout error but received %v", err)
+	}
+}
+
+func TestRunService_StreamLogs_LogFile(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	logs := "Terraform v1.9.0\nPlan: 1 to add\nPlan complete\n"
+	path := filepath.Join(t.TempDir(), "plan.log")
+	if err := os.WriteFile(path, []byte("previous run\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := &cloudMeta{writer: &testLogWriter{}, logFiles: map[LogKind]string{PlanLog: path}}
+	service := &runService{meta}
+
+	opened := 0
+	err := service.streamLogs(context.Background(), PlanLog, func(ctx context.Context) (io.Reader, error) {
+		opened++
+		if opened == 1 {
+			return &failingLogReader{r: strings.NewReader(logs), limit: 20}, nil
+		}
+		return strings.NewReader(logs), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != logs {
+		t.Errorf("expected log file:\n%s\nbut received:\n%s", logs, content)
+	}
+}
+
+func TestRunService_StreamLogs_LogFileError(t *testing.T) {
+	writer := &testLogWriter{}
+	path := filepath.Join(t.TempDir(), "missing", "apply.log")
+	c := &Cloud{cloudMeta: &cloudMeta{writer: writer}}
+	c.UseLogFile(ApplyLog, path)
+	service := &runService{c.cloudMeta}
+
+	err := service.streamLogs(context.Background(), ApplyLog, func(ctx context.Context) (io.Reader, error) {
+		return strings.NewReader("Apply complete\n"), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// logs are still written to stdout when the file cannot be opened
+	if writer.lines[len(writer.lines)-1] != "Apply complete" {
+		t.Errorf("expected apply logs to be output but received %v", writer.lines)
+	}
+}
+
+func TestRunService_StreamLogs_Redacted(t *testing.T) {
+	redactor, err := logging.NewRedactor([]string{`ghp_[A-Za-z0-9]+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	redactor.AddValues(`"hunter2"`)
+	writer := &testLogWriter{}
+	path := filepath.Join(t.TempDir(), "plan.log")
+	service := &runService{&cloudMeta{writer: writer, redactor: redactor, logFiles: map[LogKind]string{PlanLog: path}}}
+
+	err = service.streamLogs(context.Background(), PlanLog, func(ctx context.Context) (io.Reader, error) {
+		return strings.NewReader("  + password = \"hunter2\"\n  + token = ghp_abc123\n"), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"-------------- Plan Log --------------", "  + password = [REDACTED]", "  + token = [REDACTED]"}
+	if strings.Join(writer.lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected lines:\n%s\nbut received:\n%s", strings.Join(expected, "\n"), strings.Join(writer.lines, "\n"))
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "hunter2") || strings.Contains(string(content), "ghp_abc123") {
+		t.Errorf("expected log file to be redacted but received:\n%s", content)
+	}
+}
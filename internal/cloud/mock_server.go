@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+const (
+	mockAPIVersion = "2.6"
+	mockAppName    = "HCP Terraform"
+	mockToken      = "mock-token"
+)
+
+// mockTransport serves canned HCP Terraform API responses from a fixtures directory,
+// so pipelines can be exercised without a real organization. A request is answered by
+// the file named after its method, in the directory matching the request path:
+//
+//	<fixtures>/api/v2/runs/run-abc123/GET.json
+//
+// Numbered fixtures, GET.1.json, GET.2.json and so on, are served in order with the
+// last repeating, to step a polled resource through its statuses. Fixtures without the
+// .json extension, such as plan logs, are served as plain text in the requested chunks. Requests without a
+// fixture receive a 404 not found error.
+type mockTransport struct {
+	dir string
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newMockTransport(dir string) (*mockTransport, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mock server fixtures: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("mock server fixtures %q is not a directory", dir)
+	}
+	return &mockTransport{dir: dir, calls: map[string]int{}}, nil
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		// drain uploads so callers see the request complete
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	reqPath := path.Clean("/" + req.URL.Path)
+	fixture, ok := t.fixture(req.Method, reqPath)
+	if !ok {
+		if reqPath == "/api/v2/ping" {
+			return mockResponse(req, http.StatusNoContent, "", nil), nil
+		}
+		log.Printf("[DEBUG] mock server: no fixture for %s %s", req.Method, reqPath)
+		body := fmt.Sprintf(`{"errors":[{"status":"404","title":"not found","detail":"no mock fixture for %s %s"}]}`, req.Method, reqPath)
+		return mockResponse(req, http.StatusNotFound, tfe.ContentTypeJSONAPI, []byte(body)), nil
+	}
+
+	body, err := os.ReadFile(fixture)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mock fixture: %w", err)
+	}
+	log.Printf("[DEBUG] mock server: %s %s served from %s", req.Method, reqPath, fixture)
+
+	if filepath.Ext(fixture) != ".json" {
+		return mockResponse(req, http.StatusOK, "text/plain", logChunk(req, body)), nil
+	}
+	return mockResponse(req, fixtureStatus(req.Method, body), tfe.ContentTypeJSONAPI, body), nil
+}
+
+// returns the fixture for the next call to the method and path, if any
+func (t *mockTransport) fixture(method string, reqPath string) (string, bool) {
+	dir := filepath.Join(t.dir, filepath.FromSlash(reqPath))
+
+	t.mu.Lock()
+	key := method + " " + reqPath
+	t.calls[key]++
+	call := t.calls[key]
+	t.mu.Unlock()
+
+	// numbered fixtures step through responses, repeating the last
+	last := ""
+	for i := 1; i <= call; i++ {
+		name := filepath.Join(dir, fmt.Sprintf("%s.%d.json", method, i))
+		if _, err := os.Stat(name); err != nil {
+			break
+		}
+		last = name
+	}
+	if last != "" {
+		return last, true
+	}
+
+	for _, name := range []string{method + ".json", method} {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// logs are read in chunks with limit and offset query parameters
+func logChunk(req *http.Request, body []byte) []byte {
+	query := req.URL.Query()
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset > 0 {
+		body = body[min(offset, len(body)):]
+	}
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit >= 0 {
+		body = body[:min(limit, len(body))]
+	}
+	return body
+}
+
+// error documents report their own status, otherwise successful writes are created
+func fixtureStatus(method string, body []byte) int {
+	var doc struct {
+		Errors []struct {
+			Status string `json:"status"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &doc); err == nil && len(doc.Errors) > 0 {
+		if status, err := strconv.Atoi(doc.Errors[0].Status); err == nil {
+			return status
+		}
+		return http.StatusInternalServerError
+	}
+	if method == http.MethodPost {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func mockResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	header := http.Header{}
+	header.Set("TFP-API-Version", mockAPIVersion)
+	header.Set("TFP-AppName", mockAppName)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return cannedResponse(req, status, header, body)
+}
+
+func cannedResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// returns a client answered by the mock server fixtures instead of the HCP Terraform API
+func newMockClient(options *ClientOptions) (*tfe.Client, error) {
+	transport, err := newMockTransport(options.MockFixtures)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Using mock server fixtures: %s", options.MockFixtures)
+	return newOfflineClient(options, transport)
+}
+
+// returns a client for a transport that does not reach HCP Terraform, no credentials are required
+func newOfflineClient(options *ClientOptions, transport http.RoundTripper) (*tfe.Client, error) {
+	base := transport
+	if options.Timings != nil {
+		base = &timingTransport{base: base, timings: options.Timings}
+	}
+	if options.Recorder != nil {
+		base = options.Recorder.transport(base)
+	}
+	base = newAPIErrorTransport(base)
+
+	host := options.Hostname
+	if host == "" {
+		host = os.Getenv("TF_HOSTNAME")
+	}
+	if host == "" {
+		host = defaultHostname
+	}
+	token := options.Token
+	if token == "" {
+		token = mockToken
+	}
+	if options.Credentials != nil {
+		*options.Credentials = Credentials{Hostname: host, Token: token, Source: "offline"}
+	}
+
+	tfeConfig := tfe.DefaultConfig()
+	tfeConfig.Headers.Set("User-Agent", getUserAgent(options.Platform))
+	tfeConfig.Address = fmt.Sprintf("https://%s", host)
+	tfeConfig.Token = token
+	tfeConfig.HTTPClient = &http.Client{Transport: base}
+
+	client, err := tfe.NewClient(tfeConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.RetryServerErrors(false)
+	return client, nil
+}
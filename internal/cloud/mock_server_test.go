@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func writeMockFixture(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMockServer(t *testing.T) {
+	dir := t.TempDir()
+	writeMockFixture(t, dir, "api/v2/runs/run-abc123/GET.1.json", `{"data":{"id":"run-abc123","type":"runs","attributes":{"status":"planning"}}}`)
+	writeMockFixture(t, dir, "api/v2/runs/run-abc123/GET.2.json", `{"data":{"id":"run-abc123","type":"runs","attributes":{"status":"planned"}}}`)
+	writeMockFixture(t, dir, "api/v2/runs/run-forbidden/GET.json", `{"errors":[{"status":"401","title":"unauthorized"}]}`)
+	writeMockFixture(t, dir, "api/v2/runs/run-abc123/actions/apply/POST.json", `{}`)
+	writeMockFixture(t, dir, "api/v2/organizations/abc-company/workspaces/networking/GET.json", `{"data":{"id":"ws-abc123","type":"workspaces","attributes":{"name":"networking"}}}`)
+
+	client, err := NewTfeClient(&ClientOptions{MockFixtures: dir})
+	if err != nil {
+		t.Fatalf("expected no error creating the mock client, received: %s", err)
+	}
+	if !client.IsCloud() {
+		t.Error("expected the mock client to report HCP Terraform")
+	}
+
+	ctx := context.Background()
+	t.Run("numbered fixtures", func(t *testing.T) {
+		for _, expected := range []tfe.RunStatus{tfe.RunPlanning, tfe.RunPlanned, tfe.RunPlanned} {
+			run, err := client.Runs.Read(ctx, "run-abc123")
+			if err != nil {
+				t.Fatalf("expected no error reading run, received: %s", err)
+			}
+			if run.Status != expected {
+				t.Errorf("expected run status %q but received %q", expected, run.Status)
+			}
+		}
+	})
+
+	t.Run("fixture", func(t *testing.T) {
+		ws, err := client.Workspaces.Read(ctx, "abc-company", "networking")
+		if err != nil {
+			t.Fatalf("expected no error reading workspace, received: %s", err)
+		}
+		if ws.ID != "ws-abc123" {
+			t.Errorf("expected workspace ws-abc123 but received %q", ws.ID)
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		if err := client.Runs.Apply(ctx, "run-abc123", tfe.RunApplyOptions{}); err != nil {
+			t.Errorf("expected no error applying run, received: %s", err)
+		}
+	})
+
+	t.Run("error fixture", func(t *testing.T) {
+		_, err := client.Runs.Read(ctx, "run-forbidden")
+		if !errors.Is(err, tfe.ErrUnauthorized) {
+			t.Errorf("expected unauthorized error but received: %v", err)
+		}
+	})
+
+	t.Run("missing fixture", func(t *testing.T) {
+		_, err := client.Runs.Read(ctx, "run-missing")
+		if !errors.Is(err, tfe.ErrResourceNotFound) {
+			t.Errorf("expected not found error but received: %v", err)
+		}
+	})
+}
+
+func TestMockServer_PlainText(t *testing.T) {
+	dir := t.TempDir()
+	writeMockFixture(t, dir, "logs/plan-abc123/GET", "Terraform will perform the following actions:\n")
+
+	transport, err := newMockTransport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := tfe.DefaultConfig().HTTPClient
+	client.Transport = transport
+
+	resp, err := client.Get("https://archivist.terraform.io/logs/plan-abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "Terraform will perform the following actions:\n" {
+		t.Errorf("expected plan log fixture but received %q", string(body))
+	}
+}
+
+func TestMockServer_MissingDirectory(t *testing.T) {
+	_, err := NewTfeClient(&ClientOptions{MockFixtures: filepath.Join(t.TempDir(), "missing")})
+	if err == nil {
+		t.Error("expected an error for a missing fixtures directory")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	oidcRequestTimeout = 10 * time.Second
+	// environment variable containing a workload identity token, eg. GitLab `id_tokens`
+	oidcTokenEnv = "TF_OIDC_TOKEN"
+	// RFC 8693 token exchange
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	jwtTokenType           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+type OIDCOptions struct {
+	// audience requested for the workload identity token
+	Audience string
+	// optional RFC 8693 token exchange endpoint that returns an HCP Terraform API token.
+	// when empty, the workload identity token is used as the API token
+	ExchangeURL string
+}
+
+// workload identity tokens are credentials, so they are requested without the tracing
+// and recording transports of the HCP Terraform client
+func newOIDCClient(proxyURL string) (*http.Client, error) {
+	transport, err := newProxyTransport(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// resolves an HCP Terraform API token from the CI platform's workload identity token
+func resolveOIDCToken(ctx context.Context, client *http.Client, opts *OIDCOptions) (string, error) {
+	idToken, err := workloadIdentityToken(ctx, client, opts.Audience)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.ExchangeURL == "" {
+		log.Printf("[DEBUG] using workload identity token as api token")
+		return idToken, nil
+	}
+
+	log.Printf("[DEBUG] exchanging workload identity token with: %s", opts.ExchangeURL)
+	return exchangeOIDCToken(ctx, client, opts.ExchangeURL, idToken, opts.Audience)
+}
+
+func workloadIdentityToken(ctx context.Context, client *http.Client, audience string) (string, error) {
+	if token := os.Getenv(oidcTokenEnv); token != "" {
+		log.Printf("[DEBUG] workload identity token read from %s", oidcTokenEnv)
+		return token, nil
+	}
+
+	// https://docs.github.com/en/actions/security-for-github-actions/security-hardening-your-deployments/about-security-hardening-with-openid-connect
+	requestURL, requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"), os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL != "" && requestToken != "" {
+		log.Printf("[DEBUG] requesting workload identity token from GitHub Actions")
+		return githubOIDCToken(ctx, client, requestURL, requestToken, audience)
+	}
+
+	return "", fmt.Errorf("no workload identity token available, set %s or grant the `id-token: write` permission in GitHub Actions", oidcTokenEnv)
+}
+
+func githubOIDCToken(ctx context.Context, client *http.Client, requestURL string, requestToken string, audience string) (string, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
+	if audience != "" {
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", requestToken))
+
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := doJSON(client, req, &body); err != nil {
+		return "", fmt.Errorf("error requesting GitHub Actions workload identity token: %w", err)
+	}
+	return body.Value, nil
+}
+
+func exchangeOIDCToken(ctx context.Context, client *http.Client, exchangeURL string, idToken string, audience string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", tokenExchangeGrantType)
+	form.Set("subject_token", idToken)
+	form.Set("subject_token_type", jwtTokenType)
+	if audience != "" {
+		form.Set("audience", audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doJSON(client, req, &body); err != nil {
+		return "", fmt.Errorf("error exchanging workload identity token: %w", err)
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("token exchange response did not include an access_token")
+	}
+	return body.AccessToken, nil
+}
+
+func doJSON(client *http.Client, req *http.Request, v interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveOIDCToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github":
+			if r.Header.Get("Authorization") != "Bearer request-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("audience") != "app.terraform.io" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"value": "github-id-token"})
+		case "/exchange":
+			r.ParseForm()
+			if r.Form.Get("grant_type") != tokenExchangeGrantType || r.Form.Get("subject_token") != "github-id-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "exchanged-api-token"})
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		exchangeURL string
+		expected    string
+		wantErr     bool
+	}{
+		{
+			name:     "env-token-passthrough",
+			env:      map[string]string{oidcTokenEnv: "gitlab-id-token"},
+			expected: "gitlab-id-token",
+		},
+		{
+			name: "github-token-passthrough",
+			env: map[string]string{
+				"ACTIONS_ID_TOKEN_REQUEST_URL":   server.URL + "/github?api-version=2.0",
+				"ACTIONS_ID_TOKEN_REQUEST_TOKEN": "request-token",
+			},
+			expected: "github-id-token",
+		},
+		{
+			name: "github-token-exchange",
+			env: map[string]string{
+				"ACTIONS_ID_TOKEN_REQUEST_URL":   server.URL + "/github",
+				"ACTIONS_ID_TOKEN_REQUEST_TOKEN": "request-token",
+			},
+			exchangeURL: server.URL + "/exchange",
+			expected:    "exchanged-api-token",
+		},
+		{
+			name:    "no-token-available",
+			env:     map[string]string{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, k := range []string{oidcTokenEnv, "ACTIONS_ID_TOKEN_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_TOKEN"} {
+				t.Setenv(k, tc.env[k])
+			}
+
+			token, err := resolveOIDCToken(context.Background(), server.Client(), &OIDCOptions{
+				Audience:    "app.terraform.io",
+				ExchangeURL: tc.exchangeURL,
+			})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %t, received: %v", tc.wantErr, err)
+			}
+			if token != tc.expected {
+				t.Errorf("expected %q but received %q", tc.expected, token)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type OrganizationService interface {
+	ListOrganizations(context.Context) ([]*OrganizationDetails, error)
+	ReadOrganization(context.Context, string) (*OrganizationDetails, error)
+	ReadRunQueue(ctx context.Context, orgName string, agentPoolID string) (*RunQueueDetails, error)
+}
+
+type OrganizationDetails struct {
+	Name         string          `json:"name"`
+	Email        string          `json:"email"`
+	Entitlements map[string]bool `json:"entitlements"`
+}
+
+type organizationService struct {
+	*cloudMeta
+}
+
+// lists organizations the token has access to, along with their entitlements
+func (s *organizationService) ListOrganizations(ctx context.Context) ([]*OrganizationDetails, error) {
+	list, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Organization, *tfe.Pagination, error) {
+		page, err := s.tfe.Organizations.List(ctx, &tfe.OrganizationListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing organizations: %s", err)
+		return nil, err
+	}
+
+	orgs := []*OrganizationDetails{}
+	for _, org := range list {
+		details, err := s.organizationDetails(ctx, org)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, details)
+	}
+	return orgs, nil
+}
+
+func (s *organizationService) ReadOrganization(ctx context.Context, orgName string) (*OrganizationDetails, error) {
+	org, err := s.tfe.Organizations.Read(ctx, orgName)
+	if err != nil {
+		log.Printf("[ERROR] error reading organization: %q error: %s", orgName, err)
+		return nil, err
+	}
+	return s.organizationDetails(ctx, org)
+}
+
+func (s *organizationService) organizationDetails(ctx context.Context, org *tfe.Organization) (*OrganizationDetails, error) {
+	e, err := s.tfe.Organizations.ReadEntitlements(ctx, org.Name)
+	if err != nil {
+		log.Printf("[ERROR] error reading entitlements for organization: %q error: %s", org.Name, err)
+		return nil, err
+	}
+	return &OrganizationDetails{
+		Name:         org.Name,
+		Email:        org.Email,
+		Entitlements: entitlementsMap(e),
+	}, nil
+}
+
+// entitlements keyed by api attribute name
+func entitlementsMap(e *tfe.Entitlements) map[string]bool {
+	return map[string]bool{
+		"agents":                  e.Agents,
+		"audit-logging":           e.AuditLogging,
+		"cost-estimation":         e.CostEstimation,
+		"global-run-tasks":        e.GlobalRunTasks,
+		"operations":              e.Operations,
+		"private-module-registry": e.PrivateModuleRegistry,
+		"run-tasks":               e.RunTasks,
+		"sentinel":                e.Sentinel,
+		"sso":                     e.SSO,
+		"state-storage":           e.StateStorage,
+		"teams":                   e.Teams,
+		"vcs-integrations":        e.VCSIntegrations,
+	}
+}
+
+func NewOrganizationService(meta *cloudMeta) *organizationService {
+	return &organizationService{meta}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrganizationService_ListOrganizations(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mOrgs := mocks.NewMockOrganizations(ctrl)
+	gomock.InOrder(
+		mOrgs.EXPECT().List(ctx, &tfe.OrganizationListOptions{
+			ListOptions: tfe.ListOptions{PageSize: 100},
+		}).Return(&tfe.OrganizationList{
+			Pagination: &tfe.Pagination{CurrentPage: 1, NextPage: 2},
+			Items:      []*tfe.Organization{{Name: "abc-company"}},
+		}, nil),
+		mOrgs.EXPECT().List(ctx, &tfe.OrganizationListOptions{
+			ListOptions: tfe.ListOptions{PageNumber: 2, PageSize: 100},
+		}).Return(&tfe.OrganizationList{
+			Pagination: &tfe.Pagination{CurrentPage: 2},
+			Items:      []*tfe.Organization{{Name: "xyz-company"}},
+		}, nil),
+	)
+	mOrgs.EXPECT().ReadEntitlements(ctx, "abc-company").Return(&tfe.Entitlements{CostEstimation: true}, nil)
+	mOrgs.EXPECT().ReadEntitlements(ctx, "xyz-company").Return(&tfe.Entitlements{Sentinel: true}, nil)
+
+	service := NewOrganizationService(&cloudMeta{
+		tfe:    &tfe.Client{Organizations: mOrgs},
+		writer: &defaultWriter{},
+	})
+
+	orgs, err := service.ListOrganizations(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(orgs) != 2 {
+		t.Fatalf("expected 2 organizations but received %d", len(orgs))
+	}
+	if !orgs[0].Entitlements["cost-estimation"] || orgs[0].Entitlements["sentinel"] {
+		t.Errorf("unexpected entitlements for %s: %v", orgs[0].Name, orgs[0].Entitlements)
+	}
+	if !orgs[1].Entitlements["sentinel"] {
+		t.Errorf("unexpected entitlements for %s: %v", orgs[1].Name, orgs[1].Entitlements)
+	}
+}
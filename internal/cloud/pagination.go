@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+const (
+	defaultPageSize = 100
+	// maximum page size accepted by the API
+	maxPageSize = 100
+)
+
+var pageSize = defaultPageSize
+
+// sets the number of items requested per page by list operations
+func ConfigurePageSize(size int) error {
+	if size < 1 || size > maxPageSize {
+		return fmt.Errorf("page size must be between 1 and %d, received %d", maxPageSize, size)
+	}
+	log.Printf("[DEBUG] list page size: %d", size)
+	pageSize = size
+	return nil
+}
+
+// reads every page of a list operation, list is called with the options of each page
+// and returns the items and pagination of the page
+func listAll[T any](list func(tfe.ListOptions) ([]T, *tfe.Pagination, error)) ([]T, error) {
+	items := []T{}
+	opts := tfe.ListOptions{PageSize: pageSize}
+	for {
+		page, pagination, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if pagination == nil || pagination.NextPage == 0 || pagination.NextPage <= pagination.CurrentPage {
+			return items, nil
+		}
+		opts.PageNumber = pagination.NextPage
+	}
+}
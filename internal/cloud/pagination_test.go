@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func testPageSize(t *testing.T, size int) {
+	t.Helper()
+	previous := pageSize
+	if err := ConfigurePageSize(size); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pageSize = previous })
+}
+
+func TestListAll(t *testing.T) {
+	testPageSize(t, 2)
+
+	pages := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	requested := []tfe.ListOptions{}
+	items, err := listAll(func(opts tfe.ListOptions) ([]string, *tfe.Pagination, error) {
+		requested = append(requested, opts)
+		current := len(requested)
+		next := current + 1
+		if current == len(pages) {
+			next = 0
+		}
+		return pages[current-1], &tfe.Pagination{CurrentPage: current, NextPage: next, TotalPages: len(pages)}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(items) != 5 || items[4] != "e" {
+		t.Errorf("expected items of every page but received %v", items)
+	}
+
+	expected := []tfe.ListOptions{{PageSize: 2}, {PageNumber: 2, PageSize: 2}, {PageNumber: 3, PageSize: 2}}
+	if len(requested) != len(expected) {
+		t.Fatalf("expected %d pages to be requested but received %d", len(expected), len(requested))
+	}
+	for i := range expected {
+		if requested[i] != expected[i] {
+			t.Errorf("expected page options %+v but received %+v", expected[i], requested[i])
+		}
+	}
+}
+
+func TestListAll_Error(t *testing.T) {
+	calls := 0
+	_, err := listAll(func(opts tfe.ListOptions) ([]string, *tfe.Pagination, error) {
+		calls++
+		if calls == 2 {
+			return nil, nil, errors.New("internal server error")
+		}
+		return []string{"a"}, &tfe.Pagination{CurrentPage: 1, NextPage: 2}, nil
+	})
+	if err == nil || err.Error() != "internal server error" {
+		t.Errorf("expected error from second page but received %v", err)
+	}
+}
+
+func TestConfigurePageSize(t *testing.T) {
+	previous := pageSize
+	defer func() { pageSize = previous }()
+
+	for _, size := range []int{0, -1, 101} {
+		if err := ConfigurePageSize(size); err == nil {
+			t.Errorf("expected page size %d to be rejected", size)
+		}
+	}
+	if err := ConfigurePageSize(25); err != nil || pageSize != 25 {
+		t.Errorf("expected page size 25 but received %d, error: %v", pageSize, err)
+	}
+}
+
+func TestRunService_LogTaskStage_Paginated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mTaskStages := mocks.NewMockTaskStages(ctrl)
+	mPolicyEvaluations := mocks.NewMockPolicyEvaluations(ctrl)
+
+	mTaskStages.EXPECT().List(ctx, "run-1", &tfe.TaskStageListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+	}).Return(&tfe.TaskStageList{
+		Items:      []*tfe.TaskStage{{ID: "ts-1", Stage: tfe.PrePlan}},
+		Pagination: &tfe.Pagination{CurrentPage: 1, NextPage: 2},
+	}, nil)
+	mTaskStages.EXPECT().List(ctx, "run-1", &tfe.TaskStageListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 2, PageSize: 100},
+	}).Return(&tfe.TaskStageList{
+		Items:      []*tfe.TaskStage{{ID: "ts-2", Stage: tfe.PostPlan}},
+		Pagination: &tfe.Pagination{CurrentPage: 2},
+	}, nil)
+
+	// only the task stage on the second page matches
+	mPolicyEvaluations.EXPECT().List(ctx, "ts-2", &tfe.PolicyEvaluationListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+	}).Return(&tfe.PolicyEvaluationList{Pagination: &tfe.Pagination{CurrentPage: 1}}, nil)
+
+	writer := &testLogWriter{}
+	service := &runService{&cloudMeta{tfe: &tfe.Client{TaskStages: mTaskStages, PolicyEvaluations: mPolicyEvaluations}, writer: writer}}
+	if err := service.LogTaskStage(ctx, &tfe.Run{ID: "run-1"}, tfe.PostPlan); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(writer.lines) < 2 || writer.lines[1] != "TaskStage (ts-2), Status: '', Stage: 'post_plan'" {
+		t.Errorf("expected task stage from the second page to be logged but received %v", writer.lines)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"golang.org/x/sync/errgroup"
+)
+
+// maximum policy evaluations to read policy set outcomes for at the same time
+const policyOutcomeConcurrency = 5
+
+// reads the policy set outcomes of each OPA policy evaluation concurrently.
+// outcomes are returned in the order of the evaluations, sentinel evaluations have none.
+func (s *runService) listPolicySetOutcomes(ctx context.Context, evaluations []*tfe.PolicyEvaluation) ([][]*tfe.PolicySetOutcome, error) {
+	outcomes := make([][]*tfe.PolicySetOutcome, len(evaluations))
+	errs := make([]error, len(evaluations))
+
+	var g errgroup.Group
+	g.SetLimit(policyOutcomeConcurrency)
+	for i, evaluation := range evaluations {
+		if evaluation.PolicyKind != tfe.OPA {
+			continue
+		}
+		g.Go(func() error {
+			outcomes[i], errs[i] = listAll(func(opts tfe.ListOptions) ([]*tfe.PolicySetOutcome, *tfe.Pagination, error) {
+				page, err := s.tfe.PolicySetOutcomes.List(ctx, evaluation.ID, &tfe.PolicySetOutcomeListOptions{ListOptions: &opts})
+				if err != nil {
+					return nil, nil, err
+				}
+				return page.Items, page.Pagination, nil
+			})
+			return nil
+		})
+	}
+	g.Wait()
+
+	// report the error of the first evaluation that failed, regardless of completion order
+	for i, err := range errs {
+		if err != nil {
+			log.Printf("[ERROR] error listing policy set outcomes of policy evaluation: %q error: %s", evaluations[i].ID, err)
+			return nil, err
+		}
+	}
+	return outcomes, nil
+}
+
+// stage reported for legacy sentinel policy checks, which do not belong to a task stage
+const PolicyCheckStage = "policy_check"
+
+const (
+	PolicyStatusPassed      = "passed"
+	PolicyStatusFailed      = "failed"
+	PolicyStatusUnevaluated = "unevaluated"
+)
+
+// normalized policy results of a run, aggregated across legacy policy checks and every task stage
+type PolicyEvaluation struct {
+	RunID            string `json:"run_id"`
+	Status           string `json:"status"`
+	RequiresOverride bool   `json:"requires_override"`
+	PolicyResultCounts
+	Stages []*PolicyStageResult `json:"stages"`
+}
+
+type PolicyStageResult struct {
+	ID               string `json:"id"`
+	Stage            string `json:"stage"`
+	Status           string `json:"status"`
+	RequiresOverride bool   `json:"requires_override"`
+	CanOverride      bool   `json:"can_override"`
+	PolicyResultCounts
+	Evaluations []*PolicyEvaluationDetails `json:"evaluations,omitempty"`
+}
+
+// individual policy outcomes of every stage, only the policies that did not pass unless all is set
+func (e *PolicyEvaluation) Policies(all bool) []*PolicyOutcome {
+	policies := []*PolicyOutcome{}
+	for _, stage := range e.Stages {
+		for _, evaluation := range stage.Evaluations {
+			for _, set := range evaluation.PolicySets {
+				for _, policy := range set.Policies {
+					if !all && policy.Status == PolicyStatusPassed {
+						continue
+					}
+					policies = append(policies, &PolicyOutcome{
+						Stage:            stage.Stage,
+						PolicySet:        set.Name,
+						Name:             policy.Name,
+						Description:      policy.Description,
+						EnforcementLevel: policy.EnforcementLevel,
+						Status:           policy.Status,
+					})
+				}
+			}
+		}
+	}
+	return policies
+}
+
+// logs the sentinel output of legacy policy checks and the policy set outcomes of policy evaluations, per stage
+func (s *runService) LogPolicyEvaluation(ctx context.Context, evaluation *PolicyEvaluation) error {
+	for _, stage := range evaluation.Stages {
+		s.writer.Output(fmt.Sprintf("-------------- Policies: %s (%s) --------------", stage.Stage, stage.ID))
+		if stage.Stage == PolicyCheckStage {
+			if err := s.logPolicyCheck(ctx, stage.ID); err != nil {
+				return fmt.Errorf("error reading logs of policy check %s: %s", stage.ID, err.Error())
+			}
+			continue
+		}
+		for _, p := range stage.Evaluations {
+			s.writer.Output(fmt.Sprintf("- PolicyEvalutation (%s), Status: '%s', PolicyKind: '%s'", p.ID, p.Status, p.PolicyKind))
+			for _, o := range p.PolicySets {
+				s.writer.Output(fmt.Sprintf("  - PolicySet '%s', Passed: (%d), AdvisoryFailed: (%d), MandatoryFailed: (%d), Failed: (%d)", o.Name, o.Passed, o.AdvisoryFailed, o.MandatoryFailed, o.Errored))
+				for _, policy := range o.Policies {
+					s.writer.Output(fmt.Sprintf("    - Policy '%s', Status: '%s', EnforcementLevel: '%s', Description: '%s'", policy.Name, policy.Status, policy.EnforcementLevel, policy.Description))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *runService) logPolicyCheck(ctx context.Context, policyCheckID string) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	logReader, err := s.tfe.PolicyChecks.Logs(ctxTimeout, policyCheckID)
+	if err != nil {
+		return err
+	}
+	_, err = s.outputRunLogLines(logReader, nil)
+	return err
+}
+
+func (c *PolicyResultCounts) add(o PolicyResultCounts) {
+	c.Passed += o.Passed
+	c.AdvisoryFailed += o.AdvisoryFailed
+	c.MandatoryFailed += o.MandatoryFailed
+	c.Errored += o.Errored
+}
+
+// reads the policy results of a run from its legacy policy checks and the policy evaluations of all task stages
+func (s *runService) GetPolicyEvaluation(ctx context.Context, runID string) (*PolicyEvaluation, error) {
+	var taskStages []*TaskStageDetails
+	if s.capabilities.Supports(TaskStages) && s.capabilities.Supports(PolicyEvaluations) {
+		var err error
+		taskStages, err = s.ReadTaskStages(ctx, runID, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.policyEvaluation(ctx, runID, taskStages)
+}
+
+// aggregates the legacy policy checks of a run with the policy evaluations of its task stages
+func (s *runService) policyEvaluation(ctx context.Context, runID string, taskStages []*TaskStageDetails) (*PolicyEvaluation, error) {
+	result := &PolicyEvaluation{RunID: runID, Stages: []*PolicyStageResult{}}
+
+	policyChecks, err := listAll(func(opts tfe.ListOptions) ([]*tfe.PolicyCheck, *tfe.Pagination, error) {
+		page, err := s.tfe.PolicyChecks.List(ctx, runID, &tfe.PolicyCheckListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pcheck := range policyChecks {
+		stage := &PolicyStageResult{
+			ID:               pcheck.ID,
+			Stage:            PolicyCheckStage,
+			Status:           string(pcheck.Status),
+			RequiresOverride: pcheck.Status == tfe.PolicySoftFailed && pcheck.Actions != nil && pcheck.Actions.IsOverridable,
+			CanOverride:      pcheck.Permissions != nil && pcheck.Permissions.CanOverride,
+		}
+		if pcheck.Result != nil {
+			// soft and hard failures are both mandatory, soft failures can be overridden
+			stage.PolicyResultCounts = PolicyResultCounts{
+				Passed:          pcheck.Result.Passed,
+				AdvisoryFailed:  pcheck.Result.AdvisoryFailed,
+				MandatoryFailed: pcheck.Result.SoftFailed + pcheck.Result.HardFailed,
+			}
+		}
+		result.Stages = append(result.Stages, stage)
+	}
+
+	for _, task := range taskStages {
+		if !(len(task.PolicyEvaluations) > 0) {
+			continue
+		}
+		stage := &PolicyStageResult{
+			ID:               task.ID,
+			Stage:            task.Stage,
+			Status:           task.Status,
+			RequiresOverride: task.Status == string(tfe.TaskStageAwaitingOverride),
+			CanOverride:      task.CanOverride,
+			Evaluations:      task.PolicyEvaluations,
+		}
+		for _, evaluation := range task.PolicyEvaluations {
+			stage.add(evaluation.PolicyResultCounts)
+		}
+		result.Stages = append(result.Stages, stage)
+	}
+
+	result.Status = PolicyStatusUnevaluated
+	for _, stage := range result.Stages {
+		result.add(stage.PolicyResultCounts)
+		result.RequiresOverride = result.RequiresOverride || stage.RequiresOverride
+		result.Status = PolicyStatusPassed
+	}
+	if result.MandatoryFailed > 0 || result.Errored > 0 {
+		result.Status = PolicyStatusFailed
+	}
+	return result, nil
+}
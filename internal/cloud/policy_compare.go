@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import "sort"
+
+// differences of the policy results between a base and a head run
+type PolicyComparison struct {
+	BaseRunID        string                     `json:"base_run_id"`
+	HeadRunID        string                     `json:"head_run_id"`
+	NewlyFailing     []*PolicyComparisonOutcome `json:"newly_failing"`
+	NewlyPassing     []*PolicyComparisonOutcome `json:"newly_passing"`
+	Unchanged        []*PolicyComparisonOutcome `json:"unchanged"`
+	BaseCounts       PolicyResultCounts         `json:"base_counts"`
+	HeadCounts       PolicyResultCounts         `json:"head_counts"`
+	HasNewViolations bool                       `json:"has_new_violations"`
+}
+
+type PolicyComparisonOutcome struct {
+	PolicySet        string `json:"policy_set"`
+	Name             string `json:"name"`
+	EnforcementLevel string `json:"enforcement_level"`
+	BaseStatus       string `json:"base_status,omitempty"`
+	HeadStatus       string `json:"head_status,omitempty"`
+}
+
+// compares individual policies by policy set and name, a policy missing from a run has not failed in that run
+func ComparePolicyEvaluations(base *PolicyEvaluation, head *PolicyEvaluation) *PolicyComparison {
+	comparison := &PolicyComparison{
+		BaseRunID:    base.RunID,
+		HeadRunID:    head.RunID,
+		NewlyFailing: []*PolicyComparisonOutcome{},
+		NewlyPassing: []*PolicyComparisonOutcome{},
+		Unchanged:    []*PolicyComparisonOutcome{},
+		BaseCounts:   base.PolicyResultCounts,
+		HeadCounts:   head.PolicyResultCounts,
+	}
+
+	outcomes := map[string]*PolicyComparisonOutcome{}
+	keys := []string{}
+	outcome := func(p *PolicyOutcome) *PolicyComparisonOutcome {
+		key := p.PolicySet + "/" + p.Name
+		if o, ok := outcomes[key]; ok {
+			return o
+		}
+		o := &PolicyComparisonOutcome{PolicySet: p.PolicySet, Name: p.Name, EnforcementLevel: p.EnforcementLevel}
+		outcomes[key] = o
+		keys = append(keys, key)
+		return o
+	}
+	for _, p := range base.Policies(true) {
+		outcome(p).BaseStatus = p.Status
+	}
+	for _, p := range head.Policies(true) {
+		o := outcome(p)
+		o.HeadStatus = p.Status
+		o.EnforcementLevel = p.EnforcementLevel
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		o := outcomes[key]
+		baseFailed := o.BaseStatus != "" && o.BaseStatus != PolicyStatusPassed
+		headFailed := o.HeadStatus != "" && o.HeadStatus != PolicyStatusPassed
+		switch {
+		case headFailed && !baseFailed:
+			comparison.NewlyFailing = append(comparison.NewlyFailing, o)
+		case baseFailed && !headFailed:
+			comparison.NewlyPassing = append(comparison.NewlyPassing, o)
+		default:
+			comparison.Unchanged = append(comparison.Unchanged, o)
+		}
+	}
+
+	// stages without individual policies, such as legacy policy checks, are compared by their counts
+	comparison.HasNewViolations = len(comparison.NewlyFailing) > 0 ||
+		head.MandatoryFailed > base.MandatoryFailed ||
+		head.Errored > base.Errored
+	return comparison
+}
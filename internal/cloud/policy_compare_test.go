@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import "testing"
+
+func testComparedEvaluation(runID string, statuses map[string]string) *PolicyEvaluation {
+	set := &PolicySetOutcomeDetails{Name: "baseline"}
+	for _, name := range []string{"require-tags", "restrict-regions", "deny-public-buckets", "limit-instance-size"} {
+		if status, ok := statuses[name]; ok {
+			set.Policies = append(set.Policies, &PolicyOutcome{Name: name, EnforcementLevel: "mandatory", Status: status})
+		}
+	}
+	return &PolicyEvaluation{RunID: runID, Stages: []*PolicyStageResult{{
+		Stage:       "post_plan",
+		Evaluations: []*PolicyEvaluationDetails{{PolicySets: []*PolicySetOutcomeDetails{set}}},
+	}}}
+}
+
+func TestComparePolicyEvaluations(t *testing.T) {
+	base := testComparedEvaluation("run-base", map[string]string{
+		"require-tags":        "failed",
+		"restrict-regions":    "passed",
+		"deny-public-buckets": "failed",
+	})
+	head := testComparedEvaluation("run-head", map[string]string{
+		"require-tags":        "passed",
+		"restrict-regions":    "failed",
+		"deny-public-buckets": "failed",
+		"limit-instance-size": "failed",
+	})
+
+	comparison := ComparePolicyEvaluations(base, head)
+	names := func(outcomes []*PolicyComparisonOutcome) []string {
+		result := []string{}
+		for _, o := range outcomes {
+			result = append(result, o.Name)
+		}
+		return result
+	}
+
+	// the policy only in the head run is newly failing
+	if got := names(comparison.NewlyFailing); len(got) != 2 || got[0] != "limit-instance-size" || got[1] != "restrict-regions" {
+		t.Errorf("unexpected newly failing policies %v", got)
+	}
+	if got := names(comparison.NewlyPassing); len(got) != 1 || got[0] != "require-tags" {
+		t.Errorf("unexpected newly passing policies %v", got)
+	}
+	if got := names(comparison.Unchanged); len(got) != 1 || got[0] != "deny-public-buckets" {
+		t.Errorf("unexpected unchanged policies %v", got)
+	}
+	if !comparison.HasNewViolations {
+		t.Error("expected new violations")
+	}
+}
+
+func TestComparePolicyEvaluations_Counts(t *testing.T) {
+	// legacy policy checks only report counts
+	base := &PolicyEvaluation{RunID: "run-base", PolicyResultCounts: PolicyResultCounts{MandatoryFailed: 1}}
+	head := &PolicyEvaluation{RunID: "run-head", PolicyResultCounts: PolicyResultCounts{MandatoryFailed: 1}}
+	if ComparePolicyEvaluations(base, head).HasNewViolations {
+		t.Error("expected no new violations for pre-existing failures")
+	}
+	head.MandatoryFailed = 2
+	if !ComparePolicyEvaluations(base, head).HasNewViolations {
+		t.Error("expected new violations when mandatory failures increase")
+	}
+}
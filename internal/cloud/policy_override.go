@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// stage of a run with failed mandatory policies that an override applies to
+type PolicyOverrideTarget struct {
+	ID              string `json:"id"`
+	Stage           string `json:"stage"`
+	Status          string `json:"status"`
+	MandatoryFailed int    `json:"mandatory_failed"`
+}
+
+type PolicyOverride struct {
+	RunID     string                  `json:"run_id"`
+	RunStatus string                  `json:"run_status"`
+	Targets   []*PolicyOverrideTarget `json:"targets"`
+}
+
+// returned when the run is not waiting for a policy override, such as after it was overridden
+type OverrideNotRequiredError struct {
+	Status tfe.RunStatus
+}
+
+func (e *OverrideNotRequiredError) Error() string {
+	return fmt.Sprintf("run is not waiting for a policy override, status: '%s'", e.Status)
+}
+
+// validates the failed policies of a run can be overridden, and returns what would be overridden without overriding it
+func (s *runService) PlanPolicyOverride(ctx context.Context, runID string) (*PolicyOverride, error) {
+	run, err := s.tfe.Runs.Read(ctx, runID)
+	if err != nil {
+		log.Printf("[ERROR] error reading run: %q error: %s", runID, err)
+		return nil, err
+	}
+	if !(&RunStatusError{Status: run.Status}).PolicyBlocked() {
+		return nil, &OverrideNotRequiredError{Status: run.Status}
+	}
+
+	evaluation, err := s.GetPolicyEvaluation(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	override := &PolicyOverride{RunID: runID, RunStatus: string(run.Status), Targets: []*PolicyOverrideTarget{}}
+	for _, stage := range evaluation.Stages {
+		if !stage.RequiresOverride || !(stage.MandatoryFailed > 0) {
+			continue
+		}
+		if !stage.CanOverride {
+			return nil, fmt.Errorf("token does not have permission to override the failed policies of %s (%s)", stage.Stage, stage.ID)
+		}
+		override.Targets = append(override.Targets, &PolicyOverrideTarget{
+			ID:              stage.ID,
+			Stage:           stage.Stage,
+			Status:          stage.Status,
+			MandatoryFailed: stage.MandatoryFailed,
+		})
+	}
+	if !(len(override.Targets) > 0) {
+		return nil, fmt.Errorf("run has no failed mandatory policies that can be overridden")
+	}
+	return override, nil
+}
+
+// overrides the targets of a planned override, the justification is recorded as a comment on the run
+func (s *runService) OverridePolicies(ctx context.Context, override *PolicyOverride, justification string) error {
+	for _, target := range override.Targets {
+		var err error
+		if target.Stage == PolicyCheckStage {
+			_, err = s.tfe.PolicyChecks.Override(ctx, target.ID)
+		} else {
+			_, err = s.tfe.TaskStages.Override(ctx, target.ID, tfe.TaskStageOverrideOptions{Comment: tfe.String(justification)})
+		}
+		if err != nil {
+			log.Printf("[ERROR] error overriding policies of: %q error: %s", target.ID, err)
+			return fmt.Errorf("error overriding the failed policies of %s (%s): %s", target.Stage, target.ID, err.Error())
+		}
+	}
+	if _, err := s.tfe.Comments.Create(ctx, override.RunID, tfe.CommentCreateOptions{Body: justification}); err != nil {
+		log.Printf("[ERROR] error adding override justification to run: %q error: %s", override.RunID, err)
+		return fmt.Errorf("policies were overridden, but the justification could not be added to the run: %s", err.Error())
+	}
+	return nil
+}
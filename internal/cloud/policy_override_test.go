@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func testPolicyOverrideService(t *testing.T, status tfe.RunStatus, canOverride bool) (*runService, *mocks.MockPolicyChecks, *mocks.MockComments) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mRuns := mocks.NewMockRuns(ctrl)
+	mPolicyChecks := mocks.NewMockPolicyChecks(ctrl)
+	mComments := mocks.NewMockComments(ctrl)
+
+	mRuns.EXPECT().Read(gomock.Any(), "run-1").Return(&tfe.Run{ID: "run-1", Status: status}, nil)
+	mPolicyChecks.EXPECT().List(gomock.Any(), "run-1", gomock.Any()).Return(&tfe.PolicyCheckList{
+		Items: []*tfe.PolicyCheck{{
+			ID:          "polchk-1",
+			Status:      tfe.PolicySoftFailed,
+			Actions:     &tfe.PolicyActions{IsOverridable: true},
+			Permissions: &tfe.PolicyPermissions{CanOverride: canOverride},
+			Result:      &tfe.PolicyResult{Passed: 2, SoftFailed: 1},
+		}},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil).AnyTimes()
+
+	// releases without task stages only report legacy policy checks
+	service := &runService{&cloudMeta{
+		tfe:          &tfe.Client{Runs: mRuns, PolicyChecks: mPolicyChecks, Comments: mComments},
+		writer:       &testLogWriter{},
+		capabilities: &Capabilities{TFEVersion: "v202201-1"},
+	}}
+	return service, mPolicyChecks, mComments
+}
+
+func TestRunService_PlanPolicyOverride(t *testing.T) {
+	service, _, _ := testPolicyOverrideService(t, tfe.RunPolicyOverride, true)
+	override, err := service.PlanPolicyOverride(context.Background(), "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(override.Targets) != 1 || override.Targets[0].ID != "polchk-1" || override.Targets[0].MandatoryFailed != 1 {
+		t.Errorf("unexpected override targets %+v", override.Targets)
+	}
+}
+
+func TestRunService_PlanPolicyOverride_Ineligible(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      tfe.RunStatus
+		canOverride bool
+		expected    string
+	}{
+		{name: "status", status: tfe.RunPlanned, canOverride: true, expected: "run is not waiting for a policy override, status: 'planned'"},
+		{name: "permissions", status: tfe.RunPolicyOverride, canOverride: false, expected: "token does not have permission to override the failed policies of policy_check (polchk-1)"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, _ := testPolicyOverrideService(t, tc.status, tc.canOverride)
+			_, err := service.PlanPolicyOverride(context.Background(), "run-1")
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected error %q but received %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRunService_OverridePolicies(t *testing.T) {
+	ctx := context.Background()
+	service, mPolicyChecks, mComments := testPolicyOverrideService(t, tfe.RunPolicyOverride, true)
+	override, err := service.PlanPolicyOverride(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mPolicyChecks.EXPECT().Override(ctx, "polchk-1").Return(&tfe.PolicyCheck{ID: "polchk-1", Status: tfe.PolicyOverridden}, nil)
+	mComments.EXPECT().Create(ctx, "run-1", tfe.CommentCreateOptions{Body: "approved in CHANGE-1"}).Return(&tfe.Comment{ID: "wsc-1"}, nil)
+	if err := service.OverridePolicies(ctx, override, "approved in CHANGE-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_ListPolicySetOutcomes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mOutcomes := mocks.NewMockPolicySetOutcomes(ctrl)
+
+	evaluations := []*tfe.PolicyEvaluation{{ID: "poleval-sentinel", PolicyKind: tfe.Sentinel}}
+	var active, maxActive int32
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("poleval-%d", i)
+		evaluations = append(evaluations, &tfe.PolicyEvaluation{ID: id, PolicyKind: tfe.OPA})
+		// later evaluations finish first
+		delay := time.Duration(12-i) * time.Millisecond
+		mOutcomes.EXPECT().List(gomock.Any(), id, gomock.Any()).DoAndReturn(func(context.Context, string, *tfe.PolicySetOutcomeListOptions) (*tfe.PolicySetOutcomeList, error) {
+			n := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			time.Sleep(delay)
+			return &tfe.PolicySetOutcomeList{
+				Items:      []*tfe.PolicySetOutcome{{ID: "psout-" + id, PolicySetName: id}},
+				Pagination: &tfe.Pagination{CurrentPage: 1},
+			}, nil
+		})
+	}
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{PolicySetOutcomes: mOutcomes}, writer: &testLogWriter{}}}
+	outcomes, err := service.listPolicySetOutcomes(ctx, evaluations)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(outcomes[0]) != 0 {
+		t.Errorf("expected no outcomes for sentinel evaluation but received %d", len(outcomes[0]))
+	}
+	for i, evaluation := range evaluations[1:] {
+		if len(outcomes[i+1]) != 1 || outcomes[i+1][0].PolicySetName != evaluation.ID {
+			t.Errorf("expected outcome of %s at index %d but received %v", evaluation.ID, i+1, outcomes[i+1])
+		}
+	}
+	if maxActive > policyOutcomeConcurrency {
+		t.Errorf("expected at most %d concurrent requests but received %d", policyOutcomeConcurrency, maxActive)
+	}
+}
+
+func TestRunService_ListPolicySetOutcomes_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mOutcomes := mocks.NewMockPolicySetOutcomes(ctrl)
+
+	mOutcomes.EXPECT().List(gomock.Any(), "poleval-1", gomock.Any()).Return(nil, errors.New("forbidden"))
+	mOutcomes.EXPECT().List(gomock.Any(), "poleval-2", gomock.Any()).Return(&tfe.PolicySetOutcomeList{}, nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{PolicySetOutcomes: mOutcomes}, writer: &testLogWriter{}}}
+	_, err := service.listPolicySetOutcomes(ctx, []*tfe.PolicyEvaluation{
+		{ID: "poleval-1", PolicyKind: tfe.OPA},
+		{ID: "poleval-2", PolicyKind: tfe.OPA},
+	})
+	if err == nil || err.Error() != "forbidden" {
+		t.Errorf("expected forbidden error but received %v", err)
+	}
+}
+
+func TestRunService_GetPolicyEvaluation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mPolicyChecks := mocks.NewMockPolicyChecks(ctrl)
+	mTaskStages := mocks.NewMockTaskStages(ctrl)
+	mPolicyEvaluations := mocks.NewMockPolicyEvaluations(ctrl)
+
+	mPolicyChecks.EXPECT().List(ctx, "run-1", gomock.Any()).Return(&tfe.PolicyCheckList{
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+	mTaskStages.EXPECT().List(ctx, "run-1", gomock.Any()).Return(&tfe.TaskStageList{
+		Items: []*tfe.TaskStage{
+			{ID: "ts-1", Stage: tfe.PrePlan, Status: tfe.TaskStagePassed},
+			{ID: "ts-2", Stage: tfe.PostPlan, Status: tfe.TaskStageAwaitingOverride},
+			{ID: "ts-3", Stage: tfe.PreApply, Status: tfe.TaskStagePassed},
+		},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+	evaluations := map[string]*tfe.PolicyEvaluation{
+		"ts-1": {ID: "poleval-1", Status: tfe.PolicyEvaluationPassed, PolicyKind: tfe.Sentinel, ResultCount: &tfe.PolicyResultCount{Passed: 2}},
+		"ts-2": {ID: "poleval-2", Status: tfe.PolicyEvaluationFailed, PolicyKind: tfe.Sentinel, ResultCount: &tfe.PolicyResultCount{Passed: 1, MandatoryFailed: 1}},
+	}
+	for _, id := range []string{"ts-1", "ts-2", "ts-3"} {
+		list := &tfe.PolicyEvaluationList{Pagination: &tfe.Pagination{CurrentPage: 1}}
+		if evaluation, ok := evaluations[id]; ok {
+			list.Items = []*tfe.PolicyEvaluation{evaluation}
+		}
+		mPolicyEvaluations.EXPECT().List(ctx, id, gomock.Any()).Return(list, nil)
+	}
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{
+		PolicyChecks:      mPolicyChecks,
+		TaskStages:        mTaskStages,
+		PolicyEvaluations: mPolicyEvaluations,
+	}, writer: &testLogWriter{}}}
+	result, err := service.GetPolicyEvaluation(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Status != PolicyStatusFailed || !result.RequiresOverride {
+		t.Errorf("expected failed policies requiring an override but received %+v", result)
+	}
+	if result.Passed != 3 || result.MandatoryFailed != 1 {
+		t.Errorf("expected results aggregated across stages but received %+v", result.PolicyResultCounts)
+	}
+	// the pre_apply stage has no policy evaluations
+	if len(result.Stages) != 2 || result.Stages[0].Stage != "pre_plan" || result.Stages[1].Stage != "post_plan" {
+		t.Fatalf("expected a breakdown of the pre_plan and post_plan stages but received %+v", result.Stages)
+	}
+	if result.Stages[0].RequiresOverride || !result.Stages[1].RequiresOverride {
+		t.Errorf("expected only the post_plan stage to require an override")
+	}
+}
+
+func TestRunService_GetPolicyEvaluation_PolicyChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mPolicyChecks := mocks.NewMockPolicyChecks(ctrl)
+
+	mPolicyChecks.EXPECT().List(ctx, "run-1", gomock.Any()).Return(&tfe.PolicyCheckList{
+		Items: []*tfe.PolicyCheck{{
+			ID:      "polchk-1",
+			Status:  tfe.PolicySoftFailed,
+			Actions: &tfe.PolicyActions{IsOverridable: true},
+			Result:  &tfe.PolicyResult{Passed: 4, AdvisoryFailed: 1, SoftFailed: 1},
+		}},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+
+	// releases without task stages only report legacy policy checks
+	service := &runService{&cloudMeta{
+		tfe:          &tfe.Client{PolicyChecks: mPolicyChecks},
+		writer:       &testLogWriter{},
+		capabilities: &Capabilities{TFEVersion: "v202201-1"},
+	}}
+	result, err := service.GetPolicyEvaluation(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Status != PolicyStatusFailed || !result.RequiresOverride || result.MandatoryFailed != 1 || result.AdvisoryFailed != 1 {
+		t.Errorf("unexpected policy evaluation %+v", result)
+	}
+	if len(result.Stages) != 1 || result.Stages[0].Stage != PolicyCheckStage {
+		t.Errorf("expected a policy_check stage but received %+v", result.Stages)
+	}
+}
+
+func TestPolicyEvaluation_Policies(t *testing.T) {
+	evaluation := &PolicyEvaluation{Stages: []*PolicyStageResult{{
+		Stage: "post_plan",
+		Evaluations: []*PolicyEvaluationDetails{{PolicySets: []*PolicySetOutcomeDetails{{
+			Name: "baseline",
+			Policies: []*PolicyOutcome{
+				{Name: "require-tags", EnforcementLevel: "mandatory", Status: "passed"},
+				{Name: "restrict-regions", EnforcementLevel: "advisory", Status: "failed"},
+			},
+		}}}},
+	}}}
+
+	failed := evaluation.Policies(false)
+	if len(failed) != 1 || failed[0].Name != "restrict-regions" || failed[0].Stage != "post_plan" || failed[0].PolicySet != "baseline" {
+		t.Errorf("expected only the failed policy but received %+v", failed)
+	}
+	if all := evaluation.Policies(true); len(all) != 2 {
+		t.Errorf("expected all policies but received %+v", all)
+	}
+}
+
+func TestRunService_LogPolicyEvaluation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mPolicyChecks := mocks.NewMockPolicyChecks(ctrl)
+	mPolicyChecks.EXPECT().Logs(gomock.Any(), "polchk-1").Return(strings.NewReader("Sentinel Result: false\n"), nil)
+
+	writer := &testLogWriter{}
+	service := &runService{&cloudMeta{tfe: &tfe.Client{PolicyChecks: mPolicyChecks}, writer: writer}}
+	err := service.LogPolicyEvaluation(context.Background(), &PolicyEvaluation{Stages: []*PolicyStageResult{
+		{ID: "polchk-1", Stage: PolicyCheckStage},
+		{ID: "ts-1", Stage: "post_plan", Evaluations: []*PolicyEvaluationDetails{{
+			ID:         "poleval-1",
+			Status:     "failed",
+			PolicyKind: "opa",
+			PolicySets: []*PolicySetOutcomeDetails{{
+				Name:     "baseline",
+				Policies: []*PolicyOutcome{{Name: "restrict-regions", EnforcementLevel: "mandatory", Status: "failed", Description: "Only approved regions"}},
+			}},
+		}}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	output := strings.Join(writer.lines, "\n")
+	for _, expected := range []string{
+		"-------------- Policies: policy_check (polchk-1) --------------",
+		"Sentinel Result: false",
+		"- PolicyEvalutation (poleval-1), Status: 'failed', PolicyKind: 'opa'",
+		"    - Policy 'restrict-regions', Status: 'failed', EnforcementLevel: 'mandatory', Description: 'Only approved regions'",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected logs to contain %q but received %s", expected, output)
+		}
+	}
+}
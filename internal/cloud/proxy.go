@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/tfci/internal/logging"
+)
+
+// returns an http client that uses the explicit proxy when provided,
+// otherwise honors the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+func newHTTPClient(proxyURL string, rateLimits *RateLimitTracker, timings *APITimings) (*http.Client, error) {
+	transport, err := newProxyTransport(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var base http.RoundTripper = transport
+	if logging.IsTrace() {
+		base = &traceTransport{base: transport}
+	}
+	if timings != nil {
+		base = &timingTransport{base: base, timings: timings}
+	}
+
+	return &http.Client{Transport: newRetryTransport(base, rateLimits)}, nil
+}
+
+// pooled transport without tracing or retries
+func newProxyTransport(proxyURL string) (*http.Transport, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+	if proxyURL == "" {
+		return transport, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url %q, expected format: http://proxy.example.com:3128", redactProxyURL(u))
+	}
+	transport.Proxy = http.ProxyURL(u)
+	return transport, nil
+}
+
+// logs the proxy resolved for the target address, since proxy failures are otherwise opaque
+func logProxy(client *http.Client, address string) {
+	rt, ok := client.Transport.(*retryTransport)
+	if !ok {
+		return
+	}
+	base := rt.base
+	if tt, ok := base.(*timingTransport); ok {
+		base = tt.base
+	}
+	if tt, ok := base.(*traceTransport); ok {
+		base = tt.base
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodGet, address, nil)
+	if err != nil {
+		return
+	}
+
+	proxy, err := transport.Proxy(req)
+	if err != nil {
+		log.Printf("[ERROR] error resolving proxy for %s: %s", address, err)
+		return
+	}
+	if proxy == nil {
+		log.Printf("[DEBUG] no proxy configured for: %s", address)
+		return
+	}
+	log.Printf("[DEBUG] using proxy: %s for: %s", redactProxyURL(proxy), address)
+}
+
+// proxy urls may contain credentials
+func redactProxyURL(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	redacted := *u
+	redacted.User = url.User("redacted")
+	return redacted.String()
+}
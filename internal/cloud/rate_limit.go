@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// records rate limited (429) responses, go-tfe backs off and retries these requests
+type RateLimitTracker struct {
+	mu sync.Mutex
+	// number of rate limited responses
+	count int
+	// sum of retry-after durations reported by HCP Terraform
+	retryAfter time.Duration
+}
+
+func NewRateLimitTracker() *RateLimitTracker {
+	return &RateLimitTracker{}
+}
+
+func (r *RateLimitTracker) record(resp *http.Response) {
+	if r == nil || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	wait := retryAfter(resp)
+	log.Printf("[WARN] request to %s was rate limited, retry after: %s", resp.Request.URL.Path, wait)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count++
+	r.retryAfter += wait
+}
+
+// returns number of rate limited responses and total retry-after duration
+func (r *RateLimitTracker) Stats() (int, time.Duration) {
+	if r == nil {
+		return 0, 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count, r.retryAfter
+}
+
+// HCP Terraform responds with X-RateLimit-Reset in seconds, fallback to the standard Retry-After header
+func retryAfter(resp *http.Response) time.Duration {
+	for _, header := range []string{"X-RateLimit-Reset", "Retry-After"} {
+		v := resp.Header.Get(header)
+		if v == "" {
+			continue
+		}
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(seconds * float64(time.Second))
+		}
+	}
+	return 0
+}
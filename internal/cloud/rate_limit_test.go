@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTracker(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.Header().Set("X-RateLimit-Reset", "0.5")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tracker := NewRateLimitTracker()
+	client := &http.Client{Transport: &retryTransport{
+		base:       http.DefaultTransport,
+		rateLimits: tracker,
+	}}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	count, retryAfter := tracker.Stats()
+	if count != 2 {
+		t.Errorf("expected 2 rate limited responses but received %d", count)
+	}
+	if expected := 2500 * time.Millisecond; retryAfter != expected {
+		t.Errorf("expected retry after %s but received %s", expected, retryAfter)
+	}
+}
+
+func TestRateLimitTracker_Nil(t *testing.T) {
+	var tracker *RateLimitTracker
+	tracker.record(&http.Response{StatusCode: http.StatusTooManyRequests})
+
+	if count, _ := tracker.Stats(); count != 0 {
+		t.Errorf("expected no rate limited responses but received %d", count)
+	}
+}
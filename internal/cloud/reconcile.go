@@ -0,0 +1,395 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// desired state of a workspace, unset settings are left unchanged
+type WorkspaceSpec struct {
+	Name             string          `yaml:"name"`
+	Description      *string         `yaml:"description"`
+	Project          string          `yaml:"project"`
+	TerraformVersion *string         `yaml:"terraform_version"`
+	WorkingDirectory *string         `yaml:"working_directory"`
+	ExecutionMode    *string         `yaml:"execution_mode"`
+	AutoApply        *bool           `yaml:"auto_apply"`
+	Tags             []string        `yaml:"tags"`
+	Variables        []*VariableSpec `yaml:"variables"`
+}
+
+type VariableSpec struct {
+	Key         string `yaml:"key"`
+	Value       string `yaml:"value"`
+	Description string `yaml:"description"`
+	// terraform or env, defaults to terraform
+	Category  string `yaml:"category"`
+	HCL       bool   `yaml:"hcl"`
+	Sensitive bool   `yaml:"sensitive"`
+}
+
+func (v *VariableSpec) category() (tfe.CategoryType, error) {
+	switch v.Category {
+	case "", string(tfe.CategoryTerraform):
+		return tfe.CategoryTerraform, nil
+	case string(tfe.CategoryEnv):
+		return tfe.CategoryEnv, nil
+	}
+	return "", fmt.Errorf("variable %q has invalid category %q, expected terraform or env", v.Key, v.Category)
+}
+
+type ReconcileOptions struct {
+	Organization string
+	// tag added to reconciled workspaces, pruning only deletes workspaces with the tag
+	ManagedTag string
+	// removes tags, variables and managed workspaces that are not in the spec
+	Prune bool
+	// reports changes without making them
+	DryRun bool
+}
+
+// reconcile actions
+const (
+	ReconcileCreate    = "create"
+	ReconcileUpdate    = "update"
+	ReconcileDelete    = "delete"
+	ReconcileUnchanged = "unchanged"
+)
+
+type ReconcileChange struct {
+	Workspace string   `json:"workspace"`
+	Action    string   `json:"action"`
+	Changes   []string `json:"changes,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type ReconcileService interface {
+	ReconcileWorkspace(context.Context, ReconcileOptions, *WorkspaceSpec) (*ReconcileChange, error)
+	PruneWorkspaces(context.Context, ReconcileOptions, []string) ([]*ReconcileChange, error)
+}
+
+type reconcileService struct {
+	*cloudMeta
+}
+
+// creates or updates the workspace to match the spec
+func (s *reconcileService) ReconcileWorkspace(ctx context.Context, options ReconcileOptions, spec *WorkspaceSpec) (*ReconcileChange, error) {
+	change := &ReconcileChange{Workspace: spec.Name, Action: ReconcileUnchanged}
+	for _, v := range spec.Variables {
+		if _, err := v.category(); err != nil {
+			return change, err
+		}
+	}
+
+	var project *tfe.Project
+	if spec.Project != "" {
+		p, err := s.readProject(ctx, options.Organization, spec.Project)
+		if err != nil {
+			return change, err
+		}
+		project = p
+	}
+
+	ws, err := s.tfe.Workspaces.Read(ctx, options.Organization, spec.Name)
+	if err != nil && !errors.Is(err, tfe.ErrResourceNotFound) {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", spec.Name, options.Organization, err)
+		return change, err
+	}
+	if ws == nil || errors.Is(err, tfe.ErrResourceNotFound) {
+		return s.createWorkspace(ctx, options, spec, project)
+	}
+
+	update := tfe.WorkspaceUpdateOptions{}
+	if spec.Description != nil && *spec.Description != ws.Description {
+		update.Description = spec.Description
+		change.Changes = append(change.Changes, "update description")
+	}
+	if spec.TerraformVersion != nil && *spec.TerraformVersion != ws.TerraformVersion {
+		update.TerraformVersion = spec.TerraformVersion
+		change.Changes = append(change.Changes, fmt.Sprintf("update terraform_version from %q to %q", ws.TerraformVersion, *spec.TerraformVersion))
+	}
+	if spec.WorkingDirectory != nil && *spec.WorkingDirectory != ws.WorkingDirectory {
+		update.WorkingDirectory = spec.WorkingDirectory
+		change.Changes = append(change.Changes, fmt.Sprintf("update working_directory from %q to %q", ws.WorkingDirectory, *spec.WorkingDirectory))
+	}
+	if spec.ExecutionMode != nil && *spec.ExecutionMode != ws.ExecutionMode {
+		update.ExecutionMode = spec.ExecutionMode
+		change.Changes = append(change.Changes, fmt.Sprintf("update execution_mode from %q to %q", ws.ExecutionMode, *spec.ExecutionMode))
+	}
+	if spec.AutoApply != nil && *spec.AutoApply != ws.AutoApply {
+		update.AutoApply = spec.AutoApply
+		change.Changes = append(change.Changes, fmt.Sprintf("update auto_apply from %t to %t", ws.AutoApply, *spec.AutoApply))
+	}
+	if project != nil && (ws.Project == nil || ws.Project.ID != project.ID) {
+		update.Project = project
+		change.Changes = append(change.Changes, fmt.Sprintf("move to project %q", project.Name))
+	}
+	if len(change.Changes) > 0 && !options.DryRun {
+		if _, err := s.tfe.Workspaces.Update(ctx, options.Organization, spec.Name, update); err != nil {
+			log.Printf("[ERROR] error updating workspace: %q error: %s", spec.Name, err)
+			return change, err
+		}
+	}
+
+	if err := s.reconcileTags(ctx, options, spec, ws, change); err != nil {
+		return change, err
+	}
+	if err := s.reconcileVariables(ctx, options, spec, ws.ID, change); err != nil {
+		return change, err
+	}
+
+	if len(change.Changes) > 0 {
+		change.Action = ReconcileUpdate
+	}
+	return change, nil
+}
+
+func (s *reconcileService) createWorkspace(ctx context.Context, options ReconcileOptions, spec *WorkspaceSpec, project *tfe.Project) (*ReconcileChange, error) {
+	change := &ReconcileChange{Workspace: spec.Name, Action: ReconcileCreate}
+	tags := []*tfe.Tag{}
+	for _, name := range desiredTags(options, spec) {
+		tags = append(tags, &tfe.Tag{Name: name})
+		change.Changes = append(change.Changes, fmt.Sprintf("add tag %q", name))
+	}
+	for _, v := range spec.Variables {
+		change.Changes = append(change.Changes, fmt.Sprintf("create variable %q", v.Key))
+	}
+	if options.DryRun {
+		return change, nil
+	}
+
+	ws, err := s.tfe.Workspaces.Create(ctx, options.Organization, tfe.WorkspaceCreateOptions{
+		Name:             tfe.String(spec.Name),
+		Description:      spec.Description,
+		TerraformVersion: spec.TerraformVersion,
+		WorkingDirectory: spec.WorkingDirectory,
+		ExecutionMode:    spec.ExecutionMode,
+		AutoApply:        spec.AutoApply,
+		Project:          project,
+		Tags:             tags,
+	})
+	if err != nil {
+		log.Printf("[ERROR] error creating workspace: %q organization: %q, error: %s", spec.Name, options.Organization, err)
+		return change, err
+	}
+
+	for _, v := range spec.Variables {
+		if err := s.createVariable(ctx, ws.ID, v); err != nil {
+			return change, err
+		}
+	}
+	return change, nil
+}
+
+func desiredTags(options ReconcileOptions, spec *WorkspaceSpec) []string {
+	tags := slices.Clone(spec.Tags)
+	if options.ManagedTag != "" && !slices.Contains(tags, options.ManagedTag) {
+		tags = append(tags, options.ManagedTag)
+	}
+	return tags
+}
+
+func (s *reconcileService) reconcileTags(ctx context.Context, options ReconcileOptions, spec *WorkspaceSpec, ws *tfe.Workspace, change *ReconcileChange) error {
+	desired := desiredTags(options, spec)
+	add := []*tfe.Tag{}
+	for _, name := range desired {
+		if !slices.Contains(ws.TagNames, name) {
+			add = append(add, &tfe.Tag{Name: name})
+			change.Changes = append(change.Changes, fmt.Sprintf("add tag %q", name))
+		}
+	}
+	remove := []*tfe.Tag{}
+	if options.Prune {
+		for _, name := range ws.TagNames {
+			if !slices.Contains(desired, name) {
+				remove = append(remove, &tfe.Tag{Name: name})
+				change.Changes = append(change.Changes, fmt.Sprintf("remove tag %q", name))
+			}
+		}
+	}
+	if options.DryRun {
+		return nil
+	}
+
+	if len(add) > 0 {
+		if err := s.tfe.Workspaces.AddTags(ctx, ws.ID, tfe.WorkspaceAddTagsOptions{Tags: add}); err != nil {
+			log.Printf("[ERROR] error adding tags to workspace: %q error: %s", spec.Name, err)
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if err := s.tfe.Workspaces.RemoveTags(ctx, ws.ID, tfe.WorkspaceRemoveTagsOptions{Tags: remove}); err != nil {
+			log.Printf("[ERROR] error removing tags from workspace: %q error: %s", spec.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *reconcileService) reconcileVariables(ctx context.Context, options ReconcileOptions, spec *WorkspaceSpec, workspaceID string, change *ReconcileChange) error {
+	existing, err := s.listVariables(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	managed := map[*tfe.Variable]bool{}
+	for _, v := range spec.Variables {
+		category, _ := v.category()
+		var current *tfe.Variable
+		for _, e := range existing {
+			if e.Key == v.Key && e.Category == category {
+				current = e
+				break
+			}
+		}
+
+		if current == nil {
+			change.Changes = append(change.Changes, fmt.Sprintf("create variable %q", v.Key))
+			if !options.DryRun {
+				if err := s.createVariable(ctx, workspaceID, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		managed[current] = true
+		// sensitive values cannot be read, so they are always updated
+		if current.Value == v.Value && current.HCL == v.HCL && current.Sensitive == v.Sensitive && current.Description == v.Description && !v.Sensitive {
+			continue
+		}
+		change.Changes = append(change.Changes, fmt.Sprintf("update variable %q", v.Key))
+		if options.DryRun {
+			continue
+		}
+		if _, err := s.tfe.Variables.Update(ctx, workspaceID, current.ID, tfe.VariableUpdateOptions{
+			Key:         tfe.String(v.Key),
+			Value:       tfe.String(v.Value),
+			Description: tfe.String(v.Description),
+			HCL:         tfe.Bool(v.HCL),
+			Sensitive:   tfe.Bool(v.Sensitive),
+		}); err != nil {
+			log.Printf("[ERROR] error updating variable: %q in workspace: %q error: %s", v.Key, spec.Name, err)
+			return err
+		}
+	}
+
+	if !options.Prune {
+		return nil
+	}
+	for _, e := range existing {
+		if managed[e] {
+			continue
+		}
+		change.Changes = append(change.Changes, fmt.Sprintf("delete variable %q", e.Key))
+		if options.DryRun {
+			continue
+		}
+		if err := s.tfe.Variables.Delete(ctx, workspaceID, e.ID); err != nil {
+			log.Printf("[ERROR] error deleting variable: %q in workspace: %q error: %s", e.Key, spec.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *reconcileService) createVariable(ctx context.Context, workspaceID string, v *VariableSpec) error {
+	category, err := v.category()
+	if err != nil {
+		return err
+	}
+	if _, err := s.tfe.Variables.Create(ctx, workspaceID, tfe.VariableCreateOptions{
+		Key:         tfe.String(v.Key),
+		Value:       tfe.String(v.Value),
+		Description: tfe.String(v.Description),
+		Category:    tfe.Category(category),
+		HCL:         tfe.Bool(v.HCL),
+		Sensitive:   tfe.Bool(v.Sensitive),
+	}); err != nil {
+		log.Printf("[ERROR] error creating variable: %q error: %s", v.Key, err)
+		return err
+	}
+	return nil
+}
+
+func (m *cloudMeta) listVariables(ctx context.Context, workspaceID string) ([]*tfe.Variable, error) {
+	variables, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Variable, *tfe.Pagination, error) {
+		page, err := m.tfe.Variables.List(ctx, workspaceID, &tfe.VariableListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing variables of workspace: %q error: %s", workspaceID, err)
+		return nil, err
+	}
+	return variables, nil
+}
+
+func (m *cloudMeta) readProject(ctx context.Context, orgName string, name string) (*tfe.Project, error) {
+	projects, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Project, *tfe.Pagination, error) {
+		page, err := m.tfe.Projects.List(ctx, orgName, &tfe.ProjectListOptions{ListOptions: opts, Name: name})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error reading project: %q organization: %q, error: %s", name, orgName, err)
+		return nil, err
+	}
+	for _, p := range projects {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("project %q was not found in organization %q", name, orgName)
+}
+
+// safely deletes workspaces with the managed tag that are not listed to keep
+func (s *reconcileService) PruneWorkspaces(ctx context.Context, options ReconcileOptions, keep []string) ([]*ReconcileChange, error) {
+	if options.ManagedTag == "" {
+		return nil, errors.New("pruning workspaces requires a managed tag")
+	}
+	names, err := NewWorkspaceService(s.cloudMeta).ListWorkspaceNames(ctx, options.Organization, []string{options.ManagedTag})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*ReconcileChange{}
+	for _, name := range names {
+		if slices.Contains(keep, name) {
+			continue
+		}
+		change := &ReconcileChange{Workspace: name, Action: ReconcileDelete, Changes: []string{"delete workspace"}}
+		changes = append(changes, change)
+		if options.DryRun {
+			continue
+		}
+		// workspaces that still manage resources are not deleted, their state would be lost
+		err := s.tfe.Workspaces.SafeDelete(ctx, options.Organization, name)
+		switch {
+		case errors.Is(err, tfe.ErrWorkspaceNotSafeToDelete):
+			change.Error = "workspace still manages resources, destroy them before deleting the workspace"
+		case err != nil:
+			change.Error = err.Error()
+		}
+		if err != nil {
+			log.Printf("[ERROR] error deleting workspace: %q organization: %q, error: %s", name, options.Organization, err)
+		}
+	}
+	return changes, nil
+}
+
+func NewReconcileService(meta *cloudMeta) ReconcileService {
+	return &reconcileService{meta}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcileService_CreateWorkspace(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spec := &WorkspaceSpec{
+		Name:      "networking",
+		Project:   "Platform",
+		AutoApply: tfe.Bool(true),
+		Tags:      []string{"networking"},
+		Variables: []*VariableSpec{{Key: "AWS_REGION", Value: "us-east-1", Category: "env"}},
+	}
+	project := &tfe.Project{ID: "prj-1", Name: "Platform"}
+
+	mProjects := mocks.NewMockProjects(ctrl)
+	mProjects.EXPECT().List(ctx, "abc-company", &tfe.ProjectListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, Name: "Platform"}).
+		Return(&tfe.ProjectList{Items: []*tfe.Project{project}}, nil).Times(2)
+
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(nil, tfe.ErrResourceNotFound).Times(2)
+	mWorkspaces.EXPECT().Create(ctx, "abc-company", tfe.WorkspaceCreateOptions{
+		Name:      tfe.String("networking"),
+		AutoApply: tfe.Bool(true),
+		Project:   project,
+		Tags:      []*tfe.Tag{{Name: "networking"}, {Name: "tfci-managed"}},
+	}).Return(&tfe.Workspace{ID: "ws-1", Name: "networking"}, nil)
+
+	mVariables := mocks.NewMockVariables(ctrl)
+	mVariables.EXPECT().Create(ctx, "ws-1", tfe.VariableCreateOptions{
+		Key:         tfe.String("AWS_REGION"),
+		Value:       tfe.String("us-east-1"),
+		Description: tfe.String(""),
+		Category:    tfe.Category(tfe.CategoryEnv),
+		HCL:         tfe.Bool(false),
+		Sensitive:   tfe.Bool(false),
+	}).Return(&tfe.Variable{ID: "var-1"}, nil)
+
+	service := NewReconcileService(&cloudMeta{
+		tfe:    &tfe.Client{Projects: mProjects, Workspaces: mWorkspaces, Variables: mVariables},
+		writer: &defaultWriter{},
+	})
+
+	options := ReconcileOptions{Organization: "abc-company", ManagedTag: "tfci-managed", DryRun: true}
+	change, err := service.ReconcileWorkspace(ctx, options, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if change.Action != ReconcileCreate || len(change.Changes) != 3 {
+		t.Errorf("unexpected dry run change: %+v", change)
+	}
+
+	options.DryRun = false
+	if _, err := service.ReconcileWorkspace(ctx, options, spec); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReconcileService_UpdateWorkspace(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spec := &WorkspaceSpec{
+		Name:             "networking",
+		TerraformVersion: tfe.String("1.9.0"),
+		AutoApply:        tfe.Bool(false),
+		Tags:             []string{"networking"},
+		Variables: []*VariableSpec{
+			{Key: "region", Value: "us-east-1"},
+			{Key: "cidr", Value: "10.0.0.0/16"},
+			{Key: "zones", Value: `["a"]`, HCL: true},
+		},
+	}
+
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{
+		ID:               "ws-1",
+		Name:             "networking",
+		TerraformVersion: "1.8.0",
+		TagNames:         []string{"networking", "legacy"},
+	}, nil)
+	mWorkspaces.EXPECT().Update(ctx, "abc-company", "networking", tfe.WorkspaceUpdateOptions{
+		TerraformVersion: tfe.String("1.9.0"),
+	}).Return(&tfe.Workspace{ID: "ws-1"}, nil)
+	mWorkspaces.EXPECT().AddTags(ctx, "ws-1", tfe.WorkspaceAddTagsOptions{Tags: []*tfe.Tag{{Name: "tfci-managed"}}}).Return(nil)
+	mWorkspaces.EXPECT().RemoveTags(ctx, "ws-1", tfe.WorkspaceRemoveTagsOptions{Tags: []*tfe.Tag{{Name: "legacy"}}}).Return(nil)
+
+	mVariables := mocks.NewMockVariables(ctrl)
+	mVariables.EXPECT().List(ctx, "ws-1", &tfe.VariableListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.VariableList{
+		Items: []*tfe.Variable{
+			{ID: "var-1", Key: "region", Value: "us-east-1", Category: tfe.CategoryTerraform},
+			{ID: "var-2", Key: "cidr", Value: "10.1.0.0/16", Category: tfe.CategoryTerraform},
+			{ID: "var-3", Key: "unused", Value: "x", Category: tfe.CategoryTerraform},
+		},
+	}, nil)
+	mVariables.EXPECT().Update(ctx, "ws-1", "var-2", gomock.Any()).Return(&tfe.Variable{}, nil)
+	mVariables.EXPECT().Create(ctx, "ws-1", gomock.Any()).Return(&tfe.Variable{}, nil)
+	mVariables.EXPECT().Delete(ctx, "ws-1", "var-3").Return(nil)
+
+	service := NewReconcileService(&cloudMeta{
+		tfe:    &tfe.Client{Workspaces: mWorkspaces, Variables: mVariables},
+		writer: &defaultWriter{},
+	})
+
+	change, err := service.ReconcileWorkspace(ctx, ReconcileOptions{Organization: "abc-company", ManagedTag: "tfci-managed", Prune: true}, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if change.Action != ReconcileUpdate {
+		t.Errorf("expected update action but received %s", change.Action)
+	}
+
+	expected := []string{
+		`update terraform_version from "1.8.0" to "1.9.0"`,
+		`add tag "tfci-managed"`,
+		`remove tag "legacy"`,
+		`update variable "cidr"`,
+		`create variable "zones"`,
+		`delete variable "unused"`,
+	}
+	if strings.Join(change.Changes, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected changes:\n%s\nbut received:\n%s", strings.Join(expected, "\n"), strings.Join(change.Changes, "\n"))
+	}
+}
+
+func TestReconcileService_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{
+		ID:        "ws-1",
+		Name:      "networking",
+		AutoApply: true,
+		TagNames:  []string{"tfci-managed"},
+	}, nil)
+	mVariables := mocks.NewMockVariables(ctrl)
+	mVariables.EXPECT().List(ctx, "ws-1", gomock.Any()).Return(&tfe.VariableList{}, nil)
+
+	service := NewReconcileService(&cloudMeta{
+		tfe:    &tfe.Client{Workspaces: mWorkspaces, Variables: mVariables},
+		writer: &defaultWriter{},
+	})
+
+	change, err := service.ReconcileWorkspace(ctx, ReconcileOptions{Organization: "abc-company", ManagedTag: "tfci-managed"}, &WorkspaceSpec{
+		Name:      "networking",
+		AutoApply: tfe.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if change.Action != ReconcileUnchanged || len(change.Changes) != 0 {
+		t.Errorf("expected no changes but received %+v", change)
+	}
+}
+
+func TestReconcileService_InvalidCategory(t *testing.T) {
+	service := NewReconcileService(&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}})
+	_, err := service.ReconcileWorkspace(context.Background(), ReconcileOptions{}, &WorkspaceSpec{
+		Name:      "networking",
+		Variables: []*VariableSpec{{Key: "region", Category: "terraform-env"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `invalid category "terraform-env"`) {
+		t.Errorf("expected invalid category error but received %v", err)
+	}
+}
+
+func TestReconcileService_PruneWorkspaces(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dryRun        bool
+		deleteErr     error
+		expectedError string
+	}{
+		{name: "deleted"},
+		{name: "dry run", dryRun: true},
+		{name: "manages resources", deleteErr: tfe.ErrWorkspaceNotSafeToDelete, expectedError: "workspace still manages resources, destroy them before deleting the workspace"},
+		{name: "locked", deleteErr: tfe.ErrWorkspaceLockedCannotDelete, expectedError: tfe.ErrWorkspaceLockedCannotDelete.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+			mWorkspaces.EXPECT().List(ctx, "abc-company", &tfe.WorkspaceListOptions{
+				ListOptions: tfe.ListOptions{PageSize: 100},
+				Tags:        "tfci-managed",
+			}).Return(&tfe.WorkspaceList{
+				Items: []*tfe.Workspace{{Name: "networking"}, {Name: "old"}},
+			}, nil)
+			// a dry run does not delete the workspace
+			if !tc.dryRun {
+				mWorkspaces.EXPECT().SafeDelete(ctx, "abc-company", "old").Return(tc.deleteErr)
+			}
+
+			service := NewReconcileService(&cloudMeta{
+				tfe:    &tfe.Client{Workspaces: mWorkspaces},
+				writer: &defaultWriter{},
+			})
+
+			changes, err := service.PruneWorkspaces(ctx, ReconcileOptions{Organization: "abc-company", ManagedTag: "tfci-managed", Prune: true, DryRun: tc.dryRun}, []string{"networking"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(changes) != 1 || changes[0].Workspace != "old" || changes[0].Action != ReconcileDelete {
+				t.Fatalf("unexpected prune changes: %+v", changes)
+			}
+			if changes[0].Error != tc.expectedError {
+				t.Errorf("expected error %q but received %q", tc.expectedError, changes[0].Error)
+			}
+		})
+	}
+}
+
+func TestReconcileService_PruneWorkspaces_ManagedTag(t *testing.T) {
+	service := NewReconcileService(&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}})
+	if _, err := service.PruneWorkspaces(context.Background(), ReconcileOptions{Organization: "abc-company"}, nil); err == nil {
+		t.Error("expected an error pruning without a managed tag")
+	}
+}
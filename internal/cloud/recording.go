@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/logging"
+	"github.com/hashicorp/tfci/version"
+)
+
+const recordingVersion = 1
+
+// path segments at least this long, such as signed log and upload urls, are replaced in recordings
+const minRecordedSecretLength = 64
+
+// response headers kept in recordings, the api version and app name are required to replay capability checks
+var recordedHeaders = []string{"Content-Type", "TFP-API-Version", "TFP-AppName", "X-TFE-Version"}
+
+var recordedURLPattern = regexp.MustCompile(`https?://[^\s"'\\]+`)
+
+// Recording is a session of API interactions, written with --record and replayed with --replay
+type Recording struct {
+	Version      int            `json:"version"`
+	TFCIVersion  string         `json:"tfci_version"`
+	Command      string         `json:"command,omitempty"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Method string `json:"method"`
+	// path and query of the request
+	URL         string            `json:"url"`
+	RequestBody string            `json:"request_body,omitempty"`
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body"`
+
+	requestContentType string
+}
+
+// Recorder captures API interactions, sensitive values are redacted when the recording is saved
+type Recorder struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	redactor     *logging.Redactor
+	// credentials of the client, such as a workload identity token used as the api token
+	credentials *logging.Redactor
+}
+
+// NewRecorder returns a Recorder redacting the redactor's values and patterns, in addition to tokens
+func NewRecorder(redactor *logging.Redactor) *Recorder {
+	return &Recorder{redactor: redactor, credentials: &logging.Redactor{}}
+}
+
+// redacts the credential wherever it appears in the recording
+func (r *Recorder) addCredential(value string) {
+	r.credentials.AddValues(value)
+}
+
+func (r *Recorder) transport(base http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{base: base, recorder: r}
+}
+
+func (r *Recorder) record(i *Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, i)
+}
+
+// Save writes the redacted recording of the command to path
+func (r *Recorder) Save(path string, command string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recording := &Recording{
+		Version:      recordingVersion,
+		TFCIVersion:  version.GetVersion(),
+		Command:      command,
+		Interactions: r.redact(),
+	}
+	b, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("error writing recording: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) redact() []*Interaction {
+	// signed urls are replaced consistently, so replayed requests still match
+	secrets := map[string]string{}
+	var replacements []string
+	addSecrets := func(u string) {
+		for _, segment := range strings.Split(strings.SplitN(u, "?", 2)[0], "/") {
+			if len(segment) >= minRecordedSecretLength && secrets[segment] == "" {
+				secrets[segment] = fmt.Sprintf("redacted-%d", len(secrets)+1)
+				replacements = append(replacements, segment, secrets[segment])
+			}
+		}
+	}
+	for _, i := range r.interactions {
+		addSecrets(i.URL)
+		// signed urls in responses may not have been requested
+		for _, u := range recordedURLPattern.FindAllString(i.Body, -1) {
+			addSecrets(u)
+		}
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	redacted := make([]*Interaction, 0, len(r.interactions))
+	for _, i := range r.interactions {
+		out := *i
+		out.URL = replacer.Replace(i.URL)
+		out.RequestBody = r.redactBody(i.requestContentType, replacer.Replace(i.RequestBody))
+		out.Body = r.redactBody(i.Headers["Content-Type"], replacer.Replace(i.Body))
+		redacted = append(redacted, &out)
+	}
+	return redacted
+}
+
+func (r *Recorder) redactBody(contentType string, body string) string {
+	if body == "" {
+		return body
+	}
+	if isJSONContent(contentType) {
+		body = string(logging.RedactJSON([]byte(body)))
+	}
+	return r.credentials.Redact(r.redactor.Redact(body))
+}
+
+// recordingTransport captures each request and response, request bodies are only kept when JSON
+type recordingTransport struct {
+	base     http.RoundTripper
+	recorder *Recorder
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction := &Interaction{
+		Method:  req.Method,
+		URL:     req.URL.RequestURI(),
+		Headers: map[string]string{},
+	}
+	if contentType := req.Header.Get("Content-Type"); isJSONContent(contentType) && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			interaction.RequestBody = string(b)
+			interaction.requestContentType = contentType
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	// restore the body for the caller
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return resp, err
+	}
+
+	interaction.Status = resp.StatusCode
+	interaction.Body = string(b)
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			interaction.Headers[h] = v
+		}
+	}
+	t.recorder.record(interaction)
+	return resp, nil
+}
+
+// replayTransport answers requests with the recorded responses, in the order they were recorded
+type replayTransport struct {
+	mu           sync.Mutex
+	interactions map[string][]*Interaction
+	calls        map[string]int
+}
+
+func newReplayTransport(path string) (*replayTransport, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading recording: %w", err)
+	}
+	var recording Recording
+	if err := json.Unmarshal(b, &recording); err != nil {
+		return nil, fmt.Errorf("error parsing recording %s: %w", path, err)
+	}
+	if recording.Version != recordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d, expected %d", recording.Version, recordingVersion)
+	}
+
+	t := &replayTransport{interactions: map[string][]*Interaction{}, calls: map[string]int{}}
+	for _, i := range recording.Interactions {
+		key := i.Method + " " + i.URL
+		t.interactions[key] = append(t.interactions[key], i)
+	}
+	return t, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	key := req.Method + " " + req.URL.RequestURI()
+	t.mu.Lock()
+	recorded := t.interactions[key]
+	call := t.calls[key]
+	t.calls[key]++
+	t.mu.Unlock()
+
+	if len(recorded) == 0 {
+		log.Printf("[DEBUG] replay: no recorded response for %s", key)
+		body := fmt.Sprintf(`{"errors":[{"status":"404","title":"not found","detail":"no recorded response for %s %s"}]}`, req.Method, path.Clean(req.URL.Path))
+		return mockResponse(req, http.StatusNotFound, tfe.ContentTypeJSONAPI, []byte(body)), nil
+	}
+	// the last response repeats, as polling may take more requests than recorded
+	interaction := recorded[min(call, len(recorded)-1)]
+	log.Printf("[DEBUG] replay: %s status: %d", key, interaction.Status)
+
+	header := http.Header{}
+	for k, v := range interaction.Headers {
+		header.Set(k, v)
+	}
+	return cannedResponse(req, interaction.Status, header, []byte(interaction.Body)), nil
+}
+
+// returns a client answered by a recorded session instead of the HCP Terraform API
+func newReplayClient(options *ClientOptions) (*tfe.Client, error) {
+	transport, err := newReplayTransport(options.Replay)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Replaying recorded session: %s", options.Replay)
+	return newOfflineClient(options, transport)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/logging"
+)
+
+func TestRecorder_Replay(t *testing.T) {
+	dir := t.TempDir()
+	signed := strings.Repeat("a", minRecordedSecretLength)
+	writeMockFixture(t, dir, "api/v2/runs/run-abc123/GET.1.json", `{"data":{"id":"run-abc123","type":"runs","attributes":{"status":"planning"}}}`)
+	writeMockFixture(t, dir, "api/v2/runs/run-abc123/GET.2.json", `{"data":{"id":"run-abc123","type":"runs","attributes":{"status":"planned","message":"deploy s3cr3t-value"}}}`)
+	writeMockFixture(t, dir, "api/v2/teams/team-abc123/authentication-token/POST.json", `{"data":{"id":"at-abc123","type":"authentication-tokens","attributes":{"token":"abc.atlasv1.secret"}}}`)
+	writeMockFixture(t, dir, "api/v2/plans/plan-abc123/GET.json", `{"data":{"id":"plan-abc123","type":"plans","attributes":{"status":"finished","log-read-url":"https://archivist.terraform.io/v1/object/`+signed+`"}}}`)
+	writeMockFixture(t, dir, "v1/object/"+signed+"/GET", "Plan: 1 to add, 0 to change, 0 to destroy.\n")
+
+	redactor := &logging.Redactor{}
+	redactor.AddValues("s3cr3t-value")
+	recorder := NewRecorder(redactor)
+	client, err := NewTfeClient(&ClientOptions{MockFixtures: dir, Recorder: recorder})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.Runs.Read(ctx, "run-abc123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.TeamTokens.Create(ctx, "team-abc123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Plans.Read(ctx, "plan-abc123"); err != nil {
+		t.Fatal(err)
+	}
+	logs, err := client.Plans.Logs(ctx, "plan-abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(logs); err != nil {
+		t.Fatal(err)
+	}
+
+	session := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Save(session, "run show"); err != nil {
+		t.Fatalf("expected no error saving recording, received: %s", err)
+	}
+	b, err := os.ReadFile(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"s3cr3t-value", "abc.atlasv1.secret", signed} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("expected %q to be redacted from the recording", secret)
+		}
+	}
+
+	replay, err := NewTfeClient(&ClientOptions{Replay: session})
+	if err != nil {
+		t.Fatalf("expected no error replaying recording, received: %s", err)
+	}
+	for _, expected := range []tfe.RunStatus{tfe.RunPlanning, tfe.RunPlanned, tfe.RunPlanned} {
+		run, err := replay.Runs.Read(ctx, "run-abc123")
+		if err != nil {
+			t.Fatalf("expected no error reading replayed run, received: %s", err)
+		}
+		if run.Status != expected {
+			t.Errorf("expected replayed run status %q but received %q", expected, run.Status)
+		}
+	}
+
+	plan, err := replay.Plans.Read(ctx, "plan-abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(plan.LogReadURL, "/redacted-1") {
+		t.Errorf("expected signed log url to be redacted but received %q", plan.LogReadURL)
+	}
+	logs, err = replay.Plans.Logs(ctx, "plan-abc123")
+	if err != nil {
+		t.Fatalf("expected no error reading replayed plan logs, received: %s", err)
+	}
+	out, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "Plan: 1 to add") {
+		t.Errorf("expected replayed plan logs but received %q", string(out))
+	}
+
+	if _, err := replay.Runs.Read(ctx, "run-missing"); !errors.Is(err, tfe.ErrResourceNotFound) {
+		t.Errorf("expected not found error for an unrecorded request but received: %v", err)
+	}
+}
+
+func TestRecorder_Credentials(t *testing.T) {
+	recorder := NewRecorder(nil)
+	recorder.addCredential("eyJhbGciOiJSUzI1NiJ9.github-id-token")
+	recorder.record(&Interaction{
+		Method:  "GET",
+		URL:     "/api/v2/account/details",
+		Status:  200,
+		Headers: map[string]string{"Content-Type": tfe.ContentTypeJSONAPI},
+		Body:    `{"data":{"id":"user-abc123","type":"users","attributes":{"description":"token eyJhbGciOiJSUzI1NiJ9.github-id-token"}}}`,
+	})
+
+	session := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Save(session, "whoami"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "github-id-token") {
+		t.Errorf("expected the credential to be redacted from the recording but received %s", b)
+	}
+}
+
+func TestNewReplayTransport_Invalid(t *testing.T) {
+	session := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(session, []byte(`{"version": 99}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newReplayTransport(session); err == nil {
+		t.Error("expected an error for an unsupported recording version")
+	}
+	if _, err := newReplayTransport(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing recording")
+	}
+}
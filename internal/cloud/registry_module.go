@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/sethvargo/go-retry"
+)
+
+type PublishModuleOptions struct {
+	Organization string
+	Name         string
+	Provider     string
+	Version      string
+	// directory of the module configuration, packed and uploaded as the version tarball
+	Directory string
+}
+
+type RegistryModuleDetails struct {
+	ID                  string                          `json:"id"`
+	Name                string                          `json:"name"`
+	Provider            string                          `json:"provider"`
+	Namespace           string                          `json:"namespace"`
+	RegistryName        string                          `json:"registry_name"`
+	Status              string                          `json:"status"`
+	NoCode              bool                            `json:"no_code"`
+	PublishingMechanism string                          `json:"publishing_mechanism"`
+	Versions            []*RegistryModuleVersionDetails `json:"versions"`
+}
+
+type RegistryModuleVersionDetails struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newRegistryModuleDetails(module *tfe.RegistryModule) *RegistryModuleDetails {
+	details := &RegistryModuleDetails{
+		ID:                  module.ID,
+		Name:                module.Name,
+		Provider:            module.Provider,
+		Namespace:           module.Namespace,
+		RegistryName:        string(module.RegistryName),
+		Status:              string(module.Status),
+		NoCode:              module.NoCode,
+		PublishingMechanism: string(module.PublishingMechanism),
+		Versions:            []*RegistryModuleVersionDetails{},
+	}
+	for _, v := range module.VersionStatuses {
+		details.Versions = append(details.Versions, &RegistryModuleVersionDetails{
+			Version: v.Version,
+			Status:  string(v.Status),
+			Error:   v.Error,
+		})
+	}
+	return details
+}
+
+// returns the details of the given version, nil when it was never published
+func (d *RegistryModuleDetails) Version(version string) *RegistryModuleVersionDetails {
+	for _, v := range d.Versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
+type RegistryModuleService interface {
+	PublishModule(context.Context, PublishModuleOptions) (*tfe.RegistryModuleVersion, error)
+	ListModules(ctx context.Context, organization string) ([]*RegistryModuleDetails, error)
+	ReadModule(ctx context.Context, organization, name, provider string) (*RegistryModuleDetails, error)
+}
+
+type registryModuleService struct {
+	*cloudMeta
+}
+
+// publishes a version of a private registry module without a VCS connection, creating the module
+// when it does not exist yet, and waits until the registry has ingested the uploaded tarball
+func (s *registryModuleService) PublishModule(ctx context.Context, options PublishModuleOptions) (*tfe.RegistryModuleVersion, error) {
+	moduleID := tfe.RegistryModuleID{
+		Organization: options.Organization,
+		Name:         options.Name,
+		Provider:     options.Provider,
+		Namespace:    options.Organization,
+		RegistryName: tfe.PrivateRegistry,
+	}
+
+	module, err := s.tfe.RegistryModules.Read(ctx, moduleID)
+	if err != nil && !errors.Is(err, tfe.ErrResourceNotFound) {
+		log.Printf("[ERROR] error reading registry module: %s/%s organization: %q error: %s", options.Name, options.Provider, options.Organization, err)
+		return nil, err
+	}
+	if module == nil || errors.Is(err, tfe.ErrResourceNotFound) {
+		module, err = s.tfe.RegistryModules.Create(ctx, options.Organization, tfe.RegistryModuleCreateOptions{
+			Name:         tfe.String(options.Name),
+			Provider:     tfe.String(options.Provider),
+			RegistryName: tfe.PrivateRegistry,
+			Namespace:    options.Organization,
+		})
+		if err != nil {
+			log.Printf("[ERROR] error creating registry module: %s/%s organization: %q error: %s", options.Name, options.Provider, options.Organization, err)
+			return nil, err
+		}
+		s.writer.Output(fmt.Sprintf("Registry Module has been created: %s", module.ID))
+	}
+
+	version, err := s.tfe.RegistryModules.CreateVersion(ctx, moduleID, tfe.RegistryModuleCreateVersionOptions{
+		Version: tfe.String(options.Version),
+	})
+	if err != nil {
+		log.Printf("[ERROR] error creating registry module version: %s error: %s", options.Version, err)
+		return nil, err
+	}
+	s.writer.Output(fmt.Sprintf("Registry Module Version has been created: %s", version.ID))
+
+	if err := s.tfe.RegistryModules.Upload(ctx, *version, options.Directory); err != nil {
+		log.Printf("[ERROR] error uploading registry module version: %s error: %s", options.Version, err)
+		return version, err
+	}
+
+	s.writer.Output("Uploading module...")
+
+	retryErr := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
+		log.Printf("[DEBUG] Monitoring Module Version Status...")
+		v, err := s.tfe.RegistryModules.ReadVersion(ctx, moduleID, options.Version)
+		if err != nil {
+			return err
+		}
+		s.writer.Output(fmt.Sprintf("Module Version Status: %q", v.Status))
+		version = v
+		if registryModuleVersionFinished(v.Status) {
+			return nil
+		}
+		return retryableTimeoutError("publish module version")
+	})
+	if retryErr != nil {
+		log.Printf("[ERROR] error waiting for module version ingestion: %s", retryErr)
+		return version, retryErr
+	}
+
+	if version.Status != tfe.RegistryModuleVersionStatusOk {
+		return version, fmt.Errorf("registry module version %s finished with status %q", options.Version, version.Status)
+	}
+	return version, nil
+}
+
+func (s *registryModuleService) ListModules(ctx context.Context, organization string) ([]*RegistryModuleDetails, error) {
+	modules, err := listAll(func(opts tfe.ListOptions) ([]*tfe.RegistryModule, *tfe.Pagination, error) {
+		list, err := s.tfe.RegistryModules.List(ctx, organization, &tfe.RegistryModuleListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing registry modules of organization: %q error: %s", organization, err)
+		return nil, err
+	}
+	details := make([]*RegistryModuleDetails, 0, len(modules))
+	for _, module := range modules {
+		details = append(details, newRegistryModuleDetails(module))
+	}
+	return details, nil
+}
+
+func (s *registryModuleService) ReadModule(ctx context.Context, organization, name, provider string) (*RegistryModuleDetails, error) {
+	module, err := s.tfe.RegistryModules.Read(ctx, tfe.RegistryModuleID{
+		Organization: organization,
+		Name:         name,
+		Provider:     provider,
+		Namespace:    organization,
+		RegistryName: tfe.PrivateRegistry,
+	})
+	if err != nil {
+		log.Printf("[ERROR] error reading registry module: %s/%s organization: %q error: %s", name, provider, organization, err)
+		return nil, err
+	}
+	return newRegistryModuleDetails(module), nil
+}
+
+func registryModuleVersionFinished(status tfe.RegistryModuleVersionStatus) bool {
+	switch status {
+	case tfe.RegistryModuleVersionStatusOk,
+		tfe.RegistryModuleVersionStatusCloneFailed,
+		tfe.RegistryModuleVersionStatusRegIngressReqFailed,
+		tfe.RegistryModuleVersionStatusRegIngressFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewRegistryModuleService(meta *cloudMeta) *registryModuleService {
+	return &registryModuleService{meta}
+}
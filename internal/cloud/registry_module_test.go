@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRegistryModuleService_PublishModule(t *testing.T) {
+	ctx := context.Background()
+	options := PublishModuleOptions{Organization: "abc-company", Name: "vpc", Provider: "aws", Version: "1.2.3", Directory: "./module"}
+	moduleID := tfe.RegistryModuleID{Organization: "abc-company", Name: "vpc", Provider: "aws", Namespace: "abc-company", RegistryName: tfe.PrivateRegistry}
+
+	t.Run("creates a missing module", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mModules := mocks.NewMockRegistryModules(ctrl)
+		version := &tfe.RegistryModuleVersion{ID: "modver-1", Version: "1.2.3", Status: tfe.RegistryModuleVersionStatusPending}
+		gomock.InOrder(
+			mModules.EXPECT().Read(ctx, moduleID).Return(nil, tfe.ErrResourceNotFound),
+			mModules.EXPECT().Create(ctx, "abc-company", tfe.RegistryModuleCreateOptions{
+				Name:         tfe.String("vpc"),
+				Provider:     tfe.String("aws"),
+				RegistryName: tfe.PrivateRegistry,
+				Namespace:    "abc-company",
+			}).Return(&tfe.RegistryModule{ID: "mod-1"}, nil),
+			mModules.EXPECT().CreateVersion(ctx, moduleID, tfe.RegistryModuleCreateVersionOptions{Version: tfe.String("1.2.3")}).Return(version, nil),
+			mModules.EXPECT().Upload(ctx, *version, "./module").Return(nil),
+			mModules.EXPECT().ReadVersion(ctx, moduleID, "1.2.3").Return(&tfe.RegistryModuleVersion{ID: "modver-1", Version: "1.2.3", Status: tfe.RegistryModuleVersionStatusOk}, nil),
+		)
+
+		service := NewRegistryModuleService(&cloudMeta{tfe: &tfe.Client{RegistryModules: mModules}, writer: &defaultWriter{}})
+		published, err := service.PublishModule(ctx, options)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if published.Status != tfe.RegistryModuleVersionStatusOk {
+			t.Errorf("expected status ok but received %s", published.Status)
+		}
+	})
+
+	t.Run("fails when ingestion fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mModules := mocks.NewMockRegistryModules(ctrl)
+		version := &tfe.RegistryModuleVersion{ID: "modver-1", Version: "1.2.3", Status: tfe.RegistryModuleVersionStatusPending}
+		mModules.EXPECT().Read(ctx, moduleID).Return(&tfe.RegistryModule{ID: "mod-1"}, nil)
+		mModules.EXPECT().CreateVersion(ctx, moduleID, gomock.Any()).Return(version, nil)
+		mModules.EXPECT().Upload(ctx, *version, "./module").Return(nil)
+		mModules.EXPECT().ReadVersion(ctx, moduleID, "1.2.3").Return(&tfe.RegistryModuleVersion{ID: "modver-1", Status: tfe.RegistryModuleVersionStatusRegIngressFailed}, nil)
+
+		service := NewRegistryModuleService(&cloudMeta{tfe: &tfe.Client{RegistryModules: mModules}, writer: &defaultWriter{}})
+		published, err := service.PublishModule(ctx, options)
+		if err == nil {
+			t.Fatal("expected an error for a failed ingestion")
+		}
+		if published == nil || published.Status != tfe.RegistryModuleVersionStatusRegIngressFailed {
+			t.Errorf("expected the failed version to be returned but received %v", published)
+		}
+	})
+}
+
+func TestRegistryModuleService_ListModules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mModules := mocks.NewMockRegistryModules(ctrl)
+	mModules.EXPECT().List(ctx, "abc-company", &tfe.RegistryModuleListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.RegistryModuleList{
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+		Items: []*tfe.RegistryModule{{
+			ID:              "mod-1",
+			Name:            "vpc",
+			Provider:        "aws",
+			Status:          tfe.RegistryModuleStatusSetupComplete,
+			NoCode:          true,
+			VersionStatuses: []tfe.RegistryModuleVersionStatuses{{Version: "1.2.3", Status: tfe.RegistryModuleVersionStatusOk}},
+		}},
+	}, nil)
+
+	service := NewRegistryModuleService(&cloudMeta{tfe: &tfe.Client{RegistryModules: mModules}, writer: &defaultWriter{}})
+	modules, err := service.ListModules(ctx, "abc-company")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(modules) != 1 || !modules[0].NoCode || modules[0].Status != "setup_complete" {
+		t.Fatalf("unexpected modules %v", modules)
+	}
+	if v := modules[0].Version("1.2.3"); v == nil || v.Status != "ok" {
+		t.Errorf("expected version 1.2.3 to be published but received %v", v)
+	}
+	if v := modules[0].Version("2.0.0"); v != nil {
+		t.Errorf("expected version 2.0.0 to not be published but received %v", v)
+	}
+}
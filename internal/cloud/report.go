@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"time"
+)
+
+// governance evidence of a run: its plan changes, cost estimation, policy results and task stage outcomes
+type RunReport struct {
+	RunID        string               `json:"run_id"`
+	RunStatus    string               `json:"run_status"`
+	Message      string               `json:"message"`
+	CreatedAt    time.Time            `json:"created_at"`
+	Plan         *PlanChanges         `json:"plan,omitempty"`
+	CostEstimate *CostEstimateDetails `json:"cost_estimate,omitempty"`
+	Policies     *PolicyEvaluation    `json:"policies"`
+	TaskStages   []*TaskStageDetails  `json:"task_stages"`
+}
+
+type PlanChanges struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	HasChanges   bool   `json:"has_changes"`
+	Additions    int    `json:"additions"`
+	Changes      int    `json:"changes"`
+	Destructions int    `json:"destructions"`
+	Imports      int    `json:"imports"`
+}
+
+// aggregates the governance evidence of a run into a single report
+func (s *runService) GetRunReport(ctx context.Context, runID string) (*RunReport, error) {
+	run, err := s.GetRun(ctx, GetRunOptions{RunID: runID})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RunReport{
+		RunID:      run.ID,
+		RunStatus:  string(run.Status),
+		Message:    run.Message,
+		CreatedAt:  run.CreatedAt,
+		TaskStages: []*TaskStageDetails{},
+	}
+	if run.Plan != nil {
+		report.Plan = &PlanChanges{
+			ID:           run.Plan.ID,
+			Status:       string(run.Plan.Status),
+			HasChanges:   run.Plan.HasChanges,
+			Additions:    run.Plan.ResourceAdditions,
+			Changes:      run.Plan.ResourceChanges,
+			Destructions: run.Plan.ResourceDestructions,
+			Imports:      run.Plan.ResourceImports,
+		}
+	}
+	if run.CostEstimate != nil {
+		report.CostEstimate = newCostEstimateDetails(run.CostEstimate)
+	}
+
+	// task stages are read once for both their outcomes and policy evaluations
+	if s.capabilities.Supports(TaskStages) {
+		report.TaskStages, err = s.ReadTaskStages(ctx, runID, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+	report.Policies, err = s.policyEvaluation(ctx, runID, report.TaskStages)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_GetRunReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mRuns := mocks.NewMockRuns(ctrl)
+	mPolicyChecks := mocks.NewMockPolicyChecks(ctrl)
+	mTaskStages := mocks.NewMockTaskStages(ctrl)
+	mTaskResults := mocks.NewMockTaskResults(ctrl)
+	mPolicyEvaluations := mocks.NewMockPolicyEvaluations(ctrl)
+
+	mRuns.EXPECT().ReadWithOptions(ctx, "run-1", gomock.Any()).Return(&tfe.Run{
+		ID:           "run-1",
+		Status:       tfe.RunPlanned,
+		Plan:         &tfe.Plan{ID: "plan-1", Status: tfe.PlanFinished, HasChanges: true, ResourceAdditions: 2, ResourceDestructions: 1},
+		CostEstimate: &tfe.CostEstimate{ID: "ce-1", Status: tfe.CostEstimateFinished, DeltaMonthlyCost: "12.50"},
+	}, nil)
+	mPolicyChecks.EXPECT().List(ctx, "run-1", gomock.Any()).Return(&tfe.PolicyCheckList{
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+	// task stages are only read once, for their task results and their policy evaluations
+	mTaskStages.EXPECT().List(ctx, "run-1", gomock.Any()).Return(&tfe.TaskStageList{
+		Items:      []*tfe.TaskStage{{ID: "ts-1", Stage: tfe.PostPlan, Status: tfe.TaskStagePassed, TaskResults: []*tfe.TaskResult{{ID: "taskrs-1"}}}},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil).Times(1)
+	mTaskResults.EXPECT().Read(ctx, "taskrs-1").Return(&tfe.TaskResult{ID: "taskrs-1", TaskName: "security-scanner", Status: tfe.TaskPassed}, nil)
+	mPolicyEvaluations.EXPECT().List(ctx, "ts-1", gomock.Any()).Return(&tfe.PolicyEvaluationList{
+		Items:      []*tfe.PolicyEvaluation{{ID: "poleval-1", Status: tfe.PolicyEvaluationPassed, PolicyKind: tfe.Sentinel, ResultCount: &tfe.PolicyResultCount{Passed: 4}}},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{
+		Runs:              mRuns,
+		PolicyChecks:      mPolicyChecks,
+		TaskStages:        mTaskStages,
+		TaskResults:       mTaskResults,
+		PolicyEvaluations: mPolicyEvaluations,
+	}, writer: &testLogWriter{}}}
+	report, err := service.GetRunReport(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if report.Plan.Additions != 2 || report.Plan.Destructions != 1 || !report.Plan.HasChanges {
+		t.Errorf("unexpected plan changes %+v", report.Plan)
+	}
+	if report.CostEstimate.DeltaMonthlyCost != "12.50" {
+		t.Errorf("unexpected cost estimate %+v", report.CostEstimate)
+	}
+	if report.Policies.Status != PolicyStatusPassed || report.Policies.Passed != 4 {
+		t.Errorf("unexpected policy results %+v", report.Policies)
+	}
+	if len(report.TaskStages) != 1 || report.TaskStages[0].TaskResults[0].TaskName != "security-scanner" {
+		t.Errorf("unexpected task stages %+v", report.TaskStages)
+	}
+}
@@ -6,6 +6,7 @@ package cloud
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"sync"
 	"time"
@@ -19,10 +20,127 @@ const (
 	tfMaxTimeout           = "TF_MAX_TIMEOUT"
 )
 
+// default go-tfe request retry behavior
+const (
+	defaultMaxRetries       = 30
+	defaultRetryWaitMin     = 100 * time.Millisecond
+	defaultRetryWaitMax     = 400 * time.Millisecond
+	defaultPollBackoffStart = 2 * time.Second
+	defaultPollBackoffMax   = 7 * time.Second
+	maxBackoffJitter        = 100
+)
+
+// presets for the wait between polling attempts and the maximum time to wait for an operation
+type pollProfile struct {
+	start   time.Duration
+	capped  time.Duration
+	timeout time.Duration
+}
+
+const defaultPollProfile = "default"
+
+var pollProfiles = map[string]pollProfile{
+	// fast feedback, such as speculative plans for pull requests
+	"fast":             {start: 1 * time.Second, capped: 3 * time.Second, timeout: 30 * time.Minute},
+	defaultPollProfile: {start: defaultPollBackoffStart, capped: defaultPollBackoffMax, timeout: defaultTimeoutDuration},
+	// fewer requests for long running operations, such as scheduled applies
+	"relaxed": {start: 5 * time.Second, capped: 30 * time.Second, timeout: 2 * time.Hour},
+}
+
 var (
-	once = new(sync.Once)
+	once         = new(sync.Once)
+	maxTimeout   = defaultTimeoutDuration
+	retryOptions = &RetryOptions{MaxRetries: defaultMaxRetries}
 )
 
+// configures request retries and polling, zero values retain defaults
+type RetryOptions struct {
+	// maximum retries for requests that result in a server error
+	MaxRetries int
+	// initial wait between retries and polling attempts
+	InitialBackoff time.Duration
+	// maximum wait between retries and polling attempts
+	MaxBackoff time.Duration
+	// when set, polls at a fixed interval instead of backing off
+	PollInterval time.Duration
+	// maximum time to read plan and apply logs, defaults to the maximum timeout
+	LogTimeout time.Duration
+	// maximum time to wait for the current state version to be processed before reading outputs,
+	// defaults to StateVersionOutputMaxDuration
+	StateProcessingTimeout time.Duration
+	// randomizes each wait between polling attempts by +/- the percentage,
+	// so parallel jobs do not poll in lockstep. zero disables jitter
+	BackoffJitter uint64
+	// preset polling backoff and maximum timeout: fast, default or relaxed.
+	// explicit backoff options and TF_MAX_TIMEOUT take precedence
+	PollProfile string
+}
+
+func (o *RetryOptions) pollProfile() pollProfile {
+	if p, ok := pollProfiles[o.PollProfile]; ok {
+		return p
+	}
+	return pollProfiles[defaultPollProfile]
+}
+
+func ConfigureRetry(opts *RetryOptions) error {
+	if opts.BackoffJitter > maxBackoffJitter {
+		return fmt.Errorf("backoff jitter must be a percentage between 0 and %d, received %d", maxBackoffJitter, opts.BackoffJitter)
+	}
+	if _, ok := pollProfiles[opts.PollProfile]; opts.PollProfile != "" && !ok {
+		return fmt.Errorf("invalid poll profile %q, expected fast, default or relaxed", opts.PollProfile)
+	}
+	log.Printf("[DEBUG] retry options, max retries: %d, initial backoff: %s, max backoff: %s, poll interval: %s, log timeout: %s, state processing timeout: %s, backoff jitter: %d%%, poll profile: %q", opts.MaxRetries, opts.InitialBackoff, opts.MaxBackoff, opts.PollInterval, opts.LogTimeout, opts.StateProcessingTimeout, opts.BackoffJitter, opts.PollProfile)
+	retryOptions = opts
+	// the maximum timeout depends on the poll profile
+	once = new(sync.Once)
+	return nil
+}
+
+func newRetryTransport(base http.RoundTripper, rateLimits *RateLimitTracker) *retryTransport {
+	transport := &retryTransport{
+		base:       base,
+		rateLimits: rateLimits,
+		maxRetries: retryOptions.MaxRetries,
+		minWait:    defaultRetryWaitMin,
+		maxWait:    defaultRetryWaitMax,
+	}
+	if retryOptions.InitialBackoff > 0 {
+		transport.minWait = retryOptions.InitialBackoff
+	}
+	if retryOptions.MaxBackoff > 0 {
+		transport.maxWait = retryOptions.MaxBackoff
+	}
+	if transport.maxWait < transport.minWait {
+		transport.maxWait = transport.minWait
+	}
+	return transport
+}
+
+// backoff between polling attempts, bounded by maxDuration
+func pollBackoff(maxDuration time.Duration) retry.Backoff {
+	var backoff retry.Backoff
+	if retryOptions.PollInterval > 0 {
+		backoff = retry.NewConstant(retryOptions.PollInterval)
+	} else {
+		profile := retryOptions.pollProfile()
+		start, capped := profile.start, profile.capped
+		if retryOptions.InitialBackoff > 0 {
+			start = retryOptions.InitialBackoff
+		}
+		if retryOptions.MaxBackoff > 0 {
+			capped = retryOptions.MaxBackoff
+		}
+		backoff = retry.NewFibonacci(start)
+		backoff = retry.WithCappedDuration(capped, backoff)
+	}
+
+	if retryOptions.BackoffJitter > 0 {
+		backoff = retry.WithJitterPercent(retryOptions.BackoffJitter, backoff)
+	}
+	return retry.WithMaxDuration(maxDuration, backoff)
+}
+
 type RetryTimeoutError struct {
 	msg string
 }
@@ -40,15 +158,12 @@ func retryableTimeoutError(operation string) error {
 func (retryErr *RetryTimeoutError) Error() string { return retryErr.msg }
 
 func defaultBackoff() retry.Backoff {
-	backoff := retry.NewFibonacci(2 * time.Second)
-	backoff = retry.WithCappedDuration(7*time.Second, backoff)
-	backoff = retry.WithMaxDuration(Timeout(), backoff)
-	return backoff
+	return pollBackoff(Timeout())
 }
 
 func Timeout() time.Duration {
-	timeout := defaultTimeoutDuration
 	once.Do(func() {
+		maxTimeout = retryOptions.pollProfile().timeout
 		timeoutEnv := os.Getenv(tfMaxTimeout)
 		if timeoutEnv == "" {
 			return
@@ -61,7 +176,7 @@ func Timeout() time.Duration {
 		}
 
 		log.Printf("[DEBUG] timeout duration has successfully been set as %v", t)
-		timeout = t
+		maxTimeout = t
 	})
-	return timeout
+	return maxTimeout
 }
@@ -37,3 +37,41 @@ func TestTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestPollProfile(t *testing.T) {
+	original := retryOptions
+	t.Cleanup(func() {
+		retryOptions = original
+		once = new(sync.Once)
+	})
+	os.Setenv(tfMaxTimeout, "")
+
+	if err := ConfigureRetry(&RetryOptions{PollProfile: "relaxed"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := Timeout(); got != 2*time.Hour {
+		t.Errorf("Timeout() = %v, want %v", got, 2*time.Hour)
+	}
+	backoff := pollBackoff(time.Hour)
+	if next, _ := backoff.Next(); next != 5*time.Second {
+		t.Errorf("expected relaxed initial backoff of 5s but received %v", next)
+	}
+
+	// explicit options take precedence over the profile
+	if err := ConfigureRetry(&RetryOptions{PollProfile: "fast", InitialBackoff: 3 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(tfMaxTimeout, "10m")
+	defer os.Setenv(tfMaxTimeout, "")
+	if got := Timeout(); got != 10*time.Minute {
+		t.Errorf("Timeout() = %v, want %v", got, 10*time.Minute)
+	}
+	backoff = pollBackoff(time.Hour)
+	if next, _ := backoff.Next(); next != 3*time.Second {
+		t.Errorf("expected initial backoff of 3s but received %v", next)
+	}
+
+	if err := ConfigureRetry(&RetryOptions{PollProfile: "turbo"}); err == nil {
+		t.Error("expected unknown poll profile to be rejected")
+	}
+}
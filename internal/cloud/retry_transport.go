@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// retries server errors with configurable attempts and backoff.
+// go-tfe hardcodes its retry behavior, so server errors are retried here instead
+// and the go-tfe client only retries rate limited requests
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+	rateLimits *RateLimitTracker
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			// requests with a body can only be retried if the body can be re-read
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					return resp, err
+				}
+				body, bodyErr := req.GetBody()
+				if bodyErr != nil {
+					return resp, err
+				}
+				req = req.Clone(req.Context())
+				req.Body = body
+			}
+		}
+
+		resp, err = t.base.RoundTrip(req)
+		t.rateLimits.record(resp)
+		if !isRetryableResponse(resp, err) || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if err != nil {
+			log.Printf("[DEBUG] retrying request: %s %s, attempt: %d, in: %s, error: %s", req.Method, req.URL.Path, attempt+1, wait, err)
+		} else {
+			log.Printf("[DEBUG] retrying request: %s %s, attempt: %d, in: %s, status: %s", req.Method, req.URL.Path, attempt+1, wait, resp.Status)
+			// drain and close the body so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// exponential backoff, capped at maxWait
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.minWait
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+		wait *= 2
+	}
+	if wait > t.maxWait {
+		wait = t.maxWait
+	}
+	return wait
+}
+
+func isRetryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	testCases := []struct {
+		name           string
+		maxRetries     int
+		failures       int
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "recovers-from-server-errors",
+			maxRetries:     3,
+			failures:       2,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  3,
+		},
+		{
+			name:           "exceeds-max-retries",
+			maxRetries:     1,
+			failures:       5,
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  2,
+		},
+		{
+			name:           "retries-disabled",
+			maxRetries:     0,
+			failures:       1,
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tc.failures {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &retryTransport{
+				base:       http.DefaultTransport,
+				maxRetries: tc.maxRetries,
+				minWait:    time.Millisecond,
+				maxWait:    2 * time.Millisecond,
+			}}
+
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("expected status %d but received %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls but received %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	transport := &retryTransport{minWait: 100 * time.Millisecond, maxWait: 400 * time.Millisecond}
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 400 * time.Millisecond}
+	for attempt, want := range expected {
+		if got := transport.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestPollBackoff_Interval(t *testing.T) {
+	original := retryOptions
+	t.Cleanup(func() { retryOptions = original })
+
+	if err := ConfigureRetry(&RetryOptions{PollInterval: 10 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	backoff := pollBackoff(time.Minute)
+	for i := 0; i < 3; i++ {
+		next, stop := backoff.Next()
+		if stop || next != 10*time.Second {
+			t.Fatalf("expected fixed interval of %v, received %v", 10*time.Second, next)
+		}
+	}
+}
+
+func TestPollBackoff_Jitter(t *testing.T) {
+	original := retryOptions
+	t.Cleanup(func() { retryOptions = original })
+
+	if err := ConfigureRetry(&RetryOptions{PollInterval: 10 * time.Second, BackoffJitter: 20}); err != nil {
+		t.Fatal(err)
+	}
+	backoff := pollBackoff(time.Hour)
+	varied := false
+	for i := 0; i < 20; i++ {
+		next, stop := backoff.Next()
+		if stop || next < 8*time.Second || next > 12*time.Second {
+			t.Fatalf("expected wait within 20%% of %v, received %v", 10*time.Second, next)
+		}
+		if next != 10*time.Second {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Error("expected jitter to vary the wait between polling attempts")
+	}
+}
+
+func TestConfigureRetry_InvalidJitter(t *testing.T) {
+	original := retryOptions
+	t.Cleanup(func() { retryOptions = original })
+
+	if err := ConfigureRetry(&RetryOptions{BackoffJitter: 101}); err == nil {
+		t.Error("expected jitter above 100 percent to be rejected")
+	}
+}
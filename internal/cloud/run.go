@@ -10,14 +10,16 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/logging"
+	"github.com/hashicorp/tfci/internal/notify"
 	"github.com/sethvargo/go-retry"
 )
 
-const LogTimeout = time.Second * 10
-
 var (
 	ForceCancel              = tfe.RunStatus("force_canceled")
 	PrePlanAwaitingDecision  = tfe.RunStatus("pre_apply_awaiting_decision")
@@ -25,27 +27,50 @@ var (
 	PreApplyAwaitingDecision = tfe.RunStatus("pre_plan_awaiting_decision")
 )
 
+// run can no longer be discarded, discarding it is a noop with -noop-ok
 var DiscardNoopStatus = []tfe.RunStatus{
 	tfe.RunErrored,
 	tfe.RunCanceled,
+	tfe.RunDiscarded,
+	ForceCancel,
 	tfe.RunApplied,
 	tfe.RunPlanned,
 	tfe.RunPlannedAndFinished,
 }
 
+// run can no longer be canceled, canceling it is a noop with -noop-ok
 var CancelNoopStatus = []tfe.RunStatus{
 	tfe.RunErrored,
+	tfe.RunCanceled,
 	tfe.RunDiscarded,
+	ForceCancel,
 	tfe.RunApplied,
 	tfe.RunPlanned,
 	tfe.RunPlannedAndFinished,
 	tfe.RunPlannedAndSaved,
 }
 
+// run no longer needs a policy override, overriding it is a noop with -noop-ok. Runs that errored, or were
+// canceled or discarded, may have ended without the override and are not a noop
+var OverrideNoopStatus = []tfe.RunStatus{
+	tfe.RunPolicyOverride,
+	tfe.RunPolicyChecked,
+	tfe.RunPostPlanCompleted,
+	tfe.RunPlanned,
+	tfe.RunConfirmed,
+	tfe.RunQueuingApply,
+	tfe.RunApplyQueued,
+	tfe.RunApplying,
+	tfe.RunApplied,
+	tfe.RunPlannedAndFinished,
+	tfe.RunPlannedAndSaved,
+}
+
 var NoopStatus = []tfe.RunStatus{
 	tfe.RunErrored,
 	tfe.RunCanceled,
 	tfe.RunDiscarded,
+	tfe.RunPolicyOverride,
 	ForceCancel,
 	PrePlanAwaitingDecision,
 	PostPlanAwaitingDecision,
@@ -62,6 +87,18 @@ type CreateRunOptions struct {
 	SavePlan               bool
 	RunVariables           []*tfe.RunVariable
 	TargetAddrs            []string
+	// environment variables set on the workspace before the run is created
+	EnvVariables []*EnvVariable
+	// when set, attaches to an unfinished run created with the same key instead of creating a new run
+	IdempotencyKey string
+	// when set, no run is created if the configuration and run variables match the last applied run,
+	// the last applied run is returned with ErrRunUnchanged
+	SkipUnchanged bool
+	// for VCS workspaces, creates the run from a branch or commit of the connected repository
+	Branch    string
+	CommitSHA string
+	// when set, non-speculative runs wait for the workspace to be unlocked and its current run to finish
+	WaitForIdle bool
 }
 
 type ApplyRunOptions struct {
@@ -71,6 +108,8 @@ type ApplyRunOptions struct {
 
 type GetRunOptions struct {
 	RunID string
+	// related resources to include in addition to the plan, cost estimate and workspace
+	Include []tfe.RunIncludeOpt
 }
 
 type DiscardRunOptions struct {
@@ -87,8 +126,10 @@ type CancelRunOptions struct {
 type RunService interface {
 	RunLink(context.Context, string, *tfe.Run) (string, error)
 	GetRun(context.Context, GetRunOptions) (*tfe.Run, error)
+	GetWorkspaceRun(context.Context, WorkspaceRunOptions) (*tfe.Run, error)
 	CreateRun(context.Context, CreateRunOptions) (*tfe.Run, error)
 	ApplyRun(context.Context, ApplyRunOptions) (*tfe.Run, error)
+	WaitForApply(context.Context, string) (*tfe.Run, error)
 	DiscardRun(context.Context, DiscardRunOptions) (*tfe.Run, error)
 	CancelRun(context.Context, CancelRunOptions) (*tfe.Run, error)
 	GetPlanLogs(context.Context, string) error
@@ -96,29 +137,82 @@ type RunService interface {
 	GetPolicyCheckLogs(context.Context, *tfe.Run) error
 	LogCostEstimation(context.Context, *tfe.Run)
 	LogTaskStage(context.Context, *tfe.Run, tfe.Stage) error
+	ReadTaskStages(context.Context, string, tfe.Stage) ([]*TaskStageDetails, error)
+	GetPolicyEvaluation(context.Context, string) (*PolicyEvaluation, error)
+	LogPolicyEvaluation(context.Context, *PolicyEvaluation) error
+	PlanPolicyOverride(context.Context, string) (*PolicyOverride, error)
+	OverridePolicies(context.Context, *PolicyOverride, string) error
+	GetRunReport(context.Context, string) (*RunReport, error)
+	GetCostEstimate(context.Context, string) (*CostEstimateDetails, error)
+	GetCostEstimateResources(context.Context, string) ([]*CostEstimateResource, error)
+	TransientRunError(context.Context, *tfe.Run) (string, error)
+	RetryRun(context.Context, RetryRunOptions) (*tfe.Run, error)
+	GetRunDiagnostics(context.Context, *tfe.Run) ([]*Diagnostic, error)
+	GetApplySummary(context.Context, *tfe.Run) (*ApplySummary, error)
+	WatchRun(context.Context, string, func(*tfe.Run)) (*tfe.Run, error)
+	RunLogs(context.Context, *tfe.Run) (io.Reader, error)
+	RunAction(context.Context, string, string, string) error
 }
 
 type runService struct {
 	*cloudMeta
 }
 
+// the workspace included in the run read avoids a workspace read, links fall back to the run id
+// when the workspace cannot be read, such as when the token lacks workspace read permission
+// an empty organization is resolved from the run's workspace
 func (service *runService) RunLink(ctx context.Context, organization string, run *tfe.Run) (string, error) {
-	wId := run.Workspace.ID
-	tfWorkspace, err := service.tfe.Workspaces.ReadByID(ctx, wId)
-	if err != nil {
-		log.Printf("[ERROR] problem generating run link while fetching run by id: %s", wId)
-		return "", err
+	var link string
+	switch {
+	case run.Workspace != nil && run.Workspace.Name != "":
+		link = service.workspaceRunLink(inheritOrganization(organization, run.Workspace), run.Workspace.Name, run.ID)
+	case run.Workspace != nil:
+		wId := run.Workspace.ID
+		tfWorkspace, err := service.readWorkspaceByID(ctx, wId)
+		if err != nil {
+			log.Printf("[WARN] problem generating run link while fetching workspace by id: %s, linking by run id: %s", wId, err)
+			link = service.runLinkByID(organization, run.ID)
+		} else {
+			link = service.workspaceRunLink(inheritOrganization(organization, tfWorkspace), tfWorkspace.Name, run.ID)
+		}
+	default:
+		link = service.runLinkByID(organization, run.ID)
 	}
-	url := service.tfe.BaseURL()
-	link := fmt.Sprintf("%s://%s/app/%s/workspaces/%s/runs/%s", url.Scheme, url.Host, organization, tfWorkspace.Name, run.ID)
 	service.writer.Output(fmt.Sprintf("View Run in HCP Terraform: %s", link))
 
 	return link, nil
 }
 
+// returns the organization of the workspace when the organization was not configured
+func inheritOrganization(organization string, w *tfe.Workspace) string {
+	if organization == "" && w.Organization != nil {
+		log.Printf("[DEBUG] using organization: %q of workspace: %q", w.Organization.Name, w.ID)
+		return w.Organization.Name
+	}
+	return organization
+}
+
+func (service *runService) workspaceRunLink(organization string, workspace string, runID string) string {
+	url := service.tfe.BaseURL()
+	return fmt.Sprintf("%s://%s/app/%s/workspaces/%s/runs/%s", url.Scheme, url.Host, organization, workspace, runID)
+}
+
+// link to the run without the workspace name, redirected by HCP Terraform to the workspace run
+func (service *runService) runLinkByID(organization string, runID string) string {
+	url := service.tfe.BaseURL()
+	return fmt.Sprintf("%s://%s/app/%s/runs/%s", url.Scheme, url.Host, organization, runID)
+}
+
 func (service *runService) GetRun(ctx context.Context, options GetRunOptions) (*tfe.Run, error) {
+	logging.With("run_id", options.RunID)
+	include := []tfe.RunIncludeOpt{"cost_estimate", "plan", tfe.RunWorkspace}
+	for _, opt := range options.Include {
+		if !slices.Contains(include, opt) {
+			include = append(include, opt)
+		}
+	}
 	run, err := service.tfe.Runs.ReadWithOptions(ctx, options.RunID, &tfe.RunReadOptions{
-		Include: []tfe.RunIncludeOpt{"cost_estimate", "plan"},
+		Include: include,
 	})
 	if err != nil {
 		log.Printf("[ERROR] error reading run: %q error: %s", options.RunID, err)
@@ -130,17 +224,79 @@ func (service *runService) GetRun(ctx context.Context, options GetRunOptions) (*
 func (service *runService) CreateRun(ctx context.Context, options CreateRunOptions) (*tfe.Run, error) {
 	var createOpts tfe.RunCreateOptions
 	var cv *tfe.ConfigurationVersion
+	// a speculative plan must not change the workspace for every later run
+	if options.PlanOnly && len(options.EnvVariables) > 0 {
+		return nil, errors.New("environment variables cannot be set for plan only runs, they remain set on the workspace for later runs")
+	}
 	// read workspace
-	w, err := service.tfe.Workspaces.Read(ctx, options.Organization, options.Workspace)
+	w, err := service.readWorkspace(ctx, options.Organization, options.Workspace)
 	if err != nil {
 		log.Printf("[ERROR] error reading workspace: %q organization: %q error: %s", options.Workspace, options.Organization, err)
 		return nil, err
 	}
 
+	// runs in progress lock the workspace, check for an existing run first
+	if options.IdempotencyKey != "" {
+		existing, err := service.findRunByIdempotencyKey(ctx, w.ID, options.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			logging.With("run_id", existing.ID)
+			service.writer.Output(fmt.Sprintf("Attaching to existing Run ID: %q with idempotency key: %q", existing.ID, options.IdempotencyKey))
+			return service.waitForRun(ctx, existing)
+		}
+		options.Message = idempotencyMessage(options.Message, options.IdempotencyKey)
+	}
+
+	if options.Branch != "" || options.CommitSHA != "" {
+		options.ConfigurationVersionID, err = service.vcsConfigurationVersion(ctx, w, options.Branch, options.CommitSHA)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.WaitForIdle && !options.PlanOnly {
+		w, err = service.waitForIdleWorkspace(ctx, w.ID)
+		if err != nil {
+			log.Printf("[ERROR] error waiting for workspace: %q to be idle error: %s", options.Workspace, err)
+			return nil, err
+		}
+	}
+
 	if w.Locked && !options.PlanOnly {
 		return nil, errors.New("run has been specified as non-speculative and the workspace is currently locked")
 	}
 
+	// environment variables remain set for later runs, so they are only set once the run can be created
+	envChanged, err := service.setEnvVariables(ctx, w.ID, options.EnvVariables)
+	if err != nil {
+		log.Printf("[ERROR] error setting environment variables of workspace: %q error: %s", options.Workspace, err)
+		return nil, err
+	}
+	for _, v := range options.EnvVariables {
+		service.redactor.AddValues(v.Value)
+	}
+
+	// changed environment variables are not part of the last applied run, so a run is always created
+	if options.SkipUnchanged && !envChanged {
+		last, err := service.findUnchangedRun(ctx, w.ID, options)
+		if err != nil {
+			log.Printf("[ERROR] error comparing with the last applied run of workspace: %q error: %s", options.Workspace, err)
+			return nil, err
+		}
+		if last != nil {
+			service.writer.Output(fmt.Sprintf("Skipping run, the configuration and variables are unchanged since Run ID: %q", last.ID))
+			return last, ErrRunUnchanged
+		}
+	}
+
+	if options.SavePlan {
+		if err := service.capabilities.Require(SavedPlans); err != nil {
+			return nil, err
+		}
+	}
+
 	if options.ConfigurationVersionID != "" {
 		cv, err = service.tfe.ConfigurationVersions.Read(ctx, options.ConfigurationVersionID)
 		if err != nil {
@@ -163,6 +319,10 @@ func (service *runService) CreateRun(ctx context.Context, options CreateRunOptio
 	createOpts.SavePlan = tfe.Bool(options.SavePlan)
 	createOpts.Variables = options.RunVariables
 	createOpts.TargetAddrs = options.TargetAddrs
+	// run variables are commonly secrets, which providers may echo in plan and apply logs
+	for _, v := range options.RunVariables {
+		service.redactor.AddValues(v.Value)
+	}
 
 	// create the run
 	run, err := service.tfe.Runs.Create(ctx, createOpts)
@@ -172,17 +332,28 @@ func (service *runService) CreateRun(ctx context.Context, options CreateRunOptio
 		return nil, err
 	}
 
+	logging.With("run_id", run.ID)
 	service.writer.Output(fmt.Sprintf("Created Run ID: %q", run.ID))
+	createdEvent := notify.NewEvent(notify.RunCreated, run.ID, string(run.Status))
+	createdEvent.RunID = run.ID
+	service.emit(ctx, createdEvent)
 
+	return service.waitForRun(ctx, run)
+}
+
+// monitors a created run until it has planned, or reached a status that needs no further action
+func (service *runService) waitForRun(ctx context.Context, run *tfe.Run) (*tfe.Run, error) {
 	costEstimateEnabled, policyChecksEnabled := hasCostEstimate(run), hasPolicyChecks(run)
 	desiredStatus := getDesiredRunStatus(run, policyChecksEnabled, costEstimateEnabled)
 
 	log.Printf("[DEBUG] PlanOnly: %t, AutoApply: %t, CostEstimation: %t, PolicyChecks: %t", run.PlanOnly, run.AutoApply, costEstimateEnabled, policyChecksEnabled)
 
+	runID := run.ID
+	lastStatus := run.Status
 	retryErr := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
 		log.Printf("[DEBUG] Monitoring run status...")
 		r, err := service.GetRun(ctx, GetRunOptions{
-			RunID: run.ID,
+			RunID: runID,
 		})
 
 		// update run
@@ -191,6 +362,7 @@ func (service *runService) CreateRun(ctx context.Context, options CreateRunOptio
 		if err != nil {
 			return err
 		}
+		service.emitRunStatus(ctx, run, &lastStatus)
 
 		service.writer.Output(fmt.Sprintf("Run Status: %q", run.Status))
 
@@ -204,6 +376,7 @@ func (service *runService) CreateRun(ctx context.Context, options CreateRunOptio
 		}
 		return retryableTimeoutError("create run ")
 	})
+	service.emitRunCompleted(ctx, runID, run, retryErr)
 
 	if retryErr != nil {
 		return run, retryErr
@@ -213,19 +386,25 @@ func (service *runService) CreateRun(ctx context.Context, options CreateRunOptio
 }
 
 func (service *runService) ApplyRun(ctx context.Context, options ApplyRunOptions) (*tfe.Run, error) {
-	var applyRun *tfe.Run
 	if err := service.tfe.Runs.Apply(ctx, options.RunID, tfe.RunApplyOptions{
 		Comment: tfe.String(options.Comment),
 	}); err != nil {
 		log.Printf("[ERROR] error applying run: %q error: %s", options.RunID, err)
-		return applyRun, err
+		return nil, err
 	}
 
-	if retryErr := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
+	return service.WaitForApply(ctx, options.RunID)
+}
+
+// monitors a run until it has been applied, runs awaiting confirmation are applied once confirmed in HCP Terraform
+func (service *runService) WaitForApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	var applyRun *tfe.Run
+	var lastStatus tfe.RunStatus
+	retryErr := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
 		log.Printf("[DEBUG] Monitoring apply run status...")
 
 		run, runErr := service.GetRun(ctx, GetRunOptions{
-			RunID: options.RunID,
+			RunID: runID,
 		})
 
 		applyRun = run
@@ -233,6 +412,7 @@ func (service *runService) ApplyRun(ctx context.Context, options ApplyRunOptions
 		if runErr != nil {
 			return runErr
 		}
+		service.emitRunStatus(ctx, run, &lastStatus)
 
 		service.writer.Output(fmt.Sprintf("Run Status: %q", run.Status))
 
@@ -245,7 +425,10 @@ func (service *runService) ApplyRun(ctx context.Context, options ApplyRunOptions
 			return nil
 		}
 		return retryableTimeoutError("apply run")
-	}); retryErr != nil {
+	})
+	service.emitRunCompleted(ctx, runID, applyRun, retryErr)
+
+	if retryErr != nil {
 		return applyRun, retryErr
 	}
 
@@ -261,7 +444,8 @@ func (service *runService) DiscardRun(ctx context.Context, options DiscardRunOpt
 		return discardRun, err
 	}
 
-	if retryErr := retry.Do(ctx, defaultBackoff(), func(context context.Context) error {
+	var lastStatus tfe.RunStatus
+	retryErr := retry.Do(ctx, defaultBackoff(), func(context context.Context) error {
 		log.Printf("[DEBUG] Monitoring discard run status...")
 		run, runErr := service.GetRun(ctx, GetRunOptions{
 			RunID: options.RunID,
@@ -272,6 +456,7 @@ func (service *runService) DiscardRun(ctx context.Context, options DiscardRunOpt
 		if runErr != nil {
 			return runErr
 		}
+		service.emitRunStatus(ctx, run, &lastStatus)
 
 		service.writer.Output(fmt.Sprintf("Run Status: %q", run.Status))
 
@@ -284,7 +469,10 @@ func (service *runService) DiscardRun(ctx context.Context, options DiscardRunOpt
 			return nil
 		}
 		return retryableTimeoutError("discard run")
-	}); retryErr != nil {
+	})
+	service.emitRunCompleted(ctx, options.RunID, discardRun, retryErr)
+
+	if retryErr != nil {
 		return discardRun, retryErr
 	}
 
@@ -309,6 +497,7 @@ func (service *runService) CancelRun(ctx context.Context, options CancelRunOptio
 		return cancelRun, err
 	}
 
+	var lastStatus tfe.RunStatus
 	retryErr := retry.Do(ctx, defaultBackoff(), func(context context.Context) error {
 		log.Printf("[DEBUG] Monitoring cancel run status...")
 		run, runErr := service.GetRun(ctx, GetRunOptions{
@@ -320,6 +509,7 @@ func (service *runService) CancelRun(ctx context.Context, options CancelRunOptio
 		if runErr != nil {
 			return runErr
 		}
+		service.emitRunStatus(ctx, run, &lastStatus)
 
 		service.writer.Output(fmt.Sprintf("Run Status: %q", run.Status))
 
@@ -333,6 +523,8 @@ func (service *runService) CancelRun(ctx context.Context, options CancelRunOptio
 		}
 		return retryableTimeoutError("cancel run")
 	})
+	service.emitRunCompleted(ctx, options.RunID, cancelRun, retryErr)
+
 	if retryErr != nil {
 		return cancelRun, retryErr
 	}
@@ -341,43 +533,15 @@ func (service *runService) CancelRun(ctx context.Context, options CancelRunOptio
 }
 
 func (service *runService) GetPlanLogs(ctx context.Context, planID string) error {
-	ctxTimeout, cancel := context.WithTimeout(ctx, LogTimeout)
-	defer cancel()
-
-	var err error
-	var logReader io.Reader
-	logReader, err = service.tfe.Plans.Logs(ctxTimeout, planID)
-	if err != nil {
-		return err
-	}
-
-	service.writer.Output(fmt.Sprintf("-------------- %s --------------", "Plan Log"))
-	err = outputRunLogLines(logReader, service.writer)
-	if err != nil {
-		return err
-	}
-	fmt.Println()
-	return nil
+	return service.streamLogs(ctx, PlanLog, func(ctx context.Context) (io.Reader, error) {
+		return service.tfe.Plans.Logs(ctx, planID)
+	})
 }
 
 func (service *runService) GetApplyLogs(ctx context.Context, applyID string) error {
-	ctxTimeout, cancel := context.WithTimeout(ctx, LogTimeout)
-	defer cancel()
-
-	var err error
-	var logReader io.Reader
-	logReader, err = service.tfe.Applies.Logs(ctxTimeout, applyID)
-	if err != nil {
-		return err
-	}
-
-	service.writer.Output(fmt.Sprintf("-------------- %s --------------", "Apply Log"))
-	err = outputRunLogLines(logReader, service.writer)
-	if err != nil {
-		return err
-	}
-	fmt.Println()
-	return nil
+	return service.streamLogs(ctx, ApplyLog, func(ctx context.Context) (io.Reader, error) {
+		return service.tfe.Applies.Logs(ctx, applyID)
+	})
 }
 
 func (s *runService) GetPolicyCheckLogs(ctx context.Context, run *tfe.Run) error {
@@ -385,14 +549,20 @@ func (s *runService) GetPolicyCheckLogs(ctx context.Context, run *tfe.Run) error
 		return nil
 	}
 
-	policyChecks, err := s.tfe.PolicyChecks.List(ctx, run.ID, &tfe.PolicyCheckListOptions{})
+	policyChecks, err := listAll(func(opts tfe.ListOptions) ([]*tfe.PolicyCheck, *tfe.Pagination, error) {
+		page, err := s.tfe.PolicyChecks.List(ctx, run.ID, &tfe.PolicyCheckListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
 	if err != nil {
 		return err
 	}
 
 	logStart := true
 	fmt.Println()
-	for _, pcheck := range policyChecks.Items {
+	for _, pcheck := range policyChecks {
 		ctxTimeout, cancel := context.WithTimeout(ctx, time.Second*10)
 		defer cancel()
 
@@ -401,6 +571,10 @@ func (s *runService) GetPolicyCheckLogs(ctx context.Context, run *tfe.Run) error
 			continue
 		}
 
+		policyEvent := notify.NewEvent(notify.PolicyResult, pcheck.ID, string(pcheck.Status))
+		policyEvent.RunID = run.ID
+		s.emit(ctx, policyEvent)
+
 		var err error
 		var logReader io.Reader
 		logReader, err = s.tfe.PolicyChecks.Logs(ctxTimeout, pcheck.ID)
@@ -414,7 +588,7 @@ func (s *runService) GetPolicyCheckLogs(ctx context.Context, run *tfe.Run) error
 			logStart = false
 		}
 
-		err = outputRunLogLines(logReader, s.writer)
+		_, err = s.outputRunLogLines(logReader, nil)
 		if err != nil {
 			return err
 		}
@@ -425,11 +599,15 @@ func (s *runService) GetPolicyCheckLogs(ctx context.Context, run *tfe.Run) error
 }
 
 func (s *runService) LogTaskStage(ctx context.Context, run *tfe.Run, stage tfe.Stage) error {
-	taskStages, err := s.tfe.TaskStages.List(ctx, run.ID, &tfe.TaskStageListOptions{})
+	// legacy policy checks are logged separately on releases without task stages
+	if !s.capabilities.Supports(TaskStages) {
+		return nil
+	}
+	taskStages, err := s.ReadTaskStages(ctx, run.ID, stage)
 	if err != nil {
 		return err
 	}
-	if !(len(taskStages.Items) > 0) {
+	if !(len(taskStages) > 0) {
 		return nil
 	}
 
@@ -440,27 +618,23 @@ func (s *runService) LogTaskStage(ctx context.Context, run *tfe.Run, stage tfe.S
 	}
 
 	fmt.Println()
-	for _, task := range taskStages.Items {
-		if task.Stage == stage {
-			s.writer.Output(fmt.Sprintf("-------------- %s --------------", labelMap[string(stage)]))
-			s.writer.Output(fmt.Sprintf("TaskStage (%s), Status: '%s', Stage: '%s'", task.ID, task.Status, task.Stage))
-			for _, taskResult := range task.TaskResults {
-				taskResult, resErr := s.tfe.TaskResults.Read(ctx, taskResult.ID)
-				if resErr != nil {
-					return fmt.Errorf("error reading results for task results: %s", resErr.Error())
-				}
-				s.writer.Output(fmt.Sprintf("- TaskResult (%s), Name: '%s', Status: '%s', EnforcementLevel: '%s', Message: '%s'", taskResult.ID, taskResult.TaskName, taskResult.Status, taskResult.WorkspaceTaskEnforcementLevel, taskResult.Message))
-			}
-			evaluations, pErr := s.tfe.PolicyEvaluations.List(ctx, task.ID, &tfe.PolicyEvaluationListOptions{})
-			if pErr != nil {
-				return fmt.Errorf("error reading results for policy evaluations: %s", pErr.Error())
-			}
-			for _, p := range evaluations.Items {
-				s.writer.Output(fmt.Sprintf("- PolicyEvalutation (%s), Status: '%s', PolicyKind: '%s'", p.ID, p.Status, p.PolicyKind))
-				s.writer.Output(fmt.Sprintf("  Passed: (%d), AdvisoryFailed: (%d), MandatoryFailed: (%d), Failed: (%d)", p.ResultCount.Passed, p.ResultCount.AdvisoryFailed, p.ResultCount.MandatoryFailed, p.ResultCount.Errored))
+	for _, task := range taskStages {
+		s.writer.Output(fmt.Sprintf("-------------- %s --------------", labelMap[string(stage)]))
+		s.writer.Output(fmt.Sprintf("TaskStage (%s), Status: '%s', Stage: '%s'", task.ID, task.Status, task.Stage))
+		for _, taskResult := range task.TaskResults {
+			s.writer.Output(fmt.Sprintf("- TaskResult (%s), Name: '%s', Status: '%s', EnforcementLevel: '%s', Message: '%s'", taskResult.ID, taskResult.TaskName, taskResult.Status, taskResult.EnforcementLevel, taskResult.Message))
+		}
+		for _, p := range task.PolicyEvaluations {
+			policyEvent := notify.NewEvent(notify.PolicyResult, p.ID, p.Status)
+			policyEvent.RunID = run.ID
+			s.emit(ctx, policyEvent)
+			s.writer.Output(fmt.Sprintf("- PolicyEvalutation (%s), Status: '%s', PolicyKind: '%s'", p.ID, p.Status, p.PolicyKind))
+			s.writer.Output(fmt.Sprintf("  Passed: (%d), AdvisoryFailed: (%d), MandatoryFailed: (%d), Failed: (%d)", p.Passed, p.AdvisoryFailed, p.MandatoryFailed, p.Errored))
+			for _, o := range p.PolicySets {
+				s.writer.Output(fmt.Sprintf("  - PolicySet '%s', Passed: (%d), AdvisoryFailed: (%d), MandatoryFailed: (%d), Failed: (%d)", o.Name, o.Passed, o.AdvisoryFailed, o.MandatoryFailed, o.Errored))
 			}
-			fmt.Println()
 		}
+		fmt.Println()
 	}
 	return nil
 }
@@ -476,28 +650,31 @@ func (s *runService) LogCostEstimation(ctx context.Context, run *tfe.Run) {
 	fmt.Println()
 }
 
-func outputRunLogLines(logs io.Reader, writer Writer) error {
-	var err error
+// writes each log line, returning the number of bytes written including line endings.
+// a partial line is only written when the logs end without a line ending.
+// log lines are redacted before they are written, and also copied to file when not nil
+func (m *cloudMeta) outputRunLogLines(logs io.Reader, file io.Writer) (int64, error) {
+	var written int64
 	reader := bufio.NewReaderSize(logs, 64*1024)
-	for next := true; next; {
-		var l, line []byte
-
-		for isPrefix := true; isPrefix; {
-			l, isPrefix, err = reader.ReadLine()
-			if err != nil {
-				if err != io.EOF {
-					return err
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return written, err
+		}
+		if len(line) > 0 {
+			redactedLine := m.redactor.Redact(string(line))
+			m.writer.Output(strings.TrimRight(redactedLine, "\r\n"))
+			if file != nil {
+				if _, fileErr := io.WriteString(file, redactedLine); fileErr != nil {
+					log.Printf("[ERROR] error writing log line to file: %s", fileErr)
 				}
-				next = false
 			}
-			line = append(line, l...)
+			written += int64(len(line))
 		}
-
-		if next || len(line) > 0 {
-			writer.Output(string(line))
+		if err == io.EOF {
+			return written, nil
 		}
 	}
-	return nil
 }
 
 func NewRunService(meta *cloudMeta) RunService {
@@ -551,6 +728,33 @@ func getDesiredRunStatus(run *tfe.Run, policyChecksEnabled bool, costEstimateEna
 	return desiredStatus
 }
 
+// returned when a run ends in a status other than the desired status
+type RunStatusError struct {
+	Status tfe.RunStatus
+}
+
+func (e *RunStatusError) Error() string {
+	return fmt.Sprintf("run has ended with: '%s' status", e.Status)
+}
+
+// run ended without applying, eg. canceled or discarded
+func (e *RunStatusError) Canceled() bool {
+	switch e.Status {
+	case tfe.RunCanceled, tfe.RunDiscarded, ForceCancel:
+		return true
+	}
+	return false
+}
+
+// run is waiting for a policy override or a decision on a failed task stage
+func (e *RunStatusError) PolicyBlocked() bool {
+	switch e.Status {
+	case tfe.RunPolicyOverride, PrePlanAwaitingDecision, PostPlanAwaitingDecision, PreApplyAwaitingDecision:
+		return true
+	}
+	return false
+}
+
 func isRunComplete(run *tfe.Run, desiredStatus []tfe.RunStatus, noopStatus []tfe.RunStatus) (done bool, err error) {
 	for _, s := range desiredStatus {
 		if run.Status == s {
@@ -560,7 +764,7 @@ func isRunComplete(run *tfe.Run, desiredStatus []tfe.RunStatus, noopStatus []tfe
 	for _, v := range noopStatus {
 		// we've reached non operable state, return error
 		if run.Status == v {
-			return true, fmt.Errorf("run has ended with: '%s' status", run.Status)
+			return true, &RunStatusError{Status: run.Status}
 		}
 	}
 	return false, nil
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type RunQueueDetails struct {
+	Organization string `json:"organization"`
+	AgentPoolID  string `json:"agent_pool_id,omitempty"`
+	PendingCount int    `json:"pending_count"`
+	ActiveCount  int    `json:"active_count"`
+	// age of the oldest run waiting to start, zero without pending runs
+	OldestPendingSeconds int64        `json:"oldest_pending_seconds"`
+	Runs                 []*QueuedRun `json:"runs"`
+}
+
+type QueuedRun struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	WorkspaceID string    `json:"workspace_id"`
+	Pending     bool      `json:"pending"`
+	CreatedAt   time.Time `json:"created_at"`
+	AgeSeconds  int64     `json:"age_seconds"`
+}
+
+// runs in the queue that are waiting to start planning or applying
+func runQueuePending(status tfe.RunStatus) bool {
+	switch status {
+	case tfe.RunPending, tfe.RunQueuing, tfe.RunPlanQueued, tfe.RunQueuingApply, tfe.RunApplyQueued:
+		return true
+	default:
+		return false
+	}
+}
+
+func newRunQueueDetails(organization, agentPoolID string, runs []*tfe.Run, now time.Time) *RunQueueDetails {
+	details := &RunQueueDetails{
+		Organization: organization,
+		AgentPoolID:  agentPoolID,
+		Runs:         []*QueuedRun{},
+	}
+	for _, run := range runs {
+		queued := &QueuedRun{
+			ID:         run.ID,
+			Status:     string(run.Status),
+			Pending:    runQueuePending(run.Status),
+			CreatedAt:  run.CreatedAt,
+			AgeSeconds: int64(now.Sub(run.CreatedAt).Seconds()),
+		}
+		if run.Workspace != nil {
+			queued.WorkspaceID = run.Workspace.ID
+		}
+		if queued.Pending {
+			details.PendingCount++
+			if queued.AgeSeconds > details.OldestPendingSeconds {
+				details.OldestPendingSeconds = queued.AgeSeconds
+			}
+		} else {
+			details.ActiveCount++
+		}
+		details.Runs = append(details.Runs, queued)
+	}
+	return details
+}
+
+// reads the pending and active runs of the organization, limited to the workspaces
+// using the agent pool when an agent pool id is provided
+func (s *organizationService) ReadRunQueue(ctx context.Context, orgName string, agentPoolID string) (*RunQueueDetails, error) {
+	runs, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Run, *tfe.Pagination, error) {
+		queue, err := s.tfe.Organizations.ReadRunQueue(ctx, orgName, tfe.ReadRunQueueOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return queue.Items, queue.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error reading run queue of organization: %q error: %s", orgName, err)
+		return nil, err
+	}
+
+	if agentPoolID != "" {
+		filtered := []*tfe.Run{}
+		for _, run := range runs {
+			if run.Workspace == nil {
+				continue
+			}
+			w, err := s.readWorkspaceByID(ctx, run.Workspace.ID)
+			if err != nil {
+				log.Printf("[ERROR] error reading workspace: %q of queued run: %q error: %s", run.Workspace.ID, run.ID, err)
+				return nil, err
+			}
+			if w.AgentPool != nil && w.AgentPool.ID == agentPoolID {
+				filtered = append(filtered, run)
+			}
+		}
+		runs = filtered
+	}
+	return newRunQueueDetails(orgName, agentPoolID, runs, time.Now()), nil
+}
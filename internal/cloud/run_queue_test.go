@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewRunQueueDetails(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	runs := []*tfe.Run{
+		{ID: "run-1", Status: tfe.RunPending, CreatedAt: now.Add(-9 * time.Minute), Workspace: &tfe.Workspace{ID: "ws-1"}},
+		{ID: "run-2", Status: tfe.RunPlanQueued, CreatedAt: now.Add(-2 * time.Minute), Workspace: &tfe.Workspace{ID: "ws-2"}},
+		{ID: "run-3", Status: tfe.RunPlanning, CreatedAt: now.Add(-30 * time.Minute), Workspace: &tfe.Workspace{ID: "ws-3"}},
+	}
+	queue := newRunQueueDetails("abc-company", "", runs, now)
+	if queue.PendingCount != 2 || queue.ActiveCount != 1 {
+		t.Errorf("expected 2 pending and 1 active runs but received %d and %d", queue.PendingCount, queue.ActiveCount)
+	}
+	// active runs are not waiting, so do not count towards the oldest pending age
+	if queue.OldestPendingSeconds != 540 {
+		t.Errorf("expected oldest pending age of 540 seconds but received %d", queue.OldestPendingSeconds)
+	}
+	if len(queue.Runs) != 3 || queue.Runs[2].WorkspaceID != "ws-3" || queue.Runs[2].Pending {
+		t.Errorf("unexpected queued runs %v", queue.Runs)
+	}
+}
+
+func TestOrganizationService_ReadRunQueueAgentPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mOrgs := mocks.NewMockOrganizations(ctrl)
+	mOrgs.EXPECT().ReadRunQueue(ctx, "abc-company", tfe.ReadRunQueueOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.RunQueue{
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+		Items: []*tfe.Run{
+			{ID: "run-1", Status: tfe.RunPending, CreatedAt: time.Now(), Workspace: &tfe.Workspace{ID: "ws-1"}},
+			{ID: "run-2", Status: tfe.RunPlanning, CreatedAt: time.Now(), Workspace: &tfe.Workspace{ID: "ws-2"}},
+		},
+	}, nil)
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().ReadByID(ctx, "ws-1").Return(&tfe.Workspace{ID: "ws-1", AgentPool: &tfe.AgentPool{ID: "apool-1"}}, nil)
+	mWorkspaces.EXPECT().ReadByID(ctx, "ws-2").Return(&tfe.Workspace{ID: "ws-2"}, nil)
+
+	service := NewOrganizationService(&cloudMeta{tfe: &tfe.Client{Organizations: mOrgs, Workspaces: mWorkspaces}, writer: &defaultWriter{}})
+	queue, err := service.ReadRunQueue(ctx, "abc-company", "apool-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(queue.Runs) != 1 || queue.Runs[0].ID != "run-1" || queue.PendingCount != 1 || queue.AgentPoolID != "apool-1" {
+		t.Errorf("unexpected run queue %+v", queue)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type RunTaskDetails struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	HMACKeySet  bool   `json:"hmac_key_set"`
+}
+
+func newRunTaskDetails(task *tfe.RunTask) *RunTaskDetails {
+	return &RunTaskDetails{
+		ID:          task.ID,
+		Name:        task.Name,
+		URL:         task.URL,
+		Description: task.Description,
+		Enabled:     task.Enabled,
+		HMACKeySet:  task.HMACKey != nil && *task.HMACKey != "",
+	}
+}
+
+type CreateRunTaskOptions struct {
+	Organization string
+	Name         string
+	URL          string
+	Description  string
+	HMACKey      string
+	Enabled      bool
+}
+
+type AttachRunTaskOptions struct {
+	Organization string
+	Workspace    string
+	// name or id of the organization run task
+	RunTask          string
+	EnforcementLevel tfe.TaskEnforcementLevel
+	Stage            tfe.Stage
+}
+
+type RunTaskService interface {
+	ListRunTasks(ctx context.Context, organization string) ([]*RunTaskDetails, error)
+	CreateRunTask(ctx context.Context, options CreateRunTaskOptions) (*RunTaskDetails, error)
+	AttachRunTask(ctx context.Context, options AttachRunTaskOptions) (*tfe.WorkspaceRunTask, error)
+}
+
+type runTaskService struct {
+	*cloudMeta
+}
+
+func (s *runTaskService) listRunTasks(ctx context.Context, organization string) ([]*tfe.RunTask, error) {
+	tasks, err := listAll(func(opts tfe.ListOptions) ([]*tfe.RunTask, *tfe.Pagination, error) {
+		list, err := s.tfe.RunTasks.List(ctx, organization, &tfe.RunTaskListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing run tasks of organization: %q error: %s", organization, err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *runTaskService) ListRunTasks(ctx context.Context, organization string) ([]*RunTaskDetails, error) {
+	tasks, err := s.listRunTasks(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]*RunTaskDetails, 0, len(tasks))
+	for _, task := range tasks {
+		details = append(details, newRunTaskDetails(task))
+	}
+	return details, nil
+}
+
+func (s *runTaskService) CreateRunTask(ctx context.Context, options CreateRunTaskOptions) (*RunTaskDetails, error) {
+	createOptions := tfe.RunTaskCreateOptions{
+		Name:     options.Name,
+		URL:      options.URL,
+		Category: "task",
+		Enabled:  tfe.Bool(options.Enabled),
+	}
+	if options.Description != "" {
+		createOptions.Description = tfe.String(options.Description)
+	}
+	if options.HMACKey != "" {
+		createOptions.HMACKey = tfe.String(options.HMACKey)
+	}
+	task, err := s.tfe.RunTasks.Create(ctx, options.Organization, createOptions)
+	if err != nil {
+		log.Printf("[ERROR] error creating run task: %q organization: %q error: %s", options.Name, options.Organization, err)
+		return nil, err
+	}
+	return newRunTaskDetails(task), nil
+}
+
+// attaches a run task to a workspace, updating the enforcement level and stage when the run task
+// is already attached so bootstrap pipelines can be rerun
+func (s *runTaskService) AttachRunTask(ctx context.Context, options AttachRunTaskOptions) (*tfe.WorkspaceRunTask, error) {
+	// run tasks only ran after the plan before task stages were introduced
+	if options.Stage != tfe.PostPlan {
+		if err := s.capabilities.Require(TaskStages); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := s.findRunTask(ctx, options.Organization, options.RunTask)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.readWorkspace(ctx, options.Organization, options.Workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q error: %s", options.Workspace, options.Organization, err)
+		return nil, err
+	}
+
+	attached, err := listAll(func(opts tfe.ListOptions) ([]*tfe.WorkspaceRunTask, *tfe.Pagination, error) {
+		list, err := s.tfe.WorkspaceRunTasks.List(ctx, workspace.ID, &tfe.WorkspaceRunTaskListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing run tasks of workspace: %q error: %s", workspace.ID, err)
+		return nil, err
+	}
+	for _, wrt := range attached {
+		if wrt.RunTask == nil || wrt.RunTask.ID != task.ID {
+			continue
+		}
+		if wrt.EnforcementLevel == options.EnforcementLevel && wrt.Stage == options.Stage {
+			return wrt, nil
+		}
+		updated, err := s.tfe.WorkspaceRunTasks.Update(ctx, workspace.ID, wrt.ID, tfe.WorkspaceRunTaskUpdateOptions{
+			EnforcementLevel: options.EnforcementLevel,
+			Stage:            &options.Stage,
+		})
+		if err != nil {
+			log.Printf("[ERROR] error updating workspace run task: %q error: %s", wrt.ID, err)
+			return nil, err
+		}
+		return updated, nil
+	}
+
+	wrt, err := s.tfe.WorkspaceRunTasks.Create(ctx, workspace.ID, tfe.WorkspaceRunTaskCreateOptions{
+		EnforcementLevel: options.EnforcementLevel,
+		RunTask:          task,
+		Stage:            &options.Stage,
+	})
+	if err != nil {
+		log.Printf("[ERROR] error attaching run task: %q to workspace: %q error: %s", task.Name, options.Workspace, err)
+		return nil, err
+	}
+	return wrt, nil
+}
+
+func (s *runTaskService) findRunTask(ctx context.Context, organization, nameOrID string) (*tfe.RunTask, error) {
+	if strings.HasPrefix(nameOrID, "task-") {
+		task, err := s.tfe.RunTasks.Read(ctx, nameOrID)
+		if err != nil {
+			log.Printf("[ERROR] error reading run task: %q error: %s", nameOrID, err)
+			return nil, err
+		}
+		return task, nil
+	}
+	tasks, err := s.listRunTasks(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.Name == nameOrID {
+			return task, nil
+		}
+	}
+	return nil, fmt.Errorf("run task %q in organization %q: %w", nameOrID, organization, tfe.ErrResourceNotFound)
+}
+
+func NewRunTaskService(meta *cloudMeta) *runTaskService {
+	return &runTaskService{meta}
+}
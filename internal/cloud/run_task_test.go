@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunTaskService_CreateRunTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mTasks := mocks.NewMockRunTasks(ctrl)
+	mTasks.EXPECT().Create(ctx, "abc-company", tfe.RunTaskCreateOptions{
+		Name:     "scanner",
+		URL:      "https://scanner.example.com/hook",
+		Category: "task",
+		Enabled:  tfe.Bool(true),
+		HMACKey:  tfe.String("secret"),
+	}).Return(&tfe.RunTask{ID: "task-1", Name: "scanner", Enabled: true, HMACKey: tfe.String("secret")}, nil)
+
+	service := NewRunTaskService(&cloudMeta{tfe: &tfe.Client{RunTasks: mTasks}, writer: &defaultWriter{}})
+	task, err := service.CreateRunTask(ctx, CreateRunTaskOptions{Organization: "abc-company", Name: "scanner", URL: "https://scanner.example.com/hook", HMACKey: "secret", Enabled: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if task.ID != "task-1" || !task.HMACKeySet {
+		t.Errorf("unexpected run task %+v", task)
+	}
+}
+
+func TestRunTaskService_AttachRunTask(t *testing.T) {
+	ctx := context.Background()
+	task := &tfe.RunTask{ID: "task-1", Name: "scanner"}
+	workspace := &tfe.Workspace{ID: "ws-1", Name: "networking"}
+	options := AttachRunTaskOptions{Organization: "abc-company", Workspace: "networking", RunTask: "scanner", EnforcementLevel: tfe.Mandatory, Stage: tfe.PrePlan}
+
+	cases := map[string]struct {
+		attached []*tfe.WorkspaceRunTask
+		expect   func(*mocks.MockWorkspaceRunTasks)
+	}{
+		"attaches the run task": {
+			expect: func(m *mocks.MockWorkspaceRunTasks) {
+				m.EXPECT().Create(ctx, "ws-1", tfe.WorkspaceRunTaskCreateOptions{EnforcementLevel: tfe.Mandatory, RunTask: task, Stage: &options.Stage}).Return(&tfe.WorkspaceRunTask{ID: "wstask-1", EnforcementLevel: tfe.Mandatory, Stage: tfe.PrePlan}, nil)
+			},
+		},
+		"updates an attached run task": {
+			attached: []*tfe.WorkspaceRunTask{{ID: "wstask-1", EnforcementLevel: tfe.Advisory, Stage: tfe.PostPlan, RunTask: task}},
+			expect: func(m *mocks.MockWorkspaceRunTasks) {
+				m.EXPECT().Update(ctx, "ws-1", "wstask-1", tfe.WorkspaceRunTaskUpdateOptions{EnforcementLevel: tfe.Mandatory, Stage: &options.Stage}).Return(&tfe.WorkspaceRunTask{ID: "wstask-1", EnforcementLevel: tfe.Mandatory, Stage: tfe.PrePlan}, nil)
+			},
+		},
+		"keeps a matching run task": {
+			attached: []*tfe.WorkspaceRunTask{{ID: "wstask-1", EnforcementLevel: tfe.Mandatory, Stage: tfe.PrePlan, RunTask: task}},
+			expect:   func(*mocks.MockWorkspaceRunTasks) {},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mTasks := mocks.NewMockRunTasks(ctrl)
+			mTasks.EXPECT().List(ctx, "abc-company", &tfe.RunTaskListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.RunTaskList{Items: []*tfe.RunTask{task}}, nil)
+			mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+			mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(workspace, nil)
+			mWorkspaceTasks := mocks.NewMockWorkspaceRunTasks(ctrl)
+			mWorkspaceTasks.EXPECT().List(ctx, "ws-1", &tfe.WorkspaceRunTaskListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.WorkspaceRunTaskList{Items: tc.attached}, nil)
+			tc.expect(mWorkspaceTasks)
+
+			service := NewRunTaskService(&cloudMeta{tfe: &tfe.Client{RunTasks: mTasks, Workspaces: mWorkspaces, WorkspaceRunTasks: mWorkspaceTasks}, writer: &defaultWriter{}})
+			wrt, err := service.AttachRunTask(ctx, options)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if wrt.ID != "wstask-1" || wrt.EnforcementLevel != tfe.Mandatory || wrt.Stage != tfe.PrePlan {
+				t.Errorf("unexpected workspace run task %+v", wrt)
+			}
+		})
+	}
+}
+
+func TestRunTaskService_AttachRunTaskUnsupportedStage(t *testing.T) {
+	service := NewRunTaskService(&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}, capabilities: &Capabilities{TFEVersion: "v202201-1"}})
+	_, err := service.AttachRunTask(context.Background(), AttachRunTaskOptions{Organization: "abc-company", Workspace: "networking", RunTask: "scanner", Stage: tfe.PrePlan})
+	var unsupported *UnsupportedError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("expected an unsupported error but received %v", err)
+	}
+}
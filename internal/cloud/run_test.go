@@ -5,6 +5,9 @@ package cloud
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/go-tfe"
@@ -192,6 +195,7 @@ func TestRunService_CreateRun(t *testing.T) {
 				Include: []tfe.RunIncludeOpt{
 					"cost_estimate",
 					"plan",
+					tfe.RunWorkspace,
 				},
 			}
 
@@ -242,3 +246,87 @@ func TestRunService_CreateRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRunService_RunLink(t *testing.T) {
+	workspaceReads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-readable":
+			workspaceReads++
+			fmt.Fprint(w, `{"data":{"id":"ws-readable","type":"workspaces","attributes":{"name":"networking"},"relationships":{"organization":{"data":{"id":"abc-company","type":"organizations"}}}}}`)
+		case "/api/v2/workspaces/ws-forbidden":
+			workspaceReads++
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client, err := tfe.NewClient(&tfe.Config{Address: server.URL, Token: "token"})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	service := NewRunService(&cloudMeta{tfe: client, writer: &defaultWriter{}, workspaces: newWorkspaceCache()})
+
+	testCases := []struct {
+		name           string
+		organization   string
+		run            *tfe.Run
+		expected       string
+		workspaceReads int
+	}{
+		{
+			name:         "included-workspace",
+			organization: "abc-company",
+			run:          &tfe.Run{ID: "run-1", Workspace: &tfe.Workspace{ID: "ws-included", Name: "compute"}},
+			expected:     server.URL + "/app/abc-company/workspaces/compute/runs/run-1",
+		},
+		{
+			name:           "workspace-read",
+			run:            &tfe.Run{ID: "run-2", Workspace: &tfe.Workspace{ID: "ws-readable"}},
+			expected:       server.URL + "/app/abc-company/workspaces/networking/runs/run-2",
+			workspaceReads: 1,
+		},
+		{
+			name:     "included-workspace-organization",
+			run:      &tfe.Run{ID: "run-6", Workspace: &tfe.Workspace{ID: "ws-included", Name: "compute", Organization: &tfe.Organization{Name: "abc-company"}}},
+			expected: server.URL + "/app/abc-company/workspaces/compute/runs/run-6",
+		},
+		{
+			name:     "cached-workspace",
+			run:      &tfe.Run{ID: "run-3", Workspace: &tfe.Workspace{ID: "ws-readable"}},
+			expected: server.URL + "/app/abc-company/workspaces/networking/runs/run-3",
+		},
+		{
+			name:           "workspace-not-readable",
+			organization:   "abc-company",
+			run:            &tfe.Run{ID: "run-4", Workspace: &tfe.Workspace{ID: "ws-forbidden"}},
+			expected:       server.URL + "/app/abc-company/runs/run-4",
+			workspaceReads: 1,
+		},
+		{
+			name:         "no-workspace",
+			organization: "abc-company",
+			run:          &tfe.Run{ID: "run-5"},
+			expected:     server.URL + "/app/abc-company/runs/run-5",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workspaceReads = 0
+			link, err := service.RunLink(context.Background(), tc.organization, tc.run)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if link != tc.expected {
+				t.Errorf("expected link %q but received %q", tc.expected, link)
+			}
+			if workspaceReads != tc.workspaceReads {
+				t.Errorf("expected %d workspace reads but received %d", tc.workspaceReads, workspaceReads)
+			}
+		})
+	}
+}
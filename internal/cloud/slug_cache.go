@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	slug "github.com/hashicorp/go-slug"
+)
+
+// configuration versions uploaded for previously packed slugs, keyed by workspace and directory
+type slugCache struct {
+	Entries map[string]*slugCacheEntry `json:"entries"`
+}
+
+type slugCacheEntry struct {
+	Hash                   string `json:"hash"`
+	ConfigurationVersionID string `json:"configuration_version_id"`
+}
+
+func slugCacheKey(options UploadOptions) string {
+	return fmt.Sprintf("%s/%s:%s:speculative=%t:provisional=%t", options.Organization, options.Workspace, options.ConfigurationDirectory, options.Speculative, options.Provisional)
+}
+
+// a missing or unreadable cache file is treated as empty
+func readSlugCache(path string) *slugCache {
+	cache := &slugCache{Entries: map[string]*slugCacheEntry{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] ignoring slug cache: %q error: %s", path, err)
+		}
+		return cache
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		log.Printf("[WARN] ignoring invalid slug cache: %q error: %s", path, err)
+		return &slugCache{Entries: map[string]*slugCacheEntry{}}
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string]*slugCacheEntry{}
+	}
+	return cache
+}
+
+func (c *slugCache) write(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// hashes the contents of the slug that would be uploaded for the directory, honoring .terraformignore.
+// modification times are excluded, they change with every checkout.
+func slugHash(dir string) (string, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := slug.Pack(dir, pw, true)
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+	return hashSlugArchive(pr)
+}
+
+// hashes the entries of a gzipped slug archive, such as a downloaded configuration version
+func hashSlugArchive(archive io.Reader) (string, error) {
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00%o\x00%s\x00%d\x00", header.Name, header.Typeflag, header.Mode, header.Linkname, header.Size)
+		if _, err := io.Copy(hash, tr); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
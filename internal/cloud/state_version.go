@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+var (
+	ErrStateSerialConflict  = errors.New("state serial must be greater than the serial of the current state version")
+	ErrStateLineageConflict = errors.New("state lineage does not match the lineage of the current state version")
+)
+
+type StateVersionService interface {
+	PushState(context.Context, PushStateOptions) (*StateVersionDetails, error)
+}
+
+type PushStateOptions struct {
+	Organization string
+	Workspace    string
+	State        []byte
+	// overrides the serial of the state file when set
+	Serial *int64
+	// pushes the state even when the lineage or serial conflict with the current state version
+	Force bool
+}
+
+type StateVersionDetails struct {
+	ID      string `json:"id"`
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+type stateVersionService struct {
+	*cloudMeta
+}
+
+// fields of a terraform state file needed to create a state version
+type stateFile struct {
+	Serial  *int64 `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// creates a state version from a state file. The workspace is locked while the state version is created,
+// and unless forced, the lineage must match and the serial must be greater than the current state version.
+func (s *stateVersionService) PushState(ctx context.Context, options PushStateOptions) (*StateVersionDetails, error) {
+	raw := options.State
+	if options.Serial != nil {
+		var err error
+		if raw, err = setStateSerial(raw, *options.Serial); err != nil {
+			return nil, fmt.Errorf("error parsing state file: %w", err)
+		}
+	}
+	state := &stateFile{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, fmt.Errorf("error parsing state file: %w", err)
+	}
+	if state.Serial == nil {
+		return nil, fmt.Errorf("state file has no serial")
+	}
+
+	w, err := s.readWorkspace(ctx, options.Organization, options.Workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", options.Workspace, options.Organization, err)
+		return nil, err
+	}
+
+	if _, err := s.tfe.Workspaces.Lock(ctx, w.ID, tfe.WorkspaceLockOptions{
+		Reason: tfe.String("tfci: pushing state"),
+	}); err != nil {
+		log.Printf("[ERROR] error locking workspace: %q error: %s", w.ID, err)
+		return nil, fmt.Errorf("error locking workspace %q: %w", options.Workspace, err)
+	}
+	defer func() {
+		if _, err := s.tfe.Workspaces.Unlock(ctx, w.ID); err != nil {
+			log.Printf("[ERROR] error unlocking workspace: %q error: %s", w.ID, err)
+			s.writer.Error(fmt.Sprintf("Error unlocking workspace %q, it must be unlocked manually: %s", options.Workspace, err))
+		}
+	}()
+
+	// the current state version is compared while holding the lock, so it cannot change before the push
+	if !options.Force {
+		if err := s.checkCurrentState(ctx, w.ID, state); err != nil {
+			return nil, err
+		}
+	}
+
+	sv, err := s.tfe.StateVersions.Create(ctx, w.ID, tfe.StateVersionCreateOptions{
+		Lineage: tfe.String(state.Lineage),
+		MD5:     tfe.String(fmt.Sprintf("%x", md5.Sum(raw))),
+		Serial:  state.Serial,
+		State:   tfe.String(base64.StdEncoding.EncodeToString(raw)),
+	})
+	if err != nil {
+		log.Printf("[ERROR] error creating state version in workspace: %q error: %s", w.ID, err)
+		return nil, err
+	}
+
+	return &StateVersionDetails{ID: sv.ID, Serial: sv.Serial, Lineage: state.Lineage}, nil
+}
+
+// checks the state against the current state version of the workspace, workspaces without state accept any state
+func (s *stateVersionService) checkCurrentState(ctx context.Context, workspaceID string, state *stateFile) error {
+	current, err := s.tfe.StateVersions.ReadCurrent(ctx, workspaceID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("[ERROR] error reading current state version of workspace: %q error: %s", workspaceID, err)
+		return err
+	}
+
+	// the lineage is not an attribute of the state version, it is read from the state file
+	raw, err := s.tfe.StateVersions.Download(ctx, current.DownloadURL)
+	if err != nil {
+		log.Printf("[ERROR] error downloading current state version: %q error: %s", current.ID, err)
+		return err
+	}
+	currentState := &stateFile{}
+	if err := json.Unmarshal(raw, currentState); err != nil {
+		return fmt.Errorf("error parsing current state version %q: %w", current.ID, err)
+	}
+
+	if state.Lineage != "" && currentState.Lineage != "" && state.Lineage != currentState.Lineage {
+		return fmt.Errorf("%w, lineage: %s current lineage: %s", ErrStateLineageConflict, state.Lineage, currentState.Lineage)
+	}
+	if *state.Serial <= current.Serial {
+		return fmt.Errorf("%w, serial: %d current serial: %d", ErrStateSerialConflict, *state.Serial, current.Serial)
+	}
+	return nil
+}
+
+// rewrites the serial of a state file, so the uploaded state matches the serial of the state version
+func setStateSerial(state []byte, serial int64) ([]byte, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(state, &doc); err != nil {
+		return nil, err
+	}
+	doc["serial"] = json.RawMessage(fmt.Sprintf("%d", serial))
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func NewStateVersionService(meta *cloudMeta) *stateVersionService {
+	return &stateVersionService{meta}
+}
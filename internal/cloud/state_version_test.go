@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStateVersionService_PushState(t *testing.T) {
+	state := []byte(`{"version":4,"serial":7,"lineage":"abc-123","resources":[]}`)
+
+	testCases := []struct {
+		name           string
+		serial         *int64
+		force          bool
+		current        *tfe.StateVersion
+		currentLineage string
+		currentErr     error
+		lockErr        error
+		expectCreate   bool
+		expectedSerial int64
+		expectedErr    error
+	}{
+		{
+			name:           "pushes state newer than current",
+			current:        &tfe.StateVersion{Serial: 3},
+			expectCreate:   true,
+			expectedSerial: 7,
+		},
+		{
+			name:           "pushes state to workspace without state",
+			currentErr:     tfe.ErrResourceNotFound,
+			expectCreate:   true,
+			expectedSerial: 7,
+		},
+		{
+			name:        "rejects serial not newer than current",
+			current:     &tfe.StateVersion{Serial: 7},
+			expectedErr: ErrStateSerialConflict,
+		},
+		{
+			name:           "overrides serial",
+			serial:         tfe.Int64(12),
+			current:        &tfe.StateVersion{Serial: 7},
+			expectCreate:   true,
+			expectedSerial: 12,
+		},
+		{
+			name:           "rejects lineage of another state",
+			current:        &tfe.StateVersion{Serial: 3},
+			currentLineage: "def-456",
+			expectedErr:    ErrStateLineageConflict,
+		},
+		{
+			name:           "forces lineage and serial",
+			force:          true,
+			current:        &tfe.StateVersion{Serial: 9},
+			currentLineage: "def-456",
+			expectCreate:   true,
+			expectedSerial: 7,
+		},
+		{
+			name:        "fails when workspace is locked",
+			lockErr:     tfe.ErrWorkspaceLocked,
+			expectedErr: tfe.ErrWorkspaceLocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+			mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+			mStateVersions := mocks.NewMockStateVersions(ctrl)
+			// the current state version is only read once the workspace is locked
+			lock := mWorkspaces.EXPECT().Lock(ctx, "ws-1", gomock.Any()).Return(&tfe.Workspace{ID: "ws-1", Locked: true}, tc.lockErr)
+			if tc.lockErr == nil {
+				mWorkspaces.EXPECT().Unlock(ctx, "ws-1").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+			}
+			if tc.lockErr == nil && !tc.force {
+				mStateVersions.EXPECT().ReadCurrent(ctx, "ws-1").After(lock).Return(tc.current, tc.currentErr)
+			}
+			if tc.lockErr == nil && !tc.force && tc.current != nil {
+				lineage := tc.currentLineage
+				if lineage == "" {
+					lineage = "abc-123"
+				}
+				mStateVersions.EXPECT().Download(ctx, gomock.Any()).Return([]byte(`{"version":4,"serial":3,"lineage":"`+lineage+`"}`), nil)
+			}
+			if tc.expectCreate {
+				mStateVersions.EXPECT().Create(ctx, "ws-1", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, options tfe.StateVersionCreateOptions) (*tfe.StateVersion, error) {
+						if *options.Serial != tc.expectedSerial {
+							t.Errorf("expected serial %d, got %d", tc.expectedSerial, *options.Serial)
+						}
+						if *options.Lineage != "abc-123" {
+							t.Errorf("expected lineage abc-123, got %s", *options.Lineage)
+						}
+						raw, err := base64.StdEncoding.DecodeString(*options.State)
+						if err != nil {
+							t.Fatal(err)
+						}
+						uploaded := &stateFile{}
+						if err := json.Unmarshal(raw, uploaded); err != nil {
+							t.Fatal(err)
+						}
+						if *uploaded.Serial != tc.expectedSerial {
+							t.Errorf("expected uploaded state serial %d, got %d", tc.expectedSerial, *uploaded.Serial)
+						}
+						return &tfe.StateVersion{ID: "sv-1", Serial: *options.Serial}, nil
+					})
+			}
+
+			service := NewStateVersionService(&cloudMeta{
+				tfe:    &tfe.Client{Workspaces: mWorkspaces, StateVersions: mStateVersions},
+				writer: &defaultWriter{},
+			})
+			sv, err := service.PushState(ctx, PushStateOptions{
+				Organization: "abc-company",
+				Workspace:    "networking",
+				State:        state,
+				Serial:       tc.serial,
+				Force:        tc.force,
+			})
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if sv.ID != "sv-1" || sv.Serial != tc.expectedSerial {
+				t.Errorf("unexpected state version: %+v", sv)
+			}
+		})
+	}
+}
+
+func TestStateVersionService_PushStateInvalidFile(t *testing.T) {
+	service := NewStateVersionService(&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}})
+	for name, state := range map[string]string{
+		"not json":  "terraform",
+		"no serial": `{"version":4,"lineage":"abc-123"}`,
+	} {
+		if _, err := service.PushState(context.Background(), PushStateOptions{State: []byte(state)}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+var ErrTagBindingTarget = errors.New("tag bindings require either a workspace or a project")
+
+type TagBindingDetails struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// set for effective tag bindings inherited from the project of a workspace
+	Inherited bool `json:"inherited,omitempty"`
+}
+
+// workspace or project the tag bindings belong to
+type TagBindingTarget struct {
+	Organization string
+	Workspace    string
+	Project      string
+}
+
+type TagBindingService interface {
+	ListTagBindings(ctx context.Context, target TagBindingTarget, effective bool) ([]*TagBindingDetails, error)
+	AddTagBindings(ctx context.Context, target TagBindingTarget, bindings []*TagBindingDetails) ([]*TagBindingDetails, error)
+	ListTaggedWorkspaces(ctx context.Context, orgName string, bindings []*TagBindingDetails) ([]string, error)
+}
+
+type tagBindingService struct {
+	*cloudMeta
+}
+
+// lists the tag bindings of the workspace or project. Effective tag bindings include the tag bindings
+// a workspace inherits from its project.
+func (s *tagBindingService) ListTagBindings(ctx context.Context, target TagBindingTarget, effective bool) ([]*TagBindingDetails, error) {
+	if err := s.capabilities.Require(TagBindings); err != nil {
+		return nil, err
+	}
+
+	var direct []*tfe.TagBinding
+	var effectiveBindings []*tfe.EffectiveTagBinding
+	var err error
+	switch {
+	case target.Workspace != "":
+		w, wErr := s.readWorkspace(ctx, target.Organization, target.Workspace)
+		if wErr != nil {
+			log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", target.Workspace, target.Organization, wErr)
+			return nil, wErr
+		}
+		if direct, err = s.tfe.Workspaces.ListTagBindings(ctx, w.ID); err == nil && effective {
+			effectiveBindings, err = s.tfe.Workspaces.ListEffectiveTagBindings(ctx, w.ID)
+		}
+	case target.Project != "":
+		p, pErr := s.readProject(ctx, target.Organization, target.Project)
+		if pErr != nil {
+			return nil, pErr
+		}
+		if direct, err = s.tfe.Projects.ListTagBindings(ctx, p.ID); err == nil && effective {
+			effectiveBindings, err = s.tfe.Projects.ListEffectiveTagBindings(ctx, p.ID)
+		}
+	default:
+		return nil, ErrTagBindingTarget
+	}
+	if err != nil {
+		log.Printf("[ERROR] error listing tag bindings of workspace: %q project: %q error: %s", target.Workspace, target.Project, err)
+		return nil, err
+	}
+
+	if !effective {
+		return newTagBindingDetails(direct), nil
+	}
+	own := map[string]bool{}
+	for _, b := range direct {
+		own[b.Key] = true
+	}
+	details := make([]*TagBindingDetails, 0, len(effectiveBindings))
+	for _, b := range effectiveBindings {
+		details = append(details, &TagBindingDetails{Key: b.Key, Value: b.Value, Inherited: !own[b.Key]})
+	}
+	sortTagBindings(details)
+	return details, nil
+}
+
+// adds tag bindings to the workspace or project, replacing the values of existing keys.
+// Returns all tag bindings of the workspace or project.
+func (s *tagBindingService) AddTagBindings(ctx context.Context, target TagBindingTarget, bindings []*TagBindingDetails) ([]*TagBindingDetails, error) {
+	if err := s.capabilities.Require(TagBindings); err != nil {
+		return nil, err
+	}
+
+	tagBindings := make([]*tfe.TagBinding, 0, len(bindings))
+	for _, b := range bindings {
+		tagBindings = append(tagBindings, &tfe.TagBinding{Key: b.Key, Value: b.Value})
+	}
+
+	var added []*tfe.TagBinding
+	var err error
+	switch {
+	case target.Workspace != "":
+		w, wErr := s.readWorkspace(ctx, target.Organization, target.Workspace)
+		if wErr != nil {
+			log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", target.Workspace, target.Organization, wErr)
+			return nil, wErr
+		}
+		added, err = s.tfe.Workspaces.AddTagBindings(ctx, w.ID, tfe.WorkspaceAddTagBindingsOptions{TagBindings: tagBindings})
+	case target.Project != "":
+		p, pErr := s.readProject(ctx, target.Organization, target.Project)
+		if pErr != nil {
+			return nil, pErr
+		}
+		added, err = s.tfe.Projects.AddTagBindings(ctx, p.ID, tfe.ProjectAddTagBindingsOptions{TagBindings: tagBindings})
+	default:
+		return nil, ErrTagBindingTarget
+	}
+	if err != nil {
+		log.Printf("[ERROR] error adding tag bindings to workspace: %q project: %q error: %s", target.Workspace, target.Project, err)
+		return nil, err
+	}
+	return newTagBindingDetails(added), nil
+}
+
+// lists the names of workspaces with all of the tag bindings, a tag binding without a value
+// matches any value of the key.
+func (s *tagBindingService) ListTaggedWorkspaces(ctx context.Context, orgName string, bindings []*TagBindingDetails) ([]string, error) {
+	if err := s.capabilities.Require(TagBindings); err != nil {
+		return nil, err
+	}
+
+	tagBindings := make([]*tfe.TagBinding, 0, len(bindings))
+	for _, b := range bindings {
+		tagBindings = append(tagBindings, &tfe.TagBinding{Key: b.Key, Value: b.Value})
+	}
+
+	workspaces, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Workspace, *tfe.Pagination, error) {
+		page, err := s.tfe.Workspaces.List(ctx, orgName, &tfe.WorkspaceListOptions{
+			ListOptions: opts,
+			TagBindings: tagBindings,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing workspaces in organization: %q with tag bindings, error: %s", orgName, err)
+		return nil, err
+	}
+
+	names := make([]string, 0, len(workspaces))
+	for _, w := range workspaces {
+		names = append(names, w.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func newTagBindingDetails(bindings []*tfe.TagBinding) []*TagBindingDetails {
+	details := make([]*TagBindingDetails, 0, len(bindings))
+	for _, b := range bindings {
+		details = append(details, &TagBindingDetails{Key: b.Key, Value: b.Value})
+	}
+	sortTagBindings(details)
+	return details
+}
+
+func sortTagBindings(bindings []*TagBindingDetails) {
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Key < bindings[j].Key
+	})
+}
+
+func NewTagBindingService(meta *cloudMeta) *tagBindingService {
+	return &tagBindingService{meta}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTagBindingService_ListTagBindings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("workspace effective tag bindings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+		mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+		mWorkspaces.EXPECT().ListTagBindings(ctx, "ws-1").Return([]*tfe.TagBinding{{Key: "team", Value: "platform"}}, nil)
+		mWorkspaces.EXPECT().ListEffectiveTagBindings(ctx, "ws-1").Return([]*tfe.EffectiveTagBinding{
+			{Key: "team", Value: "platform"},
+			{Key: "cost-center", Value: "1234"},
+		}, nil)
+
+		service := NewTagBindingService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces}, writer: &defaultWriter{}})
+		bindings, err := service.ListTagBindings(ctx, TagBindingTarget{Organization: "abc-company", Workspace: "networking"}, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := []*TagBindingDetails{
+			{Key: "cost-center", Value: "1234", Inherited: true},
+			{Key: "team", Value: "platform"},
+		}
+		if !reflect.DeepEqual(bindings, expected) {
+			t.Errorf("expected tag bindings %+v, got %+v", expected, bindings)
+		}
+	})
+
+	t.Run("project tag bindings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mProjects := mocks.NewMockProjects(ctrl)
+		mProjects.EXPECT().List(ctx, "abc-company", &tfe.ProjectListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, Name: "payments"}).Return(&tfe.ProjectList{
+			Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1},
+			Items:      []*tfe.Project{{ID: "prj-1", Name: "payments"}},
+		}, nil)
+		mProjects.EXPECT().ListTagBindings(ctx, "prj-1").Return([]*tfe.TagBinding{{Key: "cost-center", Value: "1234"}}, nil)
+
+		service := NewTagBindingService(&cloudMeta{tfe: &tfe.Client{Projects: mProjects}, writer: &defaultWriter{}})
+		bindings, err := service.ListTagBindings(ctx, TagBindingTarget{Organization: "abc-company", Project: "payments"}, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := []*TagBindingDetails{{Key: "cost-center", Value: "1234"}}
+		if !reflect.DeepEqual(bindings, expected) {
+			t.Errorf("expected tag bindings %+v, got %+v", expected, bindings)
+		}
+	})
+
+	t.Run("requires a target", func(t *testing.T) {
+		service := NewTagBindingService(&cloudMeta{tfe: &tfe.Client{}, writer: &defaultWriter{}})
+		if _, err := service.ListTagBindings(ctx, TagBindingTarget{Organization: "abc-company"}, false); !errors.Is(err, ErrTagBindingTarget) {
+			t.Errorf("expected error %q, got %v", ErrTagBindingTarget, err)
+		}
+	})
+
+	t.Run("unsupported by terraform enterprise", func(t *testing.T) {
+		service := NewTagBindingService(&cloudMeta{
+			tfe:          &tfe.Client{},
+			writer:       &defaultWriter{},
+			capabilities: &Capabilities{TFEVersion: "v202401-1"},
+		})
+		var unsupported *UnsupportedError
+		if _, err := service.ListTagBindings(ctx, TagBindingTarget{Workspace: "networking"}, false); !errors.As(err, &unsupported) {
+			t.Errorf("expected unsupported error, got %v", err)
+		}
+	})
+}
+
+func TestTagBindingService_AddTagBindings(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+	mWorkspaces.EXPECT().AddTagBindings(ctx, "ws-1", tfe.WorkspaceAddTagBindingsOptions{
+		TagBindings: []*tfe.TagBinding{{Key: "team", Value: "platform"}},
+	}).Return([]*tfe.TagBinding{{Key: "team", Value: "platform"}, {Key: "env", Value: "prod"}}, nil)
+
+	service := NewTagBindingService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces}, writer: &defaultWriter{}})
+	bindings, err := service.AddTagBindings(ctx, TagBindingTarget{Organization: "abc-company", Workspace: "networking"}, []*TagBindingDetails{{Key: "team", Value: "platform"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []*TagBindingDetails{{Key: "env", Value: "prod"}, {Key: "team", Value: "platform"}}
+	if !reflect.DeepEqual(bindings, expected) {
+		t.Errorf("expected tag bindings %+v, got %+v", expected, bindings)
+	}
+}
+
+func TestTagBindingService_ListTaggedWorkspaces(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().List(ctx, "abc-company", &tfe.WorkspaceListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+		TagBindings: []*tfe.TagBinding{{Key: "team", Value: "payments"}},
+	}).Return(&tfe.WorkspaceList{
+		Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1},
+		Items:      []*tfe.Workspace{{Name: "payments-db"}, {Name: "payments-api"}},
+	}, nil)
+
+	service := NewTagBindingService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces}, writer: &defaultWriter{}})
+	names, err := service.ListTaggedWorkspaces(ctx, "abc-company", []*TagBindingDetails{{Key: "team", Value: "payments"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(names, []string{"payments-api", "payments-db"}) {
+		t.Errorf("expected sorted workspace names but received %v", names)
+	}
+}
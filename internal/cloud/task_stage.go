@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type TaskStageDetails struct {
+	ID                string                     `json:"id"`
+	Stage             string                     `json:"stage"`
+	Status            string                     `json:"status"`
+	CanOverride       bool                       `json:"can_override"`
+	TaskResults       []*TaskResultDetails       `json:"task_results"`
+	PolicyEvaluations []*PolicyEvaluationDetails `json:"policy_evaluations"`
+}
+
+type TaskResultDetails struct {
+	ID               string `json:"id"`
+	TaskName         string `json:"task_name"`
+	Status           string `json:"status"`
+	EnforcementLevel string `json:"enforcement_level"`
+	Message          string `json:"message"`
+	URL              string `json:"url,omitempty"`
+}
+
+type PolicyEvaluationDetails struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	PolicyKind string `json:"policy_kind"`
+	PolicyResultCounts
+	PolicySets []*PolicySetOutcomeDetails `json:"policy_sets,omitempty"`
+}
+
+type PolicySetOutcomeDetails struct {
+	Name string `json:"name"`
+	PolicyResultCounts
+	Policies []*PolicyOutcome `json:"policies,omitempty"`
+}
+
+type PolicyOutcome struct {
+	Stage            string `json:"stage,omitempty"`
+	PolicySet        string `json:"policy_set,omitempty"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	EnforcementLevel string `json:"enforcement_level"`
+	Status           string `json:"status"`
+}
+
+type PolicyResultCounts struct {
+	Passed          int `json:"passed"`
+	AdvisoryFailed  int `json:"advisory_failed"`
+	MandatoryFailed int `json:"mandatory_failed"`
+	Errored         int `json:"errored"`
+}
+
+func newPolicyResultCounts(c *tfe.PolicyResultCount) PolicyResultCounts {
+	if c == nil {
+		return PolicyResultCounts{}
+	}
+	return PolicyResultCounts{Passed: c.Passed, AdvisoryFailed: c.AdvisoryFailed, MandatoryFailed: c.MandatoryFailed, Errored: c.Errored}
+}
+
+// reads the task stages of a run with their task results and policy evaluations, all stages when stage is empty
+func (s *runService) ReadTaskStages(ctx context.Context, runID string, stage tfe.Stage) ([]*TaskStageDetails, error) {
+	if err := s.capabilities.Require(TaskStages); err != nil {
+		return nil, err
+	}
+	taskStages, err := listAll(func(opts tfe.ListOptions) ([]*tfe.TaskStage, *tfe.Pagination, error) {
+		page, err := s.tfe.TaskStages.List(ctx, runID, &tfe.TaskStageListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stages := []*TaskStageDetails{}
+	for _, task := range taskStages {
+		if stage != "" && task.Stage != stage {
+			continue
+		}
+		details := &TaskStageDetails{
+			ID:                task.ID,
+			Stage:             string(task.Stage),
+			Status:            string(task.Status),
+			CanOverride:       task.Permissions != nil && task.Permissions.CanOverride != nil && *task.Permissions.CanOverride,
+			TaskResults:       []*TaskResultDetails{},
+			PolicyEvaluations: []*PolicyEvaluationDetails{},
+		}
+		for _, taskResult := range task.TaskResults {
+			taskResult, resErr := s.tfe.TaskResults.Read(ctx, taskResult.ID)
+			if resErr != nil {
+				return nil, fmt.Errorf("error reading results for task results: %s", resErr.Error())
+			}
+			details.TaskResults = append(details.TaskResults, &TaskResultDetails{
+				ID:               taskResult.ID,
+				TaskName:         taskResult.TaskName,
+				Status:           string(taskResult.Status),
+				EnforcementLevel: string(taskResult.WorkspaceTaskEnforcementLevel),
+				Message:          taskResult.Message,
+				URL:              taskResult.URL,
+			})
+		}
+		if s.capabilities.Supports(PolicyEvaluations) {
+			evaluations, pErr := listAll(func(opts tfe.ListOptions) ([]*tfe.PolicyEvaluation, *tfe.Pagination, error) {
+				page, err := s.tfe.PolicyEvaluations.List(ctx, task.ID, &tfe.PolicyEvaluationListOptions{ListOptions: opts})
+				if err != nil {
+					return nil, nil, err
+				}
+				return page.Items, page.Pagination, nil
+			})
+			if pErr != nil {
+				return nil, fmt.Errorf("error reading results for policy evaluations: %s", pErr.Error())
+			}
+			outcomes, oErr := s.listPolicySetOutcomes(ctx, evaluations)
+			if oErr != nil {
+				return nil, fmt.Errorf("error reading results for policy set outcomes: %s", oErr.Error())
+			}
+			for i, p := range evaluations {
+				evaluation := &PolicyEvaluationDetails{
+					ID:                 p.ID,
+					Status:             string(p.Status),
+					PolicyKind:         string(p.PolicyKind),
+					PolicyResultCounts: newPolicyResultCounts(p.ResultCount),
+				}
+				for _, o := range outcomes[i] {
+					set := &PolicySetOutcomeDetails{
+						Name:               o.PolicySetName,
+						PolicyResultCounts: newPolicyResultCounts(&o.ResultCount),
+					}
+					for _, policy := range o.Outcomes {
+						set.Policies = append(set.Policies, &PolicyOutcome{
+							Name:             policy.PolicyName,
+							Description:      policy.Description,
+							EnforcementLevel: string(policy.EnforcementLevel),
+							Status:           policy.Status,
+						})
+					}
+					evaluation.PolicySets = append(evaluation.PolicySets, set)
+				}
+				details.PolicyEvaluations = append(details.PolicyEvaluations, evaluation)
+			}
+		}
+		stages = append(stages, details)
+	}
+	return stages, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_ReadTaskStages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mTaskStages := mocks.NewMockTaskStages(ctrl)
+	mTaskResults := mocks.NewMockTaskResults(ctrl)
+	mPolicyEvaluations := mocks.NewMockPolicyEvaluations(ctrl)
+	mPolicySetOutcomes := mocks.NewMockPolicySetOutcomes(ctrl)
+
+	mTaskStages.EXPECT().List(ctx, "run-1", &tfe.TaskStageListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+	}).Return(&tfe.TaskStageList{
+		Items: []*tfe.TaskStage{
+			{ID: "ts-1", Stage: tfe.PrePlan, Status: tfe.TaskStagePassed},
+			{ID: "ts-2", Stage: tfe.PostPlan, Status: tfe.TaskStageFailed, TaskResults: []*tfe.TaskResult{{ID: "taskrs-1"}}},
+		},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+	mTaskResults.EXPECT().Read(ctx, "taskrs-1").Return(&tfe.TaskResult{
+		ID:                            "taskrs-1",
+		TaskName:                      "security-scanner",
+		Status:                        tfe.TaskFailed,
+		WorkspaceTaskEnforcementLevel: tfe.Mandatory,
+		Message:                       "2 critical findings",
+	}, nil)
+	mPolicyEvaluations.EXPECT().List(ctx, "ts-2", &tfe.PolicyEvaluationListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+	}).Return(&tfe.PolicyEvaluationList{
+		Items: []*tfe.PolicyEvaluation{
+			{ID: "poleval-1", Status: tfe.PolicyEvaluationPassed, PolicyKind: tfe.OPA, ResultCount: &tfe.PolicyResultCount{Passed: 3}},
+		},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+	mPolicySetOutcomes.EXPECT().List(ctx, "poleval-1", gomock.Any()).Return(&tfe.PolicySetOutcomeList{
+		Items: []*tfe.PolicySetOutcome{{
+			PolicySetName: "baseline",
+			ResultCount:   tfe.PolicyResultCount{Passed: 3},
+			Outcomes:      []tfe.Outcome{{PolicyName: "require-tags", Description: "Resources must be tagged", EnforcementLevel: tfe.EnforcementMandatory, Status: "passed"}},
+		}},
+		Pagination: &tfe.Pagination{CurrentPage: 1},
+	}, nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{
+		TaskStages:        mTaskStages,
+		TaskResults:       mTaskResults,
+		PolicyEvaluations: mPolicyEvaluations,
+		PolicySetOutcomes: mPolicySetOutcomes,
+	}, writer: &defaultWriter{}}}
+
+	stages, err := service.ReadTaskStages(ctx, "run-1", tfe.PostPlan)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(stages) != 1 || stages[0].ID != "ts-2" || stages[0].Status != "failed" {
+		t.Fatalf("expected only the post_plan task stage but received %+v", stages)
+	}
+	result := stages[0].TaskResults[0]
+	if result.TaskName != "security-scanner" || result.Status != "failed" || result.EnforcementLevel != "mandatory" {
+		t.Errorf("unexpected task result %+v", result)
+	}
+	evaluation := stages[0].PolicyEvaluations[0]
+	if evaluation.Passed != 3 || len(evaluation.PolicySets) != 1 || evaluation.PolicySets[0].Name != "baseline" {
+		t.Errorf("unexpected policy evaluation %+v", evaluation)
+	}
+	if policies := evaluation.PolicySets[0].Policies; len(policies) != 1 || policies[0].Name != "require-tags" || policies[0].Description != "Resources must be tagged" {
+		t.Errorf("unexpected policies %+v", policies)
+	}
+}
+
+func TestRunService_ReadTaskStages_Unsupported(t *testing.T) {
+	service := &runService{&cloudMeta{
+		tfe:          &tfe.Client{},
+		writer:       &defaultWriter{},
+		capabilities: &Capabilities{TFEVersion: "v202201-1"},
+	}}
+	if _, err := service.ReadTaskStages(context.Background(), "run-1", ""); err == nil {
+		t.Fatal("expected error reading task stages from a release without task stages")
+	}
+}
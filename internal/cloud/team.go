@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type TeamDetails struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"`
+	UserCount  int    `json:"user_count"`
+	SSOTeamID  string `json:"sso_team_id,omitempty"`
+}
+
+func newTeamDetails(team *tfe.Team) *TeamDetails {
+	return &TeamDetails{
+		ID:         team.ID,
+		Name:       team.Name,
+		Visibility: team.Visibility,
+		UserCount:  team.UserCount,
+		SSOTeamID:  team.SSOTeamID,
+	}
+}
+
+type TeamAccessOptions struct {
+	Organization string
+	Team         string
+	Workspace    string
+	Access       tfe.AccessType
+}
+
+type TeamService interface {
+	ListTeams(ctx context.Context, organization string) ([]*TeamDetails, error)
+	CreateTeam(ctx context.Context, organization, name, visibility string) (*TeamDetails, error)
+	AddTeamAccess(ctx context.Context, options TeamAccessOptions) (*tfe.TeamAccess, error)
+	RegenerateTeamToken(ctx context.Context, organization, team string) (*tfe.TeamToken, error)
+}
+
+type teamService struct {
+	*cloudMeta
+}
+
+func (s *teamService) ListTeams(ctx context.Context, organization string) ([]*TeamDetails, error) {
+	teams, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Team, *tfe.Pagination, error) {
+		list, err := s.tfe.Teams.List(ctx, organization, &tfe.TeamListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing teams of organization: %q error: %s", organization, err)
+		return nil, err
+	}
+	details := make([]*TeamDetails, 0, len(teams))
+	for _, team := range teams {
+		details = append(details, newTeamDetails(team))
+	}
+	return details, nil
+}
+
+func (s *teamService) CreateTeam(ctx context.Context, organization, name, visibility string) (*TeamDetails, error) {
+	options := tfe.TeamCreateOptions{Name: tfe.String(name)}
+	if visibility != "" {
+		options.Visibility = tfe.String(visibility)
+	}
+	team, err := s.tfe.Teams.Create(ctx, organization, options)
+	if err != nil {
+		log.Printf("[ERROR] error creating team: %q organization: %q error: %s", name, organization, err)
+		return nil, err
+	}
+	return newTeamDetails(team), nil
+}
+
+// grants a team access to a workspace, updating the access level when the team already has access
+// so onboarding pipelines can be rerun
+func (s *teamService) AddTeamAccess(ctx context.Context, options TeamAccessOptions) (*tfe.TeamAccess, error) {
+	team, err := s.readTeam(ctx, options.Organization, options.Team)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.readWorkspace(ctx, options.Organization, options.Workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q error: %s", options.Workspace, options.Organization, err)
+		return nil, err
+	}
+
+	accesses, err := listAll(func(opts tfe.ListOptions) ([]*tfe.TeamAccess, *tfe.Pagination, error) {
+		list, err := s.tfe.TeamAccess.List(ctx, &tfe.TeamAccessListOptions{ListOptions: opts, WorkspaceID: workspace.ID})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing team access of workspace: %q error: %s", workspace.ID, err)
+		return nil, err
+	}
+	for _, access := range accesses {
+		if access.Team == nil || access.Team.ID != team.ID {
+			continue
+		}
+		if access.Access == options.Access {
+			return access, nil
+		}
+		updated, err := s.tfe.TeamAccess.Update(ctx, access.ID, tfe.TeamAccessUpdateOptions{Access: tfe.Access(options.Access)})
+		if err != nil {
+			log.Printf("[ERROR] error updating team access: %q error: %s", access.ID, err)
+			return nil, err
+		}
+		return updated, nil
+	}
+
+	access, err := s.tfe.TeamAccess.Add(ctx, tfe.TeamAccessAddOptions{
+		Access:    tfe.Access(options.Access),
+		Team:      team,
+		Workspace: workspace,
+	})
+	if err != nil {
+		log.Printf("[ERROR] error adding team: %q access to workspace: %q error: %s", options.Team, options.Workspace, err)
+		return nil, err
+	}
+	return access, nil
+}
+
+// creates a new team token, the previous token of the team stops working
+func (s *teamService) RegenerateTeamToken(ctx context.Context, organization, teamName string) (*tfe.TeamToken, error) {
+	team, err := s.readTeam(ctx, organization, teamName)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.tfe.TeamTokens.Create(ctx, team.ID)
+	if err != nil {
+		log.Printf("[ERROR] error regenerating token of team: %q error: %s", teamName, err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *teamService) readTeam(ctx context.Context, organization, name string) (*tfe.Team, error) {
+	list, err := s.tfe.Teams.List(ctx, organization, &tfe.TeamListOptions{Names: []string{name}})
+	if err != nil {
+		log.Printf("[ERROR] error reading team: %q organization: %q error: %s", name, organization, err)
+		return nil, err
+	}
+	for _, team := range list.Items {
+		if team.Name == name {
+			return team, nil
+		}
+	}
+	return nil, fmt.Errorf("team %q in organization %q: %w", name, organization, tfe.ErrResourceNotFound)
+}
+
+func NewTeamService(meta *cloudMeta) *teamService {
+	return &teamService{meta}
+}
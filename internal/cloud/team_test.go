@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTeamService_AddTeamAccess(t *testing.T) {
+	ctx := context.Background()
+	team := &tfe.Team{ID: "team-1", Name: "platform"}
+	workspace := &tfe.Workspace{ID: "ws-1", Name: "networking"}
+	options := TeamAccessOptions{Organization: "abc-company", Team: "platform", Workspace: "networking", Access: tfe.AccessWrite}
+
+	cases := map[string]struct {
+		existing []*tfe.TeamAccess
+		expect   func(*mocks.MockTeamAccesses)
+		access   tfe.AccessType
+	}{
+		"adds missing access": {
+			expect: func(m *mocks.MockTeamAccesses) {
+				m.EXPECT().Add(ctx, tfe.TeamAccessAddOptions{Access: tfe.Access(tfe.AccessWrite), Team: team, Workspace: workspace}).Return(&tfe.TeamAccess{ID: "tws-1", Access: tfe.AccessWrite}, nil)
+			},
+			access: tfe.AccessWrite,
+		},
+		"updates existing access": {
+			existing: []*tfe.TeamAccess{{ID: "tws-1", Access: tfe.AccessRead, Team: team}},
+			expect: func(m *mocks.MockTeamAccesses) {
+				m.EXPECT().Update(ctx, "tws-1", tfe.TeamAccessUpdateOptions{Access: tfe.Access(tfe.AccessWrite)}).Return(&tfe.TeamAccess{ID: "tws-1", Access: tfe.AccessWrite}, nil)
+			},
+			access: tfe.AccessWrite,
+		},
+		"keeps matching access": {
+			existing: []*tfe.TeamAccess{{ID: "tws-1", Access: tfe.AccessWrite, Team: team}},
+			expect:   func(*mocks.MockTeamAccesses) {},
+			access:   tfe.AccessWrite,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mTeams := mocks.NewMockTeams(ctrl)
+			mTeams.EXPECT().List(ctx, "abc-company", &tfe.TeamListOptions{Names: []string{"platform"}}).Return(&tfe.TeamList{Items: []*tfe.Team{team}}, nil)
+			mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+			mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(workspace, nil)
+			mAccess := mocks.NewMockTeamAccesses(ctrl)
+			mAccess.EXPECT().List(ctx, &tfe.TeamAccessListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, WorkspaceID: "ws-1"}).Return(&tfe.TeamAccessList{Items: tc.existing}, nil)
+			tc.expect(mAccess)
+
+			service := NewTeamService(&cloudMeta{tfe: &tfe.Client{Teams: mTeams, Workspaces: mWorkspaces, TeamAccess: mAccess}, writer: &defaultWriter{}})
+			access, err := service.AddTeamAccess(ctx, options)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if access.ID != "tws-1" || access.Access != tc.access {
+				t.Errorf("unexpected team access %v", access)
+			}
+		})
+	}
+}
+
+func TestTeamService_RegenerateTeamToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mTeams := mocks.NewMockTeams(ctrl)
+	mTeams.EXPECT().List(ctx, "abc-company", &tfe.TeamListOptions{Names: []string{"platform"}}).Return(&tfe.TeamList{Items: []*tfe.Team{{ID: "team-1", Name: "platform"}}}, nil)
+	mTeams.EXPECT().List(ctx, "abc-company", &tfe.TeamListOptions{Names: []string{"missing"}}).Return(&tfe.TeamList{}, nil)
+	mTokens := mocks.NewMockTeamTokens(ctrl)
+	mTokens.EXPECT().Create(ctx, "team-1").Return(&tfe.TeamToken{ID: "at-1", Token: "secret"}, nil)
+
+	service := NewTeamService(&cloudMeta{tfe: &tfe.Client{Teams: mTeams, TeamTokens: mTokens}, writer: &defaultWriter{}})
+	token, err := service.RegenerateTeamToken(ctx, "abc-company", "platform")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.Token != "secret" {
+		t.Errorf("unexpected team token %v", token)
+	}
+
+	if _, err := service.RegenerateTeamToken(ctx, "abc-company", "missing"); !errors.Is(err, tfe.ErrResourceNotFound) {
+		t.Errorf("expected a not found error but received %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-tfe"
+)
+
+var ErrTerraformVersionUnavailable = errors.New("terraform version is not available")
+
+// HCP Terraform offers every Terraform release, listed by the HashiCorp releases API
+var releasesAPIURL = "https://api.releases.hashicorp.com/v1/releases/terraform"
+
+// the releases API returns at most 20 releases per page
+const releasesPageSize = 20
+
+// exact versions are validated, constraints such as "~> 1.9" and "latest" are validated by the API
+var exactVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?$`)
+
+type WorkspaceVersionChange struct {
+	Workspace       string `json:"workspace"`
+	PreviousVersion string `json:"previous_version"`
+	Version         string `json:"version"`
+	// the version is deprecated by the Terraform Enterprise site admins
+	Deprecated bool `json:"deprecated"`
+}
+
+type TerraformVersionService interface {
+	ListAvailableTerraformVersions(ctx context.Context, search string) ([]*TerraformVersionDetails, error)
+	SetWorkspaceTerraformVersion(ctx context.Context, organization, workspace, version string) (*WorkspaceVersionChange, error)
+}
+
+type terraformVersionService struct {
+	*cloudMeta
+	httpClient *http.Client
+}
+
+// lists the Terraform versions workspaces can select. Terraform Enterprise versions are read from the
+// admin API and require a site admin token.
+func (s *terraformVersionService) ListAvailableTerraformVersions(ctx context.Context, search string) ([]*TerraformVersionDetails, error) {
+	var versions []*TerraformVersionDetails
+	var err error
+	if s.capabilities != nil && s.capabilities.IsCloud {
+		versions, err = s.listReleases(ctx)
+	} else {
+		versions, err = s.listEnterpriseVersions(ctx, search)
+	}
+	if err != nil {
+		log.Printf("[ERROR] error listing terraform versions: %s", err)
+		return nil, err
+	}
+
+	available := []*TerraformVersionDetails{}
+	for _, v := range versions {
+		if v.Enabled && strings.Contains(v.Version, search) {
+			available = append(available, v)
+		}
+	}
+	return available, nil
+}
+
+func (s *terraformVersionService) listEnterpriseVersions(ctx context.Context, search string) ([]*TerraformVersionDetails, error) {
+	versions, err := listAll(func(opts tfe.ListOptions) ([]*tfe.AdminTerraformVersion, *tfe.Pagination, error) {
+		list, err := s.tfe.Admin.TerraformVersions.List(ctx, &tfe.AdminTerraformVersionsListOptions{ListOptions: opts, Search: search})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	details := make([]*TerraformVersionDetails, 0, len(versions))
+	for _, v := range versions {
+		details = append(details, newTerraformVersionDetails(v))
+	}
+	return details, nil
+}
+
+type release struct {
+	Version          string `json:"version"`
+	IsPrerelease     bool   `json:"is_prerelease"`
+	TimestampCreated string `json:"timestamp_created"`
+}
+
+// lists releases newest first, pages continue after the creation time of the last release
+func (s *terraformVersionService) listReleases(ctx context.Context) ([]*TerraformVersionDetails, error) {
+	details := []*TerraformVersionDetails{}
+	after := ""
+	for {
+		params := url.Values{"limit": {fmt.Sprintf("%d", releasesPageSize)}}
+		if after != "" {
+			params.Set("after", after)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesAPIURL+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		releases := []*release{}
+		err = json.NewDecoder(resp.Body).Decode(&releases)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected releases API response status: %d", resp.StatusCode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding releases: %w", err)
+		}
+
+		for _, r := range releases {
+			details = append(details, &TerraformVersionDetails{
+				Version:  r.Version,
+				Enabled:  true,
+				Official: true,
+				Beta:     r.IsPrerelease,
+			})
+		}
+		if len(releases) < releasesPageSize {
+			return details, nil
+		}
+		after = releases[len(releases)-1].TimestampCreated
+	}
+}
+
+// updates the Terraform version of the workspace, after validating an exact version is available
+func (s *terraformVersionService) SetWorkspaceTerraformVersion(ctx context.Context, organization, workspace, version string) (*WorkspaceVersionChange, error) {
+	w, err := s.readWorkspace(ctx, organization, workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", workspace, organization, err)
+		return nil, err
+	}
+	change := &WorkspaceVersionChange{Workspace: workspace, PreviousVersion: w.TerraformVersion, Version: version}
+
+	if exactVersionPattern.MatchString(version) {
+		available, err := s.findVersion(ctx, version)
+		switch {
+		case errors.Is(err, tfe.ErrUnauthorized) || errors.Is(err, tfe.ErrResourceNotFound):
+			// listing Terraform Enterprise versions requires a site admin token
+			log.Printf("[WARN] unable to list terraform versions, the version is validated when the workspace is updated: %s", err)
+		case err != nil:
+			return nil, err
+		case available == nil || !available.Enabled:
+			return nil, fmt.Errorf("%w: %s", ErrTerraformVersionUnavailable, version)
+		default:
+			change.Deprecated = available.Deprecated
+		}
+	}
+
+	if w.TerraformVersion == version {
+		return change, nil
+	}
+	if _, err := s.tfe.Workspaces.UpdateByID(ctx, w.ID, tfe.WorkspaceUpdateOptions{
+		TerraformVersion: tfe.String(version),
+	}); err != nil {
+		log.Printf("[ERROR] error updating terraform version of workspace: %q error: %s", w.ID, err)
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *terraformVersionService) findVersion(ctx context.Context, version string) (*TerraformVersionDetails, error) {
+	var versions []*TerraformVersionDetails
+	var err error
+	if s.capabilities != nil && s.capabilities.IsCloud {
+		versions, err = s.listReleases(ctx)
+	} else {
+		versions, err = s.listEnterpriseVersions(ctx, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func NewTerraformVersionService(meta *cloudMeta) *terraformVersionService {
+	return &terraformVersionService{cloudMeta: meta, httpClient: cleanhttp.DefaultPooledClient()}
+}
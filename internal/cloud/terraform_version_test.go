@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTerraformVersionService_ListReleases(t *testing.T) {
+	// two pages, the second continuing after the creation time of the last release of the first
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		releases := []*release{}
+		switch r.URL.Query().Get("after") {
+		case "":
+			for i := 0; i < releasesPageSize; i++ {
+				releases = append(releases, &release{Version: fmt.Sprintf("1.9.%d", releasesPageSize-i), TimestampCreated: fmt.Sprintf("t%d", i)})
+			}
+		case fmt.Sprintf("t%d", releasesPageSize-1):
+			releases = append(releases, &release{Version: "1.10.0-beta1", IsPrerelease: true}, &release{Version: "1.8.5"})
+		default:
+			t.Errorf("unexpected after: %q", r.URL.Query().Get("after"))
+		}
+		json.NewEncoder(w).Encode(releases)
+	}))
+	defer server.Close()
+	defer func(u string) { releasesAPIURL = u }(releasesAPIURL)
+	releasesAPIURL = server.URL
+
+	service := NewTerraformVersionService(&cloudMeta{
+		tfe:          &tfe.Client{},
+		writer:       &defaultWriter{},
+		capabilities: &Capabilities{IsCloud: true},
+	})
+	versions, err := service.ListAvailableTerraformVersions(context.Background(), "1.8")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(versions) != 1 || versions[0].Version != "1.8.5" {
+		t.Errorf("expected version 1.8.5, got %+v", versions)
+	}
+
+	all, err := service.ListAvailableTerraformVersions(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(all) != releasesPageSize+2 {
+		t.Errorf("expected %d versions, got %d", releasesPageSize+2, len(all))
+	}
+}
+
+func TestTerraformVersionService_SetWorkspaceTerraformVersion(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name           string
+		version        string
+		versions       []*tfe.AdminTerraformVersion
+		listErr        error
+		expectList     bool
+		expectUpdate   bool
+		expectedErr    error
+		expectedChange *WorkspaceVersionChange
+	}{
+		{
+			name:           "updates available version",
+			version:        "1.9.5",
+			versions:       []*tfe.AdminTerraformVersion{{ID: "tool-1", Version: "1.9.5", Enabled: true, Deprecated: true}},
+			expectList:     true,
+			expectUpdate:   true,
+			expectedChange: &WorkspaceVersionChange{Workspace: "networking", PreviousVersion: "1.5.7", Version: "1.9.5", Deprecated: true},
+		},
+		{
+			name:        "rejects disabled version",
+			version:     "1.9.5",
+			versions:    []*tfe.AdminTerraformVersion{{ID: "tool-1", Version: "1.9.5"}},
+			expectList:  true,
+			expectedErr: ErrTerraformVersionUnavailable,
+		},
+		{
+			name:        "rejects missing version",
+			version:     "1.99.0",
+			expectList:  true,
+			expectedErr: ErrTerraformVersionUnavailable,
+		},
+		{
+			name:           "updates when versions cannot be listed",
+			version:        "1.9.5",
+			listErr:        tfe.ErrResourceNotFound,
+			expectList:     true,
+			expectUpdate:   true,
+			expectedChange: &WorkspaceVersionChange{Workspace: "networking", PreviousVersion: "1.5.7", Version: "1.9.5"},
+		},
+		{
+			name:           "does not validate constraints",
+			version:        "~> 1.9.0",
+			expectUpdate:   true,
+			expectedChange: &WorkspaceVersionChange{Workspace: "networking", PreviousVersion: "1.5.7", Version: "~> 1.9.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+			mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1", TerraformVersion: "1.5.7"}, nil)
+			mVersions := mocks.NewMockAdminTerraformVersions(ctrl)
+			if tc.expectList {
+				var list *tfe.AdminTerraformVersionsList
+				if tc.listErr == nil {
+					list = &tfe.AdminTerraformVersionsList{Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1}, Items: tc.versions}
+				}
+				mVersions.EXPECT().List(ctx, &tfe.AdminTerraformVersionsListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, Search: tc.version}).Return(list, tc.listErr)
+			}
+			if tc.expectUpdate {
+				mWorkspaces.EXPECT().UpdateByID(ctx, "ws-1", tfe.WorkspaceUpdateOptions{TerraformVersion: tfe.String(tc.version)}).Return(&tfe.Workspace{ID: "ws-1"}, nil)
+			}
+
+			service := NewTerraformVersionService(&cloudMeta{
+				tfe:    &tfe.Client{Workspaces: mWorkspaces, Admin: tfe.Admin{TerraformVersions: mVersions}},
+				writer: &defaultWriter{},
+			})
+			change, err := service.SetWorkspaceTerraformVersion(ctx, "abc-company", "networking", tc.version)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if *change != *tc.expectedChange {
+				t.Errorf("expected change %+v, got %+v", tc.expectedChange, change)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package cloud
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -31,11 +32,54 @@ func getUserAgent(platform string) string {
 	return agent
 }
 
-func NewTfeClient(hostFlag string, tokenFlag string, platform string) (*tfe.Client, error) {
+type ClientOptions struct {
+	// --hostname flag value
+	Hostname string
+	// --token flag value
+	Token string
+	// CI platform, included in the user agent
+	Platform string
+	// when set, resolves the api token from a workload identity token
+	OIDC *OIDCOptions
+	// explicit proxy, in addition to standard proxy environment variables
+	ProxyURL string
+	// records rate limited responses
+	RateLimits *RateLimitTracker
+	// records the duration of each request, when set
+	Timings *APITimings
+	// serves responses from a fixtures directory instead of the HCP Terraform API, when set
+	MockFixtures string
+	// serves responses from a recorded session instead of the HCP Terraform API, when set
+	Replay string
+	// captures each request and response, when set
+	Recorder *Recorder
+	// populated with the resolved hostname and token, when set
+	Credentials *Credentials
+}
+
+// Credentials resolved by NewTfeClient, passed on to plugins
+type Credentials struct {
+	Hostname string
+	Token    string
+	// where the token was read from: option, environment, terraform-cli, oidc or offline
+	Source string
+}
+
+func NewTfeClient(options *ClientOptions) (*tfe.Client, error) {
+	if options.MockFixtures != "" && options.Replay != "" {
+		return nil, fmt.Errorf("--mock-server and --replay cannot be used together")
+	}
+	if options.MockFixtures != "" {
+		return newMockClient(options)
+	}
+	if options.Replay != "" {
+		return newReplayClient(options)
+	}
+
 	tfeConfig := tfe.DefaultConfig()
 
-	host := hostFlag
-	if hostFlag == "" {
+	host := options.Hostname
+	if host == "" {
 		hostEnv := os.Getenv("TF_HOSTNAME")
 		if hostEnv != "" {
 			host = hostEnv
@@ -46,30 +90,83 @@ func NewTfeClient(hostFlag string, tokenFlag string, platform string) (*tfe.Clie
 
 	log.Printf("[DEBUG] Initializing HCP Terraform client, host: %s", host)
 
-	token := tokenFlag
-	if tokenFlag == "" {
+	httpClient, err := newHTTPClient(options.ProxyURL, options.RateLimits, options.Timings)
+	if err != nil {
+		return nil, err
+	}
+	address := fmt.Sprintf("https://%s", host)
+	logProxy(httpClient, address)
+	if options.Recorder != nil {
+		httpClient.Transport = options.Recorder.transport(httpClient.Transport)
+	}
+	// outermost, the failed response is returned as an error and not seen by other transports
+	httpClient.Transport = newAPIErrorTransport(httpClient.Transport)
+
+	token := options.Token
+	tokenSource := "option"
+	if token == "" {
 		tokenEnv := os.Getenv("TF_API_TOKEN")
 		if tokenEnv != "" {
 			token = tokenEnv
+			tokenSource = "environment"
+		}
+	}
+
+	// fallback to credentials from `terraform login` or the Terraform CLI configuration
+	if token == "" && options.OIDC == nil {
+		cliToken, err := newCLICredentials().Token(host)
+		if err != nil {
+			log.Printf("[ERROR] error reading Terraform CLI credentials: %s", err)
+			return nil, err
 		}
+		token = cliToken
+		tokenSource = "terraform-cli"
 	}
 
-	tfeConfig.Headers.Set("User-Agent", getUserAgent(platform))
-	tfeConfig.Address = fmt.Sprintf("https://%s", host)
+	if options.OIDC != nil {
+		if options.OIDC.Audience == "" {
+			options.OIDC.Audience = host
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), oidcRequestTimeout)
+		defer cancel()
+		oidcClient, err := newOIDCClient(options.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		oidcToken, err := resolveOIDCToken(ctx, oidcClient, options.OIDC)
+		if err != nil {
+			return nil, err
+		}
+		token = oidcToken
+		tokenSource = "oidc"
+	}
+
+	tfeConfig.Headers.Set("User-Agent", getUserAgent(options.Platform))
+	tfeConfig.Address = address
 	tfeConfig.Token = token
+	tfeConfig.HTTPClient = httpClient
 
 	if tfeConfig.Token == "" {
 		return nil, fmt.Errorf("HCP Terraform API token is not set")
 	}
 
 	log.Printf("[DEBUG] token has been set")
+	// workload identity tokens are requested without the recording transport, but may still be echoed
+	if options.Recorder != nil {
+		options.Recorder.addCredential(token)
+	}
+	if options.Credentials != nil {
+		*options.Credentials = Credentials{Hostname: host, Token: token, Source: tokenSource}
+	}
 
 	client, err := tfe.NewClient(tfeConfig)
 	if err != nil {
+		log.Printf("[ERROR] error connecting to: %s, verify proxy settings if running behind an egress proxy", address)
 		return nil, err
 	}
 
-	client.RetryServerErrors(true)
+	// server errors are retried by the http client transport
+	client.RetryServerErrors(false)
 
 	log.Printf("[DEBUG] TFC/E Version: %s", client.RemoteAPIVersion())
 
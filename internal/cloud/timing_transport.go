@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// resource ids such as ws-abc123, and signed upload or log urls
+var timingIDPattern = regexp.MustCompile(`^[a-z]+-[A-Za-z0-9]{8,}$`)
+
+const maxTimingSegmentLength = 32
+
+// records the duration of requests to the HCP Terraform API, grouped by method and path
+type APITimings struct {
+	mu    sync.Mutex
+	calls map[string]*APICallTiming
+}
+
+type APICallTiming struct {
+	Method string
+	// path with resource ids replaced by :id
+	Path   string
+	Count  int
+	Errors int
+	Total  time.Duration
+	Max    time.Duration
+}
+
+func NewAPITimings() *APITimings {
+	return &APITimings{calls: map[string]*APICallTiming{}}
+}
+
+func (t *APITimings) record(method string, path string, duration time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := method + " " + path
+	call, ok := t.calls[key]
+	if !ok {
+		call = &APICallTiming{Method: method, Path: path}
+		t.calls[key] = call
+	}
+	call.Count++
+	call.Total += duration
+	if duration > call.Max {
+		call.Max = duration
+	}
+	if failed {
+		call.Errors++
+	}
+}
+
+// returns the recorded calls, slowest total duration first
+func (t *APITimings) Summary() []APICallTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summary := make([]APICallTiming, 0, len(t.calls))
+	for _, call := range t.calls {
+		summary = append(summary, *call)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Total != summary[j].Total {
+			return summary[i].Total > summary[j].Total
+		}
+		return summary[i].Method+summary[i].Path < summary[j].Method+summary[j].Path
+	})
+	return summary
+}
+
+func timingPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if timingIDPattern.MatchString(s) || len(s) > maxTimingSegmentLength {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// timingTransport records the duration of each request attempt
+type timingTransport struct {
+	base    http.RoundTripper
+	timings *APITimings
+}
+
+func (t *timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	failed := err != nil || resp.StatusCode >= http.StatusBadRequest
+	t.timings.record(req.Method, timingPath(req.URL.Path), time.Since(start), failed)
+	return resp, err
+}
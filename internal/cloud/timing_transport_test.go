@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTimingPath(t *testing.T) {
+	cases := map[string]string{
+		"/api/v2/runs/run-CZcmD7eagjhyX0vN/apply":                 "/api/v2/runs/:id/apply",
+		"/api/v2/organizations/abc-company/workspaces/core":       "/api/v2/organizations/abc-company/workspaces/core",
+		"/v1/object/dmF1bHQ6djE6bG9uZy1zaWduZWQtdXBsb2FkLXRva2Vu": "/v1/object/:id",
+	}
+	for path, expected := range cases {
+		if actual := timingPath(path); actual != expected {
+			t.Errorf("expected %s for %s but received %s", expected, path, actual)
+		}
+	}
+}
+
+func TestTimingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/runs/run-CZcmD7eagjhyX0vN" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	timings := NewAPITimings()
+	client := &http.Client{Transport: &timingTransport{base: http.DefaultTransport, timings: timings}}
+	for _, path := range []string{"/api/v2/runs/run-abcdefghijkl", "/api/v2/runs/run-CZcmD7eagjhyX0vN", "/api/v2/ping"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	summary := timings.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("expected requests to be grouped into 2 calls but received %d", len(summary))
+	}
+	for _, call := range summary {
+		switch call.Path {
+		case "/api/v2/runs/:id":
+			if call.Count != 2 || call.Errors != 1 {
+				t.Errorf("expected 2 requests with 1 error but received %d with %d errors", call.Count, call.Errors)
+			}
+		case "/api/v2/ping":
+			if call.Count != 1 || call.Errors != 0 {
+				t.Errorf("expected 1 request without errors but received %d with %d errors", call.Count, call.Errors)
+			}
+		default:
+			t.Errorf("unexpected call: %s %s", call.Method, call.Path)
+		}
+	}
+}
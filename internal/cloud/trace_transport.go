@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/tfci/internal/logging"
+)
+
+// larger bodies such as plan logs are not logged
+const maxTraceBodySize = 64 * 1024
+
+// traceTransport logs each request and response at TRACE level,
+// tokens and sensitive variable values are redacted from JSON bodies
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	log.Printf("[TRACE] http request: %s %s body: %s", req.Method, req.URL.Path, traceRequestBody(req))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	if err != nil {
+		log.Printf("[TRACE] http request failed: %s %s duration: %s error: %s", req.Method, req.URL.Path, duration, err)
+		return resp, err
+	}
+
+	log.Printf(
+		"[TRACE] http response: %s %s status: %d duration: %s request_id: %s body: %s",
+		req.Method, req.URL.Path, resp.StatusCode, duration, resp.Header.Get("X-Request-Id"), traceResponseBody(resp),
+	)
+	return resp, nil
+}
+
+func traceRequestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return "<none>"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "<unreadable>"
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return "<unreadable>"
+	}
+	return traceBody(req.Header.Get("Content-Type"), b)
+}
+
+func traceResponseBody(resp *http.Response) string {
+	if resp.Body == nil || !isJSONContent(resp.Header.Get("Content-Type")) {
+		return "<omitted>"
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	// restore the body for the caller
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return "<unreadable>"
+	}
+	return traceBody(resp.Header.Get("Content-Type"), b)
+}
+
+func traceBody(contentType string, b []byte) string {
+	switch {
+	case len(b) == 0:
+		return "<none>"
+	case !isJSONContent(contentType) || len(b) > maxTraceBodySize:
+		return "<omitted>"
+	default:
+		return string(logging.RedactJSON(b))
+	}
+}
+
+func isJSONContent(contentType string) bool {
+	return strings.Contains(contentType, "json")
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTraceTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"attributes":{"token":"secret-token"}}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(io.Discard)
+
+	client := &http.Client{Transport: &traceTransport{base: http.DefaultTransport}}
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/api/v2/runs", strings.NewReader(`{"data":{"attributes":{"variables":[{"key":"password","value":"hunter2"}]}}}`))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if !strings.Contains(string(body), "secret-token") {
+		t.Errorf("expected response body to be preserved but received %s", body)
+	}
+
+	output := logs.String()
+	for _, expected := range []string{"POST /api/v2/runs", "status: 201", "request_id: req-123", "[REDACTED]"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected logs to contain %q but received %s", expected, output)
+		}
+	}
+	for _, secret := range []string{"hunter2", "secret-token"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to be redacted from logs", secret)
+		}
+	}
+}
+
+func TestTraceTransport_OIDCToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/token" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(`{"value":"eyJhbGciOiJSUzI1NiJ9.github-id-token"}`))
+	}))
+	defer server.Close()
+	t.Setenv("TF_LOG", "TRACE")
+	t.Setenv(oidcTokenEnv, "")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", server.URL+"/token")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(io.Discard)
+
+	// requests of the HCP Terraform client are traced
+	apiClient, err := newHTTPClient("", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := apiClient.Get(server.URL + "/api/v2/ping")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	oidcClient, err := newOIDCClient("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := resolveOIDCToken(context.Background(), oidcClient, &OIDCOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "eyJhbGciOiJSUzI1NiJ9.github-id-token" {
+		t.Errorf("unexpected token: %q", token)
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "[TRACE] http response: GET /api/v2/ping") {
+		t.Errorf("expected requests to be traced but received %s", output)
+	}
+	if strings.Contains(output, "github-id-token") || strings.Contains(output, "GET /token") {
+		t.Errorf("expected the workload identity token request not to be traced but received %s", output)
+	}
+}
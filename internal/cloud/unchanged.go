@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// returned with the last applied run when a run is skipped by CreateRunOptions.SkipUnchanged
+var ErrRunUnchanged = errors.New("configuration and run variables are unchanged since the last applied run")
+
+// reads the most recent applied run of the workspace, nil when the workspace has none
+func (service *runService) lastAppliedRun(ctx context.Context, workspaceID string) (*tfe.Run, error) {
+	list, err := service.tfe.Runs.List(ctx, workspaceID, &tfe.RunListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 1},
+		Status:      string(tfe.RunApplied),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	return list.Items[0], nil
+}
+
+// reads the configuration version a new run would use, the latest of the workspace when not specified
+func (service *runService) nextConfigurationVersionID(ctx context.Context, workspaceID string, cvID string) (string, error) {
+	if cvID != "" {
+		return cvID, nil
+	}
+	list, err := service.tfe.ConfigurationVersions.List(ctx, workspaceID, &tfe.ConfigurationVersionListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 1},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(list.Items) == 0 {
+		return "", nil
+	}
+	return list.Items[0].ID, nil
+}
+
+func (service *runService) configurationVersionHash(ctx context.Context, cvID string) (string, error) {
+	archive, err := service.tfe.ConfigurationVersions.Download(ctx, cvID)
+	if err != nil {
+		return "", err
+	}
+	return hashSlugArchive(bytes.NewReader(archive))
+}
+
+// compares a new run to the last applied run of the workspace, returning the last applied run
+// when both use the same configuration and run variables. a run that cannot be compared is
+// treated as changed.
+func (service *runService) findUnchangedRun(ctx context.Context, workspaceID string, options CreateRunOptions) (*tfe.Run, error) {
+	if options.IsDestroy || len(options.TargetAddrs) > 0 {
+		log.Printf("[DEBUG] destroy and targeted runs are always created")
+		return nil, nil
+	}
+	last, err := service.lastAppliedRun(ctx, workspaceID)
+	if err != nil || last == nil || last.ConfigurationVersion == nil {
+		return nil, err
+	}
+	if !equalRunVariables(options.RunVariables, last.Variables) {
+		log.Printf("[DEBUG] run variables have changed since run: %q", last.ID)
+		return nil, nil
+	}
+
+	cvID, err := service.nextConfigurationVersionID(ctx, workspaceID, options.ConfigurationVersionID)
+	if err != nil || cvID == "" {
+		return nil, err
+	}
+	if cvID == last.ConfigurationVersion.ID {
+		return last, nil
+	}
+
+	lastHash, err := service.configurationVersionHash(ctx, last.ConfigurationVersion.ID)
+	if err != nil {
+		log.Printf("[WARN] unable to download configuration version: %q of run: %q error: %s", last.ConfigurationVersion.ID, last.ID, err)
+		return nil, nil
+	}
+	hash, err := service.configurationVersionHash(ctx, cvID)
+	if err != nil {
+		log.Printf("[WARN] unable to download configuration version: %q error: %s", cvID, err)
+		return nil, nil
+	}
+	log.Printf("[DEBUG] configuration version: %q hash: %s, last applied: %q hash: %s", cvID, hash, last.ConfigurationVersion.ID, lastHash)
+	if hash != lastHash {
+		return nil, nil
+	}
+	return last, nil
+}
+
+func equalRunVariables(vars []*tfe.RunVariable, applied []*tfe.RunVariableAttr) bool {
+	if len(vars) != len(applied) {
+		return false
+	}
+	values := make(map[string]string, len(applied))
+	for _, v := range applied {
+		values[v.Key] = v.Value
+	}
+	for _, v := range vars {
+		if value, ok := values[v.Key]; !ok || value != v.Value {
+			return false
+		}
+	}
+	return true
+}
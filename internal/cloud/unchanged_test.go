@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	slug "github.com/hashicorp/go-slug"
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func testSlugArchive(t *testing.T, content string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := slug.Pack(dir, &buf, true); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestEqualRunVariables(t *testing.T) {
+	applied := []*tfe.RunVariableAttr{{Key: "region", Value: `"us-east-1"`}, {Key: "size", Value: "2"}}
+	cases := []struct {
+		name     string
+		vars     []*tfe.RunVariable
+		expected bool
+	}{
+		{name: "same in any order", vars: []*tfe.RunVariable{{Key: "size", Value: "2"}, {Key: "region", Value: `"us-east-1"`}}, expected: true},
+		{name: "changed value", vars: []*tfe.RunVariable{{Key: "size", Value: "3"}, {Key: "region", Value: `"us-east-1"`}}},
+		{name: "removed", vars: []*tfe.RunVariable{{Key: "size", Value: "2"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := equalRunVariables(tc.vars, applied); actual != tc.expected {
+				t.Errorf("expected %t but received %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRunService_FindUnchangedRun(t *testing.T) {
+	ctx := context.Background()
+	lastApplied := &tfe.Run{
+		ID:                   "run-applied",
+		Status:               tfe.RunApplied,
+		ConfigurationVersion: &tfe.ConfigurationVersion{ID: "cv-applied"},
+		Variables:            []*tfe.RunVariableAttr{{Key: "size", Value: "2"}},
+	}
+	vars := []*tfe.RunVariable{{Key: "size", Value: "2"}}
+
+	cases := []struct {
+		name      string
+		options   CreateRunOptions
+		newConfig string
+		unchanged bool
+	}{
+		{name: "same configuration version", options: CreateRunOptions{ConfigurationVersionID: "cv-applied", RunVariables: vars}, unchanged: true},
+		{name: "same configuration", options: CreateRunOptions{ConfigurationVersionID: "cv-new", RunVariables: vars}, newConfig: `resource "null_resource" "a" {}`, unchanged: true},
+		{name: "changed configuration", options: CreateRunOptions{ConfigurationVersionID: "cv-new", RunVariables: vars}, newConfig: `resource "null_resource" "b" {}`},
+		{name: "changed variables", options: CreateRunOptions{ConfigurationVersionID: "cv-applied"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mRuns := mocks.NewMockRuns(ctrl)
+			mRuns.EXPECT().List(ctx, "ws-1", &tfe.RunListOptions{
+				ListOptions: tfe.ListOptions{PageSize: 1},
+				Status:      "applied",
+			}).Return(&tfe.RunList{Items: []*tfe.Run{lastApplied}}, nil)
+
+			mConfigVersions := mocks.NewMockConfigurationVersions(ctrl)
+			if tc.newConfig != "" {
+				mConfigVersions.EXPECT().Download(ctx, "cv-applied").Return(testSlugArchive(t, `resource "null_resource" "a" {}`), nil)
+				mConfigVersions.EXPECT().Download(ctx, "cv-new").Return(testSlugArchive(t, tc.newConfig), nil)
+			}
+
+			service := &runService{&cloudMeta{tfe: &tfe.Client{Runs: mRuns, ConfigurationVersions: mConfigVersions}, writer: &defaultWriter{}}}
+			run, err := service.findUnchangedRun(ctx, "ws-1", tc.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.unchanged != (run != nil) {
+				t.Errorf("expected unchanged %t but received run %v", tc.unchanged, run)
+			}
+		})
+	}
+}
+
+func TestRunService_CreateRun_SkipUnchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+	mRuns := mocks.NewMockRuns(ctrl)
+	mRuns.EXPECT().List(ctx, "ws-1", gomock.Any()).Return(&tfe.RunList{Items: []*tfe.Run{{
+		ID:                   "run-applied",
+		Status:               tfe.RunApplied,
+		ConfigurationVersion: &tfe.ConfigurationVersion{ID: "cv-1"},
+	}}}, nil)
+
+	service := NewRunService(&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces, Runs: mRuns}, writer: &defaultWriter{}})
+	run, err := service.CreateRun(ctx, CreateRunOptions{
+		Organization:           "abc-company",
+		Workspace:              "networking",
+		ConfigurationVersionID: "cv-1",
+		SkipUnchanged:          true,
+	})
+	if !errors.Is(err, ErrRunUnchanged) {
+		t.Fatalf("expected unchanged error but received %v", err)
+	}
+	if run == nil || run.ID != "run-applied" {
+		t.Errorf("expected last applied run but received %v", run)
+	}
+}
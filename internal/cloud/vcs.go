@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// resolves the configuration version for a run from the connected repository of a VCS workspace.
+// runs from the branch the workspace tracks use no configuration version, HCP Terraform fetches
+// the latest commit instead. other refs use the configuration version HCP Terraform ingressed
+// for the commit, such as for a pull request.
+func (service *runService) vcsConfigurationVersion(ctx context.Context, w *tfe.Workspace, branch string, commitSHA string) (string, error) {
+	if w.VCSRepo == nil {
+		return "", fmt.Errorf("workspace %q is not connected to a VCS repository", w.Name)
+	}
+	if commitSHA == "" && branch == w.VCSRepo.Branch {
+		log.Printf("[DEBUG] creating run from the latest commit of tracked branch: %q", branch)
+		return "", nil
+	}
+
+	list, err := service.tfe.ConfigurationVersions.List(ctx, w.ID, &tfe.ConfigurationVersionListOptions{
+		ListOptions: tfe.ListOptions{PageSize: pageSize},
+		Include:     []tfe.ConfigVerIncludeOpt{tfe.ConfigVerIngressAttributes},
+	})
+	if err != nil {
+		return "", err
+	}
+	// most recent first
+	for _, cv := range list.Items {
+		ingress := cv.IngressAttributes
+		if ingress == nil {
+			continue
+		}
+		if branch != "" && ingress.Branch != branch {
+			continue
+		}
+		if commitSHA != "" && !strings.HasPrefix(ingress.CommitSHA, commitSHA) {
+			continue
+		}
+		if cv.Status != tfe.ConfigurationUploaded {
+			log.Printf("[DEBUG] skipping configuration version: %q for commit: %q with status: %q", cv.ID, ingress.CommitSHA, cv.Status)
+			continue
+		}
+		service.writer.Output(fmt.Sprintf("Using Configuration Version ID: %q from %s at commit: %s", cv.ID, w.VCSRepo.DisplayIdentifier, ingress.CommitSHA))
+		return cv.ID, nil
+	}
+
+	ref := commitSHA
+	if ref == "" {
+		ref = fmt.Sprintf("branch %s", branch)
+	}
+	return "", fmt.Errorf("no configuration version was found for %s of %s, configuration versions are created when HCP Terraform receives the commit from the VCS provider", ref, w.VCSRepo.DisplayIdentifier)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_VCSConfigurationVersion(t *testing.T) {
+	workspace := &tfe.Workspace{
+		ID:      "ws-1",
+		Name:    "networking",
+		VCSRepo: &tfe.VCSRepo{Branch: "main", DisplayIdentifier: "abc-company/networking"},
+	}
+	versions := []*tfe.ConfigurationVersion{
+		{ID: "cv-3", Status: tfe.ConfigurationPending, IngressAttributes: &tfe.IngressAttributes{Branch: "feature", CommitSHA: "ccc333"}},
+		{ID: "cv-2", Status: tfe.ConfigurationUploaded, IngressAttributes: &tfe.IngressAttributes{Branch: "feature", CommitSHA: "bbb222"}},
+		{ID: "cv-1", Status: tfe.ConfigurationUploaded, IngressAttributes: &tfe.IngressAttributes{Branch: "main", CommitSHA: "aaa111"}},
+	}
+
+	cases := []struct {
+		name      string
+		workspace *tfe.Workspace
+		branch    string
+		commitSHA string
+		list      bool
+		expected  string
+		err       string
+	}{
+		{name: "tracked branch", workspace: workspace, branch: "main"},
+		{name: "other branch", workspace: workspace, branch: "feature", list: true, expected: "cv-2"},
+		{name: "short commit", workspace: workspace, commitSHA: "aaa1", list: true, expected: "cv-1"},
+		{name: "commit on branch", workspace: workspace, branch: "main", commitSHA: "bbb222", list: true, err: "no configuration version was found for bbb222"},
+		{name: "not vcs", workspace: &tfe.Workspace{ID: "ws-1", Name: "networking"}, branch: "main", err: `workspace "networking" is not connected to a VCS repository`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+			mConfigVersions := mocks.NewMockConfigurationVersions(ctrl)
+			if tc.list {
+				mConfigVersions.EXPECT().List(ctx, "ws-1", &tfe.ConfigurationVersionListOptions{
+					ListOptions: tfe.ListOptions{PageSize: 100},
+					Include:     []tfe.ConfigVerIncludeOpt{tfe.ConfigVerIngressAttributes},
+				}).Return(&tfe.ConfigurationVersionList{Items: versions}, nil)
+			}
+
+			service := &runService{&cloudMeta{tfe: &tfe.Client{ConfigurationVersions: mConfigVersions}, writer: &defaultWriter{}}}
+			cvID, err := service.vcsConfigurationVersion(ctx, tc.workspace, tc.branch, tc.commitSHA)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error %q but received %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if cvID != tc.expected {
+				t.Errorf("expected configuration version %q but received %q", tc.expected, cvID)
+			}
+		})
+	}
+}
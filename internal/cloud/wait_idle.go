@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/sethvargo/go-retry"
+)
+
+// polls the workspace until it is unlocked and its current run has finished, returning the idle workspace
+func (service *runService) waitForIdleWorkspace(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	var w *tfe.Workspace
+	waiting := false
+	err := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
+		var err error
+		w, err = service.tfe.Workspaces.ReadByIDWithOptions(ctx, workspaceID, &tfe.WorkspaceReadOptions{
+			Include: []tfe.WSIncludeOpt{tfe.WSCurrentRun},
+		})
+		if err != nil {
+			return err
+		}
+
+		busy := w.CurrentRun != nil && !slices.Contains(terminalRunStatuses, w.CurrentRun.Status)
+		if !busy && !w.Locked {
+			return nil
+		}
+		if !waiting {
+			waiting = true
+			if busy {
+				service.writer.Output(fmt.Sprintf("Waiting for current Run ID: %q with status: %q to finish", w.CurrentRun.ID, w.CurrentRun.Status))
+			} else {
+				service.writer.Output("Waiting for workspace to be unlocked")
+			}
+		}
+		log.Printf("[DEBUG] workspace: %q locked: %t, waiting for it to be idle", workspaceID, w.Locked)
+		return retryableTimeoutError("wait for workspace idle")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
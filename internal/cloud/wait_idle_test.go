@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_WaitForIdleWorkspace(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{PollInterval: time.Millisecond})
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	readOptions := &tfe.WorkspaceReadOptions{Include: []tfe.WSIncludeOpt{tfe.WSCurrentRun}}
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	gomock.InOrder(
+		mWorkspaces.EXPECT().ReadByIDWithOptions(ctx, "ws-1", readOptions).Return(&tfe.Workspace{
+			ID: "ws-1", Locked: true, CurrentRun: &tfe.Run{ID: "run-1", Status: tfe.RunApplying},
+		}, nil),
+		mWorkspaces.EXPECT().ReadByIDWithOptions(ctx, "ws-1", readOptions).Return(&tfe.Workspace{
+			ID: "ws-1", Locked: true, CurrentRun: &tfe.Run{ID: "run-1", Status: tfe.RunApplied},
+		}, nil),
+		mWorkspaces.EXPECT().ReadByIDWithOptions(ctx, "ws-1", readOptions).Return(&tfe.Workspace{
+			ID: "ws-1", CurrentRun: &tfe.Run{ID: "run-1", Status: tfe.RunApplied},
+		}, nil),
+	)
+
+	writer := &testLogWriter{}
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces}, writer: writer}}
+	w, err := service.waitForIdleWorkspace(ctx, "ws-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if w.Locked {
+		t.Errorf("expected workspace to be unlocked")
+	}
+	if len(writer.lines) != 1 || writer.lines[0] != `Waiting for current Run ID: "run-1" with status: "applying" to finish` {
+		t.Errorf("expected a single waiting message but received %v", writer.lines)
+	}
+}
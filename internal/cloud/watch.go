@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/sethvargo/go-retry"
+)
+
+// actions that can be taken on a watched run
+const (
+	RunActionApply   = "apply"
+	RunActionDiscard = "discard"
+	RunActionCancel  = "cancel"
+)
+
+// polls a run until it has finished, calling update with each status read.
+// unlike monitoring a created run, no output is written, so callers can present the run themselves.
+func (service *runService) WatchRun(ctx context.Context, runID string, update func(*tfe.Run)) (*tfe.Run, error) {
+	var run *tfe.Run
+	err := retry.Do(ctx, defaultBackoff(), func(ctx context.Context) error {
+		r, err := service.tfe.Runs.ReadWithOptions(ctx, runID, &tfe.RunReadOptions{
+			Include: []tfe.RunIncludeOpt{tfe.RunPlan, tfe.RunApply},
+		})
+		if err != nil {
+			return err
+		}
+		run = r
+		update(run)
+		if slices.Contains(terminalRunStatuses, run.Status) {
+			return nil
+		}
+		return retryableTimeoutError("watch run")
+	})
+	return run, err
+}
+
+// opens the logs of a run, the plan logs followed by the apply logs once the plan has finished.
+// the apply logs are read until the apply has finished, or is unreachable.
+func (service *runService) RunLogs(ctx context.Context, run *tfe.Run) (io.Reader, error) {
+	if run.Plan == nil || run.Plan.ID == "" {
+		return nil, fmt.Errorf("run %s has no plan", run.ID)
+	}
+	plan, err := service.tfe.Plans.Logs(ctx, run.Plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Apply == nil || run.Apply.ID == "" {
+		return plan, nil
+	}
+	apply := &lazyReader{open: func() (io.Reader, error) {
+		return service.tfe.Applies.Logs(ctx, run.Apply.ID)
+	}}
+	return io.MultiReader(plan, apply), nil
+}
+
+// takes an action on a run without waiting for it to complete
+func (service *runService) RunAction(ctx context.Context, runID string, action string, comment string) error {
+	var err error
+	switch action {
+	case RunActionApply:
+		err = service.tfe.Runs.Apply(ctx, runID, tfe.RunApplyOptions{Comment: tfe.String(comment)})
+	case RunActionDiscard:
+		err = service.tfe.Runs.Discard(ctx, runID, tfe.RunDiscardOptions{Comment: tfe.String(comment)})
+	case RunActionCancel:
+		err = service.tfe.Runs.Cancel(ctx, runID, tfe.RunCancelOptions{Comment: tfe.String(comment)})
+	default:
+		return fmt.Errorf("unsupported run action %q", action)
+	}
+	if err != nil {
+		log.Printf("[ERROR] error taking action: %q on run: %q error: %s", action, runID, err)
+	}
+	return err
+}
+
+// opens the underlying reader on the first read
+type lazyReader struct {
+	open   func() (io.Reader, error)
+	reader io.Reader
+}
+
+func (l *lazyReader) Read(p []byte) (int, error) {
+	if l.reader == nil {
+		r, err := l.open()
+		if err != nil {
+			return 0, err
+		}
+		l.reader = r
+	}
+	return l.reader.Read(p)
+}
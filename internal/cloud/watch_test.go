@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_WatchRun(t *testing.T) {
+	testLogRetryOptions(t, &RetryOptions{PollInterval: time.Millisecond})
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mRuns := mocks.NewMockRuns(ctrl)
+	gomock.InOrder(
+		mRuns.EXPECT().ReadWithOptions(ctx, "run-1", gomock.Any()).Return(&tfe.Run{ID: "run-1", Status: tfe.RunPlanning}, nil),
+		mRuns.EXPECT().ReadWithOptions(ctx, "run-1", gomock.Any()).Return(&tfe.Run{ID: "run-1", Status: tfe.RunPlanned}, nil),
+		mRuns.EXPECT().ReadWithOptions(ctx, "run-1", gomock.Any()).Return(&tfe.Run{ID: "run-1", Status: tfe.RunApplied}, nil),
+	)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Runs: mRuns}, writer: &defaultWriter{}}}
+	statuses := []string{}
+	run, err := service.WatchRun(ctx, "run-1", func(r *tfe.Run) {
+		statuses = append(statuses, string(r.Status))
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if run.Status != tfe.RunApplied {
+		t.Errorf("expected applied run but received %s", run.Status)
+	}
+	if strings.Join(statuses, ",") != "planning,planned,applied" {
+		t.Errorf("expected each status to be reported but received %v", statuses)
+	}
+}
+
+func TestRunService_RunAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mRuns := mocks.NewMockRuns(ctrl)
+	mRuns.EXPECT().Apply(ctx, "run-1", tfe.RunApplyOptions{Comment: tfe.String("lgtm")}).Return(nil)
+	mRuns.EXPECT().Discard(ctx, "run-1", tfe.RunDiscardOptions{Comment: tfe.String("lgtm")}).Return(nil)
+	mRuns.EXPECT().Cancel(ctx, "run-1", tfe.RunCancelOptions{Comment: tfe.String("lgtm")}).Return(nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Runs: mRuns}, writer: &defaultWriter{}}}
+	for _, action := range []string{RunActionApply, RunActionDiscard, RunActionCancel} {
+		if err := service.RunAction(ctx, "run-1", action, "lgtm"); err != nil {
+			t.Errorf("unexpected error for %s: %s", action, err)
+		}
+	}
+	if err := service.RunAction(ctx, "run-1", "approve", ""); err == nil {
+		t.Errorf("expected unsupported action error")
+	}
+}
+
+func TestRunService_RunLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mPlans := mocks.NewMockPlans(ctrl)
+	mPlans.EXPECT().Logs(ctx, "plan-1").Return(strings.NewReader("Plan: 1 to add\n"), nil)
+	mApplies := mocks.NewMockApplies(ctrl)
+	mApplies.EXPECT().Logs(ctx, "apply-1").Return(strings.NewReader("Apply complete!\n"), nil)
+
+	service := &runService{&cloudMeta{tfe: &tfe.Client{Plans: mPlans, Applies: mApplies}, writer: &defaultWriter{}}}
+	logs, err := service.RunLogs(ctx, &tfe.Run{ID: "run-1", Plan: &tfe.Plan{ID: "plan-1"}, Apply: &tfe.Apply{ID: "apply-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	out, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(out) != "Plan: 1 to add\nApply complete!\n" {
+		t.Errorf("expected plan then apply logs but received %q", out)
+	}
+}
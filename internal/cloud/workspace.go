@@ -5,7 +5,10 @@ package cloud
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-tfe"
@@ -14,25 +17,32 @@ import (
 
 type WorkspaceService interface {
 	ReadStateOutputs(context.Context, string, string) (*tfe.StateVersionOutputsList, error)
+	WaitForStateOutputs(context.Context, string, string, []string, time.Duration) (*tfe.StateVersionOutputsList, error)
+	ListWorkspaceNames(context.Context, string, []string) ([]string, error)
 }
 
 type workspaceService struct {
 	*cloudMeta
 }
 
-// wait 5 minutes for current state version finish processing
+// wait 5 minutes for current state version finish processing by default
 // primarily to prevent edge case of reading workspace outputs immediately after an apply run
 const StateVersionOutputMaxDuration = 5 * time.Minute
 
+// maximum time to wait for the current state version to be processed, very large states take longer
+func stateProcessingTimeout() time.Duration {
+	if retryOptions.StateProcessingTimeout > 0 {
+		return retryOptions.StateProcessingTimeout
+	}
+	return StateVersionOutputMaxDuration
+}
+
 func wServiceBackoff() retry.Backoff {
-	backoff := retry.NewFibonacci(2 * time.Second)
-	backoff = retry.WithCappedDuration(7*time.Second, backoff)
-	backoff = retry.WithMaxDuration(StateVersionOutputMaxDuration, backoff)
-	return backoff
+	return pollBackoff(stateProcessingTimeout())
 }
 
 func (s *workspaceService) ReadStateOutputs(ctx context.Context, orgName string, wName string) (*tfe.StateVersionOutputsList, error) {
-	w, wErr := s.tfe.Workspaces.Read(ctx, orgName, wName)
+	w, wErr := s.readWorkspace(ctx, orgName, wName)
 	if wErr != nil {
 		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", wName, orgName, wErr)
 		return nil, wErr
@@ -66,7 +76,7 @@ func (s *workspaceService) ReadStateOutputs(ctx context.Context, orgName string,
 		}
 	}
 
-	svoList, svoErr := s.tfe.StateVersionOutputs.ReadCurrent(ctx, w.ID)
+	svoList, svoErr := s.listStateOutputs(ctx, currentSV.ID)
 	if svoErr != nil {
 		log.Printf("[ERROR] error reading state version output list: %s", svoErr)
 		return nil, svoErr
@@ -75,6 +85,108 @@ func (s *workspaceService) ReadStateOutputs(ctx context.Context, orgName string,
 	return svoList, svoErr
 }
 
+// reads every page of the state version's outputs, workspaces can have hundreds of outputs
+func (s *workspaceService) listStateOutputs(ctx context.Context, svID string) (*tfe.StateVersionOutputsList, error) {
+	items, err := listAll(func(opts tfe.ListOptions) ([]*tfe.StateVersionOutput, *tfe.Pagination, error) {
+		page, err := s.tfe.StateVersions.ListOutputs(ctx, svID, &tfe.StateVersionOutputsListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tfe.StateVersionOutputsList{
+		Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1, TotalCount: len(items)},
+		Items:      items,
+	}, nil
+}
+
+// polls the current state version until all of the named outputs exist, bounded by the timeout.
+// covers consumers that start before the producing run has written its outputs
+func (s *workspaceService) WaitForStateOutputs(ctx context.Context, orgName string, wName string, names []string, timeout time.Duration) (*tfe.StateVersionOutputsList, error) {
+	w, wErr := s.readWorkspace(ctx, orgName, wName)
+	if wErr != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", wName, orgName, wErr)
+		return nil, wErr
+	}
+
+	var svoList *tfe.StateVersionOutputsList
+	missing := names
+	waiting := false
+	retryErr := retry.Do(ctx, pollBackoff(timeout), func(ctx context.Context) error {
+		currentSV, csvErr := s.tfe.StateVersions.ReadCurrent(ctx, w.ID)
+		switch {
+		// the workspace has no state until its first apply
+		case errors.Is(csvErr, tfe.ErrResourceNotFound):
+			log.Printf("[DEBUG] workspace: %q has no current state version", wName)
+		case csvErr != nil:
+			return csvErr
+		case !currentSV.ResourcesProcessed:
+			log.Printf("[DEBUG] current state version: %q has not been processed", currentSV.ID)
+		default:
+			var svoErr error
+			svoList, svoErr = s.listStateOutputs(ctx, currentSV.ID)
+			if svoErr != nil {
+				return svoErr
+			}
+			if missing = missingOutputs(svoList, names); len(missing) == 0 {
+				return nil
+			}
+		}
+
+		if !waiting {
+			waiting = true
+			s.writer.Output(fmt.Sprintf("Waiting for workspace: %q outputs: %s", wName, strings.Join(missing, ", ")))
+		}
+		return retryableTimeoutError("wait for workspace outputs")
+	})
+	if retryErr != nil {
+		log.Printf("[ERROR] error waiting for workspace: %q outputs: %v, error: %s", wName, missing, retryErr)
+		return nil, fmt.Errorf("outputs %s of workspace %q: %w", strings.Join(missing, ", "), wName, retryErr)
+	}
+	return svoList, nil
+}
+
+func missingOutputs(svoList *tfe.StateVersionOutputsList, names []string) []string {
+	existing := map[string]bool{}
+	for _, svo := range svoList.Items {
+		existing[svo.Name] = true
+	}
+	missing := []string{}
+	for _, name := range names {
+		if !existing[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// returns the names of workspaces in the organization that have all of the tags
+func (s *workspaceService) ListWorkspaceNames(ctx context.Context, orgName string, tags []string) ([]string, error) {
+	workspaces, err := listAll(func(opts tfe.ListOptions) ([]*tfe.Workspace, *tfe.Pagination, error) {
+		page, err := s.tfe.Workspaces.List(ctx, orgName, &tfe.WorkspaceListOptions{
+			ListOptions: opts,
+			Tags:        strings.Join(tags, ","),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Items, page.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing workspaces in organization: %q with tags: %v, error: %s", orgName, tags, err)
+		return nil, err
+	}
+
+	names := []string{}
+	for _, w := range workspaces {
+		names = append(names, w.Name)
+	}
+	return names, nil
+}
+
 func NewWorkspaceService(meta *cloudMeta) *workspaceService {
 	return &workspaceService{meta}
 }
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// workspaces read during a single command invocation, keyed by organization/name and by id.
+// services that modify workspaces read them directly instead.
+type workspaceCache struct {
+	mu     sync.Mutex
+	byName map[string]*tfe.Workspace
+	byID   map[string]*tfe.Workspace
+}
+
+func newWorkspaceCache() *workspaceCache {
+	return &workspaceCache{
+		byName: map[string]*tfe.Workspace{},
+		byID:   map[string]*tfe.Workspace{},
+	}
+}
+
+func workspaceCacheKey(orgName string, wName string) string {
+	return orgName + "/" + wName
+}
+
+func (c *workspaceCache) get(key string, index map[string]*tfe.Workspace) (*tfe.Workspace, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w, ok := index[key]
+	return w, ok
+}
+
+// the organization relation may not be populated, names are also cached under the requested key
+func (c *workspaceCache) add(w *tfe.Workspace, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byID[w.ID] = w
+	if w.Organization != nil {
+		c.byName[workspaceCacheKey(w.Organization.Name, w.Name)] = w
+	}
+	for _, key := range keys {
+		c.byName[key] = w
+	}
+}
+
+// reads the workspace by name, returning the workspace from earlier reads when available
+func (m *cloudMeta) readWorkspace(ctx context.Context, orgName string, wName string) (*tfe.Workspace, error) {
+	if m.workspaces == nil {
+		return m.tfe.Workspaces.Read(ctx, orgName, wName)
+	}
+	key := workspaceCacheKey(orgName, wName)
+	if w, ok := m.workspaces.get(key, m.workspaces.byName); ok {
+		log.Printf("[DEBUG] using cached workspace: %q organization: %q", wName, orgName)
+		return w, nil
+	}
+
+	w, err := m.tfe.Workspaces.Read(ctx, orgName, wName)
+	if err != nil {
+		return nil, err
+	}
+	m.workspaces.add(w, key)
+	return w, nil
+}
+
+// reads the workspace by id, returning the workspace from earlier reads when available
+func (m *cloudMeta) readWorkspaceByID(ctx context.Context, wID string) (*tfe.Workspace, error) {
+	if m.workspaces == nil {
+		return m.tfe.Workspaces.ReadByID(ctx, wID)
+	}
+	if w, ok := m.workspaces.get(wID, m.workspaces.byID); ok {
+		log.Printf("[DEBUG] using cached workspace: %q", wID)
+		return w, nil
+	}
+
+	w, err := m.tfe.Workspaces.ReadByID(ctx, wID)
+	if err != nil {
+		return nil, err
+	}
+	m.workspaces.add(w)
+	return w, nil
+}
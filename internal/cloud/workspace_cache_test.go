@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCloudMeta_ReadWorkspace_Cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+
+	ws := &tfe.Workspace{ID: "ws-1", Name: "networking"}
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(ws, nil).Times(1)
+	mWorkspaces.EXPECT().ReadByID(ctx, "ws-2").Return(&tfe.Workspace{ID: "ws-2", Name: "compute", Organization: &tfe.Organization{Name: "abc-company"}}, nil).Times(1)
+
+	meta := &cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces}, writer: &defaultWriter{}, workspaces: newWorkspaceCache()}
+	for i := 0; i < 2; i++ {
+		w, err := meta.readWorkspace(ctx, "abc-company", "networking")
+		if err != nil || w != ws {
+			t.Fatalf("expected workspace ws-1 but received %v, error: %v", w, err)
+		}
+	}
+	// read by name earlier
+	if w, err := meta.readWorkspaceByID(ctx, "ws-1"); err != nil || w != ws {
+		t.Errorf("expected cached workspace ws-1 but received %v, error: %v", w, err)
+	}
+
+	// read by id earlier, with the organization relation
+	if _, err := meta.readWorkspaceByID(ctx, "ws-2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if w, err := meta.readWorkspace(ctx, "abc-company", "compute"); err != nil || w.ID != "ws-2" {
+		t.Errorf("expected cached workspace ws-2 but received %v, error: %v", w, err)
+	}
+}
+
+func TestCloudMeta_ReadWorkspace_ErrorNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+
+	gomock.InOrder(
+		mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(nil, errors.New("internal server error")),
+		mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil),
+	)
+
+	meta := &cloudMeta{tfe: &tfe.Client{Workspaces: mWorkspaces}, writer: &defaultWriter{}, workspaces: newWorkspaceCache()}
+	if _, err := meta.readWorkspace(ctx, "abc-company", "networking"); err == nil {
+		t.Fatal("expected error reading workspace")
+	}
+	if w, err := meta.readWorkspace(ctx, "abc-company", "networking"); err != nil || w.ID != "ws-1" {
+		t.Errorf("expected workspace to be read again after an error but received %v, error: %v", w, err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+const (
+	ResourceModeManaged = "managed"
+	ResourceModeData    = "data"
+	// module of resources declared in the root module
+	rootModule = "root"
+)
+
+type WorkspaceResourceDetails struct {
+	Address      string `json:"address"`
+	Name         string `json:"name"`
+	Module       string `json:"module"`
+	Provider     string `json:"provider"`
+	ProviderType string `json:"provider_type"`
+	Mode         string `json:"mode"`
+}
+
+func newWorkspaceResourceDetails(r *tfe.WorkspaceResource) *WorkspaceResourceDetails {
+	return &WorkspaceResourceDetails{
+		Address:      r.Address,
+		Name:         r.Name,
+		Module:       r.Module,
+		Provider:     r.Provider,
+		ProviderType: r.ProviderType,
+		Mode:         resourceMode(r.Address, r.Module),
+	}
+}
+
+// the resources API does not return the mode, data sources are addressed with a "data." prefix within their module
+func resourceMode(address, module string) string {
+	if module != "" && module != rootModule {
+		address = strings.TrimPrefix(address, module+".")
+	}
+	if strings.HasPrefix(address, "data.") {
+		return ResourceModeData
+	}
+	return ResourceModeManaged
+}
+
+type WorkspaceResourceService interface {
+	ListWorkspaceResources(ctx context.Context, organization, workspace string) ([]*WorkspaceResourceDetails, error)
+}
+
+type workspaceResourceService struct {
+	*cloudMeta
+}
+
+// lists the resources tracked in the current state of the workspace
+func (s *workspaceResourceService) ListWorkspaceResources(ctx context.Context, organization, workspace string) ([]*WorkspaceResourceDetails, error) {
+	w, err := s.readWorkspace(ctx, organization, workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", workspace, organization, err)
+		return nil, err
+	}
+
+	resources, err := listAll(func(opts tfe.ListOptions) ([]*tfe.WorkspaceResource, *tfe.Pagination, error) {
+		list, err := s.tfe.WorkspaceResources.List(ctx, w.ID, &tfe.WorkspaceResourceListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] error listing resources of workspace: %q error: %s", w.ID, err)
+		return nil, err
+	}
+	details := make([]*WorkspaceResourceDetails, 0, len(resources))
+	for _, r := range resources {
+		details = append(details, newWorkspaceResourceDetails(r))
+	}
+	return details, nil
+}
+
+func NewWorkspaceResourceService(meta *cloudMeta) *workspaceResourceService {
+	return &workspaceResourceService{meta}
+}
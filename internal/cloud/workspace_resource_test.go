@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResourceMode(t *testing.T) {
+	testCases := []struct {
+		address  string
+		module   string
+		expected string
+	}{
+		{address: "aws_instance.web", module: "root", expected: ResourceModeManaged},
+		{address: "data.aws_ami.ubuntu", module: "root", expected: ResourceModeData},
+		{address: "module.vpc.aws_vpc.this[0]", module: "module.vpc", expected: ResourceModeManaged},
+		{address: "module.vpc.data.aws_region.current", module: "module.vpc", expected: ResourceModeData},
+		{address: `module.app["data"].aws_s3_bucket.logs`, module: `module.app["data"]`, expected: ResourceModeManaged},
+	}
+	for _, tc := range testCases {
+		if mode := resourceMode(tc.address, tc.module); mode != tc.expected {
+			t.Errorf("%s: expected mode %q, got %q", tc.address, tc.expected, mode)
+		}
+	}
+}
+
+func TestWorkspaceResourceService_ListWorkspaceResources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mWorkspaces := mocks.NewMockWorkspaces(ctrl)
+	mWorkspaces.EXPECT().Read(ctx, "abc-company", "networking").Return(&tfe.Workspace{ID: "ws-1"}, nil)
+	mResources := mocks.NewMockWorkspaceResources(ctrl)
+	mResources.EXPECT().List(ctx, "ws-1", &tfe.WorkspaceResourceListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}).Return(&tfe.WorkspaceResourcesList{
+		Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1},
+		Items: []*tfe.WorkspaceResource{
+			{Address: "module.vpc.aws_vpc.this", Name: "this", Module: "module.vpc", Provider: "hashicorp/aws", ProviderType: "aws_vpc"},
+			{Address: "data.aws_ami.ubuntu", Name: "ubuntu", Module: "root", Provider: "hashicorp/aws", ProviderType: "aws_ami"},
+		},
+	}, nil)
+
+	service := NewWorkspaceResourceService(&cloudMeta{
+		tfe:    &tfe.Client{Workspaces: mWorkspaces, WorkspaceResources: mResources},
+		writer: &defaultWriter{},
+	})
+	resources, err := service.ListWorkspaceResources(ctx, "abc-company", "networking")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []*WorkspaceResourceDetails{
+		{Address: "module.vpc.aws_vpc.this", Name: "this", Module: "module.vpc", Provider: "hashicorp/aws", ProviderType: "aws_vpc", Mode: ResourceModeManaged},
+		{Address: "data.aws_ami.ubuntu", Name: "ubuntu", Module: "root", Provider: "hashicorp/aws", ProviderType: "aws_ami", Mode: ResourceModeData},
+	}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected resources %+v, got %+v", expected, resources)
+	}
+}
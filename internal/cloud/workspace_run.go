@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// returned when the workspace does not have the requested run
+var ErrNoWorkspaceRun = errors.New("workspace has no runs")
+
+type WorkspaceRunOptions struct {
+	Organization string
+	Workspace    string
+	// the current run of the workspace instead of the most recent run
+	Current bool
+	// related resources to include, as with GetRunOptions
+	Include []tfe.RunIncludeOpt
+}
+
+// resolves the current or most recent run of the workspace, read with the same details as GetRun
+func (service *runService) GetWorkspaceRun(ctx context.Context, options WorkspaceRunOptions) (*tfe.Run, error) {
+	w, err := service.readWorkspace(ctx, options.Organization, options.Workspace)
+	if err != nil {
+		log.Printf("[ERROR] error reading workspace: %q organization: %q, error: %s", options.Workspace, options.Organization, err)
+		return nil, err
+	}
+
+	var runID string
+	if options.Current {
+		// the cached workspace may have been read without the current run
+		w, err = service.tfe.Workspaces.ReadByIDWithOptions(ctx, w.ID, &tfe.WorkspaceReadOptions{
+			Include: []tfe.WSIncludeOpt{tfe.WSCurrentRun},
+		})
+		if err != nil {
+			log.Printf("[ERROR] error reading current run of workspace: %q error: %s", options.Workspace, err)
+			return nil, err
+		}
+		if w.CurrentRun == nil {
+			return nil, ErrNoWorkspaceRun
+		}
+		runID = w.CurrentRun.ID
+	} else {
+		list, err := service.tfe.Runs.List(ctx, w.ID, &tfe.RunListOptions{
+			ListOptions: tfe.ListOptions{PageSize: 1},
+		})
+		if err != nil {
+			log.Printf("[ERROR] error listing runs of workspace: %q error: %s", options.Workspace, err)
+			return nil, err
+		}
+		if len(list.Items) == 0 {
+			return nil, ErrNoWorkspaceRun
+		}
+		runID = list.Items[0].ID
+	}
+	log.Printf("[DEBUG] resolved run: %q of workspace: %q, current: %t", runID, options.Workspace, options.Current)
+
+	return service.GetRun(ctx, GetRunOptions{RunID: runID, Include: options.Include})
+}
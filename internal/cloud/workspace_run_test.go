@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/go-tfe/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunService_GetWorkspaceRun(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name       string
+		current    bool
+		currentRun *tfe.Run
+		runs       []*tfe.Run
+		expected   string
+		err        error
+	}{
+		{
+			name:     "latest",
+			runs:     []*tfe.Run{{ID: "run-latest"}},
+			expected: "run-latest",
+		},
+		{
+			name: "latest-no-runs",
+			runs: []*tfe.Run{},
+			err:  ErrNoWorkspaceRun,
+		},
+		{
+			name:       "current",
+			current:    true,
+			currentRun: &tfe.Run{ID: "run-current"},
+			expected:   "run-current",
+		},
+		{
+			name:    "current-no-runs",
+			current: true,
+			err:     ErrNoWorkspaceRun,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			workspace := &tfe.Workspace{ID: "ws-abc123", Name: "networking"}
+			workspaceMock := mocks.NewMockWorkspaces(ctrl)
+			workspaceMock.EXPECT().Read(ctx, "abc-company", "networking").Return(workspace, nil)
+			runsMock := mocks.NewMockRuns(ctrl)
+			if tc.current {
+				workspaceMock.EXPECT().ReadByIDWithOptions(ctx, "ws-abc123", &tfe.WorkspaceReadOptions{
+					Include: []tfe.WSIncludeOpt{tfe.WSCurrentRun},
+				}).Return(&tfe.Workspace{ID: "ws-abc123", CurrentRun: tc.currentRun}, nil)
+			} else {
+				runsMock.EXPECT().List(ctx, "ws-abc123", &tfe.RunListOptions{
+					ListOptions: tfe.ListOptions{PageSize: 1},
+				}).Return(&tfe.RunList{Items: tc.runs}, nil)
+			}
+			if tc.expected != "" {
+				runsMock.EXPECT().ReadWithOptions(ctx, tc.expected, gomock.Any()).Return(&tfe.Run{ID: tc.expected, Status: tfe.RunApplied}, nil)
+			}
+
+			service := NewRunService(&cloudMeta{
+				tfe:    &tfe.Client{Workspaces: workspaceMock, Runs: runsMock},
+				writer: &defaultWriter{},
+			})
+			run, err := service.GetWorkspaceRun(ctx, WorkspaceRunOptions{
+				Organization: "abc-company",
+				Workspace:    "networking",
+				Current:      tc.current,
+			})
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v but received %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if run.ID != tc.expected {
+				t.Errorf("expected run %q but received %q", tc.expected, run.ID)
+			}
+		})
+	}
+}
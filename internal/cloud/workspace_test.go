@@ -5,8 +5,11 @@ package cloud
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/go-tfe/mocks"
@@ -35,9 +38,11 @@ func TestWorkspaceService_ReadStateOutputs(t *testing.T) {
 			workspaceID:   "ws-***",
 			tfeWorkspace:  &tfe.Workspace{ID: "ws-***"},
 			tfeStateVersion: &tfe.StateVersion{
+				ID:                 "sv-***",
 				ResourcesProcessed: true,
 			},
 			tfeStateVersionOutputs: &tfe.StateVersionOutputsList{
+				Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1, TotalCount: 1},
 				Items: []*tfe.StateVersionOutput{
 					{
 						Name:  "image_id",
@@ -68,17 +73,15 @@ func TestWorkspaceService_ReadStateOutputs(t *testing.T) {
 			)
 
 			// mock state version output
-			mockStateVersionOutputList := mocks.NewMockStateVersionOutputs(ctrl)
-			mockStateVersionOutputList.EXPECT().ReadCurrent(tc.ctx, tc.workspaceID).Return(
+			mockStateVersion.EXPECT().ListOutputs(tc.ctx, tc.tfeStateVersion.ID, gomock.Any()).Return(
 				tc.tfeStateVersionOutputs,
 				nil,
 			)
 
 			meta := &cloudMeta{
 				tfe: &tfe.Client{
-					Workspaces:          mWorkspace,
-					StateVersions:       mockStateVersion,
-					StateVersionOutputs: mockStateVersionOutputList,
+					Workspaces:    mWorkspace,
+					StateVersions: mockStateVersion,
 				},
 				writer: writer.NewWriter(cli.NewMockUi()),
 			}
@@ -130,6 +133,7 @@ func TestWorkspaceService_ReadStateOutputs_Retry(t *testing.T) {
 		retryCall := mockStateVersion.EXPECT().ReadCurrent(ctx, wID).Return(tfeStateVersion, nil).Times(3)
 		// Assert and mock retry is stopped when resources processed is set to true
 		doneCall := mockStateVersion.EXPECT().ReadCurrent(ctx, wID).Return(&tfe.StateVersion{
+			ID:                 "sv-***",
 			ResourcesProcessed: true,
 		}, nil)
 
@@ -139,17 +143,15 @@ func TestWorkspaceService_ReadStateOutputs_Retry(t *testing.T) {
 			doneCall,
 		)
 
-		mockStateVersionOutputList := mocks.NewMockStateVersionOutputs(ctrl)
-		mockStateVersionOutputList.EXPECT().ReadCurrent(ctx, wID).Return(
+		mockStateVersion.EXPECT().ListOutputs(ctx, "sv-***", gomock.Any()).Return(
 			tfeStateVersionOutputs,
 			nil,
 		)
 
 		meta := &cloudMeta{
 			tfe: &tfe.Client{
-				Workspaces:          mWorkspace,
-				StateVersions:       mockStateVersion,
-				StateVersionOutputs: mockStateVersionOutputList,
+				Workspaces:    mWorkspace,
+				StateVersions: mockStateVersion,
 			},
 			writer: writer.NewWriter(cli.NewMockUi()),
 		}
@@ -159,3 +161,140 @@ func TestWorkspaceService_ReadStateOutputs_Retry(t *testing.T) {
 		client.ReadStateOutputs(ctx, orgName, workspaceName)
 	})
 }
+
+func TestWorkspaceService_WaitForStateOutputs(t *testing.T) {
+	original := retryOptions
+	t.Cleanup(func() { retryOptions = original })
+	if err := ConfigureRetry(&RetryOptions{PollInterval: 10 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, orgName, workspaceName, wID := context.Background(), "test-org", "consumer", "ws-***"
+	partial := &tfe.StateVersionOutputsList{Items: []*tfe.StateVersionOutput{{Name: "image_id", Value: "ami-12345"}}}
+	complete := &tfe.StateVersionOutputsList{Items: []*tfe.StateVersionOutput{
+		{Name: "image_id", Value: "ami-12345"},
+		{Name: "cluster_endpoint", Value: "https://cluster.example.com"},
+	}}
+
+	testCases := []struct {
+		name     string
+		outputs  []*tfe.StateVersionOutputsList
+		timeout  time.Duration
+		expected *tfe.StateVersionOutputsList
+	}{
+		{
+			name:     "outputs-written-later",
+			outputs:  []*tfe.StateVersionOutputsList{partial, partial, complete},
+			timeout:  time.Minute,
+			expected: complete,
+		},
+		{
+			name:    "timeout",
+			outputs: []*tfe.StateVersionOutputsList{partial},
+			timeout: 50 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mWorkspace := mocks.NewMockWorkspaces(ctrl)
+			mWorkspace.EXPECT().Read(ctx, orgName, workspaceName).Return(&tfe.Workspace{ID: wID}, nil)
+
+			mockStateVersion := mocks.NewMockStateVersions(ctrl)
+			// no state until the producer's first apply
+			mockStateVersion.EXPECT().ReadCurrent(gomock.Any(), wID).Return(nil, tfe.ErrResourceNotFound)
+			mockStateVersion.EXPECT().ReadCurrent(gomock.Any(), wID).Return(&tfe.StateVersion{ID: "sv-***", ResourcesProcessed: true}, nil).AnyTimes()
+
+			calls := 0
+			mockStateVersion.EXPECT().ListOutputs(gomock.Any(), "sv-***", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, _ *tfe.StateVersionOutputsListOptions) (*tfe.StateVersionOutputsList, error) {
+					outputs := tc.outputs[min(calls, len(tc.outputs)-1)]
+					calls++
+					return outputs, nil
+				}).AnyTimes()
+
+			ui := cli.NewMockUi()
+			meta := &cloudMeta{
+				tfe: &tfe.Client{
+					Workspaces:    mWorkspace,
+					StateVersions: mockStateVersion,
+				},
+				writer: writer.NewWriter(ui),
+			}
+			client := NewWorkspaceService(meta)
+
+			result, err := client.WaitForStateOutputs(ctx, orgName, workspaceName, []string{"image_id", "cluster_endpoint"}, tc.timeout)
+			if tc.expected == nil {
+				var timeoutErr *RetryTimeoutError
+				if !errors.As(err, &timeoutErr) {
+					t.Fatalf("expected timeout error but received %v", err)
+				}
+				if !strings.Contains(err.Error(), "cluster_endpoint") {
+					t.Errorf("expected error to name the missing output but received %q", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error but received %s", err)
+			}
+			if !reflect.DeepEqual(result.Items, tc.expected.Items) {
+				t.Errorf("expected %v but received %v", tc.expected, result)
+			}
+			if !strings.Contains(ui.OutputWriter.String(), "cluster_endpoint") {
+				t.Errorf("expected waiting message but received %q", ui.OutputWriter.String())
+			}
+		})
+	}
+}
+
+func TestStateProcessingTimeout(t *testing.T) {
+	original := retryOptions
+	t.Cleanup(func() { retryOptions = original })
+
+	if err := ConfigureRetry(&RetryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := stateProcessingTimeout(); actual != StateVersionOutputMaxDuration {
+		t.Errorf("expected default timeout %s but received %s", StateVersionOutputMaxDuration, actual)
+	}
+
+	if err := ConfigureRetry(&RetryOptions{StateProcessingTimeout: 20 * time.Minute}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := stateProcessingTimeout(); actual != 20*time.Minute {
+		t.Errorf("expected timeout %s but received %s", 20*time.Minute, actual)
+	}
+}
+
+func TestWorkspaceService_ReadStateOutputs_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, wID, svID := context.Background(), "ws-***", "sv-***"
+
+	mWorkspace := mocks.NewMockWorkspaces(ctrl)
+	mWorkspace.EXPECT().Read(ctx, "test-org", "my-workspace").Return(&tfe.Workspace{ID: wID}, nil)
+
+	mockStateVersion := mocks.NewMockStateVersions(ctrl)
+	mockStateVersion.EXPECT().ReadCurrent(ctx, wID).Return(&tfe.StateVersion{ID: svID, ResourcesProcessed: true}, nil)
+	mockStateVersion.EXPECT().ListOutputs(ctx, svID, &tfe.StateVersionOutputsListOptions{ListOptions: tfe.ListOptions{PageSize: pageSize}}).Return(&tfe.StateVersionOutputsList{
+		Pagination: &tfe.Pagination{CurrentPage: 1, NextPage: 2, TotalPages: 2, TotalCount: 3},
+		Items:      []*tfe.StateVersionOutput{{Name: "vpc_id"}, {Name: "subnet_ids"}},
+	}, nil)
+	mockStateVersion.EXPECT().ListOutputs(ctx, svID, &tfe.StateVersionOutputsListOptions{ListOptions: tfe.ListOptions{PageSize: pageSize, PageNumber: 2}}).Return(&tfe.StateVersionOutputsList{
+		Pagination: &tfe.Pagination{CurrentPage: 2, TotalPages: 2, TotalCount: 3},
+		Items:      []*tfe.StateVersionOutput{{Name: "zone_id"}},
+	}, nil)
+
+	client := NewWorkspaceService(&cloudMeta{
+		tfe:    &tfe.Client{Workspaces: mWorkspace, StateVersions: mockStateVersion},
+		writer: writer.NewWriter(cli.NewMockUi()),
+	})
+	result, err := client.ReadStateOutputs(ctx, "test-org", "my-workspace")
+	if err != nil {
+		t.Fatalf("expected no error but received %s", err)
+	}
+	if len(result.Items) != 3 || result.Items[2].Name != "zone_id" || result.TotalCount != 3 {
+		t.Errorf("expected 3 outputs from both pages but received %d: %v", len(result.Items), result.Items)
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type AdminUserListCommand struct {
+	*Meta
+
+	Query     string
+	Suspended bool
+}
+
+func (c *AdminUserListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("admin user list")
+	f.StringVar(&c.Query, "query", "", "Only lists users with a matching username or email address.")
+	f.BoolVar(&c.Suspended, "suspended", false, "Only lists suspended users.")
+	return f
+}
+
+func (c *AdminUserListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	users, err := c.cloud.ListUsers(c.appCtx, cloud.AdminUserListOptions{Query: c.Query, SuspendedOnly: c.Suspended})
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing users in Terraform Enterprise: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("user_count", fmt.Sprintf("%d", len(users)))
+	c.addOutputWithOpts("users", users, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"username", "email", "is_admin", "is_suspended"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AdminUserListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] admin user list [options]
+
+	Lists the users of the Terraform Enterprise installation. Requires a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-query      Only lists users with a matching username or email address.
+
+	-suspended  Only lists suspended users.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AdminUserListCommand) Synopsis() string {
+	return "Lists the users of Terraform Enterprise"
+}
+
+// suspends or unsuspends a user, shared by the suspend and unsuspend commands
+func runUserSuspension(c *Meta, name string, args []string, suspended bool) int {
+	var userName string
+	f := c.flagSet(name)
+	f.StringVar(&userName, "user", "", "ID, username or email address of the user.")
+	if err := c.setupCmd(args, f); err != nil {
+		return 1
+	}
+
+	if userName == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("%s requires a user", name))
+		return 1
+	}
+
+	user, err := c.cloud.SetUserSuspended(c.appCtx, userName, suspended)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error updating user, '%s' in Terraform Enterprise: %s", userName, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("user_id", user.ID)
+	c.addOutput("is_suspended", fmt.Sprintf("%t", user.IsSuspended))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+type AdminUserSuspendCommand struct {
+	*Meta
+}
+
+func (c *AdminUserSuspendCommand) Run(args []string) int {
+	return runUserSuspension(c.Meta, "admin user suspend", args, true)
+}
+
+func (c *AdminUserSuspendCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] admin user suspend [options]
+
+	Suspends a user of the Terraform Enterprise installation, preventing them from signing in. Requires a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-user  ID, username or email address of the user.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AdminUserSuspendCommand) Synopsis() string {
+	return "Suspends a user of Terraform Enterprise"
+}
+
+type AdminUserUnsuspendCommand struct {
+	*Meta
+}
+
+func (c *AdminUserUnsuspendCommand) Run(args []string) int {
+	return runUserSuspension(c.Meta, "admin user unsuspend", args, false)
+}
+
+func (c *AdminUserUnsuspendCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] admin user unsuspend [options]
+
+	Unsuspends a suspended user of the Terraform Enterprise installation. Requires a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-user  ID, username or email address of the user.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AdminUserUnsuspendCommand) Synopsis() string {
+	return "Unsuspends a user of Terraform Enterprise"
+}
+
+type AdminOrganizationListCommand struct {
+	*Meta
+
+	Query string
+}
+
+func (c *AdminOrganizationListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("admin organization list")
+	f.StringVar(&c.Query, "query", "", "Only lists organizations with a matching name or notification email.")
+	return f
+}
+
+func (c *AdminOrganizationListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	orgs, err := c.cloud.ListAdminOrganizations(c.appCtx, c.Query)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing organizations in Terraform Enterprise: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("organization_count", fmt.Sprintf("%d", len(orgs)))
+	c.addOutputWithOpts("organizations", orgs, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"name", "notification_email", "is_disabled", "sso_enabled"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AdminOrganizationListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] admin organization list [options]
+
+	Lists all organizations of the Terraform Enterprise installation. Requires a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-query  Only lists organizations with a matching name or notification email.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AdminOrganizationListCommand) Synopsis() string {
+	return "Lists the organizations of Terraform Enterprise"
+}
+
+type AdminTerraformVersionListCommand struct {
+	*Meta
+
+	Search string
+}
+
+func (c *AdminTerraformVersionListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("admin terraform-version list")
+	f.StringVar(&c.Search, "search", "", "Only lists Terraform versions containing the search string, such as 1.9.")
+	return f
+}
+
+func (c *AdminTerraformVersionListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	versions, err := c.cloud.ListTerraformVersions(c.appCtx, c.Search)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing Terraform versions in Terraform Enterprise: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("terraform_version_count", fmt.Sprintf("%d", len(versions)))
+	c.addOutputWithOpts("terraform_versions", versions, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"version", "enabled", "deprecated", "official", "usage"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AdminTerraformVersionListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] admin terraform-version list [options]
+
+	Lists the Terraform versions available in the Terraform Enterprise installation, with their
+	availability and number of workspaces using them. Requires a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-search  Only lists Terraform versions containing the search string, such as 1.9.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AdminTerraformVersionListCommand) Synopsis() string {
+	return "Lists the Terraform versions of Terraform Enterprise"
+}
+
+type AdminTerraformVersionUpdateCommand struct {
+	*Meta
+
+	Version          string
+	Enabled          bool
+	Deprecated       bool
+	DeprecatedReason string
+}
+
+func (c *AdminTerraformVersionUpdateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("admin terraform-version update")
+	f.StringVar(&c.Version, "version", "", "Terraform version to update, such as 1.9.5.")
+	f.BoolVar(&c.Enabled, "enabled", true, "Whether workspaces can select the Terraform version.")
+	f.BoolVar(&c.Deprecated, "deprecated", false, "Whether the Terraform version is deprecated.")
+	f.StringVar(&c.DeprecatedReason, "deprecated-reason", "", "Reason shown for a deprecated Terraform version.")
+	return f
+}
+
+func (c *AdminTerraformVersionUpdateCommand) Run(args []string) int {
+	f := c.flags()
+	if err := c.setupCmd(args, f); err != nil {
+		return 1
+	}
+
+	if c.Version == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("updating a Terraform version requires a version")
+		return 1
+	}
+
+	// only flags that were passed change the availability of the version
+	options := cloud.TerraformVersionUpdateOptions{DeprecatedReason: c.DeprecatedReason}
+	f.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "enabled":
+			options.Enabled = &c.Enabled
+		case "deprecated":
+			options.Deprecated = &c.Deprecated
+		}
+	})
+	if options.Enabled == nil && options.Deprecated == nil && options.DeprecatedReason == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("updating a Terraform version requires -enabled, -deprecated or -deprecated-reason")
+		return 1
+	}
+
+	version, err := c.cloud.UpdateTerraformVersion(c.appCtx, c.Version, options)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error updating Terraform version, '%s' in Terraform Enterprise: %s", c.Version, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("enabled", fmt.Sprintf("%t", version.Enabled))
+	c.addOutput("deprecated", fmt.Sprintf("%t", version.Deprecated))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AdminTerraformVersionUpdateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] admin terraform-version update [options]
+
+	Updates the availability of a Terraform version in the Terraform Enterprise installation. Only the
+	options that are passed are changed. Requires a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-version            Terraform version to update, such as 1.9.5.
+
+	-enabled            Whether workspaces can select the Terraform version.
+
+	-deprecated         Whether the Terraform version is deprecated.
+
+	-deprecated-reason  Reason shown for a deprecated Terraform version.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AdminTerraformVersionUpdateCommand) Synopsis() string {
+	return "Updates the availability of a Terraform version in Terraform Enterprise"
+}
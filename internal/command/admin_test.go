@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testAdminService struct {
+	cloud.AdminService
+	listOptions   cloud.AdminUserListOptions
+	suspended     *bool
+	updateOptions *cloud.TerraformVersionUpdateOptions
+	err           error
+}
+
+func (s *testAdminService) ListUsers(_ context.Context, options cloud.AdminUserListOptions) ([]*cloud.AdminUserDetails, error) {
+	s.listOptions = options
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []*cloud.AdminUserDetails{{ID: "user-1", Username: "jane", IsSuspended: options.SuspendedOnly}}, nil
+}
+
+func (s *testAdminService) SetUserSuspended(_ context.Context, user string, suspended bool) (*cloud.AdminUserDetails, error) {
+	s.suspended = &suspended
+	return &cloud.AdminUserDetails{ID: "user-1", Username: user, IsSuspended: suspended}, nil
+}
+
+func (s *testAdminService) UpdateTerraformVersion(_ context.Context, version string, options cloud.TerraformVersionUpdateOptions) (*cloud.TerraformVersionDetails, error) {
+	s.updateOptions = &options
+	details := &cloud.TerraformVersionDetails{Version: version, Enabled: true}
+	if options.Enabled != nil {
+		details.Enabled = *options.Enabled
+	}
+	if options.Deprecated != nil {
+		details.Deprecated = *options.Deprecated
+	}
+	return details, nil
+}
+
+func testAdminCommandMeta(admin cloud.AdminService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.AdminService = admin
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer))
+}
+
+func TestAdminUserListCommand(t *testing.T) {
+	admin := &testAdminService{}
+	ui, meta := testAdminCommandMeta(admin)
+	cmd := &AdminUserListCommand{Meta: meta}
+	if code := cmd.Run([]string{"-suspended", "-query=jane"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !admin.listOptions.SuspendedOnly || admin.listOptions.Query != "jane" {
+		t.Errorf("unexpected list options %+v", admin.listOptions)
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, `"user_count": "1"`) {
+		t.Errorf("expected output to contain user count but received %s", output)
+	}
+}
+
+func TestAdminUserListCommand_HCPTerraform(t *testing.T) {
+	ui, meta := testAdminCommandMeta(&testAdminService{err: cloud.ErrAdminUnsupported})
+	cmd := &AdminUserListCommand{Meta: meta}
+	if code := cmd.Run([]string{}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), cloud.ErrAdminUnsupported.Error()) {
+		t.Errorf("expected unsupported error but received %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestAdminUserSuspendCommands(t *testing.T) {
+	admin := &testAdminService{}
+	ui, meta := testAdminCommandMeta(admin)
+	suspend := &AdminUserSuspendCommand{Meta: meta}
+	if code := suspend.Run([]string{"-user=jane@example.com"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if admin.suspended == nil || !*admin.suspended {
+		t.Error("expected user to be suspended")
+	}
+
+	ui, meta = testAdminCommandMeta(admin)
+	unsuspend := &AdminUserUnsuspendCommand{Meta: meta}
+	if code := unsuspend.Run([]string{"-user=jane@example.com"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if *admin.suspended {
+		t.Error("expected user to be unsuspended")
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, `"is_suspended": "false"`) {
+		t.Errorf("expected output to contain suspension but received %s", output)
+	}
+
+	_, meta = testAdminCommandMeta(admin)
+	missing := &AdminUserSuspendCommand{Meta: meta}
+	if code := missing.Run([]string{}); code != 1 {
+		t.Errorf("expected exit code 1 without a user but received %d", code)
+	}
+}
+
+func TestAdminTerraformVersionUpdateCommand(t *testing.T) {
+	testCases := []struct {
+		name               string
+		args               []string
+		expectedCode       int
+		expectedEnabled    *bool
+		expectedDeprecated *bool
+	}{
+		{
+			name:               "deprecate",
+			args:               []string{"-version=1.9.5", "-deprecated", "-deprecated-reason=upgrade to 1.10"},
+			expectedCode:       0,
+			expectedDeprecated: tfe.Bool(true),
+		},
+		{
+			name:            "disable",
+			args:            []string{"-version=1.9.5", "-enabled=false"},
+			expectedCode:    0,
+			expectedEnabled: tfe.Bool(false),
+		},
+		{
+			name:         "no-changes",
+			args:         []string{"-version=1.9.5"},
+			expectedCode: 1,
+		},
+		{
+			name:         "missing-version",
+			args:         []string{"-enabled=false"},
+			expectedCode: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			admin := &testAdminService{}
+			ui, meta := testAdminCommandMeta(admin)
+			cmd := &AdminTerraformVersionUpdateCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if tc.expectedCode != 0 {
+				return
+			}
+			if !equalBoolPtr(admin.updateOptions.Enabled, tc.expectedEnabled) {
+				t.Errorf("expected enabled %v, got %v", tc.expectedEnabled, admin.updateOptions.Enabled)
+			}
+			if !equalBoolPtr(admin.updateOptions.Deprecated, tc.expectedDeprecated) {
+				t.Errorf("expected deprecated %v, got %v", tc.expectedDeprecated, admin.updateOptions.Deprecated)
+			}
+		})
+	}
+}
+
+func equalBoolPtr(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+type AgentPoolListCommand struct {
+	*Meta
+}
+
+func (c *AgentPoolListCommand) flags() *flag.FlagSet {
+	return c.flagSet("agentpool list")
+}
+
+func (c *AgentPoolListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	pools, err := c.cloud.ListAgentPools(c.appCtx, c.organization)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing agent pools in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("agent_pool_count", fmt.Sprintf("%d", len(pools)))
+	c.addOutputWithOpts("agent_pools", pools, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"id", "name", "agent_count", "organization_scoped"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AgentPoolListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] agentpool list
+
+	Lists the agent pools of the organization, with their number of agents.
+
+` + globalOptionsHelp
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AgentPoolListCommand) Synopsis() string {
+	return "Lists the agent pools of the organization"
+}
+
+type AgentPoolCreateCommand struct {
+	*Meta
+
+	Name               string
+	OrganizationScoped bool
+}
+
+func (c *AgentPoolCreateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("agentpool create")
+	f.StringVar(&c.Name, "name", "", "Name of the agent pool.")
+	f.BoolVar(&c.OrganizationScoped, "organization-scoped", true, "When true, every workspace of the organization can use the agent pool.")
+	return f
+}
+
+func (c *AgentPoolCreateCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("creating an agent pool requires a name")
+		return 1
+	}
+
+	pool, err := c.cloud.CreateAgentPool(c.appCtx, c.organization, c.Name, c.OrganizationScoped)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error creating agent pool, '%s' in HCP Terraform: %s", c.Name, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("agent_pool_id", pool.ID)
+	c.addOutput("agent_pool_name", pool.Name)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AgentPoolCreateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] agentpool create [options]
+
+	Creates an agent pool in the organization.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name                 Name of the agent pool.
+
+	-organization-scoped  When true, every workspace of the organization can use the agent pool. Defaults to true.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AgentPoolCreateCommand) Synopsis() string {
+	return "Creates an agent pool in the organization"
+}
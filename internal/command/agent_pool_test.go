@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testAgentPoolService struct {
+	cloud.AgentPoolService
+	pools   []*cloud.AgentPoolDetails
+	revoked string
+}
+
+func (s *testAgentPoolService) ListAgentPools(context.Context, string) ([]*cloud.AgentPoolDetails, error) {
+	return s.pools, nil
+}
+
+func (s *testAgentPoolService) CreateAgentPool(_ context.Context, _, name string, organizationScoped bool) (*cloud.AgentPoolDetails, error) {
+	return &cloud.AgentPoolDetails{ID: "apool-1", Name: name, OrganizationScoped: organizationScoped}, nil
+}
+
+func (s *testAgentPoolService) CreateAgentToken(context.Context, string, string) (*tfe.AgentToken, error) {
+	return &tfe.AgentToken{ID: "at-1", Token: "secret-agent-token"}, nil
+}
+
+func (s *testAgentPoolService) RevokeAgentToken(_ context.Context, tokenID string) error {
+	s.revoked = tokenID
+	return nil
+}
+
+func testAgentCommandMeta(pools cloud.AgentPoolService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.AgentPoolService = pools
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestAgentPoolListCommand(t *testing.T) {
+	ui, meta := testAgentCommandMeta(&testAgentPoolService{pools: []*cloud.AgentPoolDetails{{ID: "apool-1", Name: "autoscaled", AgentCount: 3}}})
+	cmd := &AgentPoolListCommand{Meta: meta}
+	if code := cmd.Run([]string{}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"agent_pool_count": "1"`, `"agent_count": 3`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestAgentPoolCreateCommand(t *testing.T) {
+	ui, meta := testAgentCommandMeta(&testAgentPoolService{})
+	cmd := &AgentPoolCreateCommand{Meta: meta}
+	if code := cmd.Run([]string{}); code != 1 {
+		t.Errorf("expected exit code 1 without a name but received %d", code)
+	}
+
+	ui, meta = testAgentCommandMeta(&testAgentPoolService{})
+	cmd = &AgentPoolCreateCommand{Meta: meta}
+	if code := cmd.Run([]string{"-name=autoscaled"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"agent_pool_id": "apool-1"`) {
+		t.Errorf("expected agent pool id in output but received %s", ui.OutputWriter.String())
+	}
+}
+
+func TestAgentTokenCreateCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent-token")
+	ui, meta := testAgentCommandMeta(&testAgentPoolService{})
+	cmd := &AgentTokenCreateCommand{Meta: meta}
+	if code := cmd.Run([]string{"-agent-pool=apool-1", "-description=autoscaler", "-token-file=" + path}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, `"agent_token_id": "at-1"`) {
+		t.Errorf("expected agent token id in output but received %s", output)
+	}
+	if strings.Contains(output, "secret-agent-token") {
+		t.Errorf("expected the secret token to not be printed but received %s", output)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading token file: %s", err)
+	}
+	if string(data) != "secret-agent-token" {
+		t.Errorf("unexpected token file contents %q", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("expected token file mode 0600 but received %s", info.Mode().Perm())
+	}
+}
+
+func TestAgentTokenRevokeCommand(t *testing.T) {
+	pools := &testAgentPoolService{}
+	ui, meta := testAgentCommandMeta(pools)
+	cmd := &AgentTokenRevokeCommand{Meta: meta}
+	if code := cmd.Run([]string{"-token-id=at-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if pools.revoked != "at-1" {
+		t.Errorf("expected token at-1 to be revoked but received %q", pools.revoked)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type AgentTokenCreateCommand struct {
+	*Meta
+
+	AgentPoolID string
+	Description string
+	TokenFile   string
+}
+
+func (c *AgentTokenCreateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("agent token create")
+	f.StringVar(&c.AgentPoolID, "agent-pool", "", "ID of the agent pool to create the token in.")
+	f.StringVar(&c.Description, "description", "", "Description of the agent token.")
+	f.StringVar(&c.TokenFile, "token-file", "", "Writes the secret token value to the provided file path, readable only by the current user.")
+	return f
+}
+
+func (c *AgentTokenCreateCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.AgentPoolID == "" || c.Description == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("creating an agent token requires an agent pool id and description")
+		return 1
+	}
+
+	token, err := c.cloud.CreateAgentToken(c.appCtx, c.AgentPoolID, c.Description)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error creating agent token for agent pool, '%s' in HCP Terraform: %s", c.AgentPoolID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if c.TokenFile != "" {
+		if err := os.WriteFile(c.TokenFile, []byte(token.Token), 0600); err != nil {
+			status := c.resolveStatus(err)
+			c.addOutput("status", string(status))
+			c.addOutput("agent_token_id", token.ID)
+			c.writer.ErrorResult(fmt.Sprintf("error writing agent token to file, '%s': %s", c.TokenFile, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("agent_token_id", token.ID)
+	// the secret is only passed to the platform outputs, never printed to stdout
+	c.addOutputWithOpts("agent_token", token.Token, &outputOpts{
+		stdOut:      false,
+		multiLine:   false,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AgentTokenCreateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] agent token create [options]
+
+	Creates an agent token in an agent pool. The secret token value is not printed, it is passed to the
+	"agent_token" platform output, and written to -token-file when provided.
+
+` + globalOptionsHelp + `
+Options:
+
+	-agent-pool   ID of the agent pool to create the token in.
+
+	-description  Description of the agent token.
+
+	-token-file   Writes the secret token value to the provided file path, readable only by the current user.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AgentTokenCreateCommand) Synopsis() string {
+	return "Creates an agent token in an agent pool"
+}
+
+type AgentTokenRevokeCommand struct {
+	*Meta
+
+	TokenID string
+}
+
+func (c *AgentTokenRevokeCommand) flags() *flag.FlagSet {
+	f := c.flagSet("agent token revoke")
+	f.StringVar(&c.TokenID, "token-id", "", "ID of the agent token to revoke.")
+	return f
+}
+
+func (c *AgentTokenRevokeCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.TokenID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("revoking an agent token requires a token id")
+		return 1
+	}
+
+	if err := c.cloud.RevokeAgentToken(c.appCtx, c.TokenID); err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error revoking agent token, '%s' in HCP Terraform: %s", c.TokenID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("agent_token_id", c.TokenID)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *AgentTokenRevokeCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] agent token revoke [options]
+
+	Revokes an agent token, agents using it can no longer register or poll for work.
+
+` + globalOptionsHelp + `
+Options:
+
+	-token-id  ID of the agent token to revoke.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AgentTokenRevokeCommand) Synopsis() string {
+	return "Revokes an agent token"
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// adds the resource changes of the run's apply and the state version it created
+func (c *Meta) addApplySummary(run *tfe.Run) {
+	if run == nil || (run.Status != tfe.RunApplied && run.Status != tfe.RunErrored) {
+		return
+	}
+	summary, err := c.cloud.GetApplySummary(c.appCtx, run)
+	if err != nil {
+		c.softFailure(fmt.Sprintf("failed to read the apply summary of run %s: %s", run.ID, err.Error()))
+	}
+	if summary == nil {
+		return
+	}
+	c.addOutput("resources_added", fmt.Sprint(summary.Added))
+	c.addOutput("resources_changed", fmt.Sprint(summary.Changed))
+	c.addOutput("resources_destroyed", fmt.Sprint(summary.Destroyed))
+	c.addOutput("resources_imported", fmt.Sprint(summary.Imported))
+	if summary.StateVersionID != "" {
+		c.addOutput("state_version_id", summary.StateVersionID)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// approvalsFile lists the approvals of a policy override, json is also accepted
+//
+//	approvals:
+//	  - approver: alice
+//	    comment: "Reviewed in CHANGE-1234"
+//	  - approver: bob
+type approvalsFile struct {
+	Approvals []*approval `yaml:"approvals"`
+}
+
+type approval struct {
+	Approver string `yaml:"approver"`
+	Comment  string `yaml:"comment,omitempty"`
+}
+
+func readApprovalsFile(path string) (*approvalsFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading approvals file: %w", err)
+	}
+	approvals := &approvalsFile{}
+	if err := yaml.Unmarshal(b, approvals); err != nil {
+		return nil, fmt.Errorf("error parsing approvals file %s: %w", path, err)
+	}
+	for i, a := range approvals.Approvals {
+		if a == nil || strings.TrimSpace(a.Approver) == "" {
+			return nil, fmt.Errorf("approvals file %s: approval at index %d requires an approver", path, i)
+		}
+	}
+	return approvals, nil
+}
+
+// distinct approvers in the order they approved, the author of the override cannot approve it
+func (f *approvalsFile) distinctApprovers(author string) []string {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(author)): true}
+	approvers := []string{}
+	for _, a := range f.Approvals {
+		approver := strings.TrimSpace(a.Approver)
+		key := strings.ToLower(approver)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		approvers = append(approvers, approver)
+	}
+	return approvers
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// writes outputs with an artifact name to the artifacts directory, the full value is replaced
+// in platform output with a `<name>_file` output containing the path to the artifact
+func (c *Meta) writeArtifacts() {
+	if c.artifactsDir == "" {
+		return
+	}
+
+	var artifacts []*outputMessage
+	for _, m := range c.messages {
+		if m.artifact != "" {
+			artifacts = append(artifacts, m)
+		}
+	}
+	if len(artifacts) == 0 {
+		return
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].name < artifacts[j].name })
+
+	if err := os.MkdirAll(c.artifactsDir, 0755); err != nil {
+		log.Printf("[ERROR] problem creating artifacts directory: '%s', with: %s", c.artifactsDir, err.Error())
+		c.writer.ErrorResult(fmt.Sprintf("error creating artifacts directory %s: %s", c.artifactsDir, err.Error()))
+		return
+	}
+
+	for _, m := range artifacts {
+		path := filepath.Join(c.artifactsDir, m.artifact)
+		if err := writeArtifact(path, m); err != nil {
+			// leave the value with platform output when the artifact could not be written
+			log.Printf("[ERROR] problem writing artifact: '%s', with: %s", path, err.Error())
+			c.writer.ErrorResult(fmt.Sprintf("error writing artifact %s: %s", path, err.Error()))
+			continue
+		}
+		m.platformOut = false
+		c.addOutput(fmt.Sprintf("%s_file", m.name), path)
+	}
+}
+
+func writeArtifact(path string, m *outputMessage) error {
+	val, err := m.Value()
+	if err != nil {
+		return err
+	}
+
+	data := []byte(val)
+	// indent json values so artifacts are readable when downloaded
+	var indented bytes.Buffer
+	if json.Indent(&indented, data, "", "  ") == nil {
+		data = indented.Bytes()
+	}
+	return os.WriteFile(path, data, 0644)
+}
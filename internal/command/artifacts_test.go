@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteArtifacts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+	WithArtifactsDir(dir)(cmd.Meta)
+
+	cmd.addOutput("run_id", "run-123")
+	cmd.addOutputWithOpts("payload", map[string]string{"id": "run-123"}, &outputOpts{
+		stdOut:      false,
+		multiLine:   true,
+		platformOut: true,
+		artifact:    "run.json",
+	})
+	cmd.closeOutput()
+
+	path := filepath.Join(dir, "run.json")
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("artifact read error: %v", err)
+	}
+	if !strings.Contains(string(contents), `"id": "run-123"`) {
+		t.Errorf("expected run payload in artifact but received %q", string(contents))
+	}
+
+	payload := cmd.messages["payload"]
+	if payload.IncludeWithPlatform() {
+		t.Error("expected payload to be excluded from platform output")
+	}
+	if file := cmd.outputValue("payload_file"); file != path {
+		t.Errorf("expected payload_file output %q but received %q", path, file)
+	}
+	if cmd.outputValue("run_id") != "run-123" {
+		t.Error("expected run_id output to be unchanged")
+	}
+}
+
+func TestWriteArtifacts_NotConfigured(t *testing.T) {
+	_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+
+	cmd.addOutputWithOpts("payload", map[string]string{"id": "run-123"}, &outputOpts{
+		stdOut:      false,
+		multiLine:   true,
+		platformOut: true,
+		artifact:    "run.json",
+	})
+	cmd.closeOutput()
+
+	if !cmd.messages["payload"].IncludeWithPlatform() {
+		t.Error("expected payload to be included with platform output")
+	}
+	if _, ok := cmd.messages["payload_file"]; ok {
+		t.Error("expected no payload_file output")
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type AuditExportFormat string
+
+const (
+	AuditExportJSONL AuditExportFormat = "jsonl"
+	AuditExportJSON  AuditExportFormat = "json"
+)
+
+func parseAuditExportFormat(format string) (AuditExportFormat, error) {
+	switch AuditExportFormat(format) {
+	case "", AuditExportJSONL:
+		return AuditExportJSONL, nil
+	case AuditExportJSON:
+		return AuditExportJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q, must be one of: jsonl, json", format)
+	}
+}
+
+// accepts either a duration relative to now, such as 24h, or an RFC3339 timestamp
+func parseAuditSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("since duration %q must be positive", since)
+		}
+		return now.Add(-d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since %q, must be a duration such as 24h or an RFC3339 timestamp", since)
+	}
+	return t.UTC(), nil
+}
+
+type AuditExportCommand struct {
+	*Meta
+
+	Since  string
+	Format string
+	File   string
+}
+
+func (c *AuditExportCommand) flags() *flag.FlagSet {
+	f := c.flagSet("audit export")
+	f.StringVar(&c.Since, "since", "24h", "Exports events newer than a duration such as 24h, or an RFC3339 timestamp.")
+	f.StringVar(&c.Format, "format", "jsonl", "Format of the exported file: jsonl or json.")
+	f.StringVar(&c.File, "file", "", "File path the audit trail events are written to.")
+	return f
+}
+
+func (c *AuditExportCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.File == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("exporting audit trails requires a file path")
+		return 1
+	}
+
+	format, err := parseAuditExportFormat(c.Format)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	since, err := parseAuditSince(c.Since, time.Now())
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	events, err := c.cloud.ListAuditTrails(c.appCtx, since)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing audit trails in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if err := writeAuditExport(c.File, format, events); err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error writing audit trails to file, '%s': %s", c.File, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("since", since.Format(time.RFC3339))
+	c.addOutput("event_count", fmt.Sprintf("%d", len(events)))
+	c.addOutput("file", c.File)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func writeAuditExport(path string, format AuditExportFormat, events []*tfe.AuditTrail) error {
+	data, err := renderAuditExport(format, events)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func renderAuditExport(format AuditExportFormat, events []*tfe.AuditTrail) ([]byte, error) {
+	if format == AuditExportJSON {
+		if events == nil {
+			events = []*tfe.AuditTrail{}
+		}
+		return json.MarshalIndent(events, "", "  ")
+	}
+	// one event per line, as expected by most log shippers
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *AuditExportCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] audit export [options]
+
+	Exports the organization audit trail events to a file, for ingestion into a SIEM.
+	Requires an organization token, audit trails are only available in HCP Terraform.
+
+` + globalOptionsHelp + `
+Options:
+
+	-since   Exports events newer than a duration such as 24h, or an RFC3339 timestamp. Defaults to "24h".
+
+	-format  Format of the exported file: jsonl or json. Defaults to "jsonl".
+
+	-file    File path the audit trail events are written to.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AuditExportCommand) Synopsis() string {
+	return "Exports organization audit trail events to a file"
+}
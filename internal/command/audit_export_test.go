@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testAuditService struct {
+	since  time.Time
+	events []*tfe.AuditTrail
+}
+
+func (s *testAuditService) ListAuditTrails(_ context.Context, since time.Time) ([]*tfe.AuditTrail, error) {
+	s.since = since
+	return s.events, nil
+}
+
+func testAuditExportCommand(audit cloud.AuditService) (*cli.MockUi, *AuditExportCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.AuditService = audit
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &AuditExportCommand{Meta: meta}
+}
+
+func TestAuditExportCommand(t *testing.T) {
+	audit := &testAuditService{events: []*tfe.AuditTrail{
+		{ID: "ae66e491-1", Type: "Resource", Resource: tfe.AuditTrailResource{Action: "create", Type: "run"}},
+		{ID: "ae66e491-2", Type: "Resource", Resource: tfe.AuditTrailResource{Action: "apply", Type: "run"}},
+	}}
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	ui, cmd := testAuditExportCommand(audit)
+	if code := cmd.Run([]string{"-since=2024-01-02T00:00:00Z", "-file=" + path}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !audit.since.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected since %s", audit.since)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"event_count": "2"`) {
+		t.Errorf("expected event count in output but received %s", ui.OutputWriter.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading export: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"id":"ae66e491-2"`) {
+		t.Errorf("expected one event per line but received %s", data)
+	}
+}
+
+func TestAuditExportCommand_Validation(t *testing.T) {
+	cases := map[string][]string{
+		"requires a file": {"-since=24h"},
+		"invalid format":  {"-format=csv", "-file=audit.csv"},
+		"invalid since":   {"-since=yesterday", "-file=audit.jsonl"},
+		"negative since":  {"-since=-1h", "-file=audit.jsonl"},
+	}
+	for name, args := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui, cmd := testAuditExportCommand(&testAuditService{})
+			if code := cmd.Run(args); code != 1 {
+				t.Errorf("expected exit code 1 but received %d: %s", code, ui.OutputWriter.String())
+			}
+		})
+	}
+}
+
+func TestParseAuditSince(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	since, err := parseAuditSince("24h", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !since.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected since %s", since)
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultBatchConcurrency = 5
+
+// batchManifest lists the workspaces of a batch operation, workspaces run after the workspaces they depend on
+//
+//	workspaces:
+//	  - name: networking
+//	  - name: compute
+//	    message: "Deploy compute"
+//	    depends_on: [networking]
+//	    variables:
+//	      vpc_id: networking.vpc_id
+type batchManifest struct {
+	Workspaces []*batchTarget `yaml:"workspaces"`
+}
+
+type batchTarget struct {
+	Name                   string   `yaml:"name"`
+	Message                string   `yaml:"message,omitempty"`
+	ConfigurationVersionID string   `yaml:"configuration_version,omitempty"`
+	DependsOn              []string `yaml:"depends_on,omitempty"`
+	// run variables from upstream workspace outputs, keyed by variable name with values of "<workspace>.<output>"
+	Variables map[string]string `yaml:"variables,omitempty"`
+}
+
+func readBatchManifest(path string) (*batchManifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest: %w", err)
+	}
+	manifest := &batchManifest{}
+	if err := yaml.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("error parsing manifest %s: %w", path, err)
+	}
+	for i, target := range manifest.Workspaces {
+		if target == nil || target.Name == "" {
+			return nil, fmt.Errorf("manifest %s: workspace at index %d requires a name", path, i)
+		}
+	}
+	return manifest, nil
+}
+
+// result of a batch operation for a single workspace
+type batchResult struct {
+	Workspace string `json:"workspace"`
+	Status    Status `json:"status"`
+	RunID     string `json:"run_id,omitempty"`
+	RunStatus string `json:"run_status,omitempty"`
+	RunLink   string `json:"run_link,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// runs fn for each target in dependency order with at most concurrency targets in flight.
+// targets whose upstream workspaces did not succeed are not run. results are returned in target order.
+func runDAG(ctx context.Context, targets []*batchTarget, concurrency int, fn func(context.Context, *batchTarget) *batchResult) ([]*batchResult, error) {
+	if err := validateDAG(targets); err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+	dependents := map[string][]string{}
+	pending := map[string]int{}
+	for i, t := range targets {
+		index[t.Name] = i
+		pending[t.Name] = len(t.DependsOn)
+		for _, dep := range t.DependsOn {
+			dependents[dep] = append(dependents[dep], t.Name)
+		}
+	}
+
+	results := make([]*batchResult, len(targets))
+	ready := []string{}
+	for _, t := range targets {
+		if pending[t.Name] == 0 {
+			ready = append(ready, t.Name)
+		}
+	}
+
+	type completed struct {
+		name   string
+		result *batchResult
+	}
+	// buffered, so targets complete and free their slot while others wait to start
+	done := make(chan completed, len(targets))
+	var g errgroup.Group
+	g.SetLimit(max(concurrency, 1))
+	defer g.Wait()
+	running := 0
+	remaining := len(targets)
+
+	// marks downstream targets of a failed target as not run
+	var skip func(name string)
+	skip = func(name string) {
+		for _, d := range dependents[name] {
+			if results[index[d]] != nil {
+				continue
+			}
+			results[index[d]] = &batchResult{
+				Workspace: d,
+				Status:    Error,
+				Error:     fmt.Sprintf("not run, upstream workspace %q did not succeed", name),
+			}
+			remaining--
+			skip(d)
+		}
+	}
+
+	for remaining > 0 {
+		for len(ready) > 0 {
+			name := ready[0]
+			ready = ready[1:]
+			if results[index[name]] != nil {
+				continue
+			}
+			running++
+			t := targets[index[name]]
+			g.Go(func() error {
+				done <- completed{name: t.Name, result: fn(ctx, t)}
+				return nil
+			})
+		}
+		if running == 0 {
+			break
+		}
+
+		c := <-done
+		running--
+		remaining--
+		results[index[c.name]] = c.result
+
+		if c.result.Status != Success && c.result.Status != Noop {
+			skip(c.name)
+			continue
+		}
+		for _, d := range dependents[c.name] {
+			pending[d]--
+			if pending[d] == 0 && results[index[d]] == nil {
+				ready = append(ready, d)
+			}
+		}
+	}
+	return results, nil
+}
+
+// checks dependencies reference targets of the batch and do not form a cycle
+func validateDAG(targets []*batchTarget) error {
+	names := map[string]*batchTarget{}
+	for _, t := range targets {
+		names[t.Name] = t
+	}
+	for _, t := range targets {
+		for _, dep := range t.DependsOn {
+			if _, ok := names[dep]; !ok {
+				return fmt.Errorf("workspace %q depends on %q, which is not part of the batch", t.Name, dep)
+			}
+		}
+		for key, ref := range t.Variables {
+			ws, _, err := parseOutputReference(ref)
+			if err != nil {
+				return fmt.Errorf("workspace %q variable %q: %w", t.Name, key, err)
+			}
+			if !slices.Contains(t.DependsOn, ws) {
+				return fmt.Errorf("workspace %q variable %q references %q, which must be listed in depends_on", t.Name, key, ws)
+			}
+		}
+	}
+
+	// 0: not visited, 1: visiting, 2: visited
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(append(path, name), " -> "))
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		for _, dep := range names[name].DependsOn {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		return nil
+	}
+	for _, t := range targets {
+		if err := visit(t.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splits an upstream output reference of "<workspace>.<output>"
+func parseOutputReference(ref string) (string, string, error) {
+	ws, output, ok := strings.Cut(ref, ".")
+	if !ok || ws == "" || output == "" {
+		return "", "", fmt.Errorf("invalid output reference %q, expected <workspace>.<output>", ref)
+	}
+	return ws, output, nil
+}
+
+// run variables for the target from the current state outputs of its upstream workspaces, encoded as HCL literals
+func upstreamVariables(ctx context.Context, target *batchTarget, readOutputs func(context.Context, string) (*tfe.StateVersionOutputsList, error)) ([]*tfe.RunVariable, error) {
+	keys := make([]string, 0, len(target.Variables))
+	for key := range target.Variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	outputs := map[string]*tfe.StateVersionOutputsList{}
+	vars := []*tfe.RunVariable{}
+	for _, key := range keys {
+		ws, name, err := parseOutputReference(target.Variables[key])
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := outputs[ws]; !ok {
+			list, err := readOutputs(ctx, ws)
+			if err != nil {
+				return nil, fmt.Errorf("error reading outputs of upstream workspace %q: %w", ws, err)
+			}
+			outputs[ws] = list
+		}
+
+		var output *tfe.StateVersionOutput
+		if outputs[ws] != nil {
+			for _, o := range outputs[ws].Items {
+				if o.Name == name {
+					output = o
+					break
+				}
+			}
+		}
+		if output == nil {
+			return nil, fmt.Errorf("upstream workspace %q has no output %q", ws, name)
+		}
+		if output.Sensitive && output.Value == nil {
+			return nil, fmt.Errorf("upstream workspace %q output %q is sensitive and cannot be read", ws, name)
+		}
+
+		// JSON values are valid HCL literals
+		value, err := json.Marshal(output.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding upstream workspace %q output %q: %w", ws, name, err)
+		}
+		log.Printf("[DEBUG] passing output %q of workspace %q as variable %q to workspace %q", name, ws, key, target.Name)
+		vars = append(vars, &tfe.RunVariable{Key: key, Value: string(value)})
+	}
+	return vars, nil
+}
+
+// combines run variables, later variables replace earlier ones with the same key
+func mergeRunVariables(sets ...[]*tfe.RunVariable) []*tfe.RunVariable {
+	merged := []*tfe.RunVariable{}
+	index := map[string]int{}
+	for _, set := range sets {
+		for _, v := range set {
+			if i, ok := index[v.Key]; ok {
+				merged[i] = v
+				continue
+			}
+			index[v.Key] = len(merged)
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
+
+// outputs the aggregated and per-workspace results of a batch, returning the exit code
+func (c *Meta) batchResults(results []*batchResult) int {
+	status, failed := batchStatus(results)
+	c.addOutput("status", string(status))
+	c.addOutput("run_count", fmt.Sprintf("%d", len(results)))
+	c.addOutput("failed_count", fmt.Sprintf("%d", failed))
+	c.addOutputWithOpts("runs", results, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+
+	if failed > 0 {
+		c.writer.ErrorResult(fmt.Sprintf("%d of %d runs did not succeed", failed, len(results)))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+// aggregated status of the batch, Success only when every workspace succeeded
+func batchStatus(results []*batchResult) (Status, int) {
+	failed := 0
+	status := Success
+	for _, r := range results {
+		switch r.Status {
+		case Success, Noop:
+		case Timeout:
+			failed++
+			if status == Success {
+				status = Timeout
+			}
+		default:
+			failed++
+			status = Error
+		}
+	}
+	return status, failed
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestRunDAG_Order(t *testing.T) {
+	targets := []*batchTarget{
+		{Name: "app", DependsOn: []string{"compute", "dns"}},
+		{Name: "compute", DependsOn: []string{"networking"}},
+		{Name: "dns"},
+		{Name: "networking"},
+	}
+
+	var mu sync.Mutex
+	finished := map[string]bool{}
+	results, err := runDAG(context.Background(), targets, 2, func(_ context.Context, target *batchTarget) *batchResult {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, dep := range target.DependsOn {
+			if !finished[dep] {
+				t.Errorf("%s started before upstream %s finished", target.Name, dep)
+			}
+		}
+		finished[target.Name] = true
+		return &batchResult{Workspace: target.Name, Status: Success}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for i, r := range results {
+		if r.Workspace != targets[i].Name {
+			t.Errorf("expected result %d for %s but received %s", i, targets[i].Name, r.Workspace)
+		}
+	}
+}
+
+func TestRunDAG_UpstreamFailure(t *testing.T) {
+	targets := []*batchTarget{
+		{Name: "networking"},
+		{Name: "compute", DependsOn: []string{"networking"}},
+		{Name: "app", DependsOn: []string{"compute"}},
+		{Name: "dns"},
+	}
+
+	results, err := runDAG(context.Background(), targets, 1, func(_ context.Context, target *batchTarget) *batchResult {
+		if target.Name == "networking" {
+			return &batchResult{Workspace: target.Name, Status: Error}
+		}
+		if target.Name != "dns" {
+			t.Errorf("did not expect %s to run", target.Name)
+		}
+		return &batchResult{Workspace: target.Name, Status: Success}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []Status{Error, Error, Error, Success}
+	for i, r := range results {
+		if r.Status != expected[i] {
+			t.Errorf("expected %s to have status %s but received %s", r.Workspace, expected[i], r.Status)
+		}
+	}
+	if !strings.Contains(results[2].Error, `upstream workspace "compute"`) {
+		t.Errorf("unexpected error for app: %q", results[2].Error)
+	}
+}
+
+func TestValidateDAG(t *testing.T) {
+	cases := map[string]struct {
+		targets  []*batchTarget
+		expected string
+	}{
+		"valid": {
+			targets: []*batchTarget{
+				{Name: "networking"},
+				{Name: "compute", DependsOn: []string{"networking"}, Variables: map[string]string{"vpc_id": "networking.vpc_id"}},
+			},
+		},
+		"unknown dependency": {
+			targets:  []*batchTarget{{Name: "compute", DependsOn: []string{"networking"}}},
+			expected: `workspace "compute" depends on "networking", which is not part of the batch`,
+		},
+		"cycle": {
+			targets: []*batchTarget{
+				{Name: "a", DependsOn: []string{"c"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			expected: "dependency cycle detected: a -> c -> b -> a",
+		},
+		"variable without dependency": {
+			targets: []*batchTarget{
+				{Name: "networking"},
+				{Name: "compute", Variables: map[string]string{"vpc_id": "networking.vpc_id"}},
+			},
+			expected: `workspace "compute" variable "vpc_id" references "networking", which must be listed in depends_on`,
+		},
+		"invalid reference": {
+			targets:  []*batchTarget{{Name: "compute", Variables: map[string]string{"vpc_id": "networking"}}},
+			expected: `invalid output reference "networking"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateDAG(tc.targets)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("expected error %q but received %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestUpstreamVariables(t *testing.T) {
+	outputs := &tfe.StateVersionOutputsList{Items: []*tfe.StateVersionOutput{
+		{Name: "vpc_id", Value: "vpc-123"},
+		{Name: "subnets", Value: []interface{}{"a", "b"}},
+		{Name: "secret", Sensitive: true},
+	}}
+	read := func(_ context.Context, ws string) (*tfe.StateVersionOutputsList, error) {
+		return outputs, nil
+	}
+
+	vars, err := upstreamVariables(context.Background(), &batchTarget{
+		Name:      "compute",
+		Variables: map[string]string{"vpc_id": "networking.vpc_id", "subnet_ids": "networking.subnets"},
+	}, read)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(vars) != 2 || vars[0].Key != "subnet_ids" || vars[0].Value != `["a","b"]` || vars[1].Value != `"vpc-123"` {
+		t.Errorf("unexpected variables: %v %v", vars[0], vars[1])
+	}
+
+	for ref, expected := range map[string]string{
+		"networking.missing": `upstream workspace "networking" has no output "missing"`,
+		"networking.secret":  `upstream workspace "networking" output "secret" is sensitive`,
+	} {
+		_, err := upstreamVariables(context.Background(), &batchTarget{Name: "compute", Variables: map[string]string{"v": ref}}, read)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %q but received %v", expected, err)
+		}
+	}
+}
+
+func TestMergeRunVariables(t *testing.T) {
+	merged := mergeRunVariables(
+		[]*tfe.RunVariable{{Key: "region", Value: `"us-east-1"`}, {Key: "vpc_id", Value: `"env"`}},
+		[]*tfe.RunVariable{{Key: "vpc_id", Value: `"upstream"`}},
+	)
+	if len(merged) != 2 || merged[1].Value != `"upstream"` {
+		t.Errorf("unexpected merged variables: %v", merged)
+	}
+}
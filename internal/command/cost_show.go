@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type CostShowCommand struct {
+	*Meta
+
+	RunID string
+}
+
+func (c *CostShowCommand) flags() *flag.FlagSet {
+	f := c.flagSet("cost show")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to show the cost estimate of.")
+	return f
+}
+
+func (c *CostShowCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("showing a cost estimate requires a run id")
+		return 1
+	}
+
+	estimate, err := c.cloud.GetCostEstimate(c.appCtx, c.RunID)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error reading cost estimate of run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if estimate.ErrorMessage != "" {
+		c.softFailure(fmt.Sprintf("Cost Estimation errored: %s", estimate.ErrorMessage))
+	}
+	// only finished cost estimates have a resource breakdown
+	if estimate.Status == string(tfe.CostEstimateFinished) {
+		resources, resErr := c.cloud.GetCostEstimateResources(c.appCtx, estimate.ID)
+		if resErr != nil {
+			c.softFailure(fmt.Sprintf("failed to read cost estimate resources: %s", resErr.Error()))
+		}
+		estimate.Resources = resources
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_id", c.RunID)
+	c.addOutput("cost_estimation_id", estimate.ID)
+	c.addOutput("cost_estimation_status", estimate.Status)
+	c.addOutput("prior_monthly_cost", estimate.PriorMonthlyCost)
+	c.addOutput("proposed_monthly_cost", estimate.ProposedMonthlyCost)
+	c.addOutput("delta_monthly_cost", estimate.DeltaMonthlyCost)
+	c.addOutput("resources_count", fmt.Sprintf("%d", estimate.ResourcesCount))
+	c.addOutput("matched_resources_count", fmt.Sprintf("%d", estimate.MatchedResourcesCount))
+	c.addOutput("unmatched_resources_count", fmt.Sprintf("%d", estimate.UnmatchedResourcesCount))
+	if estimate.Resources != nil {
+		c.addOutputWithOpts("cost_resources", estimate.Resources, &outputOpts{
+			stdOut:      true,
+			multiLine:   true,
+			platformOut: true,
+			artifact:    "cost_resources.json",
+		})
+	}
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *CostShowCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] cost show [options]
+
+	Returns the cost estimate of a run, with the monthly cost of each resource.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run  Existing HCP Terraform Run ID to show the cost estimate of.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *CostShowCommand) Synopsis() string {
+	return "Returns the cost estimate of a run"
+}
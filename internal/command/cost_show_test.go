@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testCostRunService struct {
+	cloud.RunService
+	estimate     *cloud.CostEstimateDetails
+	resourcesErr error
+}
+
+func (s *testCostRunService) GetCostEstimate(context.Context, string) (*cloud.CostEstimateDetails, error) {
+	return s.estimate, nil
+}
+
+func (s *testCostRunService) GetCostEstimateResources(context.Context, string) ([]*cloud.CostEstimateResource, error) {
+	if s.resourcesErr != nil {
+		return nil, s.resourcesErr
+	}
+	return []*cloud.CostEstimateResource{{Address: "aws_instance.web", Type: "aws_instance", Matched: true, DeltaMonthlyCost: "8.35"}}, nil
+}
+
+func testCostShowCommand(runs cloud.RunService) (*cli.MockUi, *CostShowCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &CostShowCommand{Meta: meta}
+}
+
+func TestCostShowCommand(t *testing.T) {
+	runs := &testCostRunService{estimate: &cloud.CostEstimateDetails{ID: "ce-1", Status: "finished", DeltaMonthlyCost: "8.35", MatchedResourcesCount: 1}}
+	ui, cmd := testCostShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"delta_monthly_cost": "8.35"`, `"matched_resources_count": "1"`, `"address": "aws_instance.web"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestCostShowCommand_ResourcesSoftFailure(t *testing.T) {
+	runs := &testCostRunService{
+		estimate:     &cloud.CostEstimateDetails{ID: "ce-1", Status: "finished"},
+		resourcesErr: errors.New("error parsing cost estimate logs"),
+	}
+	ui, cmd := testCostShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "failed to read cost estimate resources") {
+		t.Errorf("expected soft failure but received %s", ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stackManifest maps directories of a repository to workspaces
+//
+//	stacks:
+//	  - workspace: networking
+//	    directory: infra/networking
+//	  - workspace: compute
+//	    directory: infra/compute
+//	    watch: ["modules/**", "versions.tf"]
+type stackManifest struct {
+	Stacks []*stack `yaml:"stacks"`
+}
+
+type stack struct {
+	Workspace string `yaml:"workspace" json:"workspace"`
+	Directory string `yaml:"directory" json:"directory"`
+	// additional paths or glob patterns that affect the stack, such as shared modules
+	Watch []string `yaml:"watch" json:"-"`
+}
+
+func readStackManifest(path string) (*stackManifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest: %w", err)
+	}
+	manifest := &stackManifest{}
+	if err := yaml.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("error parsing manifest %s: %w", path, err)
+	}
+	for i, s := range manifest.Stacks {
+		if s == nil || s.Workspace == "" || s.Directory == "" {
+			return nil, fmt.Errorf("manifest %s: stack at index %d requires a workspace and directory", path, i)
+		}
+	}
+	return manifest, nil
+}
+
+// reports whether the changed file is within the directory or matches the glob pattern
+func matchesPath(pattern string, file string) bool {
+	pattern = path.Clean(strings.TrimPrefix(pattern, "./"))
+	if pattern == "." || file == pattern || strings.HasPrefix(file, pattern+"/") {
+		return true
+	}
+	// "**" matches any number of directories
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return strings.HasPrefix(file, prefix+"/")
+	}
+	matched, _ := path.Match(pattern, file)
+	return matched
+}
+
+// stacks affected by any of the changed files, in manifest order
+func affectedStacks(stacks []*stack, files []string) []*stack {
+	affected := []*stack{}
+	for _, s := range stacks {
+		patterns := append([]string{s.Directory}, s.Watch...)
+	files:
+		for _, file := range files {
+			for _, pattern := range patterns {
+				if matchesPath(pattern, file) {
+					log.Printf("[DEBUG] stack %s affected by change to: %s", s.Workspace, file)
+					affected = append(affected, s)
+					break files
+				}
+			}
+		}
+	}
+	return affected
+}
+
+// files changed between the merge base of base and head, and head
+func gitChangedFiles(base string, head string) ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", "diff", "--name-only", "--no-renames", fmt.Sprintf("%s...%s", base, head))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error listing changed files with git: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	files := []string{}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+type DetectChangesCommand struct {
+	*Meta
+
+	Base           string
+	Head           string
+	Manifest       string
+	OutputManifest string
+}
+
+func (c *DetectChangesCommand) flags() *flag.FlagSet {
+	f := c.flagSet("detect-changes")
+	f.StringVar(&c.Base, "base", "", "The git commit, branch or tag to compare changes against.")
+	f.StringVar(&c.Head, "head", "HEAD", "The git commit, branch or tag with the changes.")
+	f.StringVar(&c.Manifest, "manifest", "stacks.yaml", "Path to a YAML manifest mapping directories to workspaces.")
+	f.StringVar(&c.OutputManifest, "output-manifest", "", "Writes the affected workspaces to a manifest file for \"run create-batch -manifest\".")
+	return f
+}
+
+func (c *DetectChangesCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Base == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("detecting changes requires a base commit, provide -base")
+		return 1
+	}
+
+	manifest, err := readStackManifest(c.Manifest)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	files, err := gitChangedFiles(c.Base, c.Head)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+	log.Printf("[DEBUG] %d files changed between %s and %s", len(files), c.Base, c.Head)
+
+	affected := affectedStacks(manifest.Stacks, files)
+	workspaces := []string{}
+	directories := []string{}
+	targets := []*batchTarget{}
+	for _, s := range affected {
+		workspaces = append(workspaces, s.Workspace)
+		directories = append(directories, s.Directory)
+		targets = append(targets, &batchTarget{Name: s.Workspace})
+	}
+
+	if c.OutputManifest != "" {
+		b, err := yaml.Marshal(&batchManifest{Workspaces: targets})
+		if err == nil {
+			err = os.WriteFile(c.OutputManifest, b, 0644)
+		}
+		if err != nil {
+			c.addOutput("status", string(Error))
+			c.closeOutput()
+			c.writer.ErrorResult(fmt.Sprintf("error writing manifest %s: %s", c.OutputManifest, err.Error()))
+			return 1
+		}
+	}
+
+	status := Success
+	if len(affected) == 0 {
+		c.writer.Output("No stacks are affected by the changes")
+		status = Noop
+	}
+	c.addOutput("status", string(status))
+	c.addOutput("changed_files_count", fmt.Sprintf("%d", len(files)))
+	c.addOutput("affected_count", fmt.Sprintf("%d", len(affected)))
+	c.addOutput("workspaces", strings.Join(workspaces, ","))
+	c.addOutput("directories", strings.Join(directories, ","))
+	c.addOutputWithOpts("stacks", affected, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *DetectChangesCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] detect-changes [options]
+
+	Maps files changed since a base commit to the workspaces of a stack manifest, and returns the affected workspaces.
+
+` + globalOptionsHelp + `
+Options:
+
+	-base             The git commit, branch or tag to compare changes against.
+
+	-head             The git commit, branch or tag with the changes. Defaults to HEAD.
+
+	-manifest         Path to a YAML manifest mapping directories to workspaces. Defaults to stacks.yaml.
+
+	-output-manifest  Writes the affected workspaces to a manifest file for "run create-batch -manifest".
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *DetectChangesCommand) Synopsis() string {
+	return "Returns the workspaces affected by changes since a base commit"
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+func TestMatchesPath(t *testing.T) {
+	cases := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"infra/networking", "infra/networking/main.tf", true},
+		{"./infra/networking/", "infra/networking/modules/vpc/main.tf", true},
+		{"infra/networking", "infra/networking-v2/main.tf", false},
+		{"modules/**", "modules/vpc/main.tf", true},
+		{"*.tf", "versions.tf", true},
+		{"*.tf", "infra/versions.tf", false},
+		{"infra/*/versions.tf", "infra/compute/versions.tf", true},
+	}
+	for _, tc := range cases {
+		if got := matchesPath(tc.pattern, tc.file); got != tc.want {
+			t.Errorf("matchesPath(%q, %q) = %t, want %t", tc.pattern, tc.file, got, tc.want)
+		}
+	}
+}
+
+func TestAffectedStacks(t *testing.T) {
+	stacks := []*stack{
+		{Workspace: "networking", Directory: "infra/networking"},
+		{Workspace: "compute", Directory: "infra/compute", Watch: []string{"modules/**"}},
+		{Workspace: "dns", Directory: "infra/dns"},
+	}
+	affected := affectedStacks(stacks, []string{"infra/networking/main.tf", "infra/networking/outputs.tf", "modules/vm/main.tf", "README.md"})
+	if len(affected) != 2 || affected[0].Workspace != "networking" || affected[1].Workspace != "compute" {
+		t.Errorf("expected networking and compute to be affected but received %v", affected)
+	}
+}
+
+func testGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=tfci", "-c", "user.email=tfci@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %s", strings.Join(args, " "), out)
+		}
+	}
+	write := func(name string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q", "-b", "main")
+	write("infra/networking/main.tf")
+	write("infra/compute/main.tf")
+	write("stacks.yaml")
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+	git("tag", "base")
+	write("infra/compute/variables.tf")
+	git("add", "-A")
+	git("commit", "-q", "-m", "change compute")
+
+	manifest := "stacks:\n  - workspace: networking\n    directory: infra/networking\n  - workspace: compute\n    directory: infra/compute\n"
+	if err := os.WriteFile(filepath.Join(dir, "stacks.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func testDetectChangesCommand(t *testing.T) (*cli.MockUi, *DetectChangesCommand) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer))
+	return ui, &DetectChangesCommand{Meta: meta}
+}
+
+func TestDetectChangesCommand(t *testing.T) {
+	dir := testGitRepo(t)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	ui, cmd := testDetectChangesCommand(t)
+	if code := cmd.Run([]string{"-base=base", "-output-manifest=affected.yaml"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"status": "Success"`, `"workspaces": "compute"`, `"changed_files_count": "1"`, `"directory": "infra/compute"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+
+	manifest, err := readBatchManifest("affected.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Workspaces) != 1 || manifest.Workspaces[0].Name != "compute" {
+		t.Errorf("unexpected manifest workspaces: %v", manifest.Workspaces)
+	}
+
+	ui, cmd = testDetectChangesCommand(t)
+	if code := cmd.Run([]string{"-base=HEAD"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"status": "Noop"`) {
+		t.Errorf("expected noop status without changes but received %s", ui.OutputWriter.String())
+	}
+}
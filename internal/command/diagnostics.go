@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// adds the errors and warnings Terraform reported for an errored run, errors are also written to stderr
+func (c *Meta) addRunDiagnostics(run *tfe.Run) {
+	if run == nil || run.Status != tfe.RunErrored {
+		return
+	}
+	diagnostics, err := c.cloud.GetRunDiagnostics(c.appCtx, run)
+	if err != nil {
+		c.softFailure(fmt.Sprintf("failed to read diagnostics of run %s: %s", run.ID, err.Error()))
+		return
+	}
+	if len(diagnostics) == 0 {
+		return
+	}
+
+	for _, d := range diagnostics {
+		if d.Severity == "error" {
+			c.writer.ErrorResult(formatDiagnostic(d))
+		}
+	}
+	c.addOutputWithOpts("diagnostics", diagnostics, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		artifact:    "diagnostics.json",
+	})
+}
+
+func formatDiagnostic(d *cloud.Diagnostic) string {
+	msg := fmt.Sprintf("Terraform %s: %s", d.Severity, d.Summary)
+	if d.Address != "" {
+		msg = fmt.Sprintf("%s, with %s", msg, d.Address)
+	}
+	if d.Filename != "" {
+		msg = fmt.Sprintf("%s, on %s line %d", msg, d.Filename, d.Line)
+	}
+	if d.Detail != "" {
+		msg = fmt.Sprintf("%s\n%s", msg, d.Detail)
+	}
+	return msg
+}
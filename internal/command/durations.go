@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// run phases reported as <phase>_duration_seconds outputs
+const (
+	durationUpload = "upload"
+	durationQueue  = "queue"
+	durationPlan   = "plan"
+	durationPolicy = "policy"
+	durationApply  = "apply"
+	durationTotal  = "total"
+)
+
+// returns the first timestamp that has been set
+func firstTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func latestTime(times ...time.Time) time.Time {
+	latest := time.Time{}
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// durations of the phases a run has completed, from the run status timestamps
+func runDurations(run *tfe.Run) map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	if run == nil || run.StatusTimestamps == nil {
+		return durations
+	}
+	ts := run.StatusTimestamps
+
+	add := func(phase string, start time.Time, end time.Time) {
+		if !start.IsZero() && !end.IsZero() && !end.Before(start) {
+			durations[phase] = end.Sub(start)
+		}
+	}
+
+	add(durationQueue, firstTime(ts.PlanQueuedAt, run.CreatedAt), ts.PlanningAt)
+	plannedAt := firstTime(ts.PlannedAt, ts.PlannedAndFinishedAt, ts.PlannedAndSavedAt)
+	add(durationPlan, ts.PlanningAt, plannedAt)
+	// post plan task stages evaluate policies, legacy sentinel policy checks follow cost estimation
+	if !ts.PostPlanRunningAt.IsZero() {
+		add(durationPolicy, ts.PostPlanRunningAt, ts.PostPlanCompletedAt)
+	} else {
+		add(durationPolicy, firstTime(ts.CostEstimatedAt, ts.PlannedAt), firstTime(ts.PolicyCheckedAt, ts.PolicySoftFailedAt))
+	}
+	add(durationApply, ts.ApplyingAt, firstTime(ts.AppliedAt, latestTime(ts.ErroredAt, ts.CanceledAt, ts.ForceCanceledAt)))
+	add(durationTotal, run.CreatedAt, latestTime(
+		ts.AppliedAt, ts.PlannedAndFinishedAt, ts.PlannedAndSavedAt, ts.PlannedAt, ts.PolicyCheckedAt, ts.PolicySoftFailedAt,
+		ts.PostPlanCompletedAt, ts.ErroredAt, ts.CanceledAt, ts.ForceCanceledAt, ts.DiscardedAt,
+	))
+	return durations
+}
+
+func (c *Meta) addDuration(phase string, d time.Duration) {
+	c.addOutput(phase+"_duration_seconds", strconv.FormatFloat(d.Seconds(), 'f', 3, 64))
+}
+
+func (c *Meta) addRunDurations(run *tfe.Run) {
+	for phase, d := range runDurations(run) {
+		c.addDuration(phase, d)
+	}
+}
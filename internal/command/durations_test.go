@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestRunDurations(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return created.Add(time.Duration(seconds) * time.Second) }
+
+	run := &tfe.Run{
+		CreatedAt: created,
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			PlanQueuedAt:        at(2),
+			PlanningAt:          at(12),
+			PlannedAt:           at(72),
+			PostPlanRunningAt:   at(73),
+			PostPlanCompletedAt: at(83),
+			ApplyingAt:          at(100),
+			AppliedAt:           at(160),
+		},
+	}
+
+	expected := map[string]time.Duration{
+		durationQueue:  10 * time.Second,
+		durationPlan:   60 * time.Second,
+		durationPolicy: 10 * time.Second,
+		durationApply:  60 * time.Second,
+		durationTotal:  160 * time.Second,
+	}
+	durations := runDurations(run)
+	if len(durations) != len(expected) {
+		t.Errorf("expected %d durations but received %v", len(expected), durations)
+	}
+	for phase, want := range expected {
+		if got := durations[phase]; got != want {
+			t.Errorf("expected %s duration of %v but received %v", phase, want, got)
+		}
+	}
+}
+
+func TestRunDurations_PlanOnly(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	run := &tfe.Run{
+		CreatedAt: created,
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			PlanningAt:           created.Add(5 * time.Second),
+			PlannedAndFinishedAt: created.Add(35 * time.Second),
+		},
+	}
+
+	durations := runDurations(run)
+	if durations[durationPlan] != 30*time.Second || durations[durationTotal] != 35*time.Second {
+		t.Errorf("expected plan and total durations of 30s and 35s but received %v", durations)
+	}
+	if _, ok := durations[durationApply]; ok {
+		t.Errorf("expected no apply duration for a plan-only run but received %v", durations[durationApply])
+	}
+	if len(runDurations(&tfe.Run{})) != 0 {
+		t.Error("expected no durations without status timestamps")
+	}
+}
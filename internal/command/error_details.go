@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// categories of the error output, automation can branch on these instead of parsing messages
+const (
+	errorTypeTimeout       = "timeout"
+	errorTypePolicyBlocked = "policy_blocked"
+	errorTypeCanceled      = "canceled"
+	errorTypeUnauthorized  = "unauthorized"
+	errorTypeForbidden     = "forbidden"
+	errorTypeNotFound      = "not_found"
+	errorTypeConflict      = "conflict"
+	errorTypeRateLimited   = "rate_limited"
+	errorTypeServerError   = "server_error"
+	errorTypeAPI           = "api_error"
+	errorTypeError         = "error"
+)
+
+type errorDetails struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	// failed request to HCP Terraform that caused the error, omitted when the error did not come from the API
+	RequestID  string `json:"request_id,omitempty"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+}
+
+// adds the error resolved by resolveStatus as a structured output
+func (c *Meta) addErrorDetails() {
+	if c.err == nil {
+		return
+	}
+	details := newErrorDetails(c.err)
+	c.addOutputWithOpts("error", details, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+}
+
+func newErrorDetails(err error) *errorDetails {
+	details := &errorDetails{
+		Type:    errorType(err),
+		Message: err.Error(),
+	}
+	// only the failed request that caused the error, errors raised by tfci are not caused by a request
+	var apiErr *cloud.APIError
+	if errors.As(err, &apiErr) {
+		details.RequestID = apiErr.RequestID
+		details.HTTPStatus = apiErr.StatusCode
+	}
+	return details
+}
+
+func errorType(err error) string {
+	var timeoutErr *cloud.RetryTimeoutError
+	var runErr *cloud.RunStatusError
+	var policyErr *policyFailedError
+	var apiErr *cloud.APIError
+	switch {
+	case errors.As(err, &timeoutErr):
+		return errorTypeTimeout
+	case errors.As(err, &runErr) && runErr.PolicyBlocked(), errors.As(err, &policyErr):
+		return errorTypePolicyBlocked
+	case errors.As(err, &runErr) && runErr.Canceled():
+		return errorTypeCanceled
+	case errors.Is(err, tfe.ErrUnauthorized):
+		return errorTypeUnauthorized
+	case errors.Is(err, tfe.ErrResourceNotFound):
+		return errorTypeNotFound
+	case isConflictError(err):
+		return errorTypeConflict
+	case !errors.As(err, &apiErr):
+		return errorTypeError
+	}
+
+	// the failed response of statuses without a go-tfe error
+	switch status := apiErr.StatusCode; {
+	case status == http.StatusUnauthorized:
+		return errorTypeUnauthorized
+	case status == http.StatusForbidden:
+		return errorTypeForbidden
+	case status == http.StatusNotFound:
+		return errorTypeNotFound
+	case status == http.StatusConflict:
+		return errorTypeConflict
+	case status == http.StatusTooManyRequests:
+		return errorTypeRateLimited
+	case status >= http.StatusInternalServerError:
+		return errorTypeServerError
+	default:
+		return errorTypeAPI
+	}
+}
+
+// go-tfe returns these errors for conflicting workspace lock requests
+func isConflictError(err error) bool {
+	for _, target := range []error{
+		tfe.ErrWorkspaceLocked,
+		tfe.ErrWorkspaceNotLocked,
+		tfe.ErrWorkspaceLockedByRun,
+		tfe.ErrWorkspaceLockedByTeam,
+		tfe.ErrWorkspaceLockedByUser,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
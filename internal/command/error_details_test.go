@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+func TestNewErrorDetails(t *testing.T) {
+	forbidden := &cloud.APIError{StatusCode: http.StatusForbidden, RequestID: "req-abc123", Message: "insufficient permissions"}
+	serverError := &cloud.APIError{StatusCode: http.StatusBadGateway, Message: "internal server error"}
+	invalid := &cloud.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "invalid attribute"}
+	testCases := []struct {
+		name     string
+		err      error
+		expected errorDetails
+	}{
+		{name: "error", err: errors.New("boom"), expected: errorDetails{Type: errorTypeError, Message: "boom"}},
+		{name: "timeout", err: &cloud.RetryTimeoutError{}, expected: errorDetails{Type: errorTypeTimeout}},
+		{name: "policy-blocked", err: &cloud.RunStatusError{Status: tfe.RunPolicyOverride}, expected: errorDetails{Type: errorTypePolicyBlocked}},
+		{name: "canceled", err: &cloud.RunStatusError{Status: tfe.RunDiscarded}, expected: errorDetails{Type: errorTypeCanceled}},
+		{name: "not-found", err: fmt.Errorf("reading run: %w", tfe.ErrResourceNotFound), expected: errorDetails{Type: errorTypeNotFound}},
+		{name: "unauthorized", err: tfe.ErrUnauthorized, expected: errorDetails{Type: errorTypeUnauthorized}},
+		{name: "forbidden", err: fmt.Errorf("reading workspace: %w", forbidden), expected: errorDetails{Type: errorTypeForbidden, RequestID: "req-abc123", HTTPStatus: http.StatusForbidden}},
+		{name: "conflict", err: tfe.ErrWorkspaceLocked, expected: errorDetails{Type: errorTypeConflict}},
+		{name: "server-error", err: serverError, expected: errorDetails{Type: errorTypeServerError, HTTPStatus: http.StatusBadGateway}},
+		{name: "api-error", err: invalid, expected: errorDetails{Type: errorTypeAPI, HTTPStatus: http.StatusUnprocessableEntity}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			details := newErrorDetails(tc.err)
+			if details.Message != tc.err.Error() {
+				t.Errorf("expected message %q but received %q", tc.err.Error(), details.Message)
+			}
+			details.Message = tc.expected.Message
+			if *details != tc.expected {
+				t.Errorf("expected %+v but received %+v", tc.expected, *details)
+			}
+		})
+	}
+}
+
+func TestMeta_CloseOutput_ErrorDetails(t *testing.T) {
+	_, meta := testMetaWithPlatform(t, &testPlatformContext{})
+
+	meta.addOutput("status", string(meta.resolveStatus(fmt.Errorf("reading workspace: %w", tfe.ErrResourceNotFound))))
+	var result struct {
+		Status string        `json:"status"`
+		Error  *errorDetails `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(meta.closeOutput()), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Error == nil {
+		t.Fatal("expected error output")
+	}
+	if result.Error.Type != errorTypeNotFound || result.Error.Message != "reading workspace: resource not found" {
+		t.Errorf("unexpected error output: %+v", result.Error)
+	}
+}
+
+func TestMeta_CloseOutput_ErrorDetails_FailedRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		read     string
+		err      func(error) error
+		expected int
+	}{
+		// the handled not found response did not cause the error
+		{name: "handled", read: "ws-missing", err: func(error) error { return errors.New("error parsing plan") }},
+		{name: "failed", read: "ws-forbidden", err: func(err error) error { return fmt.Errorf("reading workspace: %w", err) }, expected: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, meta := testMetaWithFixtures(t, map[string]string{
+				"api/v2/workspaces/ws-forbidden/GET.json": `{"errors":[{"status":"403","title":"forbidden"}]}`,
+			})
+			_, err := client.Workspaces.ReadByID(context.Background(), tc.read)
+			if err == nil {
+				t.Fatal("expected the request to fail")
+			}
+
+			meta.addOutput("status", string(meta.resolveStatus(tc.err(err))))
+			var result struct {
+				Error *errorDetails `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(meta.closeOutput()), &result); err != nil {
+				t.Fatal(err)
+			}
+			if result.Error == nil || result.Error.HTTPStatus != tc.expected {
+				t.Errorf("expected http status %d but received %+v", tc.expected, result.Error)
+			}
+		})
+	}
+}
+
+func TestMeta_CloseOutput_NoErrorDetails(t *testing.T) {
+	_, meta := testMetaWithPlatform(t, &testPlatformContext{})
+
+	meta.addOutput("status", string(meta.resolveStatus(nil)))
+	meta.closeOutput()
+	if _, ok := meta.messages["error"]; ok {
+		t.Error("expected no error output for a successful command")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/mitchellh/cli"
+)
+
+type ExitCodeMode string
+
+const (
+	// 0 on success or noop, 1 on any failure
+	SimpleExitCodes ExitCodeMode = "simple"
+	// distinct exit code per failure type, 0 on noop
+	DetailedExitCodes ExitCodeMode = "detailed"
+	// detailed exit codes, noop exits with ExitNoop
+	StrictExitCodes ExitCodeMode = "strict"
+)
+
+const (
+	ExitSuccess       = 0
+	ExitError         = 1
+	ExitTimeout       = 2
+	ExitNoop          = 3
+	ExitPolicyBlocked = 4
+	ExitCanceled      = 5
+	ExitAuthError     = 6
+	ExitNotFound      = 7
+	ExitForbidden     = 8
+)
+
+func ParseExitCodeMode(mode string) (ExitCodeMode, error) {
+	switch ExitCodeMode(mode) {
+	case "", SimpleExitCodes:
+		return SimpleExitCodes, nil
+	case DetailedExitCodes, StrictExitCodes:
+		return ExitCodeMode(mode), nil
+	default:
+		return "", fmt.Errorf("invalid exit code mode %q, expected one of: simple, detailed, strict", mode)
+	}
+}
+
+// maps the error resolved by resolveStatus to an exit code
+func errorExitCode(err error) int {
+	var timeoutErr *cloud.RetryTimeoutError
+	var runErr *cloud.RunStatusError
+	var policyErr *policyFailedError
+	switch {
+	case err == nil:
+		return ExitError
+	case errors.As(err, &timeoutErr):
+		return ExitTimeout
+	case errors.As(err, &runErr) && runErr.PolicyBlocked():
+		return ExitPolicyBlocked
+	case errors.As(err, &policyErr):
+		return ExitPolicyBlocked
+	case errors.As(err, &runErr) && runErr.Canceled():
+		return ExitCanceled
+	case errors.Is(err, tfe.ErrUnauthorized):
+		return ExitAuthError
+	case errors.Is(err, tfe.ErrResourceNotFound):
+		return ExitNotFound
+	default:
+		return ExitError
+	}
+}
+
+// resolves the exit code of a command from its result, for the configured exit code mode
+func (c *Meta) exitCode(code int) int {
+	if code == ExitSuccess && c.failedStrict() {
+		code = ExitError
+	}
+	if c.exitCodeMode == "" || c.exitCodeMode == SimpleExitCodes {
+		return code
+	}
+	if code == ExitSuccess {
+		if c.exitCodeMode == StrictExitCodes && c.outputValue("status") == string(Noop) {
+			return ExitNoop
+		}
+		return ExitSuccess
+	}
+	switch Status(c.outputValue("status")) {
+	case Timeout:
+		return ExitTimeout
+	case Unauthorized:
+		return ExitAuthError
+	case Forbidden:
+		return ExitForbidden
+	case NotFound:
+		return ExitNotFound
+	default:
+		return errorExitCode(c.err)
+	}
+}
+
+type exitCodeCommand struct {
+	cli.Command
+	meta *Meta
+}
+
+func (e *exitCodeCommand) Run(args []string) int {
+	return e.meta.exitCode(e.Command.Run(args))
+}
+
+// WithExitCodes wraps the command factory to apply the exit code mode of meta
+func WithExitCodes(meta *Meta, factory cli.CommandFactory) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		command, err := factory()
+		if err != nil {
+			return nil, err
+		}
+		return &exitCodeCommand{Command: command, meta: meta}, nil
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/mitchellh/cli"
+)
+
+type testExitCommand struct {
+	meta   *Meta
+	status Status
+	err    error
+}
+
+func (c *testExitCommand) Run(_ []string) int {
+	c.meta.addOutput("status", string(c.meta.resolveStatus(c.err)))
+	if c.status != "" {
+		c.meta.addOutput("status", string(c.status))
+	}
+	if c.err != nil {
+		return 1
+	}
+	return 0
+}
+func (c *testExitCommand) Help() string     { return "" }
+func (c *testExitCommand) Synopsis() string { return "" }
+
+func TestWithExitCodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mode     ExitCodeMode
+		status   Status
+		err      error
+		expected int
+	}{
+		{name: "simple-not-found", mode: SimpleExitCodes, err: tfe.ErrResourceNotFound, expected: ExitError},
+		{name: "simple-noop", mode: SimpleExitCodes, status: Noop, expected: ExitSuccess},
+		{name: "detailed-success", mode: DetailedExitCodes, expected: ExitSuccess},
+		{name: "detailed-noop", mode: DetailedExitCodes, status: Noop, expected: ExitSuccess},
+		{name: "detailed-error", mode: DetailedExitCodes, err: fmt.Errorf("boom"), expected: ExitError},
+		{name: "detailed-timeout", mode: DetailedExitCodes, err: &cloud.RetryTimeoutError{}, expected: ExitTimeout},
+		{name: "detailed-policy-blocked", mode: DetailedExitCodes, err: &cloud.RunStatusError{Status: tfe.RunPolicyOverride}, expected: ExitPolicyBlocked},
+		{name: "detailed-policy-failed", mode: DetailedExitCodes, err: &policyFailedError{failures: []string{"mandatory (1)"}}, expected: ExitPolicyBlocked},
+		{name: "detailed-canceled", mode: DetailedExitCodes, err: &cloud.RunStatusError{Status: tfe.RunDiscarded}, expected: ExitCanceled},
+		{name: "detailed-errored-run", mode: DetailedExitCodes, err: &cloud.RunStatusError{Status: tfe.RunErrored}, expected: ExitError},
+		{name: "detailed-unauthorized", mode: DetailedExitCodes, err: tfe.ErrUnauthorized, expected: ExitAuthError},
+		{name: "detailed-not-found", mode: DetailedExitCodes, err: fmt.Errorf("reading run: %w", tfe.ErrResourceNotFound), expected: ExitNotFound},
+		{name: "detailed-forbidden", mode: DetailedExitCodes, status: Forbidden, err: fmt.Errorf("insufficient permissions"), expected: ExitForbidden},
+		{name: "strict-noop", mode: StrictExitCodes, status: Noop, expected: ExitNoop},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, meta := testMetaWithPlatform(t, &testPlatformContext{}, WithExitCodeMode(tc.mode))
+			factory := WithExitCodes(meta, func() (cli.Command, error) {
+				return &testExitCommand{meta: meta, status: tc.status, err: tc.err}, nil
+			})
+
+			command, err := factory()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if code := command.Run(nil); code != tc.expected {
+				t.Errorf("expected exit code %d but received %d", tc.expected, code)
+			}
+		})
+	}
+}
+
+func TestParseExitCodeMode(t *testing.T) {
+	if mode, err := ParseExitCodeMode(""); err != nil || mode != SimpleExitCodes {
+		t.Errorf("expected default simple mode but received %q, %v", mode, err)
+	}
+	if _, err := ParseExitCodeMode("loose"); err == nil {
+		t.Errorf("expected error for invalid exit code mode")
+	}
+}
+
+type testSoftFailureCommand struct {
+	meta *Meta
+}
+
+func (c *testSoftFailureCommand) Run(_ []string) int {
+	c.meta.softFailure("failed to read plan logs: connection reset by peer")
+	c.meta.addOutput("status", string(Success))
+	c.meta.closeOutput()
+	return 0
+}
+func (c *testSoftFailureCommand) Help() string     { return "" }
+func (c *testSoftFailureCommand) Synopsis() string { return "" }
+
+func TestWithExitCodes_Strict(t *testing.T) {
+	testCases := []struct {
+		name           string
+		strict         bool
+		expectedCode   int
+		expectedStatus Status
+	}{
+		{name: "default", strict: false, expectedCode: ExitSuccess, expectedStatus: Success},
+		{name: "strict", strict: true, expectedCode: ExitError, expectedStatus: Error},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			platform := &testPlatformContext{}
+			_, meta := testMetaWithPlatform(t, platform, WithStrict(tc.strict))
+			factory := WithExitCodes(meta, func() (cli.Command, error) {
+				return &testSoftFailureCommand{meta: meta}, nil
+			})
+
+			command, err := factory()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if code := command.Run(nil); code != tc.expectedCode {
+				t.Errorf("expected exit code %d but received %d", tc.expectedCode, code)
+			}
+			if status := meta.outputValue("status"); status != string(tc.expectedStatus) {
+				t.Errorf("expected status %s but received %s", tc.expectedStatus, status)
+			}
+			if _, ok := platform.output["warnings"]; !ok {
+				t.Errorf("expected warnings platform output")
+			}
+		})
+	}
+}
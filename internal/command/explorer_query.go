@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// comparison symbols accepted in -filter, mapped to explorer filter operators
+var explorerOperators = map[string]string{
+	"=":  "is",
+	"==": "is",
+	"!=": "is_not",
+	"~":  "contains",
+	"!~": "does_not_contain",
+	"<":  "lt",
+	"<=": "lteq",
+	">":  "gt",
+	">=": "gteq",
+}
+
+var explorerOperatorNames = []string{"is", "is_not", "contains", "does_not_contain", "is_empty", "is_not_empty", "gt", "lt", "gteq", "lteq", "is_before", "is_after"}
+
+// parses a filter such as "terraform_version < 1.6" or "drifted is true"
+func parseExplorerFilter(raw string) (*cloud.ExplorerFilter, error) {
+	parts := strings.Fields(raw)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid filter %q, must be formatted as: <field> <operator> <value>", raw)
+	}
+	operator, ok := explorerOperators[parts[1]]
+	if !ok {
+		if !slices.Contains(explorerOperatorNames, parts[1]) {
+			return nil, fmt.Errorf("invalid filter %q, unsupported operator %q", raw, parts[1])
+		}
+		operator = parts[1]
+	}
+	value := strings.Join(parts[2:], " ")
+	if value == "" && operator != "is_empty" && operator != "is_not_empty" {
+		return nil, fmt.Errorf("invalid filter %q, operator %q requires a value", raw, parts[1])
+	}
+	return &cloud.ExplorerFilter{Field: parts[0], Operator: operator, Value: value}, nil
+}
+
+type ExplorerQueryCommand struct {
+	*Meta
+
+	Type    string
+	Filters []string
+	Sort    string
+	Format  string
+	File    string
+}
+
+func (c *ExplorerQueryCommand) flags() *flag.FlagSet {
+	f := c.flagSet("explorer query")
+	f.StringVar(&c.Type, "type", "", "Explorer view to query: workspaces, tf_versions, providers or modules.")
+	f.Var((*flagStringSlice)(&c.Filters), "filter", "Filters the rows, formatted as \"<field> <operator> <value>\", ex: -filter=\"terraform_version < 1.6\". This option accepts multiple values.")
+	f.StringVar(&c.Sort, "sort", "", "Field to sort the rows by, prefixed with - for descending order.")
+	f.StringVar(&c.Format, "format", "json", "Format of the file written to -file: json or csv.")
+	f.StringVar(&c.File, "file", "", "Writes the rows to the provided file path.")
+	return f
+}
+
+func (c *ExplorerQueryCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if !slices.Contains(cloud.ExplorerTypes, c.Type) {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("unsupported explorer type %q, must be one of: %s", c.Type, strings.Join(cloud.ExplorerTypes, ", ")))
+		return 1
+	}
+	if c.Format != "json" && c.Format != "csv" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("unsupported format %q, must be one of: json, csv", c.Format))
+		return 1
+	}
+	filters := make([]*cloud.ExplorerFilter, 0, len(c.Filters))
+	for _, raw := range c.Filters {
+		filter, err := parseExplorerFilter(raw)
+		if err != nil {
+			c.addOutput("status", string(Error))
+			c.closeOutput()
+			c.writer.ErrorResult(err.Error())
+			return 1
+		}
+		filters = append(filters, filter)
+	}
+
+	rows, err := c.cloud.QueryExplorer(c.appCtx, cloud.ExplorerQueryOptions{
+		Organization: c.organization,
+		Type:         c.Type,
+		Filters:      filters,
+		Sort:         c.Sort,
+	})
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error querying explorer in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if c.File != "" {
+		if err := writeExplorerRows(c.File, c.Format, rows); err != nil {
+			status := c.resolveStatus(err)
+			c.addOutput("status", string(status))
+			c.writer.ErrorResult(fmt.Sprintf("error writing explorer rows to file, '%s': %s", c.File, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("row_count", fmt.Sprintf("%d", len(rows)))
+	c.addOutputWithOpts("rows", rows, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func writeExplorerRows(path string, format string, rows []cloud.ExplorerRow) error {
+	var data []byte
+	var err error
+	if format == "csv" {
+		data, err = explorerCSV(rows)
+	} else {
+		data, err = json.MarshalIndent(rows, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// renders the rows with a sorted column per field, nested values are encoded as json
+func explorerCSV(rows []cloud.ExplorerRow) ([]byte, error) {
+	seen := map[string]bool{}
+	columns := []string{}
+	for _, row := range rows {
+		for field := range row {
+			if !seen[field] {
+				seen[field] = true
+				columns = append(columns, field)
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, field := range columns {
+			switch v := row[field].(type) {
+			case nil:
+			case string:
+				record[i] = v
+			case map[string]interface{}, []interface{}:
+				b, err := json.Marshal(v)
+				if err != nil {
+					return nil, err
+				}
+				record[i] = string(b)
+			default:
+				record[i] = fmt.Sprintf("%v", v)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (c *ExplorerQueryCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] explorer query [options]
+
+	Queries the explorer of the organization, to report on workspaces, Terraform versions, providers and modules across the fleet.
+
+` + globalOptionsHelp + `
+Options:
+
+	-type    Explorer view to query: workspaces, tf_versions, providers or modules.
+
+	-filter  Filters the rows, formatted as "<field> <operator> <value>", ex: -filter="terraform_version < 1.6".
+	         Operators are =, !=, ~ (contains), !~ (does not contain), <, <=, >, >= or any explorer operator name, such as is_before.
+	         This option accepts multiple values, rows must match all filters.
+
+	-sort    Field to sort the rows by, prefixed with - for descending order.
+
+	-format  Format of the file written to -file: json or csv. Defaults to "json".
+
+	-file    Writes the rows to the provided file path.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ExplorerQueryCommand) Synopsis() string {
+	return "Queries the explorer of the organization"
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testExplorerService struct {
+	options cloud.ExplorerQueryOptions
+}
+
+func (s *testExplorerService) QueryExplorer(_ context.Context, options cloud.ExplorerQueryOptions) ([]cloud.ExplorerRow, error) {
+	s.options = options
+	return []cloud.ExplorerRow{
+		{"workspace_name": "networking", "terraform_version": "1.5.7", "drifted": false},
+		{"workspace_name": "compute", "terraform_version": "1.4.0", "providers": []interface{}{"aws"}},
+	}, nil
+}
+
+func testExplorerQueryCommand(explorer cloud.ExplorerService) (*cli.MockUi, *ExplorerQueryCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.ExplorerService = explorer
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &ExplorerQueryCommand{Meta: meta}
+}
+
+func TestExplorerQueryCommand(t *testing.T) {
+	explorer := &testExplorerService{}
+	path := filepath.Join(t.TempDir(), "fleet.csv")
+	ui, cmd := testExplorerQueryCommand(explorer)
+	if code := cmd.Run([]string{"-type=workspaces", "-filter=terraform_version < 1.6", "-format=csv", "-file=" + path}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	expectedFilters := []*cloud.ExplorerFilter{{Field: "terraform_version", Operator: "lt", Value: "1.6"}}
+	if !reflect.DeepEqual(explorer.options.Filters, expectedFilters) || explorer.options.Organization != "abc-company" {
+		t.Errorf("unexpected query options %+v", explorer.options)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"row_count": "2"`) {
+		t.Errorf("expected row count in output but received %s", ui.OutputWriter.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading csv: %s", err)
+	}
+	expected := "drifted,providers,terraform_version,workspace_name\nfalse,,1.5.7,networking\n,\"[\"\"aws\"\"]\",1.4.0,compute\n"
+	if string(data) != expected {
+		t.Errorf("expected csv:\n%s\nbut received:\n%s", expected, data)
+	}
+}
+
+func TestExplorerQueryCommand_Validation(t *testing.T) {
+	cases := map[string][]string{
+		"unsupported type":     {"-type=runs"},
+		"unsupported format":   {"-type=workspaces", "-format=xml"},
+		"unsupported operator": {"-type=workspaces", "-filter=terraform_version <> 1.6"},
+		"missing value":        {"-type=workspaces", "-filter=terraform_version <"},
+	}
+	for name, args := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui, cmd := testExplorerQueryCommand(&testExplorerService{})
+			if code := cmd.Run(args); code != 1 {
+				t.Errorf("expected exit code 1 but received %d: %s", code, ui.OutputWriter.String())
+			}
+		})
+	}
+}
+
+func TestParseExplorerFilter(t *testing.T) {
+	cases := map[string]*cloud.ExplorerFilter{
+		"terraform_version < 1.6":            {Field: "terraform_version", Operator: "lt", Value: "1.6"},
+		"drifted = true":                     {Field: "drifted", Operator: "is", Value: "true"},
+		"workspace_name ~ prod":              {Field: "workspace_name", Operator: "contains", Value: "prod"},
+		"current_run_status is_not_empty":    {Field: "current_run_status", Operator: "is_not_empty"},
+		"project_name is platform services":  {Field: "project_name", Operator: "is", Value: "platform services"},
+		"updated_at is_after 2024-01-01T00Z": {Field: "updated_at", Operator: "is_after", Value: "2024-01-01T00Z"},
+	}
+	for raw, expected := range cases {
+		filter, err := parseExplorerFilter(raw)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %s", raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(filter, expected) {
+			t.Errorf("expected %+v for %q but received %+v", expected, raw, filter)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// HCP Terraform shows the plan and policy results on the run page, links point to their sections
+const (
+	planLinkAnchor   = "#plan"
+	policyLinkAnchor = "#policy"
+)
+
+// adds run_link, workspace_link and plan_link outputs from the link of the run,
+// and policy_link when policies were evaluated during the run
+func (c *Meta) addRunLinks(run *tfe.Run, runLink string) {
+	if runLink == "" {
+		return
+	}
+	c.addRunLink(runLink)
+	c.addOutput("plan_link", runLink+planLinkAnchor)
+	if run != nil && (len(run.PolicyChecks) > 0 || len(run.TaskStages) > 0) {
+		c.addOutput("policy_link", runLink+policyLinkAnchor)
+	}
+}
+
+// adds run_link, workspace_link and policy_link outputs for policy commands, which only have the run id.
+// links are not required, so errors reading the run are only logged
+func (c *Meta) addPolicyLinks(runID string) {
+	run, err := c.cloud.GetRun(c.appCtx, cloud.GetRunOptions{RunID: runID})
+	if err != nil {
+		log.Printf("[ERROR] error reading run: %q for policy links: %s", runID, err)
+		return
+	}
+	runLink, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
+	if runLink == "" {
+		return
+	}
+	c.addRunLink(runLink)
+	c.addOutput("policy_link", runLink+policyLinkAnchor)
+}
+
+// the workspace link is the run link without the run path
+func (c *Meta) addRunLink(runLink string) {
+	c.addOutput("run_link", runLink)
+	if workspaceLink, _, ok := strings.Cut(runLink, "/runs/"); ok {
+		c.addOutput("workspace_link", workspaceLink)
+	}
+}
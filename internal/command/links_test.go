@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestAddRunLinks(t *testing.T) {
+	runLink := "https://app.terraform.io/app/abc-company/workspaces/networking/runs/run-1"
+
+	t.Run("with policies", func(t *testing.T) {
+		_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+		cmd.addRunLinks(&tfe.Run{ID: "run-1", PolicyChecks: []*tfe.PolicyCheck{{ID: "polchk-1"}}}, runLink)
+
+		expected := map[string]string{
+			"run_link":       runLink,
+			"workspace_link": "https://app.terraform.io/app/abc-company/workspaces/networking",
+			"plan_link":      runLink + "#plan",
+			"policy_link":    runLink + "#policy",
+		}
+		for name, value := range expected {
+			if actual := cmd.outputValue(name); actual != value {
+				t.Errorf("expected %s %q but received %q", name, value, actual)
+			}
+		}
+	})
+
+	t.Run("without policies", func(t *testing.T) {
+		_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+		cmd.addRunLinks(&tfe.Run{ID: "run-1"}, runLink)
+		if _, ok := cmd.messages["policy_link"]; ok {
+			t.Error("expected no policy_link output for a run without policies")
+		}
+	})
+
+	t.Run("without link", func(t *testing.T) {
+		_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+		cmd.addRunLinks(&tfe.Run{ID: "run-1"}, "")
+		if len(cmd.messages) != 0 {
+			t.Errorf("expected no link outputs but received %v", cmd.messages)
+		}
+	})
+}
@@ -10,9 +10,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"strings"
+	"text/template"
 
 	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/config"
 	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/logging"
+	"github.com/hashicorp/tfci/internal/notify"
 )
 
 type Status string
@@ -22,8 +27,90 @@ const (
 	Error   Status = "Error"
 	Timeout Status = "Timeout"
 	Noop    Status = "Noop"
+	// the API rejected the token, or the token does not have access to the resource
+	Unauthorized Status = "Unauthorized"
+	Forbidden    Status = "Forbidden"
+	NotFound     Status = "NotFound"
 )
 
+// shared help text for options parsed before the subcommand, these can also follow the subcommand
+const globalOptionsHelp = `Global Options:
+
+	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
+
+	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
+
+	-organization   HCP Terraform Organization Name.
+
+	-oidc                   Authenticate using the CI platform's workload identity (OIDC) token instead of an API token.
+
+	-oidc-audience          Audience requested for the workload identity token. Defaults to the hostname.
+
+	-oidc-exchange-url      Token exchange endpoint that returns an API token for the workload identity token. Defaults to reading "TF_OIDC_EXCHANGE_URL" environment variable.
+
+	-proxy-url              Proxy URL used for requests to HCP Terraform. Standard "HTTPS_PROXY", "HTTP_PROXY" and "NO_PROXY" environment variables are honored when not set.
+
+	-max-retries            Maximum number of retries for requests that result in a server error. Defaults to 30.
+
+	-retry-initial-backoff  Initial wait between request retries and status polling, ex: "500ms", "2s".
+
+	-retry-max-backoff      Maximum wait between request retries and status polling, ex: "5s", "30s".
+
+	-poll-interval          Fixed interval to poll for status changes instead of backing off, ex: "10s".
+
+	-log-timeout            Maximum time to read plan and apply logs, ex: "30m". Defaults to TF_MAX_TIMEOUT.
+
+	-state-processing-timeout  Maximum time to wait for the current state version to be processed before reading workspace outputs, ex: "15m". Defaults to "5m".
+
+	-backoff-jitter         Randomizes each wait between status polling attempts by +/- the percentage, so parallel jobs do not poll in lockstep. Defaults to 20, 0 disables jitter.
+
+	-poll-profile           Preset wait between status polling attempts and maximum timeout: "fast", "default" or "relaxed". -retry-initial-backoff, -retry-max-backoff and TF_MAX_TIMEOUT take precedence.
+
+	-page-size              Number of items requested per page when listing resources, between 1 and 100. Defaults to 100.
+
+	-format         Format of the result written to stdout: "json", "yaml" or "table". Defaults to "json". -format-template takes precedence.
+
+	-columns        Comma separated columns of list results rendered with -format=table, ex: "name,status". Defaults to the columns of each list command.
+
+	-output-file    Writes the final result of the command to the provided file path, in addition to stdout and platform output.
+
+	-output-format  Format of the result written to -output-file: "json" or "yaml". Defaults to "json".
+
+	-artifacts-dir  Directory to write full payloads to as separate files with stable names, such as run.json, plan.json and plan.log. Payloads are replaced in platform output with a "<name>_file" path output.
+
+	-log-file               Writes the plan logs streamed while monitoring a run to the provided file path, in addition to stdout.
+
+	-apply-log-file         Writes the apply logs streamed while monitoring a run to the provided file path, in addition to stdout.
+
+	-redact-pattern         Regular expression matching values to redact from streamed plan and apply logs, in addition to run variable values. Can be repeated.
+
+	-format-template        Go template rendering the result written to stdout instead of json, ex: "{{ .run_id }}". Prefix with "@" to read the template from a file.
+
+	-print-platform-output  Prints what would be written to the CI platform output (eg. GITHUB_OUTPUT, GitLab .env) instead of writing it.
+
+	-notify-slack-webhook   Slack incoming webhook URL to post a message to on command completion. Defaults to reading "TF_NOTIFY_SLACK_WEBHOOK" environment variable.
+
+	-notify-webhook-url     URL to POST a JSON notification to on command completion. Defaults to reading "TF_NOTIFY_WEBHOOK_URL" environment variable.
+
+	-event-webhook-url      URL to POST NDJSON run lifecycle events to while monitoring. Defaults to reading "TF_EVENT_WEBHOOK_URL" environment variable.
+
+	-profile                Named profile setting the hostname, organization and token, from the config file or "TFCI_PROFILE_<NAME>_*" environment variables. Defaults to reading "TFCI_PROFILE" environment variable.
+
+	-config                 Path to a tfci.yaml file with default options. Defaults to reading "TFCI_CONFIG" environment variable, then "tfci.yaml" in the working directory.
+
+	-exit-code-mode         Exit codes returned on failure: "simple" (0 or 1), "detailed" (a distinct code per failure type) or "strict" (detailed, and noop results exit with 3). Defaults to "simple".
+
+	-log-format             Format of diagnostic logs enabled with "TF_LOG": "text" or "json". Defaults to reading "TF_LOG_FORMAT" environment variable.
+
+	-mock-server            Directory of fixtures to serve canned API responses from instead of HCP Terraform, for testing pipelines without a real organization. Defaults to reading "TFCI_MOCK_SERVER" environment variable.
+
+	-record                 Writes the API requests and responses of the command to the provided file path, with tokens and sensitive values redacted, to attach to bug reports.
+
+	-replay                 Runs the command against the API responses of a session written with -record instead of HCP Terraform.
+
+	-strict                 Fails the command on soft failures that are otherwise reported as warnings, such as failing to read plan, apply or policy logs, and cost estimation errors.
+`
+
 type Writer interface {
 	UseJson(json bool)
 	Output(msg string)
@@ -33,6 +120,8 @@ type Writer interface {
 }
 
 type Meta struct {
+	// name of the command being executed
+	command string
 	// Organization for HCP Terraform installation
 	organization string
 	// parent context
@@ -43,15 +132,49 @@ type Meta struct {
 	cloud *cloud.Cloud
 	// messages for stdout, platform output
 	messages map[string]*outputMessage
+	// name of the first list output rendered with --format table
+	tableOutput string
 	// writer interface to handle result and diagnostic information
 	writer Writer
 	// flag to prevent non-json messages to stdout
 	json bool
+	// optional file path to write the final result to
+	outputFile string
+	// format of the result written to outputFile: json | yaml
+	outputFormat OutputFormat
+	// optional directory full payloads are written to as separate files
+	artifactsDir string
+	// format of the result written to stdout: json | yaml | table
+	format OutputFormat
+	// columns of list results rendered as a table, instead of the command defaults
+	columns []string
+	// renders the result written to stdout instead of json
+	formatTemplate *template.Template
+	// prints platform output instead of writing it, to debug values not received downstream
+	printPlatformOutput bool
+	// notifiers to alert on command completion
+	notifiers []notify.Notifier
+	// project level defaults from tfci.yaml
+	config *config.Config
+	// maps command results to exit codes
+	exitCodeMode ExitCodeMode
+	// error resolved by resolveStatus
+	err error
+	// fails the command on soft failures, such as failing to read logs
+	strict bool
+	// soft failures that did not fail the command
+	warnings []string
 }
 
 func (c *Meta) setupCmd(args []string, flags *flag.FlagSet) error {
-	if err := flags.Parse(args); err != nil {
+	err := flags.Parse(args)
+	if err == nil && c.config != nil {
+		// options from the config file apply when not provided on the command-line
+		err = config.ApplyFlags(flags, c.config.CommandDefaults(c.command))
+	}
+	if err != nil {
 		c.emitFlagOptions()
+		c.err = err
 		c.addOutput("status", string(Error))
 		c.closeOutput()
 		c.writer.ErrorResult(fmt.Sprintf("error parsing command-line flags: %s\n", err.Error()))
@@ -63,6 +186,8 @@ func (c *Meta) setupCmd(args []string, flags *flag.FlagSet) error {
 }
 
 func (c *Meta) flagSet(name string) *flag.FlagSet {
+	c.command = name
+	logging.With("command", name)
 	f := flag.NewFlagSet(name, flag.ContinueOnError)
 	f.SetOutput(ioutil.Discard)
 	f.Usage = func() {}
@@ -80,15 +205,43 @@ func (c *Meta) emitFlagOptions() {
 }
 
 func (c *Meta) resolveStatus(err error) Status {
-	if err != nil {
-		switch err.(type) {
-		case *cloud.RetryTimeoutError:
-			return Timeout
-		default:
-			return Error
-		}
+	// classifies the exit code when the command fails
+	c.err = err
+	return statusForError(err)
+}
+
+// resolves the status from the error alone, such as for runs created concurrently
+func statusForError(err error) Status {
+	if err == nil {
+		return Success
+	}
+	return statusForErrorType(errorType(err))
+}
+
+func statusForErrorType(errType string) Status {
+	switch errType {
+	case errorTypeTimeout:
+		return Timeout
+	case errorTypeUnauthorized:
+		return Unauthorized
+	case errorTypeForbidden:
+		return Forbidden
+	case errorTypeNotFound:
+		return NotFound
+	default:
+		return Error
 	}
-	return Success
+}
+
+// reports a problem that does not fail the command, unless running with --strict
+func (c *Meta) softFailure(msg string) {
+	c.writer.ErrorResult(msg)
+	c.warnings = append(c.warnings, msg)
+}
+
+// fails a successful result when soft failures occurred in strict mode
+func (c *Meta) failedStrict() bool {
+	return c.strict && len(c.warnings) > 0
 }
 
 // adds new output value to map as &OutputMessage{}
@@ -99,11 +252,19 @@ func (c *Meta) addOutput(name string, value string) {
 // adds new output value with options &outputOpts{}
 func (c *Meta) addOutputWithOpts(name string, value interface{}, opts *outputOpts) {
 	c.messages[name] = newOutputMessage(name, value, opts)
+	if opts.table && opts.stdOut && c.tableOutput == "" {
+		c.tableOutput = name
+	}
 }
 
 // returns json result string, containing all outputs
 // if running in ci, will send outputs to platform
 func (c *Meta) closeOutput() string {
+	c.addRateLimitDetails()
+	c.addWarnings()
+	c.addErrorDetails()
+	c.writeArtifacts()
+
 	// using map[string]any to pretty marshal collection
 	stdOutput := make(map[string]interface{})
 	// map[string]OutputI interface
@@ -133,22 +294,202 @@ func (c *Meta) closeOutput() string {
 	if c.env.Context != nil {
 		// pass output data and close signifying we're done
 		c.env.Context.SetOutput(platOutput)
-		c.env.Context.CloseOutput()
+		if c.printPlatformOutput {
+			c.previewPlatformOutput()
+		} else {
+			c.env.Context.CloseOutput()
+		}
+	} else if c.printPlatformOutput {
+		c.writer.Output("No supported CI platform detected, platform output would not be written")
 	}
 
+	c.sendNotifications()
+
 	outJson, err := json.MarshalIndent(stdOutput, "", "  ")
 	if err != nil {
 		return string(err.Error())
 	}
+
+	if c.outputFile != "" {
+		if fileErr := writeOutputFile(c.outputFile, c.outputFormat, outJson); fileErr != nil {
+			log.Printf("[ERROR] problem writing output file: '%s', with: %s", c.outputFile, fileErr.Error())
+			c.writer.ErrorResult(fmt.Sprintf("error writing output file %s: %s", c.outputFile, fileErr.Error()))
+		}
+	}
+
+	if c.formatTemplate != nil {
+		rendered, err := renderTemplate(c.formatTemplate, outJson)
+		if err == nil {
+			return rendered
+		}
+		log.Printf("[ERROR] problem rendering format template, with: %s", err.Error())
+		c.writer.ErrorResult(fmt.Sprintf("error rendering format template: %s", err.Error()))
+	}
+
+	switch c.format {
+	case YAMLFormat:
+		outYaml, err := formatResult(c.format, outJson)
+		if err == nil {
+			return strings.TrimSuffix(string(outYaml), "\n")
+		}
+		log.Printf("[ERROR] problem formatting result as yaml, with: %s", err.Error())
+	case TableFormat:
+		table, err := c.renderTable(outJson)
+		if err == nil {
+			return table
+		}
+		log.Printf("[ERROR] problem rendering result as a table, with: %s", err.Error())
+		c.writer.ErrorResult(fmt.Sprintf("error rendering table: %s", err.Error()))
+	}
+
 	return string(outJson)
 }
 
+func (c *Meta) addWarnings() {
+	if len(c.warnings) == 0 {
+		return
+	}
+	c.addOutputWithOpts("warnings", c.warnings, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	status := c.outputValue("status")
+	if c.failedStrict() && (status == string(Success) || status == string(Noop)) {
+		c.writer.ErrorResult(fmt.Sprintf("failing in strict mode after %d warning(s)", len(c.warnings)))
+		c.addOutput("status", string(Error))
+	}
+}
+
+// distinguishes throttling from slowness in busy organizations
+func (c *Meta) addRateLimitDetails() {
+	count, retryAfter := c.cloud.RateLimits().Stats()
+	if count == 0 {
+		return
+	}
+
+	c.writer.Error(fmt.Sprintf("Warning: %d requests to HCP Terraform were rate limited, waiting a total of %s", count, retryAfter))
+	c.addOutput("rate_limited", "true")
+	c.addOutput("rate_limit_retry_after", retryAfter.String())
+}
+
+// returns the string value of an output, or empty string when not set
+func (c *Meta) outputValue(name string) string {
+	m, ok := c.messages[name]
+	if !ok {
+		return ""
+	}
+	if val, isStr := m.value.(string); isStr {
+		return val
+	}
+	return ""
+}
+
+// notification failures are reported but do not affect the command result
+func (c *Meta) sendNotifications() {
+	if len(c.notifiers) == 0 {
+		return
+	}
+
+	n := &notify.Notification{
+		Command: c.command,
+		Status:  c.outputValue("status"),
+		RunID:   c.outputValue("run_id"),
+		RunLink: c.outputValue("run_link"),
+		Add:     c.outputValue("add"),
+		Change:  c.outputValue("change"),
+		Destroy: c.outputValue("destroy"),
+	}
+
+	for _, notifier := range c.notifiers {
+		if err := notifier.Notify(c.appCtx, n); err != nil {
+			log.Printf("[ERROR] problem sending notification: %s", err.Error())
+			c.writer.Error(fmt.Sprintf("failed to send notification: %s", err.Error()))
+		}
+	}
+}
+
+// writes rendered platform output as diagnostic information, without writing to the platform
+func (c *Meta) previewPlatformOutput() {
+	files, err := c.env.Context.PreviewOutput()
+	if err != nil {
+		c.writer.Error(fmt.Sprintf("error rendering %s platform output: %s", c.env.PlatformType, err.Error()))
+		return
+	}
+
+	c.writer.Output(fmt.Sprintf("-------------- %s Platform Output (dry-run) --------------", c.env.PlatformType))
+	for _, f := range files {
+		mode := "write"
+		if f.Append {
+			mode = "append"
+		}
+		c.writer.Output(fmt.Sprintf("%s to %q:", mode, f.Path))
+		c.writer.Output(f.Content)
+	}
+}
+
 func WithOrg(org string) func(*Meta) {
 	return func(m *Meta) {
 		m.organization = org
 	}
 }
 
+func WithOutputFile(path string, format OutputFormat) func(*Meta) {
+	return func(m *Meta) {
+		m.outputFile = path
+		m.outputFormat = format
+	}
+}
+
+func WithArtifactsDir(dir string) func(*Meta) {
+	return func(m *Meta) {
+		m.artifactsDir = dir
+	}
+}
+
+func WithFormat(format OutputFormat, columns []string) func(*Meta) {
+	return func(m *Meta) {
+		m.format = format
+		m.columns = columns
+	}
+}
+
+func WithFormatTemplate(tmpl *template.Template) func(*Meta) {
+	return func(m *Meta) {
+		m.formatTemplate = tmpl
+	}
+}
+
+func WithPrintPlatformOutput(print bool) func(*Meta) {
+	return func(m *Meta) {
+		m.printPlatformOutput = print
+	}
+}
+
+func WithNotifiers(notifiers []notify.Notifier) func(*Meta) {
+	return func(m *Meta) {
+		m.notifiers = notifiers
+	}
+}
+
+func WithExitCodeMode(mode ExitCodeMode) func(*Meta) {
+	return func(m *Meta) {
+		m.exitCodeMode = mode
+	}
+}
+
+func WithStrict(strict bool) func(*Meta) {
+	return func(m *Meta) {
+		m.strict = strict
+	}
+}
+
+func WithConfig(cfg *config.Config) func(*Meta) {
+	return func(m *Meta) {
+		m.config = cfg
+	}
+}
+
 func WithWriter(w Writer) func(*Meta) {
 	return func(m *Meta) {
 		m.writer = w
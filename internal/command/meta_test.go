@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/config"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/notify"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testPlatformContext struct {
+	output environment.OutputMap
+	closed bool
+}
+
+func (p *testPlatformContext) ID() string       { return "test-1" }
+func (p *testPlatformContext) SHA() string      { return "13c988d4f15e06bcdd0b0af290086a3079cdadb0" }
+func (p *testPlatformContext) SHAShort() string { return "13c988d" }
+func (p *testPlatformContext) Author() string   { return "octocat" }
+func (p *testPlatformContext) WriteDir() string { return "" }
+func (p *testPlatformContext) SetOutput(output environment.OutputMap) {
+	p.output = output
+}
+func (p *testPlatformContext) PreviewOutput() ([]*environment.OutputFile, error) {
+	var files []*environment.OutputFile
+	for k, v := range p.output {
+		files = append(files, &environment.OutputFile{Path: k, Content: v.String()})
+	}
+	return files, nil
+}
+func (p *testPlatformContext) CloseOutput() error {
+	p.closed = true
+	return nil
+}
+
+func testMetaWithPlatform(t *testing.T, platform *testPlatformContext, setters ...func(*Meta)) (*cli.MockUi, *Meta) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudService := cloud.NewCloud(&tfe.Client{}, writer)
+	env := &environment.CI{PlatformType: environment.Other, Context: platform}
+
+	setters = append([]func(*Meta){WithWriter(writer)}, setters...)
+	return ui, NewMetaOpts(context.Background(), cloudService, env, setters...)
+}
+
+func TestStatusForError(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected Status
+	}{
+		{err: nil, expected: Success},
+		{err: fmt.Errorf("boom"), expected: Error},
+		{err: &cloud.RetryTimeoutError{}, expected: Timeout},
+		{err: tfe.ErrUnauthorized, expected: Unauthorized},
+		{err: fmt.Errorf("reading workspace: %w", tfe.ErrResourceNotFound), expected: NotFound},
+	}
+	for _, tc := range testCases {
+		if actual := statusForError(tc.err); actual != tc.expected {
+			t.Errorf("expected status %q for error %v but received %q", tc.expected, tc.err, actual)
+		}
+	}
+	if actual := statusForErrorType(errorTypeForbidden); actual != Forbidden {
+		t.Errorf("expected status %q but received %q", Forbidden, actual)
+	}
+}
+
+// returns a meta using a client answered by the mock server fixtures, keyed by path
+func testMetaWithFixtures(t *testing.T, fixtures map[string]string) (*tfe.Client, *Meta) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, body := range fixtures {
+		fixture := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fixture), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fixture, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, err := cloud.NewTfeClient(&cloud.ClientOptions{MockFixtures: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloudService := cloud.NewCloud(client, writer.NewWriter(cli.NewMockUi()))
+	env := &environment.CI{PlatformType: environment.Other, Context: &testPlatformContext{}}
+	return client, NewMetaOpts(context.Background(), cloudService, env)
+}
+
+func TestMeta_ResolveStatus_APIErrors(t *testing.T) {
+	client, meta := testMetaWithFixtures(t, map[string]string{
+		"api/v2/workspaces/ws-forbidden/GET.json": `{"errors":[{"status":"403","title":"forbidden"}]}`,
+	})
+	ctx := context.Background()
+
+	// a handled not found response does not classify a later error
+	if _, err := client.Workspaces.ReadByID(ctx, "ws-missing"); !errors.Is(err, tfe.ErrResourceNotFound) {
+		t.Fatalf("expected not found error but received: %v", err)
+	}
+	if actual := meta.resolveStatus(errors.New("error parsing plan")); actual != Error {
+		t.Errorf("expected status %q but received %q", Error, actual)
+	}
+
+	_, err := client.Workspaces.ReadByID(ctx, "ws-forbidden")
+	if actual := meta.resolveStatus(fmt.Errorf("reading workspace: %w", err)); actual != Forbidden {
+		t.Errorf("expected status %q but received %q", Forbidden, actual)
+	}
+}
+
+func TestMeta_CloseOutput_Platform(t *testing.T) {
+	platform := &testPlatformContext{}
+	_, meta := testMetaWithPlatform(t, platform)
+
+	meta.addOutput("run_id", "run-123")
+	meta.closeOutput()
+
+	if !platform.closed {
+		t.Fatalf("expected platform output to be written")
+	}
+	if actual := platform.output["run_id"].String(); actual != "run-123" {
+		t.Errorf("expected %q but received %q", "run-123", actual)
+	}
+}
+
+func TestMeta_CloseOutput_PrintPlatformOutput(t *testing.T) {
+	platform := &testPlatformContext{}
+	ui, meta := testMetaWithPlatform(t, platform, WithPrintPlatformOutput(true))
+
+	meta.addOutput("run_id", "run-123")
+	meta.closeOutput()
+
+	if platform.closed {
+		t.Fatalf("expected platform output not to be written")
+	}
+
+	stdout := ui.OutputWriter.String()
+	for _, expected := range []string{"Platform Output (dry-run)", `write to "run_id":`, "run-123"} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("expected %q in output but received %q", expected, stdout)
+		}
+	}
+}
+
+type testNotifier struct {
+	received *notify.Notification
+}
+
+func (n *testNotifier) Notify(_ context.Context, notification *notify.Notification) error {
+	n.received = notification
+	return nil
+}
+
+func TestMeta_CloseOutput_Notify(t *testing.T) {
+	notifier := &testNotifier{}
+	_, meta := testMetaWithPlatform(t, &testPlatformContext{}, WithNotifiers([]notify.Notifier{notifier}))
+	meta.flagSet("run apply")
+
+	meta.addOutput("status", string(Success))
+	meta.addOutput("run_id", "run-123")
+	meta.closeOutput()
+
+	if notifier.received == nil {
+		t.Fatalf("expected notification to be sent")
+	}
+	if notifier.received.Command != "run apply" || notifier.received.Status != string(Success) || notifier.received.RunID != "run-123" {
+		t.Errorf("unexpected notification: %+v", notifier.received)
+	}
+}
+
+func TestMeta_CloseOutput_NotRateLimited(t *testing.T) {
+	_, meta := testMetaWithPlatform(t, &testPlatformContext{})
+	meta.cloud.UseRateLimits(cloud.NewRateLimitTracker())
+
+	meta.addOutput("status", string(Success))
+	result := meta.closeOutput()
+
+	if strings.Contains(result, "rate_limited") {
+		t.Errorf("expected no rate limit details but received %s", result)
+	}
+}
+
+func TestMeta_CloseOutput_YAML(t *testing.T) {
+	_, meta := testMetaWithPlatform(t, &testPlatformContext{}, WithFormat(YAMLFormat, nil))
+
+	meta.addOutput("status", string(Success))
+	meta.addOutputWithOpts("run_ids", []string{"run-123", "run-456"}, &outputOpts{stdOut: true})
+	result := meta.closeOutput()
+
+	expected := "run_ids:\n    - run-123\n    - run-456\nstatus: Success"
+	if result != expected {
+		t.Errorf("expected yaml result %q but received %q", expected, result)
+	}
+}
+
+func TestMeta_SetupCmd_ConfigDefaults(t *testing.T) {
+	ui, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+	cmd.config = &config.Config{Workspace: "my-workspace"}
+
+	if code := cmd.Run([]string{}); code != 0 {
+		t.Fatalf("expected workspace from config file but received exit code %d: %s", code, ui.ErrorWriter.String())
+	}
+	if cmd.Workspace != "my-workspace" {
+		t.Errorf("expected workspace %q but received %q", "my-workspace", cmd.Workspace)
+	}
+}
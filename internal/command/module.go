@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+type ModuleListCommand struct {
+	*Meta
+}
+
+func (c *ModuleListCommand) flags() *flag.FlagSet {
+	return c.flagSet("module list")
+}
+
+func (c *ModuleListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	modules, err := c.cloud.ListModules(c.appCtx, c.organization)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing registry modules in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("module_count", fmt.Sprintf("%d", len(modules)))
+	c.addOutputWithOpts("modules", modules, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"namespace", "name", "provider", "status", "no_code"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *ModuleListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] module list
+
+	Lists the registry modules of the organization, with their published versions, statuses and no-code enablement.
+
+` + globalOptionsHelp
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModuleListCommand) Synopsis() string {
+	return "Lists the registry modules of the organization"
+}
+
+type ModuleShowCommand struct {
+	*Meta
+
+	Name     string
+	Provider string
+	Version  string
+}
+
+func (c *ModuleShowCommand) flags() *flag.FlagSet {
+	f := c.flagSet("module show")
+	f.StringVar(&c.Name, "name", "", "Name of the private registry module.")
+	f.StringVar(&c.Provider, "provider", "", "Main provider of the registry module, such as aws.")
+	f.StringVar(&c.Version, "version", "", "Reports whether this version of the registry module is published.")
+	return f
+}
+
+func (c *ModuleShowCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" || c.Provider == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("showing a module requires a name and provider")
+		return 1
+	}
+
+	module, err := c.cloud.ReadModule(c.appCtx, c.organization, c.Name, c.Provider)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error reading module, '%s/%s' in HCP Terraform: %s", c.Name, c.Provider, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("module_id", module.ID)
+	c.addOutput("module_status", module.Status)
+	c.addOutput("no_code", fmt.Sprintf("%t", module.NoCode))
+	c.addOutput("version_count", fmt.Sprintf("%d", len(module.Versions)))
+	if c.Version != "" {
+		version := module.Version(c.Version)
+		c.addOutput("version_published", fmt.Sprintf("%t", version != nil))
+		if version != nil {
+			c.addOutput("version_status", version.Status)
+		}
+	}
+	c.addOutputWithOpts("module", module, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *ModuleShowCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] module show [options]
+
+	Returns a private registry module, with its published versions, statuses and no-code enablement.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name      Name of the private registry module.
+
+	-provider  Main provider of the registry module, such as aws.
+
+	-version   Reports whether this version of the registry module is published, to avoid uploading a duplicate version.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModuleShowCommand) Synopsis() string {
+	return "Returns a private registry module and its versions"
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type ModulePublishCommand struct {
+	*Meta
+
+	Name      string
+	Provider  string
+	Version   string
+	Directory string
+}
+
+func (c *ModulePublishCommand) flags() *flag.FlagSet {
+	f := c.flagSet("module publish")
+	f.StringVar(&c.Name, "name", "", "Name of the private registry module.")
+	f.StringVar(&c.Provider, "provider", "", "Main provider of the registry module, such as aws.")
+	f.StringVar(&c.Version, "version", "", "Version of the registry module to publish, such as 1.2.3.")
+	f.StringVar(&c.Directory, "directory", ".", "Path to the module configuration files on disk.")
+	return f
+}
+
+func (c *ModulePublishCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" || c.Provider == "" || c.Version == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("publishing a module requires a name, provider and version")
+		return 1
+	}
+
+	dirPath, dirError := filepath.Abs(c.Directory)
+	if dirError != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("error resolving directory path %s", dirError.Error()))
+		return 1
+	}
+
+	log.Printf("[DEBUG] publishing module: %s/%s version: %s directory: %s", c.Name, c.Provider, c.Version, dirPath)
+
+	version, err := c.cloud.PublishModule(c.appCtx, cloud.PublishModuleOptions{
+		Organization: c.organization,
+		Name:         c.Name,
+		Provider:     c.Provider,
+		Version:      c.Version,
+		Directory:    dirPath,
+	})
+	if version != nil {
+		c.addOutput("module_version_id", version.ID)
+		c.addOutput("module_version_status", string(version.Status))
+	}
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error publishing module, '%s/%s' version '%s' in HCP Terraform: %s", c.Name, c.Provider, c.Version, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("module_version", version.Version)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *ModulePublishCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] module publish [options]
+
+	Publishes a version of a private registry module from a local directory, creating the module when it does not exist yet.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name       Name of the private registry module.
+
+	-provider   Main provider of the registry module, such as aws.
+
+	-version    Version of the registry module to publish, such as 1.2.3.
+
+	-directory  Path to the module configuration files on disk. Defaults to the current directory.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModulePublishCommand) Synopsis() string {
+	return "Publishes a version of a private registry module"
+}
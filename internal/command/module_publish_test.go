@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testRegistryModuleService struct {
+	cloud.RegistryModuleService
+	options cloud.PublishModuleOptions
+	modules []*cloud.RegistryModuleDetails
+	err     error
+}
+
+func (s *testRegistryModuleService) PublishModule(_ context.Context, options cloud.PublishModuleOptions) (*tfe.RegistryModuleVersion, error) {
+	s.options = options
+	if s.err != nil {
+		return &tfe.RegistryModuleVersion{ID: "modver-1", Version: options.Version, Status: tfe.RegistryModuleVersionStatusRegIngressFailed}, s.err
+	}
+	return &tfe.RegistryModuleVersion{ID: "modver-1", Version: options.Version, Status: tfe.RegistryModuleVersionStatusOk}, nil
+}
+
+func testModuleCommandMeta(modules cloud.RegistryModuleService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RegistryModuleService = modules
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestModulePublishCommand(t *testing.T) {
+	modules := &testRegistryModuleService{}
+	ui, meta := testModuleCommandMeta(modules)
+	cmd := &ModulePublishCommand{Meta: meta}
+	if code := cmd.Run([]string{"-name=vpc", "-provider=aws", "-version=1.2.3", "-directory=./module"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if modules.options.Organization != "abc-company" || modules.options.Name != "vpc" || !strings.HasSuffix(modules.options.Directory, "module") {
+		t.Errorf("unexpected publish options %+v", modules.options)
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"module_version_id": "modver-1"`, `"module_version_status": "ok"`, `"module_version": "1.2.3"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestModulePublishCommand_Errors(t *testing.T) {
+	t.Run("requires a version", func(t *testing.T) {
+		ui, meta := testModuleCommandMeta(&testRegistryModuleService{})
+		cmd := &ModulePublishCommand{Meta: meta}
+		if code := cmd.Run([]string{"-name=vpc", "-provider=aws"}); code != 1 {
+			t.Errorf("expected exit code 1 but received %d", code)
+		}
+		if !strings.Contains(ui.ErrorWriter.String(), "requires a name, provider and version") {
+			t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+		}
+	})
+
+	t.Run("reports a failed version", func(t *testing.T) {
+		ui, meta := testModuleCommandMeta(&testRegistryModuleService{err: errors.New("registry module version 1.2.3 finished with status \"reg_ingress_failed\"")})
+		cmd := &ModulePublishCommand{Meta: meta}
+		if code := cmd.Run([]string{"-name=vpc", "-provider=aws", "-version=1.2.3"}); code != 1 {
+			t.Errorf("expected exit code 1 but received %d", code)
+		}
+		if !strings.Contains(ui.OutputWriter.String(), `"module_version_status": "reg_ingress_failed"`) {
+			t.Errorf("expected failed version status in output but received %s", ui.OutputWriter.String())
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+func (s *testRegistryModuleService) ListModules(context.Context, string) ([]*cloud.RegistryModuleDetails, error) {
+	return s.modules, nil
+}
+
+func (s *testRegistryModuleService) ReadModule(_ context.Context, _, name, provider string) (*cloud.RegistryModuleDetails, error) {
+	for _, module := range s.modules {
+		if module.Name == name && module.Provider == provider {
+			return module, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func testRegistryModules() []*cloud.RegistryModuleDetails {
+	return []*cloud.RegistryModuleDetails{{
+		ID:       "mod-1",
+		Name:     "vpc",
+		Provider: "aws",
+		Status:   "setup_complete",
+		NoCode:   true,
+		Versions: []*cloud.RegistryModuleVersionDetails{{Version: "1.2.3", Status: "ok"}},
+	}}
+}
+
+func TestModuleListCommand(t *testing.T) {
+	ui, meta := testModuleCommandMeta(&testRegistryModuleService{modules: testRegistryModules()})
+	cmd := &ModuleListCommand{Meta: meta}
+	if code := cmd.Run([]string{}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"module_count": "1"`, `"no_code": true`, `"version": "1.2.3"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestModuleShowCommand(t *testing.T) {
+	cases := map[string]struct {
+		version  string
+		expected []string
+	}{
+		"published version": {
+			version:  "1.2.3",
+			expected: []string{`"version_published": "true"`, `"version_status": "ok"`, `"no_code": "true"`},
+		},
+		"unpublished version": {
+			version:  "1.3.0",
+			expected: []string{`"version_published": "false"`, `"version_count": "1"`},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui, meta := testModuleCommandMeta(&testRegistryModuleService{modules: testRegistryModules()})
+			cmd := &ModuleShowCommand{Meta: meta}
+			if code := cmd.Run([]string{"-name=vpc", "-provider=aws", "-version=" + tc.version}); code != 0 {
+				t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestModuleShowCommand_NotFound(t *testing.T) {
+	ui, meta := testModuleCommandMeta(&testRegistryModuleService{})
+	cmd := &ModuleShowCommand{Meta: meta}
+	if code := cmd.Run([]string{"-name=vpc", "-provider=aws"}); code == 0 {
+		t.Errorf("expected a failing exit code for a missing module: %s", ui.OutputWriter.String())
+	}
+}
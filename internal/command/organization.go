@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+type OrganizationListCommand struct {
+	*Meta
+}
+
+func (c *OrganizationListCommand) flags() *flag.FlagSet {
+	return c.flagSet("organization list")
+}
+
+func (c *OrganizationListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	orgs, err := c.cloud.ListOrganizations(c.appCtx)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing organizations in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("organization_count", fmt.Sprintf("%d", len(orgs)))
+	c.addOutputWithOpts("organizations", orgs, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"name", "email"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *OrganizationListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] organization list
+
+	Lists organizations the token has access to, along with their entitlements.
+
+` + globalOptionsHelp
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OrganizationListCommand) Synopsis() string {
+	return "Lists organizations the token has access to, along with their entitlements"
+}
+
+type OrganizationShowCommand struct {
+	*Meta
+
+	Name string
+}
+
+func (c *OrganizationShowCommand) flags() *flag.FlagSet {
+	f := c.flagSet("organization show")
+	f.StringVar(&c.Name, "name", "", "The name of the HCP Terraform Organization. Defaults to the global organization.")
+	return f
+}
+
+func (c *OrganizationShowCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" {
+		c.Name = c.organization
+	}
+	if c.Name == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("showing an organization requires an organization name")
+		return 1
+	}
+
+	org, err := c.cloud.ReadOrganization(c.appCtx, c.Name)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error showing organization, '%s' in HCP Terraform: %s", c.Name, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("organization", org.Name)
+	for _, entitlement := range []string{"cost-estimation", "sentinel", "agents", "run-tasks"} {
+		c.addOutput(strings.ReplaceAll(entitlement, "-", "_")+"_enabled", fmt.Sprintf("%t", org.Entitlements[entitlement]))
+	}
+	c.addOutputWithOpts("entitlements", org.Entitlements, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *OrganizationShowCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] organization show [options]
+
+	Returns the entitlements of an organization, such as cost estimation, policies (sentinel) and agents.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name  The name of the HCP Terraform Organization. Defaults to the global organization.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OrganizationShowCommand) Synopsis() string {
+	return "Returns the entitlements of an organization"
+}
+
+type OrganizationQueueCommand struct {
+	*Meta
+
+	Name        string
+	AgentPoolID string
+	MaxPending  int
+}
+
+func (c *OrganizationQueueCommand) flags() *flag.FlagSet {
+	f := c.flagSet("organization queue")
+	f.StringVar(&c.Name, "name", "", "The name of the HCP Terraform Organization. Defaults to the global organization.")
+	f.StringVar(&c.AgentPoolID, "agent-pool", "", "Limits the queue to the runs of workspaces using the agent pool ID.")
+	f.IntVar(&c.MaxPending, "max-pending", 0, "Reports the queue as saturated when more runs are pending. Disabled by default.")
+	return f
+}
+
+func (c *OrganizationQueueCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" {
+		c.Name = c.organization
+	}
+	if c.Name == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("showing the run queue requires an organization name")
+		return 1
+	}
+
+	queue, err := c.cloud.ReadRunQueue(c.appCtx, c.Name, c.AgentPoolID)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error reading run queue of organization, '%s' in HCP Terraform: %s", c.Name, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("pending_count", fmt.Sprintf("%d", queue.PendingCount))
+	c.addOutput("active_count", fmt.Sprintf("%d", queue.ActiveCount))
+	c.addOutput("oldest_pending_seconds", fmt.Sprintf("%d", queue.OldestPendingSeconds))
+	if c.MaxPending > 0 {
+		saturated := queue.PendingCount > c.MaxPending
+		c.addOutput("saturated", fmt.Sprintf("%t", saturated))
+		if saturated {
+			c.softFailure(fmt.Sprintf("run queue is saturated, %d runs are pending, more than the maximum of %d", queue.PendingCount, c.MaxPending))
+		}
+	}
+	c.addOutputWithOpts("run_queue", queue, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *OrganizationQueueCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] organization queue [options]
+
+	Returns the pending and active runs of an organization, with their counts and the age of the oldest pending run.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name         The name of the HCP Terraform Organization. Defaults to the global organization.
+
+	-agent-pool   Limits the queue to the runs of workspaces using the agent pool ID.
+
+	-max-pending  Reports the queue as saturated when more runs are pending, as a warning that fails the command with --strict. Disabled by default.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OrganizationQueueCommand) Synopsis() string {
+	return "Returns the run queue of an organization"
+}
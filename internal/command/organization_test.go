@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testOrganizationReader struct {
+	orgs  []*cloud.OrganizationDetails
+	queue *cloud.RunQueueDetails
+}
+
+func (o *testOrganizationReader) ListOrganizations(_ context.Context) ([]*cloud.OrganizationDetails, error) {
+	return o.orgs, nil
+}
+
+func (o *testOrganizationReader) ReadOrganization(_ context.Context, name string) (*cloud.OrganizationDetails, error) {
+	for _, org := range o.orgs {
+		if org.Name == name {
+			return org, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (o *testOrganizationReader) ReadRunQueue(_ context.Context, name string, agentPoolID string) (*cloud.RunQueueDetails, error) {
+	if o.queue == nil {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return o.queue, nil
+}
+
+func testOrganizationMeta(t *testing.T, org string) (*cli.MockUi, *Meta) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.OrganizationService = &testOrganizationReader{
+		orgs: []*cloud.OrganizationDetails{
+			{Name: "abc-company", Entitlements: map[string]bool{"cost-estimation": true, "sentinel": false}},
+		},
+	}
+
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg(org))
+}
+
+func TestOrganizationShowCommand(t *testing.T) {
+	testCases := []struct {
+		name         string
+		org          string
+		args         []string
+		expectedCode int
+		expectedOut  []string
+	}{
+		{
+			name:         "global-organization",
+			org:          "abc-company",
+			expectedCode: 0,
+			expectedOut:  []string{`"cost_estimation_enabled": "true"`, `"sentinel_enabled": "false"`},
+		},
+		{
+			name:         "name-flag",
+			args:         []string{"-name=abc-company"},
+			expectedCode: 0,
+			expectedOut:  []string{`"organization": "abc-company"`},
+		},
+		{
+			name:         "not-found",
+			args:         []string{"-name=xyz-company"},
+			expectedCode: 1,
+			expectedOut:  []string{`"status": "NotFound"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testOrganizationMeta(t, tc.org)
+			cmd := &OrganizationShowCommand{Meta: meta}
+
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganizationListCommand(t *testing.T) {
+	ui, meta := testOrganizationMeta(t, "")
+	cmd := &OrganizationListCommand{Meta: meta}
+
+	if code := cmd.Run([]string{}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, `"organization_count": "1"`) {
+		t.Errorf("expected organization count in output but received %s", output)
+	}
+}
+
+func TestOrganizationQueueCommand(t *testing.T) {
+	queue := &cloud.RunQueueDetails{
+		Organization:         "abc-company",
+		PendingCount:         3,
+		ActiveCount:          1,
+		OldestPendingSeconds: 540,
+		Runs:                 []*cloud.QueuedRun{{ID: "run-1", Status: "pending", Pending: true, AgeSeconds: 540}},
+	}
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expectedOut  []string
+		expectedErr  string
+	}{
+		{
+			name:         "counts",
+			args:         []string{},
+			expectedCode: 0,
+			expectedOut:  []string{`"pending_count": "3"`, `"active_count": "1"`, `"oldest_pending_seconds": "540"`, `"id": "run-1"`},
+		},
+		{
+			name:         "not-saturated",
+			args:         []string{"-max-pending=5"},
+			expectedCode: 0,
+			expectedOut:  []string{`"saturated": "false"`},
+		},
+		{
+			name:         "saturated",
+			args:         []string{"-max-pending=2"},
+			expectedCode: 0,
+			expectedOut:  []string{`"saturated": "true"`},
+			expectedErr:  "run queue is saturated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testOrganizationMeta(t, "abc-company")
+			meta.cloud.OrganizationService.(*testOrganizationReader).queue = queue
+			cmd := &OrganizationQueueCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			if tc.expectedErr != "" && !strings.Contains(ui.ErrorWriter.String(), tc.expectedErr) {
+				t.Errorf("expected error output to contain %s but received %s", tc.expectedErr, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
@@ -23,6 +23,11 @@ type outputMessage struct {
 	platformOut bool
 	// if the value may contain strings/json that is multiline
 	multiLine bool
+	// file name the value is written to within the artifacts directory
+	artifact string
+	// the list rendered with --format table, and its default columns
+	table   bool
+	columns []string
 }
 
 func (o *outputMessage) IncludeWithPlatform() bool {
@@ -79,6 +84,13 @@ type outputOpts struct {
 	platformOut bool
 	// option to indicate if value contains a multiline value as some platforms: gitlab do not support multiline values in `.env`
 	multiLine bool
+	// option to write the value to a file with a stable name when an artifacts directory is configured,
+	// instead of including the full value with platform output
+	artifact string
+	// option to render the list value with --format table, with the default columns.
+	// all fields are rendered without default columns
+	table   bool
+	columns []string
 }
 
 func newOutputMessage(name string, value interface{}, opts *outputOpts) *outputMessage {
@@ -88,6 +100,9 @@ func newOutputMessage(name string, value interface{}, opts *outputOpts) *outputM
 		stdOut:      opts.stdOut,
 		platformOut: opts.platformOut,
 		multiLine:   opts.multiLine,
+		artifact:    opts.artifact,
+		table:       opts.table,
+		columns:     opts.columns,
 	}
 }
 
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+type OutputFormat string
+
+const (
+	JSONFormat OutputFormat = "json"
+	YAMLFormat OutputFormat = "yaml"
+	// stdout only, lists are rendered as a table for local operators
+	TableFormat OutputFormat = "table"
+)
+
+func ParseOutputFormat(format string) (OutputFormat, error) {
+	switch OutputFormat(format) {
+	case "", JSONFormat:
+		return JSONFormat, nil
+	case YAMLFormat:
+		return YAMLFormat, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q, must be one of: json, yaml", format)
+	}
+}
+
+// parses the format of the result written to stdout, which can also be rendered as a table
+func ParseFormat(format string) (OutputFormat, error) {
+	switch OutputFormat(format) {
+	case "", JSONFormat:
+		return JSONFormat, nil
+	case YAMLFormat, TableFormat:
+		return OutputFormat(format), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, must be one of: json, yaml, table", format)
+	}
+}
+
+// converts the json result into the requested format
+func formatResult(format OutputFormat, outJson []byte) ([]byte, error) {
+	if format != YAMLFormat {
+		return outJson, nil
+	}
+
+	// round trip through json so yaml keys match the json result
+	var data interface{}
+	if err := json.Unmarshal(outJson, &data); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(data)
+}
+
+// writes the final command result to a file, in addition to stdout and platform output
+func writeOutputFile(path string, format OutputFormat, outJson []byte) error {
+	data, err := formatResult(format, outJson)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteOutputFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:     "json",
+			format:   "json",
+			expected: `"run_id": "run-123"`,
+		},
+		{
+			name:     "default",
+			format:   "",
+			expected: `"run_id": "run-123"`,
+		},
+		{
+			name:     "yaml",
+			format:   "yaml",
+			expected: "run_id: run-123",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := ParseOutputFormat(tc.format)
+			if err != nil {
+				t.Fatalf("unexpected error parsing format: %s", err)
+			}
+
+			path := filepath.Join(t.TempDir(), "result")
+			_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+			WithOutputFile(path, format)(cmd.Meta)
+
+			cmd.addOutput("run_id", "run-123")
+			cmd.closeOutput()
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("file read error: %v", err)
+			}
+			if !strings.Contains(string(contents), tc.expected) {
+				t.Errorf("expected %q in output file but received %q", tc.expected, string(contents))
+			}
+		})
+	}
+}
+
+func TestParseOutputFormat_Invalid(t *testing.T) {
+	if _, err := ParseOutputFormat("xml"); err == nil {
+		t.Fatalf("expected error for unsupported output format")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat("table"); err != nil || format != TableFormat {
+		t.Fatalf("expected table format but received %q, %v", format, err)
+	}
+	if _, err := ParseOutputFormat("table"); err == nil {
+		t.Fatalf("expected error for table output file format")
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// renders the list output of the command as a table, other commands are rendered as a table of outputs
+func (c *Meta) renderTable(outJson []byte) (string, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(outJson, &result); err != nil {
+		return "", err
+	}
+
+	// the table output is picked by name, ranging over the messages would pick one at random
+	if m, ok := c.messages[c.tableOutput]; ok && m.table && m.stdOut {
+		rows, _ := result[m.name].([]interface{})
+		columns := c.columns
+		if len(columns) == 0 {
+			columns = m.columns
+		}
+		return renderRows(rows, columns)
+	}
+
+	// scalar outputs, such as a run id and status
+	rows := []interface{}{}
+	for _, name := range sortedKeys(result) {
+		if isScalar(result[name]) {
+			rows = append(rows, map[string]interface{}{"name": name, "value": result[name]})
+		}
+	}
+	return renderRows(rows, []string{"name", "value"})
+}
+
+func renderRows(rows []interface{}, columns []string) (string, error) {
+	available := map[string]bool{}
+	for _, row := range rows {
+		if fields, ok := row.(map[string]interface{}); ok {
+			for k := range fields {
+				available[k] = true
+			}
+		}
+	}
+	// rows without default columns render every field
+	if len(columns) == 0 {
+		columns = sortedKeys(available)
+	}
+	if len(rows) > 0 {
+		for _, column := range columns {
+			if !available[column] {
+				return "", fmt.Errorf("unknown column %q, must be one of: %s", column, strings.Join(sortedKeys(available), ", "))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, strings.ToUpper(column))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fields, _ := row.(map[string]interface{})
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, tableCell(fields[column]))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// nested values are rendered as compact json, each row is kept to a single line
+func tableCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.Join(strings.Fields(v), " ")
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
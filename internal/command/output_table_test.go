@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+func TestMeta_CloseOutput_Table(t *testing.T) {
+	teams := []*cloud.TeamDetails{
+		{ID: "team-abc123", Name: "owners", Visibility: "secret", UserCount: 2},
+		{ID: "team-def456", Name: "platform engineering", Visibility: "organization", UserCount: 12},
+	}
+	testCases := []struct {
+		name     string
+		columns  []string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "default-columns",
+			expected: "ID           NAME                  VISIBILITY    USER_COUNT\nteam-abc123  owners                secret        2\nteam-def456  platform engineering  organization  12",
+		},
+		{
+			name:     "selected-columns",
+			columns:  []string{"name", "user_count"},
+			expected: "NAME                  USER_COUNT\nowners                2\nplatform engineering  12",
+		},
+		{
+			name:    "unknown-column",
+			columns: []string{"name", "members"},
+			errMsg:  `error rendering table: unknown column "members"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testMetaWithPlatform(t, &testPlatformContext{}, WithFormat(TableFormat, tc.columns))
+
+			meta.addOutput("status", string(Success))
+			meta.addOutputWithOpts("teams", teams, &outputOpts{
+				stdOut:  true,
+				table:   true,
+				columns: []string{"id", "name", "visibility", "user_count"},
+			})
+			result := meta.closeOutput()
+
+			if tc.errMsg != "" {
+				if !strings.Contains(ui.ErrorWriter.String(), tc.errMsg) {
+					t.Errorf("expected error %q but received %q", tc.errMsg, ui.ErrorWriter.String())
+				}
+				if !strings.Contains(result, `"teams"`) {
+					t.Errorf("expected json result when the table cannot be rendered but received %q", result)
+				}
+				return
+			}
+			if result != tc.expected {
+				t.Errorf("expected table:\n%s\nbut received:\n%s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMeta_CloseOutput_TableOutputs(t *testing.T) {
+	_, meta := testMetaWithPlatform(t, &testPlatformContext{}, WithFormat(TableFormat, nil))
+
+	meta.addOutput("status", string(Success))
+	meta.addOutput("run_id", "run-abc123")
+	meta.addOutputWithOpts("payload", map[string]string{"id": "run-abc123"}, &outputOpts{stdOut: true})
+	result := meta.closeOutput()
+
+	expected := "NAME    VALUE\nrun_id  run-abc123\nstatus  Success"
+	if result != expected {
+		t.Errorf("expected table:\n%s\nbut received:\n%s", expected, result)
+	}
+}
+
+func TestMeta_CloseOutput_TableFirstList(t *testing.T) {
+	// repeated, as ranging over the outputs would render either list
+	for i := 0; i < 20; i++ {
+		_, meta := testMetaWithPlatform(t, &testPlatformContext{}, WithFormat(TableFormat, nil))
+
+		meta.addOutput("status", string(Success))
+		meta.addOutputWithOpts("teams", []map[string]string{{"name": "owners"}}, &outputOpts{stdOut: true, table: true})
+		meta.addOutputWithOpts("members", []map[string]string{{"username": "octocat"}}, &outputOpts{stdOut: true, table: true})
+		result := meta.closeOutput()
+
+		expected := "NAME\nowners"
+		if result != expected {
+			t.Fatalf("expected the first list to be rendered:\n%s\nbut received:\n%s", expected, result)
+		}
+	}
+}
+
+func TestTableCell(t *testing.T) {
+	testCases := map[string]struct {
+		value    interface{}
+		expected string
+	}{
+		"nil":       {nil, ""},
+		"multiline": {"first line\nsecond  line", "first line second line"},
+		"number":    {float64(12), "12"},
+		"bool":      {true, "true"},
+		"nested":    {map[string]interface{}{"region": "us-east-1"}, `{"region":"us-east-1"}`},
+	}
+	for name, tc := range testCases {
+		if actual := tableCell(tc.value); actual != tc.expected {
+			t.Errorf("%s: expected %q but received %q", name, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+	"join": func(sep string, v []interface{}) string {
+		values := make([]string, len(v))
+		for i, item := range v {
+			values[i] = fmt.Sprint(item)
+		}
+		return strings.Join(values, sep)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"default": func(fallback interface{}, v interface{}) interface{} {
+		if v == nil || v == "" {
+			return fallback
+		}
+		return v
+	},
+}
+
+// parses an inline go template, or the contents of a file when prefixed with @
+func ParseFormatTemplate(value string) (*template.Template, error) {
+	if value == "" {
+		return nil, nil
+	}
+	text := value
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read format template: %w", err)
+		}
+		text = string(contents)
+	}
+	tmpl, err := template.New("format-template").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renders the json result through the template, outputs are accessed by name, ex: {{ .run_id }}
+func renderTemplate(tmpl *template.Template, outJson []byte) (string, error) {
+	// round trip through json so template fields match the json result
+	var data map[string]interface{}
+	if err := json.Unmarshal(outJson, &data); err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFormatTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.tmpl")
+	if err := os.WriteFile(path, []byte("run={{ .run_id }}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+		err      string
+	}{
+		{name: "inline", value: "{{ .status }}: {{ .run_id }}", expected: "Success: run-123"},
+		{name: "file", value: "@" + path, expected: "run=run-123"},
+		{name: "functions", value: `{{ .status | lower }} {{ join "," .stacks }} {{ .missing | default "none" }}`, expected: "success a,b none"},
+		{name: "json", value: "{{ json .stacks }}", expected: `["a","b"]`},
+		{name: "invalid", value: "{{ .run_id", err: "invalid format template"},
+		{name: "missing file", value: "@" + filepath.Join(t.TempDir(), "missing"), err: "unable to read format template"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := ParseFormatTemplate(tc.value)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error %q but received %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+			WithFormatTemplate(tmpl)(cmd.Meta)
+			cmd.addOutput("status", "Success")
+			cmd.addOutput("run_id", "run-123")
+			cmd.addOutputWithOpts("stacks", []string{"a", "b"}, &outputOpts{stdOut: true})
+
+			if actual := cmd.closeOutput(); actual != tc.expected {
+				t.Errorf("expected %q but received %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// stages of a pipeline run, reported with the `stage` output
+const (
+	pipelineStageUpload = "upload"
+	pipelineStagePlan   = "plan"
+	pipelineStageApply  = "apply"
+)
+
+type PipelineRunCommand struct {
+	*Meta
+
+	Workspace   string
+	Directory   string
+	Message     string
+	TargetAddrs []string
+	Comment     string
+	SlugCache   string
+
+	PlanOnly        bool
+	IsDestroy       bool
+	Apply           bool
+	WaitForApproval bool
+
+	start time.Time
+}
+
+func (c *PipelineRunCommand) flags() *flag.FlagSet {
+	f := c.flagSet("pipeline run")
+	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.StringVar(&c.Directory, "directory", "", "Path to the configuration files on disk.")
+	f.StringVar(&c.Message, "message", "", "Specifies the message to be associated with this run. A default message will be set.")
+	f.BoolVar(&c.PlanOnly, "plan-only", false, "Uploads a speculative configuration version and creates a plan-only run that cannot be applied.")
+	f.BoolVar(&c.IsDestroy, "is-destroy", false, "Specifies that the plan is a destroy plan. When true, the plan destroys all provisioned resources.")
+	f.Var((*flagStringSlice)(&c.TargetAddrs), "target", "Limit the planning operation to only the given module, resource, or resource instance and all of its dependencies.")
+	f.BoolVar(&c.Apply, "apply", false, "Applies the run once the plan is confirmable.")
+	f.BoolVar(&c.WaitForApproval, "wait-for-approval", false, "Waits for the run to be confirmed in HCP Terraform and applied.")
+	f.StringVar(&c.Comment, "comment", "", "An optional comment when applying the run.")
+	f.StringVar(&c.SlugCache, "slug-cache", "", "Path to a file caching the hash of previously uploaded configurations. An unchanged configuration reuses the previous configuration version instead of uploading again.")
+	return f
+}
+
+func (c *PipelineRunCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+	c.start = time.Now()
+
+	if c.Apply && c.WaitForApproval {
+		return c.failed(pipelineStageUpload, nil, "-apply and -wait-for-approval cannot be used together")
+	}
+	if c.PlanOnly && (c.Apply || c.WaitForApproval) {
+		return c.failed(pipelineStageUpload, nil, "plan-only runs cannot be applied, remove -apply or -wait-for-approval")
+	}
+
+	dirPath, err := filepath.Abs(c.Directory)
+	if err != nil {
+		return c.failed(pipelineStageUpload, err, fmt.Sprintf("error resolving directory path %s", err.Error()))
+	}
+
+	// upload
+	log.Printf("[DEBUG] pipeline uploading configuration, workspace: %s, directory: %s", c.Workspace, dirPath)
+	c.writer.Output(fmt.Sprintf("Uploading configuration version to workspace: %q", c.Workspace))
+	uploadStart := time.Now()
+	configVersion, err := c.cloud.UploadConfig(c.appCtx, cloud.UploadOptions{
+		Workspace:              c.Workspace,
+		Organization:           c.organization,
+		ConfigurationDirectory: dirPath,
+		Speculative:            c.PlanOnly,
+		SlugCacheFile:          c.SlugCache,
+	})
+	c.addDuration(durationUpload, time.Since(uploadStart))
+	if configVersion != nil {
+		c.addOutput("configuration_version_id", configVersion.ID)
+		c.addOutput("configuration_version_status", string(configVersion.Status))
+	}
+	if err != nil {
+		return c.failed(pipelineStageUpload, err, fmt.Sprintf("error uploading configuration version to HCP Terraform: %s", err.Error()))
+	}
+
+	// plan
+	if c.Message == "" {
+		c.Message = (&CreateRunCommand{Meta: c.Meta}).defaultRunMessage()
+	}
+	// only -env-var flags can be invalid
+	envVars, _ := collectEnvCategoryVariables(nil)
+	run, err := c.cloud.CreateRun(c.appCtx, cloud.CreateRunOptions{
+		Organization:           c.organization,
+		Workspace:              c.Workspace,
+		ConfigurationVersionID: configVersion.ID,
+		Message:                c.Message,
+		PlanOnly:               c.PlanOnly,
+		IsDestroy:              c.IsDestroy,
+		RunVariables:           collectVariables(),
+		TargetAddrs:            c.TargetAddrs,
+		EnvVariables:           envVars,
+	})
+	if run != nil {
+		(&CreateRunCommand{Meta: c.Meta}).readPlanLogs(run)
+		c.addRunDetails(run)
+	}
+	if err != nil {
+		return c.failed(pipelineStagePlan, err, fmt.Sprintf("error while creating run in HCP Terraform: %s", err.Error()))
+	}
+
+	// apply
+	if !c.Apply && !c.WaitForApproval {
+		return c.succeeded(pipelineStagePlan, Success)
+	}
+	if run.Actions == nil || !run.Actions.IsConfirmable {
+		if run.Status == tfe.RunApplied {
+			// auto-apply workspace, the run was applied with the plan
+			return c.succeeded(pipelineStageApply, Success)
+		}
+		c.writer.Output(fmt.Sprintf("Run %s, cannot be applied with status: %q. There is nothing to do.", run.ID, run.Status))
+		return c.succeeded(pipelineStagePlan, Noop)
+	}
+
+	var applied *tfe.Run
+	if c.Apply {
+		applied, err = c.cloud.ApplyRun(c.appCtx, cloud.ApplyRunOptions{
+			RunID:   run.ID,
+			Comment: c.Comment,
+		})
+	} else {
+		link, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
+		c.writer.Output(fmt.Sprintf("Waiting for run to be confirmed in HCP Terraform: %s", link))
+		applied, err = c.cloud.WaitForApply(c.appCtx, run.ID)
+	}
+	if applied != nil {
+		(&ApplyRunCommand{Meta: c.Meta}).readApplyLogs(applied)
+		c.addRunDetails(applied)
+	}
+	if err != nil {
+		return c.failed(pipelineStageApply, err, fmt.Sprintf("error applying run, '%s' in HCP Terraform: %s", run.ID, err.Error()))
+	}
+	return c.succeeded(pipelineStageApply, Success)
+}
+
+func (c *PipelineRunCommand) addRunDetails(run *tfe.Run) {
+	link, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
+	c.addRunLinks(run, link)
+	c.addOutput("run_id", run.ID)
+	c.addOutput("run_status", string(run.Status))
+	if run.Plan != nil {
+		c.addOutput("plan_id", run.Plan.ID)
+		c.addOutput("plan_status", string(run.Plan.Status))
+	}
+	if run.Apply != nil && run.Apply.ID != "" {
+		c.addOutput("apply_id", run.Apply.ID)
+		c.addOutput("apply_status", string(run.Apply.Status))
+	}
+	c.addRunDurations(run)
+	c.addRunDiagnostics(run)
+	c.addApplySummary(run)
+}
+
+func (c *PipelineRunCommand) succeeded(stage string, status Status) int {
+	// the pipeline total includes the upload
+	c.addDuration(durationTotal, time.Since(c.start))
+	c.addOutput("status", string(status))
+	c.addOutput("stage", stage)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+// reports the stage the pipeline failed in, err may be nil for invalid options
+func (c *PipelineRunCommand) failed(stage string, err error, msg string) int {
+	status := Error
+	if err != nil {
+		status = c.resolveStatus(err)
+	}
+	c.addDuration(durationTotal, time.Since(c.start))
+	c.addOutput("status", string(status))
+	c.addOutput("stage", stage)
+	c.writer.ErrorResult(msg)
+	c.writer.OutputResult(c.closeOutput())
+	return 1
+}
+
+func (c *PipelineRunCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] pipeline run [options]
+
+	Uploads a configuration version, creates a run, and optionally applies it, in a single step.
+	Defaults for each option can be set for the "pipeline run" command in the config file.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace          The name of the HCP Terraform Workspace.
+
+	-directory          Path to the terraform configuration files on disk.
+
+	-message            Specifies the message to be associated with this run. A default message will be set.
+
+	-plan-only          Uploads a speculative configuration version and creates a plan-only run that cannot be applied.
+
+	-is-destroy         Specifies that the plan is a destroy plan. When true, the plan destroys all provisioned resources.
+
+	-target             Limit the planning operation to only the given module, resource, or resource instance and all of its dependencies.
+
+	-apply              Applies the run once the plan is confirmable.
+
+	-wait-for-approval  Waits for the run to be confirmed in HCP Terraform and applied.
+
+	-comment            An optional comment when applying the run.
+
+	-slug-cache         Path to a file caching the hash of previously uploaded configurations. When the configuration is unchanged, the previous configuration version is reused instead of uploading again.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PipelineRunCommand) Synopsis() string {
+	return "Uploads configuration, creates a run, and optionally applies it in a single step"
+}
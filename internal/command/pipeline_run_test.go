@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testPipelineRunService struct {
+	cloud.RunService
+
+	run         *tfe.Run
+	applyErr    error
+	diagnostics []*cloud.Diagnostic
+
+	created *cloud.CreateRunOptions
+	applied bool
+	waited  bool
+}
+
+func (s *testPipelineRunService) CreateRun(_ context.Context, options cloud.CreateRunOptions) (*tfe.Run, error) {
+	s.created = &options
+	return s.run, nil
+}
+
+func (s *testPipelineRunService) ApplyRun(_ context.Context, _ cloud.ApplyRunOptions) (*tfe.Run, error) {
+	s.applied = true
+	return s.appliedRun(), s.applyErr
+}
+
+func (s *testPipelineRunService) WaitForApply(_ context.Context, _ string) (*tfe.Run, error) {
+	s.waited = true
+	return s.appliedRun(), s.applyErr
+}
+
+func (s *testPipelineRunService) appliedRun() *tfe.Run {
+	run := *s.run
+	run.Status = tfe.RunApplied
+	run.Apply = &tfe.Apply{ID: "apply-1", Status: tfe.ApplyFinished}
+	return &run
+}
+
+func (s *testPipelineRunService) RunLink(_ context.Context, _ string, run *tfe.Run) (string, error) {
+	return "https://app.terraform.io/runs/" + run.ID, nil
+}
+
+func (s *testPipelineRunService) GetPlanLogs(context.Context, string) error          { return nil }
+func (s *testPipelineRunService) GetApplyLogs(context.Context, string) error         { return nil }
+func (s *testPipelineRunService) GetPolicyCheckLogs(context.Context, *tfe.Run) error { return nil }
+func (s *testPipelineRunService) LogCostEstimation(context.Context, *tfe.Run)        {}
+func (s *testPipelineRunService) GetRunDiagnostics(context.Context, *tfe.Run) ([]*cloud.Diagnostic, error) {
+	return s.diagnostics, nil
+}
+func (s *testPipelineRunService) GetApplySummary(_ context.Context, run *tfe.Run) (*cloud.ApplySummary, error) {
+	return &cloud.ApplySummary{ApplyID: run.Apply.ID, Added: 2, Changed: 1, StateVersionID: "sv-1"}, nil
+}
+func (s *testPipelineRunService) LogTaskStage(context.Context, *tfe.Run, tfe.Stage) error {
+	return nil
+}
+
+func testPipelineRunCommand(t *testing.T, runs *testPipelineRunService) (*cli.MockUi, *PipelineRunCommand) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.ConfigVersionService = &SuccessfulUploader{
+		configurationVersion: &tfe.ConfigurationVersion{ID: "cv-1", Status: tfe.ConfigurationUploaded},
+	}
+	cloudMockService.RunService = runs
+
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &PipelineRunCommand{Meta: meta}
+}
+
+func testPipelineRun(status tfe.RunStatus, confirmable bool) *tfe.Run {
+	return &tfe.Run{
+		ID:      "run-1",
+		Status:  status,
+		Plan:    &tfe.Plan{ID: "plan-1", Status: tfe.PlanFinished},
+		Actions: &tfe.RunActions{IsConfirmable: confirmable},
+	}
+}
+
+func TestPipelineRunCommand(t *testing.T) {
+	cases := map[string]struct {
+		args     []string
+		run      *tfe.Run
+		applyErr error
+		code     int
+		applied  bool
+		waited   bool
+		expected []string
+	}{
+		"plan": {
+			args:     []string{"-workspace=ws", "-directory=."},
+			run:      testPipelineRun(tfe.RunPlanned, true),
+			expected: []string{`"stage": "plan"`, `"status": "Success"`, `"configuration_version_id": "cv-1"`, `"plan_id": "plan-1"`},
+		},
+		"apply": {
+			args:     []string{"-workspace=ws", "-directory=.", "-apply"},
+			run:      testPipelineRun(tfe.RunPlanned, true),
+			applied:  true,
+			expected: []string{`"stage": "apply"`, `"status": "Success"`, `"apply_id": "apply-1"`, `"run_status": "applied"`, `"resources_added": "2"`, `"resources_changed": "1"`, `"resources_destroyed": "0"`, `"state_version_id": "sv-1"`},
+		},
+		"wait for approval": {
+			args:     []string{"-workspace=ws", "-directory=.", "-wait-for-approval"},
+			run:      testPipelineRun(tfe.RunPlanned, true),
+			waited:   true,
+			expected: []string{`"stage": "apply"`, `"apply_status": "finished"`},
+		},
+		"nothing to apply": {
+			args:     []string{"-workspace=ws", "-directory=.", "-apply"},
+			run:      testPipelineRun(tfe.RunPlannedAndFinished, false),
+			expected: []string{`"stage": "plan"`, `"status": "Noop"`},
+		},
+		"apply error": {
+			args:     []string{"-workspace=ws", "-directory=.", "-apply"},
+			run:      testPipelineRun(tfe.RunPlanned, true),
+			applyErr: errors.New("run has ended with: 'errored' status"),
+			code:     1,
+			applied:  true,
+			expected: []string{`"stage": "apply"`, `"status": "Error"`},
+		},
+		"plan-only with apply": {
+			args:     []string{"-workspace=ws", "-directory=.", "-plan-only", "-apply"},
+			run:      testPipelineRun(tfe.RunPlannedAndFinished, false),
+			code:     1,
+			expected: []string{`"stage": "upload"`, `"status": "Error"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			runs := &testPipelineRunService{run: tc.run, applyErr: tc.applyErr}
+			ui, cmd := testPipelineRunCommand(t, runs)
+
+			if code := cmd.Run(tc.args); code != tc.code {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.code, code, ui.ErrorWriter.String())
+			}
+			if runs.applied != tc.applied || runs.waited != tc.waited {
+				t.Errorf("expected applied: %t, waited: %t but received applied: %t, waited: %t", tc.applied, tc.waited, runs.applied, runs.waited)
+			}
+			if runs.created != nil && runs.created.ConfigurationVersionID != "cv-1" {
+				t.Errorf("expected run to use uploaded configuration version but received %q", runs.created.ConfigurationVersionID)
+			}
+
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
@@ -9,17 +9,20 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
 )
 
 type OutputPlanCommand struct {
 	*Meta
 
 	PlanID string
+	RunID  string
 }
 
 func (c *OutputPlanCommand) flags() *flag.FlagSet {
 	f := c.flagSet("plan output")
 	f.StringVar(&c.PlanID, "plan", "", "The plan ID to retrieve JSON execution plan.")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to retrieve the plan of, instead of a plan ID.")
 
 	return f
 }
@@ -29,9 +32,37 @@ func (c *OutputPlanCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.PlanID != "" && c.RunID != "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("plan output accepts either a plan id or a run id, not both")
+		return 1
+	}
+
+	// approval jobs may only have the run id
+	if c.RunID != "" {
+		run, runErr := c.cloud.GetRun(c.appCtx, cloud.GetRunOptions{
+			RunID: c.RunID,
+		})
+		if runErr != nil {
+			c.addOutput("status", string(c.resolveStatus(runErr)))
+			c.writer.ErrorResult(fmt.Sprintf("unable to read run: %s with: %s", c.RunID, runErr.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+		c.addOutput("run_id", run.ID)
+		if run.Plan == nil || run.Plan.ID == "" {
+			c.addOutput("status", string(Error))
+			c.writer.ErrorResult(fmt.Sprintf("run %s does not have a plan", c.RunID))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+		c.PlanID = run.Plan.ID
+	}
+
 	plan, pErr := c.cloud.GetPlan(c.appCtx, c.PlanID)
 	if pErr != nil {
-		c.addOutput("status", string(Error))
+		c.addOutput("status", string(c.resolveStatus(pErr)))
 		c.addPlanDetails(plan)
 		c.writer.ErrorResult(fmt.Sprintf("error retrieving plan data %s\n", pErr.Error()))
 		c.writer.OutputResult(c.closeOutput())
@@ -58,6 +89,7 @@ func (c *OutputPlanCommand) addPlanDetails(plan *tfe.Plan) {
 		stdOut:      false,
 		multiLine:   true,
 		platformOut: true,
+		artifact:    "plan.json",
 	})
 }
 
@@ -65,19 +97,14 @@ func (c *OutputPlanCommand) Help() string {
 	helpText := `
 Usage: tfci [global options] plan output [options]
 
-	Returns the plan details for the provided Plan ID.
-
-Global Options:
-
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
-
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
-
-	-organization   HCP Terraform Organization Name.
+	Returns the plan details for the provided Plan ID, or the plan of the provided Run ID.
 
+` + globalOptionsHelp + `
 Options:
 
 	-plan           Returns the plan details for the provided Plan ID.
+
+	-run            Existing HCP Terraform Run ID to return the plan details of, instead of a Plan ID.
 	`
 	return strings.TrimSpace(helpText)
 }
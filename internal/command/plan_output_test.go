@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type testPlanService struct {
+	planID string
+}
+
+func (s *testPlanService) GetPlan(_ context.Context, planID string) (*tfe.Plan, error) {
+	s.planID = planID
+	return &tfe.Plan{ID: planID, Status: tfe.PlanFinished, ResourceAdditions: 2}, nil
+}
+
+type testPlanRunService struct {
+	cloud.RunService
+}
+
+func (s *testPlanRunService) GetRun(_ context.Context, options cloud.GetRunOptions) (*tfe.Run, error) {
+	if options.RunID == "run-noplan" {
+		return &tfe.Run{ID: options.RunID}, nil
+	}
+	return &tfe.Run{ID: options.RunID, Plan: &tfe.Plan{ID: "plan-fromrun"}}, nil
+}
+
+func TestOutputPlanCommand(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expectedPlan string
+		expectedOut  string
+	}{
+		{name: "plan-id", args: []string{"-plan=plan-abc123"}, expectedCode: 0, expectedPlan: "plan-abc123", expectedOut: `"plan_id": "plan-abc123"`},
+		{name: "run-id", args: []string{"-run=run-abc123"}, expectedCode: 0, expectedPlan: "plan-fromrun", expectedOut: `"run_id": "run-abc123"`},
+		{name: "run-without-plan", args: []string{"-run=run-noplan"}, expectedCode: 1, expectedOut: "run run-noplan does not have a plan"},
+		{name: "plan-and-run", args: []string{"-plan=plan-abc123", "-run=run-abc123"}, expectedCode: 1, expectedOut: "either a plan id or a run id"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testEndedRunMeta(&testPlanRunService{})
+			plans := &testPlanService{}
+			meta.cloud.PlanService = plans
+			c := &OutputPlanCommand{Meta: meta}
+
+			if code := c.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if plans.planID != tc.expectedPlan {
+				t.Errorf("expected plan %q to be read but received %q", tc.expectedPlan, plans.planID)
+			}
+			if output := ui.OutputWriter.String() + ui.ErrorWriter.String(); !strings.Contains(output, tc.expectedOut) {
+				t.Errorf("expected %q but received %q", tc.expectedOut, output)
+			}
+		})
+	}
+}
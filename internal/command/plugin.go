@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// executables on PATH named tfci-<name> are run as the <name> subcommand
+const pluginPrefix = "tfci-"
+
+// DiscoverPlugins returns the path of each tfci-<name> executable on PATH by command name,
+// the first directory on PATH takes precedence as with any other executable
+func DiscoverPlugins(pathEnv string) map[string]string {
+	plugins := map[string]string{}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if _, exists := plugins[name]; exists {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			plugins[name] = path
+		}
+	}
+	return plugins
+}
+
+func pluginName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(file), ".exe") {
+			return "", false
+		}
+		file = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	name, ok := strings.CutPrefix(file, pluginPrefix)
+	// command names are a single word, spaces would nest them under another command
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
+
+// PluginCommand runs a tfci-<name> executable with the resolved global context in its environment
+type PluginCommand struct {
+	*Meta
+
+	Name string
+	Path string
+	// hostname and token resolved from global options
+	Credentials *cloud.Credentials
+}
+
+func (c *PluginCommand) Run(args []string) int {
+	// "--" stops tfci from handling help flags meant for the plugin
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	cmd := exec.CommandContext(c.appCtx, c.Path, args...)
+	cmd.Env = append(os.Environ(), c.pluginEnv()...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	default:
+		c.writer.Error(fmt.Sprintf("error running plugin %s: %s", c.Path, err))
+		return 1
+	}
+}
+
+// global context is passed with the environment variables tfci reads, so plugins can call tfci in turn
+func (c *PluginCommand) pluginEnv() []string {
+	env := []string{
+		"TFCI_PLUGIN_NAME=" + c.Name,
+		"TF_CLOUD_ORGANIZATION=" + c.organization,
+	}
+	if c.Credentials != nil {
+		env = append(env,
+			"TF_HOSTNAME="+c.Credentials.Hostname,
+			"TF_API_TOKEN="+c.Credentials.Token,
+			"TFCI_TOKEN_SOURCE="+c.Credentials.Source,
+		)
+	}
+	if c.env == nil {
+		return env
+	}
+	env = append(env,
+		"TFCI_PLATFORM="+string(c.env.PlatformType),
+		"TFCI_CI="+strconv.FormatBool(c.env.CI),
+	)
+	if ctx := c.env.Context; ctx != nil {
+		env = append(env,
+			"TFCI_CONTEXT_ID="+ctx.ID(),
+			"TFCI_CONTEXT_SHA="+ctx.SHA(),
+			"TFCI_CONTEXT_AUTHOR="+ctx.Author(),
+			"TFCI_CONTEXT_WRITE_DIR="+ctx.WriteDir(),
+		)
+	}
+	return env
+}
+
+func (c *PluginCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] ` + c.Name + ` [args]
+
+	Runs the plugin executable at ` + c.Path + ` with the remaining arguments.
+
+	The plugin receives the resolved global context as environment variables: TF_HOSTNAME, TF_API_TOKEN, TFCI_TOKEN_SOURCE, TF_CLOUD_ORGANIZATION, TFCI_PLATFORM, TFCI_CI and TFCI_CONTEXT_ID, TFCI_CONTEXT_SHA, TFCI_CONTEXT_AUTHOR, TFCI_CONTEXT_WRITE_DIR. Pass plugin help flags after "--", ex: tfci ` + c.Name + ` -- --help.
+
+` + globalOptionsHelp
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PluginCommand) Synopsis() string {
+	return fmt.Sprintf("Runs the %s%s plugin", pluginPrefix, c.Name)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+func writePlugin(t *testing.T, dir string, name string, script string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a unix shell")
+	}
+	first := t.TempDir()
+	second := t.TempDir()
+	drift := writePlugin(t, first, "tfci-drift", "", 0755)
+	writePlugin(t, second, "tfci-drift", "", 0755)
+	cost := writePlugin(t, second, "tfci-cost", "", 0755)
+	writePlugin(t, second, "tfci-notexec", "", 0644)
+	writePlugin(t, second, "terraform-docs", "", 0755)
+	if err := os.Mkdir(filepath.Join(second, "tfci-dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins := DiscoverPlugins(strings.Join([]string{first, filepath.Join(first, "missing"), second}, string(os.PathListSeparator)))
+	expected := map[string]string{"drift": drift, "cost": cost}
+	if len(plugins) != len(expected) {
+		t.Fatalf("expected plugins %v but received %v", expected, plugins)
+	}
+	for name, path := range expected {
+		if plugins[name] != path {
+			t.Errorf("expected plugin %q at %q but received %q", name, path, plugins[name])
+		}
+	}
+}
+
+func TestPluginCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a unix shell")
+	}
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "env")
+	path := writePlugin(t, dir, "tfci-drift", `echo "$@" > `+envFile+`
+env | grep -E '^(TF_|TFCI_)' >> `+envFile+`
+exit 3
+`, 0755)
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	meta := NewMetaOpts(context.Background(), cloud.NewCloud(&tfe.Client{}, writer), &environment.CI{PlatformType: environment.Other}, WithWriter(writer), WithOrg("abc-company"))
+	c := &PluginCommand{
+		Meta:        meta,
+		Name:        "drift",
+		Path:        path,
+		Credentials: &cloud.Credentials{Hostname: "tfe.example.com", Token: "abc.atlasv1.token", Source: "environment"},
+	}
+
+	if code := c.Run([]string{"--", "--help", "-workspace=prod"}); code != 3 {
+		t.Errorf("expected the plugin exit code 3 but received %d", code)
+	}
+	b, err := os.ReadFile(envFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+	for _, expected := range []string{
+		"--help -workspace=prod\n",
+		"TFCI_PLUGIN_NAME=drift",
+		"TF_CLOUD_ORGANIZATION=abc-company",
+		"TF_HOSTNAME=tfe.example.com",
+		"TF_API_TOKEN=abc.atlasv1.token",
+		"TFCI_TOKEN_SOURCE=environment",
+		"TFCI_PLATFORM=Other",
+		"TFCI_CI=false",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected plugin to receive %q but received:\n%s", expected, out)
+		}
+	}
+}
+
+func TestPluginCommand_Missing(t *testing.T) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	meta := NewMetaOpts(context.Background(), cloud.NewCloud(&tfe.Client{}, writer), &environment.CI{}, WithWriter(writer))
+	c := &PluginCommand{Meta: meta, Name: "drift", Path: filepath.Join(t.TempDir(), "tfci-drift")}
+
+	if code := c.Run(nil); code != 1 {
+		t.Errorf("expected exit code 1 for a missing plugin but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "error running plugin") {
+		t.Errorf("expected plugin error but received %q", ui.ErrorWriter.String())
+	}
+}
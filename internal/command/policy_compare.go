@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type PolicyCompareCommand struct {
+	*Meta
+
+	BaseRunID string
+	HeadRunID string
+}
+
+func (c *PolicyCompareCommand) flags() *flag.FlagSet {
+	f := c.flagSet("policy compare")
+	f.StringVar(&c.BaseRunID, "base", "", "Existing HCP Terraform Run ID to compare from, eg. a run of the default branch.")
+	f.StringVar(&c.HeadRunID, "head", "", "Existing HCP Terraform Run ID to compare to, eg. a run of the change under review.")
+	return f
+}
+
+func (c *PolicyCompareCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.BaseRunID == "" || c.HeadRunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("comparing policy results requires a base and a head run id")
+		return 1
+	}
+
+	evaluations := map[string]*cloud.PolicyEvaluation{}
+	for _, runID := range []string{c.BaseRunID, c.HeadRunID} {
+		evaluation, err := c.cloud.GetPolicyEvaluation(c.appCtx, runID)
+		if err != nil {
+			status := c.resolveStatus(err)
+			c.addOutput("status", string(status))
+			c.writer.ErrorResult(fmt.Sprintf("error reading policy results of run, '%s' in HCP Terraform: %s", runID, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+		evaluations[runID] = evaluation
+	}
+
+	comparison := cloud.ComparePolicyEvaluations(evaluations[c.BaseRunID], evaluations[c.HeadRunID])
+	for _, o := range comparison.NewlyFailing {
+		c.writer.Output(fmt.Sprintf("Newly failing: Policy '%s', PolicySet: '%s', EnforcementLevel: '%s'", o.Name, o.PolicySet, o.EnforcementLevel))
+	}
+	for _, o := range comparison.NewlyPassing {
+		c.writer.Output(fmt.Sprintf("Newly passing: Policy '%s', PolicySet: '%s', EnforcementLevel: '%s'", o.Name, o.PolicySet, o.EnforcementLevel))
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("base_run_id", c.BaseRunID)
+	c.addOutput("head_run_id", c.HeadRunID)
+	c.addOutput("has_new_violations", fmt.Sprintf("%t", comparison.HasNewViolations))
+	c.addOutput("newly_failing_count", fmt.Sprintf("%d", len(comparison.NewlyFailing)))
+	c.addOutput("newly_passing_count", fmt.Sprintf("%d", len(comparison.NewlyPassing)))
+	c.addOutput("unchanged_count", fmt.Sprintf("%d", len(comparison.Unchanged)))
+	c.addOutputWithOpts("policy_comparison", comparison, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *PolicyCompareCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] policy compare [options]
+
+	Compares the policy results of two runs, reporting the policies that are
+	newly failing, newly passing or unchanged in the head run.
+
+` + globalOptionsHelp + `
+Options:
+
+	-base  Existing HCP Terraform Run ID to compare from, eg. a run of the default branch.
+
+	-head  Existing HCP Terraform Run ID to compare to, eg. a run of the change under review.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PolicyCompareCommand) Synopsis() string {
+	return "Compares the policy results of two runs"
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testPolicyCompareRunService struct {
+	cloud.RunService
+	evaluations map[string]*cloud.PolicyEvaluation
+}
+
+func (s *testPolicyCompareRunService) GetPolicyEvaluation(_ context.Context, runID string) (*cloud.PolicyEvaluation, error) {
+	return s.evaluations[runID], nil
+}
+
+func TestPolicyCompareCommand(t *testing.T) {
+	base := testPolicyEvaluation()
+	base.RunID = "run-base"
+	head := testPolicyEvaluation()
+	head.RunID = "run-head"
+	// require-tags fails in the head run
+	head.Stages[1].Evaluations[0].PolicySets[0].Policies[0] = &cloud.PolicyOutcome{Name: "require-tags", EnforcementLevel: "mandatory", Status: "failed"}
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = &testPolicyCompareRunService{evaluations: map[string]*cloud.PolicyEvaluation{"run-base": base, "run-head": head}}
+	cmd := &PolicyCompareCommand{Meta: NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))}
+
+	if code := cmd.Run([]string{"-base=run-base", "-head=run-head"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{
+		`"has_new_violations": "true"`,
+		`"newly_failing_count": "1"`,
+		`"unchanged_count": "1"`,
+		"Newly failing: Policy 'require-tags', PolicySet: 'baseline', EnforcementLevel: 'mandatory'",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
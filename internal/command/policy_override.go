@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type PolicyOverrideCommand struct {
+	*Meta
+
+	RunID             string
+	Justification     string
+	DryRun            bool
+	ApprovalsFile     string
+	RequiredApprovers int
+	NoopOk            bool
+}
+
+func (c *PolicyOverrideCommand) flags() *flag.FlagSet {
+	f := c.flagSet("policy override")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to override the failed policies of.")
+	f.StringVar(&c.Justification, "justification", "", "Reason for the override, recorded as a comment on the run.")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Validates the override and reports what would be overridden, without overriding it.")
+	f.StringVar(&c.ApprovalsFile, "approvals-file", "", "Path to a yaml or json file listing the approvers of the override.")
+	f.IntVar(&c.RequiredApprovers, "required-approvers", 0, "Number of distinct approvers required in the approvals file before overriding.")
+	f.BoolVar(&c.NoopOk, "noop-ok", false, "Reports a Noop status instead of failing when the run no longer needs a policy override.")
+	return f
+}
+
+func (c *PolicyOverrideCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" || strings.TrimSpace(c.Justification) == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("overriding policies requires a run id and a justification")
+		return 1
+	}
+
+	approvers, err := c.readApprovers()
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+	justification := c.Justification
+	if len(approvers) > 0 {
+		justification = fmt.Sprintf("%s\n\nApproved by: %s", justification, strings.Join(approvers, ", "))
+	}
+
+	override, err := c.cloud.PlanPolicyOverride(c.appCtx, c.RunID)
+	var notRequired *cloud.OverrideNotRequiredError
+	if c.NoopOk && errors.As(err, &notRequired) && slices.Contains(cloud.OverrideNoopStatus, notRequired.Status) {
+		c.addOutput("status", string(Noop))
+		c.addOutput("run_id", c.RunID)
+		c.addPolicyLinks(c.RunID)
+		c.addOutput("run_status", string(notRequired.Status))
+		c.writer.ErrorResult(fmt.Sprintf("run %s, is %s. There is nothing to override.", c.RunID, notRequired.Status))
+		c.writer.OutputResult(c.closeOutput())
+		return 0
+	}
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("unable to override policies of run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if !c.DryRun {
+		if err := c.cloud.OverridePolicies(c.appCtx, override, justification); err != nil {
+			status := c.resolveStatus(err)
+			c.addOutput("status", string(status))
+			c.addPolicyOverrideDetails(override)
+			c.writer.ErrorResult(fmt.Sprintf("error overriding policies of run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+	}
+
+	for _, target := range override.Targets {
+		action := "Overridden"
+		if c.DryRun {
+			action = "Would override"
+		}
+		c.writer.Output(fmt.Sprintf("%s: '%s' (%s), MandatoryFailed: (%d)", action, target.Stage, target.ID, target.MandatoryFailed))
+	}
+
+	c.addOutput("status", string(Success))
+	c.addPolicyOverrideDetails(override)
+	if len(approvers) > 0 {
+		c.addOutput("approvers", strings.Join(approvers, ","))
+	}
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+// reads the distinct approvers of the override, failing when fewer than the required approvers approved
+func (c *PolicyOverrideCommand) readApprovers() ([]string, error) {
+	if c.ApprovalsFile == "" {
+		if c.RequiredApprovers > 0 {
+			return nil, fmt.Errorf("overriding policies requires %d approvers, but no -approvals-file was provided", c.RequiredApprovers)
+		}
+		return nil, nil
+	}
+	approvals, err := readApprovalsFile(c.ApprovalsFile)
+	if err != nil {
+		return nil, err
+	}
+	author := ""
+	if c.env != nil && c.env.Context != nil {
+		author = c.env.Context.Author()
+	}
+	approvers := approvals.distinctApprovers(author)
+	if len(approvers) < c.RequiredApprovers {
+		return nil, fmt.Errorf("overriding policies requires %d distinct approvers, but only %d approved: %s", c.RequiredApprovers, len(approvers), strings.Join(approvers, ", "))
+	}
+	return approvers, nil
+}
+
+func (c *PolicyOverrideCommand) addPolicyOverrideDetails(override *cloud.PolicyOverride) {
+	c.addOutput("run_id", override.RunID)
+	c.addPolicyLinks(override.RunID)
+	c.addOutput("run_status", override.RunStatus)
+	c.addOutput("dry_run", fmt.Sprintf("%t", c.DryRun))
+	c.addOutput("override_count", fmt.Sprintf("%d", len(override.Targets)))
+	c.addOutputWithOpts("overrides", override.Targets, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+}
+
+func (c *PolicyOverrideCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] policy override [options]
+
+	Overrides the failed mandatory policies of a run that is waiting for a policy
+	override, so the run can continue.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run                 Existing HCP Terraform Run ID to override the failed policies of.
+
+	-justification       Reason for the override, recorded as a comment on the run.
+
+	-dry-run             Validates the run status, failed mandatory policies and
+	                     override permissions, and reports what would be overridden,
+	                     without overriding it.
+
+	-approvals-file      Path to a yaml or json file listing the approvers of the
+	                     override. Approvers are added to the justification.
+
+	-required-approvers  Number of distinct approvers required in the approvals file
+	                     before overriding. The author of the CI pipeline does not
+	                     count as an approver.
+
+	-noop-ok             Reports a "Noop" status instead of failing when the run no
+	                     longer needs a policy override, such as after it was
+	                     overridden or applied, so re-running a pipeline does not fail.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PolicyOverrideCommand) Synopsis() string {
+	return "Overrides the failed policies of a run"
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testPolicyOverrideRunService struct {
+	cloud.RunService
+	justification string
+	overridden    bool
+	// status of a run that is not waiting for a policy override
+	status tfe.RunStatus
+}
+
+func (s *testPolicyOverrideRunService) PlanPolicyOverride(_ context.Context, runID string) (*cloud.PolicyOverride, error) {
+	if s.status != "" {
+		return nil, &cloud.OverrideNotRequiredError{Status: s.status}
+	}
+	return &cloud.PolicyOverride{
+		RunID:     runID,
+		RunStatus: "post_plan_awaiting_decision",
+		Targets:   []*cloud.PolicyOverrideTarget{{ID: "ts-1", Stage: "post_plan", Status: "awaiting_override", MandatoryFailed: 1}},
+	}, nil
+}
+
+func (s *testPolicyOverrideRunService) OverridePolicies(_ context.Context, _ *cloud.PolicyOverride, justification string) error {
+	s.overridden = true
+	s.justification = justification
+	return nil
+}
+
+func (s *testPolicyOverrideRunService) GetRun(_ context.Context, options cloud.GetRunOptions) (*tfe.Run, error) {
+	return nil, errors.New("not found")
+}
+
+func testPolicyOverrideCommand(runs cloud.RunService) (*cli.MockUi, *PolicyOverrideCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &PolicyOverrideCommand{Meta: meta}
+}
+
+func TestPolicyOverrideCommand(t *testing.T) {
+	runs := &testPolicyOverrideRunService{}
+	ui, cmd := testPolicyOverrideCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-justification=approved in CHANGE-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !runs.overridden || runs.justification != "approved in CHANGE-1" {
+		t.Errorf("expected policies to be overridden with the justification")
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"override_count": "1"`, `"dry_run": "false"`, "Overridden: 'post_plan' (ts-1)"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestPolicyOverrideCommand_NoopOk(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		status       tfe.RunStatus
+		expectedCode int
+		expected     string
+	}{
+		{name: "applied", args: []string{"-noop-ok"}, status: tfe.RunApplied, expectedCode: 0, expected: `"status": "Noop"`},
+		{name: "without-noop-ok", status: tfe.RunApplied, expectedCode: 1, expected: `"status": "Error"`},
+		{name: "overridden", args: []string{"-noop-ok"}, status: tfe.RunPolicyOverride, expectedCode: 0, expected: `"status": "Noop"`},
+		{name: "pending", args: []string{"-noop-ok"}, status: tfe.RunPending, expectedCode: 1, expected: `"status": "Error"`},
+		{name: "errored", args: []string{"-noop-ok"}, status: tfe.RunErrored, expectedCode: 1, expected: `"status": "Error"`},
+		{name: "canceled", args: []string{"-noop-ok"}, status: tfe.RunCanceled, expectedCode: 1, expected: `"status": "Error"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := &testPolicyOverrideRunService{status: tc.status}
+			ui, cmd := testPolicyOverrideCommand(runs)
+			args := append([]string{"-run=run-1", "-justification=approved in CHANGE-1"}, tc.args...)
+			if code := cmd.Run(args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if runs.overridden {
+				t.Error("expected policies not to be overridden")
+			}
+			if output := ui.OutputWriter.String(); !strings.Contains(output, tc.expected) {
+				t.Errorf("expected output to contain %s but received %s", tc.expected, output)
+			}
+		})
+	}
+}
+
+func TestPolicyOverrideCommand_DryRun(t *testing.T) {
+	runs := &testPolicyOverrideRunService{}
+	ui, cmd := testPolicyOverrideCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-justification=approved in CHANGE-1", "-dry-run"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.overridden {
+		t.Error("expected policies not to be overridden in a dry run")
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"dry_run": "true"`, "Would override: 'post_plan' (ts-1), MandatoryFailed: (1)"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestPolicyOverrideCommand_RequiresJustification(t *testing.T) {
+	ui, cmd := testPolicyOverrideCommand(&testPolicyOverrideRunService{})
+	if code := cmd.Run([]string{"-run=run-1"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "overriding policies requires a run id and a justification") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
+
+func testApprovalsFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "approvals.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("unexpected error writing approvals file: %s", err)
+	}
+	return path
+}
+
+func TestPolicyOverrideCommand_RequiredApprovers(t *testing.T) {
+	path := testApprovalsFile(t, `
+approvals:
+  - approver: alice
+    comment: Reviewed in CHANGE-1
+  - approver: Alice
+  - approver: bob
+`)
+
+	runs := &testPolicyOverrideRunService{}
+	ui, cmd := testPolicyOverrideCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-justification=approved in CHANGE-1", "-approvals-file=" + path, "-required-approvers=2"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.justification != "approved in CHANGE-1\n\nApproved by: alice, bob" {
+		t.Errorf("expected approvers in the justification but received %q", runs.justification)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"approvers": "alice,bob"`) {
+		t.Errorf("expected approvers in output but received %s", ui.OutputWriter.String())
+	}
+}
+
+func TestPolicyOverrideCommand_InsufficientApprovers(t *testing.T) {
+	// duplicate approvals count once
+	path := testApprovalsFile(t, `{"approvals": [{"approver": "alice"}, {"approver": "ALICE"}]}`)
+
+	runs := &testPolicyOverrideRunService{}
+	ui, cmd := testPolicyOverrideCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-justification=approved", "-approvals-file=" + path, "-required-approvers=2"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if runs.overridden {
+		t.Error("expected policies not to be overridden")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "requires 2 distinct approvers, but only 1 approved: alice") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+
+	ui, cmd = testPolicyOverrideCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-justification=approved", "-required-approvers=1"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "no -approvals-file was provided") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestApprovalsFile_DistinctApprovers(t *testing.T) {
+	approvals := &approvalsFile{Approvals: []*approval{{Approver: "ci-bot"}, {Approver: "alice"}, {Approver: " bob "}}}
+	approvers := approvals.distinctApprovers("CI-Bot")
+	if strings.Join(approvers, ",") != "alice,bob" {
+		t.Errorf("expected the author to be excluded but received %v", approvers)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type PolicyReportFormat string
+
+const (
+	PolicyReportJSON     PolicyReportFormat = "json"
+	PolicyReportSARIF    PolicyReportFormat = "sarif"
+	PolicyReportJUnit    PolicyReportFormat = "junit"
+	PolicyReportMarkdown PolicyReportFormat = "markdown"
+)
+
+func parsePolicyReportFormat(format string) (PolicyReportFormat, error) {
+	switch PolicyReportFormat(format) {
+	case "", PolicyReportJSON:
+		return PolicyReportJSON, nil
+	case PolicyReportSARIF, PolicyReportJUnit, PolicyReportMarkdown:
+		return PolicyReportFormat(format), nil
+	default:
+		return "", fmt.Errorf("unsupported results format %q, must be one of: json, sarif, junit, markdown", format)
+	}
+}
+
+// writes the normalized policy evaluation to a file, so it can be archived as a compliance artifact
+func writePolicyReport(path string, format PolicyReportFormat, evaluation *cloud.PolicyEvaluation) error {
+	data, err := renderPolicyReport(format, evaluation)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func renderPolicyReport(format PolicyReportFormat, evaluation *cloud.PolicyEvaluation) ([]byte, error) {
+	switch format {
+	case PolicyReportSARIF:
+		return json.MarshalIndent(newSarifReport(evaluation), "", "  ")
+	case PolicyReportJUnit:
+		data, err := xml.MarshalIndent(newJUnitReport(evaluation), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(xml.Header), data...), nil
+	case PolicyReportMarkdown:
+		return []byte(markdownPolicyReport(evaluation)), nil
+	default:
+		return json.MarshalIndent(evaluation, "", "  ")
+	}
+}
+
+// failures of mandatory policies block the run, advisory failures are only reported
+func policyFailureLevel(policy *cloud.PolicyOutcome) string {
+	switch {
+	case policy.Status == cloud.PolicyStatusPassed:
+		return ""
+	case policy.EnforcementLevel == "advisory":
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+type sarifReport struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID  string       `json:"ruleId"`
+	Kind    string       `json:"kind"`
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+func newSarifReport(evaluation *cloud.PolicyEvaluation) *sarifReport {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "tfci",
+			InformationURI: "https://github.com/hashicorp/tfc-workflows-tooling",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	rules := map[string]bool{}
+	for _, policy := range evaluation.Policies(true) {
+		ruleID := fmt.Sprintf("%s/%s", policy.PolicySet, policy.Name)
+		if !rules[ruleID] {
+			rules[ruleID] = true
+			rule := sarifRule{ID: ruleID}
+			if policy.Description != "" {
+				rule.ShortDescription = &sarifMessage{Text: policy.Description}
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+		result := sarifResult{
+			RuleID:  ruleID,
+			Kind:    "pass",
+			Level:   "none",
+			Message: sarifMessage{Text: fmt.Sprintf("Policy '%s' %s in stage %s of run %s", policy.Name, policy.Status, policy.Stage, evaluation.RunID)},
+		}
+		if level := policyFailureLevel(policy); level != "" {
+			result.Kind = "fail"
+			result.Level = level
+		}
+		run.Results = append(run.Results, result)
+	}
+	return &sarifReport{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+func newJUnitReport(evaluation *cloud.PolicyEvaluation) *junitTestSuites {
+	report := &junitTestSuites{Name: fmt.Sprintf("policies %s", evaluation.RunID)}
+	policies := evaluation.Policies(true)
+	for _, stage := range evaluation.Stages {
+		suite := junitTestSuite{Name: fmt.Sprintf("%s (%s)", stage.Stage, stage.ID)}
+		for _, policy := range policies {
+			if policy.Stage != stage.Stage {
+				continue
+			}
+			testCase := junitTestCase{Name: policy.Name, ClassName: fmt.Sprintf("%s.%s", stage.Stage, policy.PolicySet)}
+			switch policyFailureLevel(policy) {
+			case "error":
+				testCase.Failure = &junitFailure{Message: fmt.Sprintf("%s policy %s", policy.EnforcementLevel, policy.Status), Type: policy.EnforcementLevel}
+			case "warning":
+				testCase.SystemOut = fmt.Sprintf("advisory policy %s", policy.Status)
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		// legacy policy checks and sentinel evaluations only report counts
+		if len(suite.TestCases) == 0 {
+			testCase := junitTestCase{Name: stage.Stage, ClassName: stage.Stage}
+			if stage.MandatoryFailed > 0 || stage.Errored > 0 {
+				testCase.Failure = &junitFailure{Message: fmt.Sprintf("%d mandatory policies failed, %d errored", stage.MandatoryFailed, stage.Errored), Type: "mandatory"}
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		for _, testCase := range suite.TestCases {
+			suite.Tests++
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+func markdownPolicyReport(evaluation *cloud.PolicyEvaluation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Policy Results: %s\n\n", evaluation.Status)
+	fmt.Fprintf(&b, "Run: `%s`, Passed: %d, Advisory failed: %d, Mandatory failed: %d, Errored: %d, Requires override: %t\n",
+		evaluation.RunID, evaluation.Passed, evaluation.AdvisoryFailed, evaluation.MandatoryFailed, evaluation.Errored, evaluation.RequiresOverride)
+
+	if len(evaluation.Stages) > 0 {
+		b.WriteString("\n| Stage | Status | Passed | Advisory Failed | Mandatory Failed | Errored |\n")
+		b.WriteString("| ----- | ------ | ------ | --------------- | ---------------- | ------- |\n")
+		for _, stage := range evaluation.Stages {
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %d |\n", stage.Stage, stage.Status, stage.Passed, stage.AdvisoryFailed, stage.MandatoryFailed, stage.Errored)
+		}
+	}
+
+	if policies := evaluation.Policies(true); len(policies) > 0 {
+		b.WriteString("\n| Stage | Policy Set | Policy | Enforcement Level | Status | Description |\n")
+		b.WriteString("| ----- | ---------- | ------ | ----------------- | ------ | ----------- |\n")
+		for _, policy := range policies {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", policy.Stage, policy.PolicySet, policy.Name, policy.EnforcementLevel, policy.Status, strings.ReplaceAll(policy.Description, "|", "\\|"))
+		}
+	}
+	return b.String()
+}
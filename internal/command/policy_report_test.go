@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+func testPolicyEvaluation() *cloud.PolicyEvaluation {
+	return &cloud.PolicyEvaluation{
+		RunID:              "run-1",
+		Status:             cloud.PolicyStatusFailed,
+		PolicyResultCounts: cloud.PolicyResultCounts{Passed: 1, AdvisoryFailed: 1, MandatoryFailed: 1},
+		Stages: []*cloud.PolicyStageResult{
+			{ID: "polchk-1", Stage: cloud.PolicyCheckStage, Status: "soft_failed", PolicyResultCounts: cloud.PolicyResultCounts{MandatoryFailed: 1}},
+			{ID: "ts-1", Stage: "post_plan", Status: "passed", PolicyResultCounts: cloud.PolicyResultCounts{Passed: 1, AdvisoryFailed: 1}, Evaluations: []*cloud.PolicyEvaluationDetails{{
+				PolicySets: []*cloud.PolicySetOutcomeDetails{{
+					Name: "baseline",
+					Policies: []*cloud.PolicyOutcome{
+						{Name: "require-tags", Description: "Resources must be tagged", EnforcementLevel: "mandatory", Status: "passed"},
+						{Name: "restrict-regions", EnforcementLevel: "advisory", Status: "failed"},
+					},
+				}},
+			}}},
+		},
+	}
+}
+
+func TestParsePolicyReportFormat(t *testing.T) {
+	if format, err := parsePolicyReportFormat(""); err != nil || format != PolicyReportJSON {
+		t.Errorf("expected json by default but received %q, %v", format, err)
+	}
+	if _, err := parsePolicyReportFormat("csv"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestRenderPolicyReport(t *testing.T) {
+	evaluation := testPolicyEvaluation()
+
+	t.Run("json", func(t *testing.T) {
+		data, err := renderPolicyReport(PolicyReportJSON, evaluation)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var decoded cloud.PolicyEvaluation
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.RunID != "run-1" || len(decoded.Stages) != 2 {
+			t.Errorf("unexpected json report %s", data)
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		data, err := renderPolicyReport(PolicyReportSARIF, evaluation)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var report sarifReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Fatalf("unexpected error decoding report: %s", err)
+		}
+		results := report.Runs[0].Results
+		if report.Version != "2.1.0" || len(results) != 2 || len(report.Runs[0].Tool.Driver.Rules) != 2 {
+			t.Fatalf("unexpected sarif report %s", data)
+		}
+		if results[0].Kind != "pass" || results[1].Kind != "fail" || results[1].Level != "warning" || results[1].RuleID != "baseline/restrict-regions" {
+			t.Errorf("unexpected sarif results %+v", results)
+		}
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		data, err := renderPolicyReport(PolicyReportJUnit, evaluation)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var report junitTestSuites
+		if err := xml.Unmarshal(data, &report); err != nil {
+			t.Fatalf("unexpected error decoding report: %s", err)
+		}
+		// the advisory failure does not fail the suite
+		if report.Tests != 3 || report.Failures != 1 || len(report.Suites) != 2 {
+			t.Errorf("unexpected junit report %s", data)
+		}
+		if report.Suites[0].TestCases[0].Failure == nil {
+			t.Errorf("expected the policy check to fail but received %+v", report.Suites[0])
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		data, err := renderPolicyReport(PolicyReportMarkdown, evaluation)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, expected := range []string{
+			"## Policy Results: failed",
+			"| policy_check | soft_failed | 0 | 0 | 1 | 0 |",
+			"| post_plan | baseline | require-tags | mandatory | passed | Resources must be tagged |",
+		} {
+			if !strings.Contains(string(data), expected) {
+				t.Errorf("expected markdown to contain %q but received %s", expected, data)
+			}
+		}
+	})
+}
+
+func TestPolicyShowCommand_ResultsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy-results.md")
+	ui, cmd := testPolicyShowCommand(&testPolicyRunService{evaluation: testPolicyEvaluation()})
+	if code := cmd.Run([]string{"-run=run-1", "-results-file=" + path, "-results-format=markdown"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected results file to be written: %s", err)
+	}
+	if !strings.HasPrefix(string(contents), "## Policy Results: failed") {
+		t.Errorf("unexpected results file %s", contents)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"results_file": "`+path+`"`) {
+		t.Errorf("expected results file in output but received %s", ui.OutputWriter.String())
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type PolicyShowCommand struct {
+	*Meta
+
+	RunID         string
+	ShowAll       bool
+	Logs          bool
+	ResultsFile   string
+	ResultsFormat string
+	FailOn        string
+}
+
+// policy failure classes that -fail-on can fail the command for
+type policyFailure string
+
+const (
+	policyFailureMandatory policyFailure = "mandatory"
+	policyFailureAdvisory  policyFailure = "advisory"
+	policyFailureError     policyFailure = "error"
+)
+
+// returned when the policy results contain a failure class selected with -fail-on
+type policyFailedError struct {
+	failures []string
+}
+
+func (e *policyFailedError) Error() string {
+	return fmt.Sprintf("policy results have failures: %s", strings.Join(e.failures, ", "))
+}
+
+func parsePolicyFailures(value string) ([]policyFailure, error) {
+	failures := []policyFailure{}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		switch policyFailure(v) {
+		case "":
+			continue
+		case policyFailureMandatory, policyFailureAdvisory, policyFailureError:
+			failures = append(failures, policyFailure(v))
+		default:
+			return nil, fmt.Errorf("invalid -fail-on value %q, expected one or more of: mandatory, advisory, error", v)
+		}
+	}
+	return failures, nil
+}
+
+// checks the policy results for the selected failure classes
+func checkPolicyFailures(evaluation *cloud.PolicyEvaluation, failOn []policyFailure) error {
+	found := []string{}
+	for _, failure := range failOn {
+		count := 0
+		switch failure {
+		case policyFailureMandatory:
+			count = evaluation.MandatoryFailed
+		case policyFailureAdvisory:
+			count = evaluation.AdvisoryFailed
+		case policyFailureError:
+			count = evaluation.Errored
+		}
+		if count > 0 {
+			found = append(found, fmt.Sprintf("%s (%d)", failure, count))
+		}
+	}
+	if len(found) > 0 {
+		return &policyFailedError{failures: found}
+	}
+	return nil
+}
+
+func (c *PolicyShowCommand) flags() *flag.FlagSet {
+	f := c.flagSet("policy show")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to show policy results of.")
+	f.BoolVar(&c.ShowAll, "show-all", false, "Include passed policies, in addition to the policies that failed.")
+	f.BoolVar(&c.Logs, "logs", false, "Print the policy check logs, or the policy set outcomes of policy evaluations, of each stage.")
+	f.StringVar(&c.ResultsFile, "results-file", "", "Writes the policy results to the provided file path, to archive as a compliance artifact.")
+	f.StringVar(&c.ResultsFormat, "results-format", "json", "Format of the file written to -results-file: json, sarif, junit or markdown.")
+	f.StringVar(&c.FailOn, "fail-on", "", "Exits with a non-zero code when the policy results contain the given failures, comma separated: mandatory, advisory, error.")
+	return f
+}
+
+func (c *PolicyShowCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("showing policy results requires a run id")
+		return 1
+	}
+
+	format, err := parsePolicyReportFormat(c.ResultsFormat)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	failOn, err := parsePolicyFailures(c.FailOn)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	evaluation, err := c.cloud.GetPolicyEvaluation(c.appCtx, c.RunID)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error reading policy results of run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	policies := evaluation.Policies(c.ShowAll)
+	c.logPolicyEvaluation(evaluation, policies)
+	if c.Logs {
+		if logErr := c.cloud.LogPolicyEvaluation(c.appCtx, evaluation); logErr != nil {
+			c.softFailure(fmt.Sprintf("failed to read policy logs: %s", logErr.Error()))
+		}
+	}
+
+	if c.ResultsFile != "" {
+		if err := writePolicyReport(c.ResultsFile, format, evaluation); err != nil {
+			c.addOutput("status", string(Error))
+			c.writer.ErrorResult(fmt.Sprintf("error writing policy results to %q: %s", c.ResultsFile, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+		c.addOutput("results_file", c.ResultsFile)
+	}
+
+	failErr := checkPolicyFailures(evaluation, failOn)
+	if failErr != nil {
+		c.addOutput("status", string(c.resolveStatus(failErr)))
+	} else {
+		c.addOutput("status", string(Success))
+	}
+	c.addOutput("run_id", c.RunID)
+	c.addPolicyLinks(c.RunID)
+	c.addOutput("policy_status", evaluation.Status)
+	c.addOutput("requires_override", fmt.Sprintf("%t", evaluation.RequiresOverride))
+	c.addOutput("policies_passed", fmt.Sprintf("%d", evaluation.Passed))
+	c.addOutput("policies_advisory_failed", fmt.Sprintf("%d", evaluation.AdvisoryFailed))
+	c.addOutput("policies_mandatory_failed", fmt.Sprintf("%d", evaluation.MandatoryFailed))
+	c.addOutput("policies_errored", fmt.Sprintf("%d", evaluation.Errored))
+	c.addOutputWithOpts("policy_stages", evaluation.Stages, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		artifact:    "policy_stages.json",
+	})
+	c.addOutputWithOpts("policies", policies, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		artifact:    "policies.json",
+	})
+	if failErr != nil {
+		c.writer.ErrorResult(failErr.Error())
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *PolicyShowCommand) logPolicyEvaluation(evaluation *cloud.PolicyEvaluation, policies []*cloud.PolicyOutcome) {
+	for _, stage := range evaluation.Stages {
+		c.writer.Output(fmt.Sprintf("Stage: '%s' (%s), Status: '%s', Passed: (%d), AdvisoryFailed: (%d), MandatoryFailed: (%d), Failed: (%d)", stage.Stage, stage.ID, stage.Status, stage.Passed, stage.AdvisoryFailed, stage.MandatoryFailed, stage.Errored))
+		for _, policy := range policies {
+			if policy.Stage != stage.Stage {
+				continue
+			}
+			line := fmt.Sprintf("- Policy '%s', PolicySet: '%s', Status: '%s', EnforcementLevel: '%s'", policy.Name, policy.PolicySet, policy.Status, policy.EnforcementLevel)
+			if policy.Description != "" {
+				line += fmt.Sprintf(", Description: '%s'", policy.Description)
+			}
+			c.writer.Output(line)
+		}
+	}
+}
+
+func (c *PolicyShowCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] policy show [options]
+
+	Shows the policy results of a run, aggregated across legacy policy checks and
+	the policy evaluations of every task stage, with a breakdown per stage.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run             Existing HCP Terraform Run ID to show policy results of.
+
+	-show-all        Include passed policies, in addition to the policies that failed.
+
+	-logs            Print the policy check logs, or the policy set outcomes of policy
+	                 evaluations, of each stage.
+
+	-results-file    Writes the policy results to the provided file path, to archive
+	                 as a compliance artifact. Unlike the global --output-file, the
+	                 file is not limited to the command result.
+
+	-results-format  Format of the file written to -results-file: json (default),
+	                 sarif, junit or markdown.
+
+	-fail-on         Exits with a non-zero code when the policy results contain the
+	                 given failures, comma separated: mandatory, advisory, error.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PolicyShowCommand) Synopsis() string {
+	return "Shows the policy results of a run"
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testPolicyRunService struct {
+	cloud.RunService
+	evaluation *cloud.PolicyEvaluation
+	logged     bool
+}
+
+func (s *testPolicyRunService) GetPolicyEvaluation(context.Context, string) (*cloud.PolicyEvaluation, error) {
+	return s.evaluation, nil
+}
+
+func (s *testPolicyRunService) LogPolicyEvaluation(context.Context, *cloud.PolicyEvaluation) error {
+	s.logged = true
+	return nil
+}
+
+func (s *testPolicyRunService) GetRun(_ context.Context, options cloud.GetRunOptions) (*tfe.Run, error) {
+	return &tfe.Run{ID: options.RunID}, nil
+}
+
+func (s *testPolicyRunService) RunLink(_ context.Context, organization string, run *tfe.Run) (string, error) {
+	return "https://app.terraform.io/app/" + organization + "/workspaces/networking/runs/" + run.ID, nil
+}
+
+func testPolicyShowCommand(runs cloud.RunService) (*cli.MockUi, *PolicyShowCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &PolicyShowCommand{Meta: meta}
+}
+
+func TestPolicyShowCommand(t *testing.T) {
+	runs := &testPolicyRunService{evaluation: &cloud.PolicyEvaluation{
+		RunID:              "run-1",
+		Status:             cloud.PolicyStatusFailed,
+		RequiresOverride:   true,
+		PolicyResultCounts: cloud.PolicyResultCounts{Passed: 3, MandatoryFailed: 1},
+		Stages: []*cloud.PolicyStageResult{
+			{ID: "ts-1", Stage: "pre_plan", Status: "passed", PolicyResultCounts: cloud.PolicyResultCounts{Passed: 2}},
+			{ID: "ts-2", Stage: "post_plan", Status: "awaiting_override", RequiresOverride: true, PolicyResultCounts: cloud.PolicyResultCounts{Passed: 1, MandatoryFailed: 1}},
+		},
+	}}
+	runs.evaluation.Stages[1].Evaluations = []*cloud.PolicyEvaluationDetails{{PolicySets: []*cloud.PolicySetOutcomeDetails{{
+		Name: "baseline",
+		Policies: []*cloud.PolicyOutcome{
+			{Name: "require-tags", Description: "Resources must be tagged", EnforcementLevel: "mandatory", Status: "passed"},
+			{Name: "restrict-regions", EnforcementLevel: "mandatory", Status: "failed"},
+		},
+	}}}}
+
+	ui, cmd := testPolicyShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{
+		`"policy_status": "failed"`,
+		`"requires_override": "true"`,
+		`"policies_passed": "3"`,
+		`"policies_mandatory_failed": "1"`,
+		`"policy_link": "https://app.terraform.io/app/abc-company/workspaces/networking/runs/run-1#policy"`,
+		`"workspace_link": "https://app.terraform.io/app/abc-company/workspaces/networking"`,
+		`"stage": "pre_plan"`,
+		"Stage: 'post_plan' (ts-2), Status: 'awaiting_override'",
+		"- Policy 'restrict-regions', PolicySet: 'baseline', Status: 'failed', EnforcementLevel: 'mandatory'",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "- Policy 'require-tags'") {
+		t.Errorf("expected passed policies to be omitted but received %s", output)
+	}
+
+	ui, cmd = testPolicyShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-show-all"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output = ui.OutputWriter.String()
+	for _, expected := range []string{
+		"- Policy 'require-tags', PolicySet: 'baseline', Status: 'passed', EnforcementLevel: 'mandatory', Description: 'Resources must be tagged'",
+		`"policy_set": "baseline"`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestPolicyShowCommand_RequiresRun(t *testing.T) {
+	ui, cmd := testPolicyShowCommand(&testPolicyRunService{})
+	if code := cmd.Run([]string{}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "showing policy results requires a run id") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestPolicyShowCommand_Logs(t *testing.T) {
+	runs := &testPolicyRunService{evaluation: &cloud.PolicyEvaluation{RunID: "run-1", Status: cloud.PolicyStatusUnevaluated}}
+
+	ui, cmd := testPolicyShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.logged {
+		t.Error("expected policy logs not to be read without -logs")
+	}
+
+	ui, cmd = testPolicyShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-logs"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !runs.logged {
+		t.Error("expected policy logs to be read with -logs")
+	}
+}
+
+func TestPolicyShowCommand_FailOn(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failOn   string
+		expected int
+	}{
+		{name: "none", failOn: "", expected: 0},
+		{name: "mandatory", failOn: "mandatory", expected: 1},
+		{name: "advisory", failOn: "advisory", expected: 1},
+		{name: "error", failOn: "error", expected: 0},
+		{name: "multiple", failOn: "error,mandatory", expected: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, cmd := testPolicyShowCommand(&testPolicyRunService{evaluation: testPolicyEvaluation()})
+			if code := cmd.Run([]string{"-run=run-1", "-fail-on=" + tc.failOn}); code != tc.expected {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expected, code, ui.ErrorWriter.String())
+			}
+			if tc.expected == 1 && !strings.Contains(ui.ErrorWriter.String(), "policy results have failures") {
+				t.Errorf("expected policy failure error but received %s", ui.ErrorWriter.String())
+			}
+		})
+	}
+}
+
+func TestPolicyShowCommand_InvalidFailOn(t *testing.T) {
+	ui, cmd := testPolicyShowCommand(&testPolicyRunService{})
+	if code := cmd.Run([]string{"-run=run-1", "-fail-on=soft"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `invalid -fail-on value "soft"`) {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
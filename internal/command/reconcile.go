@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultManagedTag = "tfci-managed"
+
+// workspaceSpecFile lists the desired state of workspaces
+//
+//	workspaces:
+//	  - name: networking
+//	    project: Platform
+//	    auto_apply: true
+//	    tags: [networking]
+//	    variables:
+//	      - key: region
+//	        value: us-east-1
+type workspaceSpecFile struct {
+	Workspaces []*cloud.WorkspaceSpec `yaml:"workspaces"`
+}
+
+func readWorkspaceSpec(path string) (*workspaceSpecFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading spec: %w", err)
+	}
+	spec := &workspaceSpecFile{}
+	// secrets such as sensitive variable values are referenced from the environment
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(b))), spec); err != nil {
+		return nil, fmt.Errorf("error parsing spec %s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for i, ws := range spec.Workspaces {
+		if ws == nil || ws.Name == "" {
+			return nil, fmt.Errorf("spec %s: workspace at index %d requires a name", path, i)
+		}
+		if seen[ws.Name] {
+			return nil, fmt.Errorf("spec %s: workspace %q is defined more than once", path, ws.Name)
+		}
+		seen[ws.Name] = true
+	}
+	return spec, nil
+}
+
+type ReconcileCommand struct {
+	*Meta
+
+	Spec       string
+	ManagedTag string
+	Prune      bool
+	DryRun     bool
+}
+
+func (c *ReconcileCommand) flags() *flag.FlagSet {
+	f := c.flagSet("reconcile")
+	f.StringVar(&c.Spec, "spec", "workspaces.yaml", "Path to a YAML spec of the desired workspaces.")
+	f.StringVar(&c.ManagedTag, "managed-tag", defaultManagedTag, "Tag added to reconciled workspaces. Only workspaces with the tag are pruned.")
+	f.BoolVar(&c.Prune, "prune", false, "Safely deletes workspaces with the managed tag that are not in the spec, and tags and variables of reconciled workspaces that are not in the spec.")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Reports the changes without making them.")
+	return f
+}
+
+func (c *ReconcileCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	spec, err := readWorkspaceSpec(c.Spec)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+	if c.Prune && c.ManagedTag == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("pruning workspaces requires a managed tag, provide -managed-tag")
+		return 1
+	}
+
+	options := cloud.ReconcileOptions{
+		Organization: c.organization,
+		ManagedTag:   c.ManagedTag,
+		Prune:        c.Prune,
+		DryRun:       c.DryRun,
+	}
+
+	changes := []*cloud.ReconcileChange{}
+	names := []string{}
+	var lastErr error
+	for _, ws := range spec.Workspaces {
+		names = append(names, ws.Name)
+		change, err := c.cloud.ReconcileWorkspace(c.appCtx, options, ws)
+		if err != nil {
+			lastErr = err
+			change.Error = err.Error()
+		}
+		c.writer.Output(fmt.Sprintf("Workspace: %q, Action: %s", change.Workspace, change.Action))
+		changes = append(changes, change)
+	}
+
+	if c.Prune {
+		pruned, err := c.cloud.PruneWorkspaces(c.appCtx, options, names)
+		if err != nil {
+			lastErr = err
+			c.writer.ErrorResult(fmt.Sprintf("error pruning workspaces: %s", err.Error()))
+		}
+		for _, change := range pruned {
+			c.writer.Output(fmt.Sprintf("Workspace: %q, Action: %s", change.Workspace, change.Action))
+		}
+		changes = append(changes, pruned...)
+	}
+
+	counts := map[string]int{}
+	failed := 0
+	for _, change := range changes {
+		counts[change.Action]++
+		if change.Error != "" {
+			failed++
+		}
+	}
+
+	status := Success
+	if counts[cloud.ReconcileUnchanged] == len(changes) {
+		status = Noop
+	}
+	if lastErr != nil {
+		status = statusForError(lastErr)
+	} else if failed > 0 {
+		status = Error
+	}
+	c.addOutput("status", string(status))
+	c.addOutput("dry_run", fmt.Sprintf("%t", c.DryRun))
+	c.addOutput("created_count", fmt.Sprintf("%d", counts[cloud.ReconcileCreate]))
+	c.addOutput("updated_count", fmt.Sprintf("%d", counts[cloud.ReconcileUpdate]))
+	c.addOutput("deleted_count", fmt.Sprintf("%d", counts[cloud.ReconcileDelete]))
+	c.addOutput("failed_count", fmt.Sprintf("%d", failed))
+	c.addOutputWithOpts("changes", changes, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+
+	if status != Success && status != Noop {
+		if failed > 0 {
+			c.writer.ErrorResult(fmt.Sprintf("%d workspaces could not be reconciled", failed))
+		}
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *ReconcileCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] reconcile [options]
+
+	Creates and updates workspaces to match a spec of their settings, project, tags and variables.
+
+` + globalOptionsHelp + `
+Options:
+
+	-spec         Path to a YAML spec of the desired workspaces. Defaults to workspaces.yaml.
+
+	-managed-tag  Tag added to reconciled workspaces. Only workspaces with the tag are pruned. Defaults to tfci-managed.
+
+	-prune        Safely deletes workspaces with the managed tag that are not in the spec, and tags and variables of reconciled workspaces that are not in the spec.
+
+	-dry-run      Reports the changes without making them.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReconcileCommand) Synopsis() string {
+	return "Creates and updates workspaces to match a spec"
+}
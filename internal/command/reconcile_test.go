@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testReconciler struct {
+	actions map[string]string
+	failure error
+	pruned  []string
+}
+
+func (r *testReconciler) ReconcileWorkspace(_ context.Context, _ cloud.ReconcileOptions, spec *cloud.WorkspaceSpec) (*cloud.ReconcileChange, error) {
+	change := &cloud.ReconcileChange{Workspace: spec.Name, Action: r.actions[spec.Name]}
+	if change.Action == "" {
+		return change, r.failure
+	}
+	return change, nil
+}
+
+func (r *testReconciler) PruneWorkspaces(_ context.Context, _ cloud.ReconcileOptions, keep []string) ([]*cloud.ReconcileChange, error) {
+	r.pruned = keep
+	return []*cloud.ReconcileChange{{Workspace: "old", Action: cloud.ReconcileDelete}}, nil
+}
+
+func testReconcileCommand(t *testing.T, reconciler *testReconciler, spec string) (*cli.MockUi, *ReconcileCommand, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "workspaces.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.ReconcileService = reconciler
+
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &ReconcileCommand{Meta: meta}, path
+}
+
+func TestReconcileCommand(t *testing.T) {
+	reconciler := &testReconciler{actions: map[string]string{
+		"networking": cloud.ReconcileCreate,
+		"compute":    cloud.ReconcileUnchanged,
+	}}
+	ui, cmd, spec := testReconcileCommand(t, reconciler, "workspaces:\n  - name: networking\n  - name: compute\n")
+
+	if code := cmd.Run([]string{"-spec=" + spec, "-prune"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if strings.Join(reconciler.pruned, ",") != "networking,compute" {
+		t.Errorf("expected workspaces in the spec to be kept but received %v", reconciler.pruned)
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"status": "Success"`, `"created_count": "1"`, `"deleted_count": "1"`, `"updated_count": "0"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestReconcileCommand_Unchanged(t *testing.T) {
+	reconciler := &testReconciler{actions: map[string]string{"networking": cloud.ReconcileUnchanged}}
+	ui, cmd, spec := testReconcileCommand(t, reconciler, "workspaces:\n  - name: networking\n")
+
+	if code := cmd.Run([]string{"-spec=" + spec}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d", code)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"status": "Noop"`) {
+		t.Errorf("expected noop status but received %s", ui.OutputWriter.String())
+	}
+}
+
+func TestReconcileCommand_Failure(t *testing.T) {
+	reconciler := &testReconciler{
+		actions: map[string]string{"networking": cloud.ReconcileUpdate},
+		failure: errors.New("project \"Platform\" was not found"),
+	}
+	ui, cmd, spec := testReconcileCommand(t, reconciler, "workspaces:\n  - name: networking\n  - name: compute\n")
+
+	if code := cmd.Run([]string{"-spec=" + spec}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"status": "Error"`, `"failed_count": "1"`, `project \"Platform\" was not found`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestReadWorkspaceSpec_Duplicate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workspaces.yaml")
+	if err := os.WriteFile(path, []byte("workspaces:\n  - name: a\n  - name: a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readWorkspaceSpec(path); err == nil || !strings.Contains(err.Error(), `workspace "a" is defined more than once`) {
+		t.Errorf("expected duplicate workspace error but received %v", err)
+	}
+}
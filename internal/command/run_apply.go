@@ -4,25 +4,38 @@
 package command
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/github"
 )
 
+const defaultApprovalTimeout = time.Hour
+
 type ApplyRunCommand struct {
 	*Meta
 
-	RunID   string
-	Comment string
+	RunID             string
+	Comment           string
+	GitHubEnvironment string
+	ApprovalTimeout   time.Duration
+	PublishOutputs    bool
 }
 
 func (c *ApplyRunCommand) flags() *flag.FlagSet {
 	f := c.flagSet("run apply")
 	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to Apply.")
 	f.StringVar(&c.Comment, "comment", "", "An optional comment about the run.")
+	f.StringVar(&c.GitHubEnvironment, "github-environment", "", "Waits for the deployment to the GitHub environment to be approved before applying.")
+	f.DurationVar(&c.ApprovalTimeout, "approval-timeout", defaultApprovalTimeout, "Maximum duration to wait for the GitHub environment approval.")
+	f.BoolVar(&c.PublishOutputs, "publish-outputs", false, "Publishes the workspace outputs as platform outputs after the run is applied.")
 
 	return f
 }
@@ -45,7 +58,7 @@ func (c *ApplyRunCommand) Run(args []string) int {
 	})
 
 	if runErr != nil {
-		c.addOutput("status", string(Error))
+		c.addOutput("status", string(c.resolveStatus(runErr)))
 		c.closeOutput()
 		c.writer.ErrorResult(fmt.Sprintf("unable to read run: %s with: %s", c.RunID, runErr.Error()))
 		return 1
@@ -67,6 +80,12 @@ func (c *ApplyRunCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.GitHubEnvironment != "" {
+		if code, ok := c.waitForApproval(run); !ok {
+			return code
+		}
+	}
+
 	latestRun, applyError := c.cloud.ApplyRun(c.appCtx, cloud.ApplyRunOptions{
 		RunID:   c.RunID,
 		Comment: c.Comment,
@@ -87,28 +106,118 @@ func (c *ApplyRunCommand) Run(args []string) int {
 
 	c.addOutput("status", string(Success))
 	c.addRunDetails(run)
+	if c.PublishOutputs {
+		c.publishOutputs(run)
+	}
 	c.writer.OutputResult(c.closeOutput())
 	return 0
 }
 
+// adds the state outputs written by the apply as the outputs list, and each non-sensitive
+// output as an "output_<name>" platform output. the run has been applied, so errors are soft failures
+func (c *ApplyRunCommand) publishOutputs(run *tfe.Run) {
+	if run.Workspace == nil || run.Workspace.Name == "" {
+		c.softFailure(fmt.Sprintf("failed to publish outputs, the workspace of run %s is unknown", run.ID))
+		return
+	}
+	organization := c.organization
+	if organization == "" && run.Workspace.Organization != nil {
+		organization = run.Workspace.Organization.Name
+	}
+
+	// waits for the state version created by the apply to be processed
+	svoList, err := c.cloud.ReadStateOutputs(c.appCtx, organization, run.Workspace.Name)
+	if err != nil {
+		c.softFailure(fmt.Sprintf("failed to publish outputs of workspace %s: %s", run.Workspace.Name, err.Error()))
+		return
+	}
+
+	workspaceOutputs := []*WorkspaceOutput{}
+	for _, svo := range svoList.Items {
+		workspaceOutputs = append(workspaceOutputs, &WorkspaceOutput{
+			Name:  svo.Name,
+			Value: svo.Value,
+		})
+		if svo.Sensitive || svo.Value == nil {
+			continue
+		}
+		c.addOutputWithOpts("output_"+svo.Name, svo.Value, &outputOpts{
+			stdOut:      false,
+			multiLine:   true,
+			platformOut: true,
+		})
+	}
+	c.addOutputWithOpts("outputs", workspaceOutputs, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+}
+
+// waits for the GitHub deployment of the current commit to the environment to be approved,
+// returns false with the exit code when the run must not be applied
+func (c *ApplyRunCommand) waitForApproval(run *tfe.Run) (int, bool) {
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		c.addOutput("status", string(Error))
+		c.addRunDetails(run)
+		c.writer.ErrorResult("waiting for a GitHub environment approval requires GITHUB_REPOSITORY to be set")
+		c.writer.OutputResult(c.closeOutput())
+		return 1, false
+	}
+
+	c.writer.Output(fmt.Sprintf("Waiting for approval of the deployment to GitHub environment %q", c.GitHubEnvironment))
+	ctx, cancel := context.WithTimeout(c.appCtx, c.ApprovalTimeout)
+	defer cancel()
+	client := github.NewClient(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_TOKEN"))
+	approval, err := client.WaitForApproval(ctx, github.ApprovalOptions{
+		Repository:  repository,
+		Environment: c.GitHubEnvironment,
+		SHA:         os.Getenv("GITHUB_SHA"),
+	})
+	if approval != nil {
+		c.addOutput("github_deployment_id", fmt.Sprintf("%d", approval.DeploymentID))
+		c.addOutput("github_deployment_state", approval.State)
+	}
+	if err != nil {
+		status := Error
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = Timeout
+		}
+		c.addOutput("status", string(status))
+		c.addRunDetails(run)
+		c.writer.ErrorResult(fmt.Sprintf("run %s was not applied, GitHub environment %q was not approved: %s", c.RunID, c.GitHubEnvironment, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1, false
+	}
+	return 0, true
+}
+
 func (c *ApplyRunCommand) addRunDetails(run *tfe.Run) {
 	if run == nil {
 		return
 	}
 	link, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
-	if link != "" {
-		c.addOutput("run_link", link)
-	}
+	c.addRunLinks(run, link)
 	c.addOutput("run_id", run.ID)
 	c.addOutput("run_status", string(run.Status))
+	c.addRunDurations(run)
+	c.addRunDiagnostics(run)
+	c.addApplySummary(run)
 }
 
 func (c *ApplyRunCommand) readApplyLogs(run *tfe.Run) {
+	// the run may have been created by a previous job, redact its variables from the apply logs
+	for _, v := range run.Variables {
+		c.cloud.RedactValues(v.Value)
+	}
 	// pre-apply task stage
-	c.cloud.LogTaskStage(c.appCtx, run, tfe.PreApply)
+	if taskErr := c.cloud.LogTaskStage(c.appCtx, run, tfe.PreApply); taskErr != nil {
+		c.softFailure(fmt.Sprintf("failed to read pre apply task stages: %s", taskErr.Error()))
+	}
 	// apply logs
 	if logErr := c.cloud.GetApplyLogs(c.appCtx, run.Apply.ID); logErr != nil {
-		c.writer.ErrorResult(fmt.Sprintf("failed to read apply logs: %s", logErr.Error()))
+		c.softFailure(fmt.Sprintf("failed to read apply logs: %s", logErr.Error()))
 	}
 }
 
@@ -118,19 +227,20 @@ Usage: tfci [global options] run apply [options]
 
 	Applies a run that is paused waiting for confirmation after a plan.
 
-Global Options:
-
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
+` + globalOptionsHelp + `
+Options:
 
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
+	-run                 Existing HCP Terraform Run ID to Apply.
 
-	-organization   HCP Terraform Organization Name.
+	-comment             An optional comment about the run.
 
-Options:
+	-github-environment  Waits for the deployment of the current commit to the GitHub environment to be approved
+	                     before applying. Requires GITHUB_REPOSITORY and a GITHUB_TOKEN that can read deployments.
 
-	-run         Existing HCP Terraform Run ID to Apply.
+	-approval-timeout    Maximum duration to wait for the GitHub environment approval. Defaults to "1h".
 
-	-comment     An optional comment about the run.
+	-publish-outputs     Publishes the workspace outputs as platform outputs after the run is applied, as
+	                     the "outputs" list and an "output_<name>" output for each non-sensitive output.
 	`
 	return strings.TrimSpace(helpText)
 }
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testApplyRunService struct {
+	cloud.RunService
+	applied bool
+}
+
+func (s *testApplyRunService) GetRun(_ context.Context, options cloud.GetRunOptions) (*tfe.Run, error) {
+	return &tfe.Run{ID: options.RunID, Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true}}, nil
+}
+
+func (s *testApplyRunService) ApplyRun(_ context.Context, options cloud.ApplyRunOptions) (*tfe.Run, error) {
+	s.applied = true
+	return &tfe.Run{ID: options.RunID, Status: tfe.RunApplied, Apply: &tfe.Apply{ID: "apply-1"}, Workspace: &tfe.Workspace{Name: "networking"}}, nil
+}
+
+func (s *testApplyRunService) RunLink(context.Context, string, *tfe.Run) (string, error) {
+	return "", nil
+}
+
+func (s *testApplyRunService) LogTaskStage(context.Context, *tfe.Run, tfe.Stage) error { return nil }
+func (s *testApplyRunService) GetApplyLogs(context.Context, string) error              { return nil }
+func (s *testApplyRunService) GetRunDiagnostics(context.Context, *tfe.Run) ([]*cloud.Diagnostic, error) {
+	return nil, nil
+}
+func (s *testApplyRunService) GetApplySummary(context.Context, *tfe.Run) (*cloud.ApplySummary, error) {
+	return nil, nil
+}
+
+func TestApplyRunCommand_GitHubEnvironment(t *testing.T) {
+	testCases := []struct {
+		name            string
+		state           string
+		expectedCode    int
+		expectedApplied bool
+		expectedOut     []string
+	}{
+		{
+			name:            "approved",
+			state:           "in_progress",
+			expectedCode:    0,
+			expectedApplied: true,
+			expectedOut:     []string{`"github_deployment_state": "in_progress"`, `"status": "Success"`},
+		},
+		{
+			name:         "rejected",
+			state:        "failure",
+			expectedCode: 1,
+			expectedOut:  []string{`"github_deployment_state": "failure"`, `"status": "Error"`},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/acme/infra/deployments":
+					fmt.Fprint(w, `[{"id": 42}]`)
+				case "/repos/acme/infra/deployments/42/statuses":
+					fmt.Fprintf(w, `[{"state": %q}]`, tc.state)
+				}
+			}))
+			defer server.Close()
+			t.Setenv("GITHUB_API_URL", server.URL)
+			t.Setenv("GITHUB_REPOSITORY", "acme/infra")
+			t.Setenv("GITHUB_SHA", "abc123")
+			t.Setenv("GITHUB_TOKEN", "gh-token")
+
+			ui := cli.NewMockUi()
+			writer := writer.NewWriter(ui)
+			runs := &testApplyRunService{}
+			cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+			cloudMockService.RunService = runs
+			meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+
+			cmd := &ApplyRunCommand{Meta: meta}
+			if code := cmd.Run([]string{"-run=run-1", "-github-environment=production"}); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if runs.applied != tc.expectedApplied {
+				t.Errorf("expected applied %t, got %t", tc.expectedApplied, runs.applied)
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRunCommand_PublishOutputs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		outputs      map[string][]*tfe.StateVersionOutput
+		expectedOut  []string
+		expectedErr  string
+		platformOuts []string
+	}{
+		{
+			name: "published",
+			outputs: map[string][]*tfe.StateVersionOutput{
+				"networking": {
+					{Name: "vpc_id", Value: "vpc-123"},
+					{Name: "subnet_ids", Value: []interface{}{"subnet-1", "subnet-2"}},
+					{Name: "db_password", Sensitive: true},
+				},
+			},
+			expectedOut:  []string{`"name": "vpc_id"`, `"status": "Success"`},
+			platformOuts: []string{"outputs", "output_vpc_id", "output_subnet_ids"},
+		},
+		{
+			name:        "unreadable-outputs",
+			outputs:     map[string][]*tfe.StateVersionOutput{},
+			expectedOut: []string{`"status": "Success"`},
+			expectedErr: "failed to publish outputs of workspace networking",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			platform := &testPlatformContext{}
+			ui, meta := testMetaWithPlatform(t, platform, WithOrg("abc-company"))
+			meta.cloud.RunService = &testApplyRunService{}
+			meta.cloud.WorkspaceService = &testWorkspaceOutputs{outputs: tc.outputs}
+
+			cmd := &ApplyRunCommand{Meta: meta}
+			if code := cmd.Run([]string{"-run=run-1", "-publish-outputs"}); code != 0 {
+				t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			if tc.expectedErr != "" && !strings.Contains(ui.ErrorWriter.String(), tc.expectedErr) {
+				t.Errorf("expected error %q but received %q", tc.expectedErr, ui.ErrorWriter.String())
+			}
+			for _, name := range tc.platformOuts {
+				if _, ok := platform.output[name]; !ok {
+					t.Errorf("expected platform output %q but received %v", name, platform.output)
+				}
+			}
+			if _, ok := platform.output["output_db_password"]; ok {
+				t.Errorf("expected sensitive output not to be published")
+			}
+			if value := platform.output["output_vpc_id"]; value != nil && value.String() != "vpc-123" {
+				t.Errorf("expected output_vpc_id %q but received %q", "vpc-123", value.String())
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package command
 import (
 	"flag"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-tfe"
@@ -18,6 +19,7 @@ type CancelRunCommand struct {
 	RunID       string
 	Comment     string
 	ForceCancel bool
+	NoopOk      bool
 }
 
 func (c *CancelRunCommand) flags() *flag.FlagSet {
@@ -25,6 +27,7 @@ func (c *CancelRunCommand) flags() *flag.FlagSet {
 	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to Discard.")
 	f.StringVar(&c.Comment, "comment", "", "An optional comment about the run.")
 	f.BoolVar(&c.ForceCancel, "force-cancel", false, "Ends the run immediately.")
+	f.BoolVar(&c.NoopOk, "noop-ok", false, "Reports a Noop status instead of failing when the run is already canceled, discarded, errored or applied.")
 
 	return f
 }
@@ -45,12 +48,24 @@ func (c *CancelRunCommand) Run(args []string) int {
 	run, runErr := c.cloud.GetRun(c.appCtx, cloud.GetRunOptions{RunID: c.RunID})
 
 	if runErr != nil {
-		c.addOutput("status", string(Error))
+		c.addOutput("status", string(c.resolveStatus(runErr)))
 		c.closeOutput()
 		c.writer.ErrorResult(fmt.Sprintf("unable to read run: %s with: %s", c.RunID, runErr.Error()))
 		return 1
 	}
 
+	cancelable := run.Actions.IsCancelable
+	if c.ForceCancel {
+		cancelable = run.Actions.IsForceCancelable
+	}
+	if !cancelable && c.NoopOk && slices.Contains(cloud.CancelNoopStatus, run.Status) {
+		c.addOutput("status", string(Noop))
+		c.addRunDetails(run)
+		c.writer.ErrorResult(fmt.Sprintf("run %s, is %s. There is nothing to cancel.", c.RunID, run.Status))
+		c.writer.OutputResult(c.closeOutput())
+		return 0
+	}
+
 	// check if run can be force-cancelled at this moment
 	if c.ForceCancel && !run.Actions.IsForceCancelable {
 		c.addOutput("status", string(Error))
@@ -98,9 +113,7 @@ func (c *CancelRunCommand) addRunDetails(run *tfe.Run) {
 		return
 	}
 	link, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
-	if link != "" {
-		c.addOutput("run_link", link)
-	}
+	c.addRunLinks(run, link)
 	c.addOutput("run_id", run.ID)
 	c.addOutput("run_status", string(run.Status))
 }
@@ -111,14 +124,7 @@ Usage: tfci [global options] run cancel [options]
 
 	Interrupts a run that is currently planning or applying.
 
-Global Options:
-
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
-
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
-
-	-organization   HCP Terraform Organization Name.
-
+` + globalOptionsHelp + `
 Options:
 
   -run            Existing HCP Terraform Run ID to Discard.
@@ -126,6 +132,8 @@ Options:
 	-comment        An optional comment about the run.
 
 	-force-cancel   Ends the run immediately.
+
+	-noop-ok        Reports a "Noop" status instead of failing when the run is already canceled, discarded, errored or applied, so re-running a pipeline does not fail.
 	`
 	return strings.TrimSpace(helpText)
 }
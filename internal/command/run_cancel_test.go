@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestCancelRunCommand_NoopOk(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		status       tfe.RunStatus
+		expectedCode int
+		expected     string
+	}{
+		{name: "canceled", args: []string{"-noop-ok"}, status: tfe.RunCanceled, expectedCode: 0, expected: `"status": "Noop"`},
+		{name: "force-canceled", args: []string{"-noop-ok", "-force-cancel"}, status: tfe.RunCanceled, expectedCode: 0, expected: `"status": "Noop"`},
+		{name: "without-noop-ok", status: tfe.RunCanceled, expectedCode: 1, expected: `"status": "Error"`},
+		{name: "pending", args: []string{"-noop-ok"}, status: tfe.RunPending, expectedCode: 1, expected: `"status": "Error"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := &testEndedRunService{status: tc.status}
+			ui, meta := testEndedRunMeta(runs)
+			cmd := &CancelRunCommand{Meta: meta}
+
+			if code := cmd.Run(append([]string{"-run=run-1"}, tc.args...)); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if runs.changed {
+				t.Error("expected the run not to be canceled")
+			}
+			if output := ui.OutputWriter.String(); !strings.Contains(output, tc.expected) {
+				t.Errorf("expected output to contain %s but received %s", tc.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+type commentDetails struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+type RunCommentAddCommand struct {
+	*Meta
+
+	RunID string
+	Body  string
+}
+
+func (c *RunCommentAddCommand) flags() *flag.FlagSet {
+	f := c.flagSet("run comment add")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to comment on.")
+	f.StringVar(&c.Body, "body", "", "The text of the comment.")
+	return f
+}
+
+func (c *RunCommentAddCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" || strings.TrimSpace(c.Body) == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("adding a comment requires a run id and a body")
+		return 1
+	}
+
+	comment, err := c.cloud.AddComment(c.appCtx, c.RunID, c.Body)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error adding comment to run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_id", c.RunID)
+	c.addOutput("comment_id", comment.ID)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *RunCommentAddCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] run comment add [options]
+
+	Adds a comment to a run, such as a ticket link or an approval.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run   Existing HCP Terraform Run ID to comment on.
+
+	-body  The text of the comment.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunCommentAddCommand) Synopsis() string {
+	return "Adds a comment to a run"
+}
+
+type RunCommentListCommand struct {
+	*Meta
+
+	RunID string
+}
+
+func (c *RunCommentListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("run comment list")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to list comments of.")
+	return f
+}
+
+func (c *RunCommentListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("listing comments requires a run id")
+		return 1
+	}
+
+	comments, err := c.cloud.ListComments(c.appCtx, c.RunID)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing comments of run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	details := []*commentDetails{}
+	for _, comment := range comments {
+		details = append(details, &commentDetails{ID: comment.ID, Body: comment.Body})
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_id", c.RunID)
+	c.addOutput("comment_count", fmt.Sprintf("%d", len(details)))
+	c.addOutputWithOpts("comments", details, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"id", "body"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *RunCommentListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] run comment list [options]
+
+	Lists the comments of a run.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run  Existing HCP Terraform Run ID to list comments of.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunCommentListCommand) Synopsis() string {
+	return "Lists the comments of a run"
+}
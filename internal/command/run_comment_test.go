@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testCommentService struct {
+	comments []*tfe.Comment
+}
+
+func (s *testCommentService) AddComment(_ context.Context, _ string, body string) (*tfe.Comment, error) {
+	comment := &tfe.Comment{ID: "wsc-1", Body: body}
+	s.comments = append(s.comments, comment)
+	return comment, nil
+}
+
+func (s *testCommentService) ListComments(context.Context, string) ([]*tfe.Comment, error) {
+	return s.comments, nil
+}
+
+func testCommentMeta(comments cloud.CommentService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.CommentService = comments
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestRunCommentCommands(t *testing.T) {
+	comments := &testCommentService{}
+
+	ui, meta := testCommentMeta(comments)
+	add := &RunCommentAddCommand{Meta: meta}
+	if code := add.Run([]string{"-run=run-1", "-body=Approved in JIRA-123"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"comment_id": "wsc-1"`) {
+		t.Errorf("expected comment id in output but received %s", ui.OutputWriter.String())
+	}
+
+	ui, meta = testCommentMeta(comments)
+	list := &RunCommentListCommand{Meta: meta}
+	if code := list.Run([]string{"-run=run-1"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"comment_count": "1"`, `"body": "Approved in JIRA-123"`, `"id": "wsc-1"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestRunCommentAddCommand_RequiresBody(t *testing.T) {
+	ui, meta := testCommentMeta(&testCommentService{})
+	add := &RunCommentAddCommand{Meta: meta}
+	if code := add.Run([]string{"-run=run-1"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "adding a comment requires a run id and a body") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
@@ -4,9 +4,11 @@
 package command
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/go-tfe"
@@ -17,9 +19,19 @@ type CreateRunCommand struct {
 	*Meta
 
 	Workspace              string
+	WorkspaceTags          []string
+	Concurrency            int
 	ConfigurationVersionID string
 	Message                string
 	TargetAddrs            []string
+	EnvVars                []string
+	VarsJSON               string
+	IdempotencyKey         string
+	SkipUnchanged          bool
+	AutoRetry              int
+	WaitForIdle            bool
+	Branch                 string
+	CommitSHA              string
 
 	PlanOnly  bool
 	IsDestroy bool
@@ -50,12 +62,22 @@ func (v *flagStringSlice) Set(raw string) error {
 func (c *CreateRunCommand) flags() *flag.FlagSet {
 	f := c.flagSet("run create")
 	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.Var((*flagStringSlice)(&c.WorkspaceTags), "workspace-tag", "Creates runs in every workspace with the tag binding formatted as key:value, instead of a single workspace. This option accepts multiple values, workspaces must have all tag bindings.")
+	f.IntVar(&c.Concurrency, "concurrency", defaultBatchConcurrency, "Maximum number of runs to create and monitor at once with -workspace-tag.")
 	f.StringVar(&c.ConfigurationVersionID, "configuration_version", "", "The Configuration Version ID to use for this run.")
 	f.StringVar(&c.Message, "message", "", "Specifies the message to be associated with this run. A default message will be set.")
 	f.BoolVar(&c.PlanOnly, "plan-only", false, "Specifies if this is a HCP Terraform speculative, plan-only run that cannot be applied.")
 	f.BoolVar(&c.IsDestroy, "is-destroy", false, "Specifies that the plan is a destroy plan. When true, the plan destroys all provisioned resources.")
 	f.BoolVar(&c.SavePlan, "save-plan", false, "Specifies whether to create a saved plan. Saved-plan runs perform their plan and checks immediately, but won't lock the workspace and become its current run until they are confirmed for apply.")
 	f.Var((*flagStringSlice)(&c.TargetAddrs), "target", "Limit the planning operation to only the given module, resource, or resource instance and all of its dependencies. You can use this option multiple times to include more than one object. This is for exceptional use only. e.g. -target=aws_s3_bucket.foo")
+	f.Var((*flagStringSlice)(&c.EnvVars), "env-var", "Environment variable formatted as KEY=VALUE to set on the workspace before creating the run, ex: -env-var=AWS_REGION=us-east-1. This option accepts multiple values.")
+	f.StringVar(&c.VarsJSON, "vars-json", "", "Path to a json document of run variables keyed by name, formatted as {\"name\": {\"value\": ..., \"hcl\": false, \"sensitive\": false}}. Use - to read from stdin.")
+	f.BoolVar(&c.SkipUnchanged, "skip-unchanged", false, "Skips creating a run when the configuration version and run variables are unchanged since the last applied run of the workspace.")
+	f.StringVar(&c.Branch, "branch", "", "For VCS-connected workspaces, creates the run from the branch of the connected repository instead of an uploaded configuration version.")
+	f.StringVar(&c.CommitSHA, "commit-sha", "", "For VCS-connected workspaces, creates the run from the commit of the connected repository instead of an uploaded configuration version.")
+	f.BoolVar(&c.WaitForIdle, "wait-for-idle", false, "Waits for the workspace to be unlocked and its current run to finish before creating a non-speculative run.")
+	f.IntVar(&c.AutoRetry, "auto-retry", 0, "Creates a new run when the run errors with a transient error, such as an agent disconnecting or cloud provider throttling, up to the given number of times.")
+	f.StringVar(&c.IdempotencyKey, "idempotency-key", "", "Attaches to an unfinished run in the workspace created with the same key, instead of creating a new run. The key is recorded in the run message.")
 	return f
 }
 
@@ -64,14 +86,45 @@ func (c *CreateRunCommand) Run(args []string) int {
 		return 1
 	}
 
+	if len(c.WorkspaceTags) > 0 && (c.Workspace != "" || c.AutoRetry > 0) {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("-workspace-tag cannot be used with -workspace or -auto-retry")
+		return 1
+	}
+
+	if c.ConfigurationVersionID != "" && (c.Branch != "" || c.CommitSHA != "") {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("-configuration_version cannot be used with -branch or -commit-sha")
+		return 1
+	}
+
 	runVars := collectVariables()
+	if c.VarsJSON != "" {
+		jsonVars, err := readVariablesJSON(c.VarsJSON, os.Stdin)
+		if err != nil {
+			c.addOutput("status", string(Error))
+			c.closeOutput()
+			c.writer.ErrorResult(err.Error())
+			return 1
+		}
+		runVars = mergeRunVariables(runVars, jsonVars)
+	}
+	envVars, err := collectEnvCategoryVariables(c.EnvVars)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
 
 	// default formatted message for run, include vcs ci runner information
 	if c.Message == "" {
 		c.Message = c.defaultRunMessage()
 	}
 
-	run, runError := c.cloud.CreateRun(c.appCtx, cloud.CreateRunOptions{
+	createOpts := cloud.CreateRunOptions{
 		Organization:           c.organization,
 		Workspace:              c.Workspace,
 		ConfigurationVersionID: c.ConfigurationVersionID,
@@ -81,7 +134,27 @@ func (c *CreateRunCommand) Run(args []string) int {
 		SavePlan:               c.SavePlan,
 		RunVariables:           runVars,
 		TargetAddrs:            c.TargetAddrs,
-	})
+		EnvVariables:           envVars,
+		IdempotencyKey:         c.IdempotencyKey,
+		SkipUnchanged:          c.SkipUnchanged,
+		WaitForIdle:            c.WaitForIdle,
+		Branch:                 c.Branch,
+		CommitSHA:              c.CommitSHA,
+	}
+	if len(c.WorkspaceTags) > 0 {
+		return c.runTagged(createOpts)
+	}
+
+	run, runError := c.cloud.CreateRun(c.appCtx, createOpts)
+	if c.AutoRetry > 0 {
+		run, runError = c.retryErroredRun(createOpts, run, runError)
+	}
+	if errors.Is(runError, cloud.ErrRunUnchanged) {
+		c.addOutput("status", string(Noop))
+		c.addOutput("last_applied_run_id", run.ID)
+		c.writer.OutputResult(c.closeOutput())
+		return 0
+	}
 	if run != nil {
 		c.readPlanLogs(run)
 	}
@@ -102,28 +175,55 @@ func (c *CreateRunCommand) Run(args []string) int {
 	return 0
 }
 
+// creates new runs for runs that errored with a transient error, up to the -auto-retry limit
+func (c *CreateRunCommand) retryErroredRun(opts cloud.CreateRunOptions, run *tfe.Run, runError error) (*tfe.Run, error) {
+	retried := []string{}
+	for len(retried) < c.AutoRetry && run != nil && run.Status == tfe.RunErrored {
+		reason, err := c.cloud.TransientRunError(c.appCtx, run)
+		if err != nil {
+			c.softFailure(fmt.Sprintf("failed to read the logs of errored run %s: %s", run.ID, err.Error()))
+			break
+		}
+		if reason == "" {
+			break
+		}
+		c.writer.Output(fmt.Sprintf("Run ID: %q errored with a transient error: %s", run.ID, reason))
+		retried = append(retried, run.ID)
+
+		run, runError = c.cloud.RetryRun(c.appCtx, cloud.RetryRunOptions{
+			CreateRunOptions: opts,
+			ErroredRunID:     retried[len(retried)-1],
+			Attempt:          len(retried),
+		})
+	}
+
+	c.addOutput("retry_count", fmt.Sprint(len(retried)))
+	c.addOutput("retried_run_ids", strings.Join(retried, ","))
+	return run, runError
+}
+
 func (c *CreateRunCommand) addRunDetails(run *tfe.Run) {
 	if run == nil {
 		log.Printf("[ERROR] run is not detected")
 		return
 	}
 	runLink, _ := c.cloud.RunService.RunLink(c.appCtx, c.organization, run)
-	if runLink != "" {
-		c.addOutput("run_link", runLink)
-	}
+	c.addRunLinks(run, runLink)
 	c.addOutput("run_id", run.ID)
 	c.addOutput("run_status", string(run.Status))
 	c.addOutput("run_message", run.Message)
 	c.addOutput("plan_id", run.Plan.ID)
 	c.addOutput("plan_status", string(run.Plan.Status))
 	c.addOutput("configuration_version_id", run.ConfigurationVersion.ID)
+	c.addRunDurations(run)
+	c.addRunDiagnostics(run)
 
 	// add cost estimation info if enabled on run
 	if run.CostEstimate != nil {
 		c.addOutput("cost_estimation_id", run.CostEstimate.ID)
 		c.addOutput("cost_estimation_status", string(run.CostEstimate.Status))
 		if run.CostEstimate.ErrorMessage != "" {
-			c.writer.ErrorResult(fmt.Sprintf("Cost Estimation errored: %s", run.CostEstimate.ErrorMessage))
+			c.softFailure(fmt.Sprintf("Cost Estimation errored: %s", run.CostEstimate.ErrorMessage))
 		}
 	}
 
@@ -131,28 +231,37 @@ func (c *CreateRunCommand) addRunDetails(run *tfe.Run) {
 		stdOut:      false,
 		multiLine:   true,
 		platformOut: true,
+		artifact:    "run.json",
 	})
 }
 
 func (c *CreateRunCommand) readPlanLogs(run *tfe.Run) {
 	// Pre Plan task stages
-	c.cloud.LogTaskStage(c.appCtx, run, tfe.PrePlan)
+	if taskErr := c.cloud.LogTaskStage(c.appCtx, run, tfe.PrePlan); taskErr != nil {
+		c.softFailure(fmt.Sprintf("failed to read pre plan task stages: %s", taskErr.Error()))
+	}
 	// Plan
 	if pLogErr := c.cloud.GetPlanLogs(c.appCtx, run.Plan.ID); pLogErr != nil {
-		c.writer.ErrorResult(fmt.Sprintf("failed to read plan logs: %s", pLogErr.Error()))
+		c.softFailure(fmt.Sprintf("failed to read plan logs: %s", pLogErr.Error()))
 	}
 	// Post Plan task stages
-	c.cloud.LogTaskStage(c.appCtx, run, tfe.PostPlan)
+	if taskErr := c.cloud.LogTaskStage(c.appCtx, run, tfe.PostPlan); taskErr != nil {
+		c.softFailure(fmt.Sprintf("failed to read post plan task stages: %s", taskErr.Error()))
+	}
 	// cost estimation
 	c.cloud.LogCostEstimation(c.appCtx, run)
 	// sentinel policies
 	if policyLogErr := c.cloud.GetPolicyCheckLogs(c.appCtx, run); policyLogErr != nil {
-		c.writer.ErrorResult(fmt.Sprintf("failed to read policy check logs: %s", policyLogErr.Error()))
+		c.softFailure(fmt.Sprintf("failed to read policy check logs: %s", policyLogErr.Error()))
 	}
 }
 
 func (c *CreateRunCommand) defaultRunMessage() string {
 	if c.env.Context != nil {
+		// some platforms do not expose a commit author, tag the message with the platform instead
+		if c.env.Context.Author() == "" {
+			return fmt.Sprintf("Triggered from HCP Terraform CI on %s for SHA (%s)", c.env.PlatformType, c.env.Context.SHAShort())
+		}
 		return fmt.Sprintf("Triggered from HCP Terraform CI by Author (%s) for SHA (%s)", c.env.Context.Author(), c.env.Context.SHAShort())
 	}
 	return `Triggered from HCP Terraform CI`
@@ -164,18 +273,15 @@ Usage: tfci [global options] run create [options]
 
 	Performs a new plan run in HCP Terraform, using a configuration version and the workspace's current variables.
 
-Global Options:
-
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
-
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
-
-	-organization   HCP Terraform Organization Name.
-
+` + globalOptionsHelp + `
 Options:
 
 	-workspace              The name of the HCP Terraform Workspace.
 
+	-workspace-tag          Creates runs in every workspace with the tag binding formatted as key:value, ex: -workspace-tag=team:payments, instead of a single workspace. This option accepts multiple values, workspaces must have all tag bindings. Results are aggregated in the "runs" output, and a -configuration_version is uploaded to each workspace.
+
+	-concurrency            Maximum number of runs to create and monitor at once with -workspace-tag. Defaults to 5.
+
 	-configuration_version  The Configuration Version ID to use for this run.
 
 	-message                Specifies the message to be associated with this run. A default message will be set.
@@ -185,6 +291,22 @@ Options:
 	-save-plan              Specifies whether to create a saved plan. Saved-plan runs perform their plan and checks immediately, but won't lock the workspace and become its current run until they are confirmed for apply.
 	-is-destroy				Specifies whether to create a destroy run.
 	-target					Focuses Terraform's attention on only a subset of resources and their dependencies. This option accepts multiple instances by providing additional target option flags.
+
+	-env-var                Environment variable formatted as KEY=VALUE to set on the workspace before creating the run, ex: -env-var=AWS_REGION=us-east-1. This option accepts multiple values, and takes precedence over TFCI_ENV_ environment variables.
+
+	-vars-json              Path to a json document of run variables keyed by name, formatted as {"name": {"value": ..., "hcl": false, "sensitive": false}}. Values are converted to HCL literals, unless "hcl" is true and the value is a string containing an HCL expression. Use "-" to read from stdin. Takes precedence over TF_VAR_ environment variables.
+
+	-skip-unchanged         Skips creating a run when the configuration version and run variables are unchanged since the last applied run of the workspace. The command reports a "Noop" status instead.
+
+	-branch                 For VCS-connected workspaces, creates the run from the branch of the connected repository instead of an uploaded configuration version. Runs from the branch the workspace tracks use its latest commit.
+
+	-commit-sha             For VCS-connected workspaces, creates the run from the commit of the connected repository instead of an uploaded configuration version.
+
+	-wait-for-idle          Waits for the workspace to be unlocked and its current run to finish before creating a non-speculative run, instead of failing when the workspace is locked. Waits up to the maximum timeout.
+
+	-auto-retry             Creates a new run when the run errors with a transient error, such as an agent disconnecting or cloud provider throttling, up to the given number of times. Retries wait 30 seconds, doubling with each retry up to 5 minutes.
+
+	-idempotency-key        Attaches to an unfinished run in the workspace created with the same key, instead of creating a new run, so retried CI jobs do not queue duplicate runs. The key is recorded in the run message.
 	`
 	return strings.TrimSpace(helpText)
 }
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type CreateBatchRunCommand struct {
+	*Meta
+
+	Workspaces  []string
+	Manifest    string
+	Tags        []string
+	Message     string
+	Concurrency int
+
+	PlanOnly  bool
+	IsDestroy bool
+}
+
+func (c *CreateBatchRunCommand) flags() *flag.FlagSet {
+	f := c.flagSet("run create-batch")
+	f.Var((*flagStringSlice)(&c.Workspaces), "workspace", "The name of a HCP Terraform Workspace to create a run in. This option accepts multiple values.")
+	f.StringVar(&c.Manifest, "manifest", "", "Path to a YAML manifest listing the workspaces to create runs in.")
+	f.Var((*flagStringSlice)(&c.Tags), "tag", "Creates runs in all workspaces with the tag. This option accepts multiple values, workspaces must have all tags.")
+	f.StringVar(&c.Message, "message", "", "Specifies the message to be associated with each run. A default message will be set.")
+	f.IntVar(&c.Concurrency, "concurrency", defaultBatchConcurrency, "Maximum number of runs to create and monitor at once.")
+	f.BoolVar(&c.PlanOnly, "plan-only", false, "Specifies if these are HCP Terraform speculative, plan-only runs that cannot be applied.")
+	f.BoolVar(&c.IsDestroy, "is-destroy", false, "Specifies that the plans are destroy plans.")
+	return f
+}
+
+func (c *CreateBatchRunCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	targets, err := c.targets()
+	if err != nil {
+		c.addOutput("status", string(c.resolveStatus(err)))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+	if len(targets) == 0 {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("creating batch runs requires at least one workspace, provide -workspace, -manifest or -tag")
+		return 1
+	}
+
+	if c.Message == "" {
+		c.Message = (&CreateRunCommand{Meta: c.Meta}).defaultRunMessage()
+	}
+	runVars := collectVariables()
+	// only -env-var flags can be invalid
+	envVars, _ := collectEnvCategoryVariables(nil)
+
+	c.writer.Output(fmt.Sprintf("Creating runs in %d workspaces, concurrency: %d", len(targets), c.Concurrency))
+	results, err := runDAG(c.appCtx, targets, c.Concurrency, func(ctx context.Context, target *batchTarget) *batchResult {
+		result := &batchResult{Workspace: target.Name, Status: Success}
+		message := target.Message
+		if message == "" {
+			message = c.Message
+		}
+		upstreamVars, varsErr := upstreamVariables(ctx, target, c.readOutputs)
+		if varsErr != nil {
+			result.Status = statusForError(varsErr)
+			result.Error = varsErr.Error()
+			c.writer.Output(fmt.Sprintf("Workspace: %q, Status: %s", result.Workspace, result.Status))
+			return result
+		}
+
+		run, runErr := c.cloud.CreateRun(ctx, cloud.CreateRunOptions{
+			Organization:           c.organization,
+			Workspace:              target.Name,
+			ConfigurationVersionID: target.ConfigurationVersionID,
+			Message:                message,
+			PlanOnly:               c.PlanOnly,
+			IsDestroy:              c.IsDestroy,
+			RunVariables:           mergeRunVariables(runVars, upstreamVars),
+			EnvVariables:           envVars,
+		})
+
+		if run != nil {
+			result.RunID = run.ID
+			result.RunStatus = string(run.Status)
+			result.RunLink, _ = c.cloud.RunLink(ctx, c.organization, run)
+		}
+		if runErr != nil {
+			result.Status = statusForError(runErr)
+			result.Error = runErr.Error()
+		}
+		c.writer.Output(fmt.Sprintf("Workspace: %q, Run ID: %q, Status: %s", result.Workspace, result.RunID, result.Status))
+		return result
+	})
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	return c.batchResults(results)
+}
+
+func (c *CreateBatchRunCommand) readOutputs(ctx context.Context, workspace string) (*tfe.StateVersionOutputsList, error) {
+	return c.cloud.ReadStateOutputs(ctx, c.organization, workspace)
+}
+
+// workspaces from -workspace, -manifest and -tag, in that order without duplicates
+func (c *CreateBatchRunCommand) targets() ([]*batchTarget, error) {
+	targets := []*batchTarget{}
+	seen := map[string]bool{}
+	add := func(t *batchTarget) {
+		if !seen[t.Name] {
+			seen[t.Name] = true
+			targets = append(targets, t)
+		}
+	}
+
+	for _, name := range c.Workspaces {
+		add(&batchTarget{Name: name})
+	}
+	if c.Manifest != "" {
+		manifest, err := readBatchManifest(c.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range manifest.Workspaces {
+			add(t)
+		}
+	}
+	if len(c.Tags) > 0 {
+		names, err := c.cloud.ListWorkspaceNames(c.appCtx, c.organization, c.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("error listing workspaces with tags %s: %w", strings.Join(c.Tags, ","), err)
+		}
+		for _, name := range names {
+			add(&batchTarget{Name: name})
+		}
+	}
+	return targets, nil
+}
+
+func (c *CreateBatchRunCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] run create-batch [options]
+
+	Creates runs in multiple workspaces concurrently, monitors them, and returns aggregated and per-workspace results.
+	Workspaces in a manifest may depend on other workspaces, these run after their upstream workspaces succeed and can receive upstream outputs as run variables.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace    The name of a HCP Terraform Workspace to create a run in. This option accepts multiple values.
+
+	-manifest     Path to a YAML manifest listing the workspaces to create runs in, with optional depends_on and variables for each workspace.
+
+	-tag          Creates runs in all workspaces with the tag. This option accepts multiple values, workspaces must have all tags.
+
+	-message      Specifies the message to be associated with each run. A default message will be set.
+
+	-concurrency  Maximum number of runs to create and monitor at once. Defaults to 5.
+
+	-plan-only    Specifies if these are HCP Terraform speculative, plan-only runs that cannot be applied.
+
+	-is-destroy   Specifies that the plans are destroy plans.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *CreateBatchRunCommand) Synopsis() string {
+	return "Creates runs in multiple workspaces concurrently and returns aggregated results"
+}
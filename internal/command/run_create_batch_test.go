@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testBatchRunService struct {
+	cloud.RunService
+
+	mu       sync.Mutex
+	created  []string
+	messages map[string]string
+	failures map[string]error
+	vars     map[string][]*tfe.RunVariable
+	// configuration version of each run, recorded when not nil
+	configVersions map[string]string
+}
+
+func (s *testBatchRunService) CreateRun(_ context.Context, options cloud.CreateRunOptions) (*tfe.Run, error) {
+	s.mu.Lock()
+	s.created = append(s.created, options.Workspace)
+	s.messages[options.Workspace] = options.Message
+	if s.vars != nil {
+		s.vars[options.Workspace] = options.RunVariables
+	}
+	if s.configVersions != nil {
+		s.configVersions[options.Workspace] = options.ConfigurationVersionID
+	}
+	s.mu.Unlock()
+
+	run := &tfe.Run{ID: "run-" + options.Workspace, Status: tfe.RunPlannedAndFinished}
+	if err := s.failures[options.Workspace]; err != nil {
+		run.Status = tfe.RunErrored
+		return run, err
+	}
+	return run, nil
+}
+
+func (s *testBatchRunService) RunLink(_ context.Context, _ string, run *tfe.Run) (string, error) {
+	return "https://app.terraform.io/runs/" + run.ID, nil
+}
+
+type testTaggedWorkspaces struct {
+	WorkspaceOutputReader
+	names []string
+}
+
+func (w *testTaggedWorkspaces) ListWorkspaceNames(_ context.Context, _ string, _ []string) ([]string, error) {
+	return w.names, nil
+}
+
+func testCreateBatchRunCommand(t *testing.T, runs *testBatchRunService) (*cli.MockUi, *CreateBatchRunCommand) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+	cloudMockService.WorkspaceService = &testTaggedWorkspaces{names: []string{"compute", "dns"}}
+
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &CreateBatchRunCommand{Meta: meta}
+}
+
+func TestCreateBatchRunCommand(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "workspaces.yaml")
+	err := os.WriteFile(manifest, []byte("workspaces:\n  - name: compute\n    message: deploy compute\n  - name: storage\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := &testBatchRunService{messages: map[string]string{}}
+	ui, cmd := testCreateBatchRunCommand(t, runs)
+
+	code := cmd.Run([]string{"-workspace=networking", "-manifest=" + manifest, "-tag=prod", "-concurrency=2", "-message=batch"})
+	if code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(runs.created) != 4 {
+		t.Errorf("expected runs in 4 unique workspaces but received %v", runs.created)
+	}
+	if runs.messages["compute"] != "deploy compute" || runs.messages["networking"] != "batch" {
+		t.Errorf("unexpected run messages: %v", runs.messages)
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"run_count": "4"`, `"failed_count": "0"`, `"status": "Success"`, `"run_id": "run-storage"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestCreateBatchRunCommand_Failure(t *testing.T) {
+	runs := &testBatchRunService{
+		messages: map[string]string{},
+		failures: map[string]error{"compute": errors.New("run has ended with: 'errored' status")},
+	}
+	ui, cmd := testCreateBatchRunCommand(t, runs)
+
+	if code := cmd.Run([]string{"-workspace=networking,compute"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"failed_count": "1"`, `"status": "Error"`, `"error": "run has ended with: 'errored' status"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestCreateBatchRunCommand_Dependencies(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "workspaces.yaml")
+	err := os.WriteFile(manifest, []byte(`workspaces:
+  - name: compute
+    depends_on: [networking]
+    variables:
+      vpc_id: networking.vpc_id
+  - name: networking
+  - name: app
+    depends_on: [compute]
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := &testBatchRunService{
+		messages: map[string]string{},
+		vars:     map[string][]*tfe.RunVariable{},
+		failures: map[string]error{"compute": errors.New("run has ended with: 'errored' status")},
+	}
+	ui, cmd := testCreateBatchRunCommand(t, runs)
+	cmd.cloud.WorkspaceService = &WorkspaceOutputReader{svo: &tfe.StateVersionOutputsList{
+		Items: []*tfe.StateVersionOutput{{Name: "vpc_id", Value: "vpc-123"}},
+	}}
+
+	if code := cmd.Run([]string{"-manifest=" + manifest}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+
+	if strings.Join(runs.created, ",") != "networking,compute" {
+		t.Errorf("expected runs in networking then compute but received %v", runs.created)
+	}
+	if vars := runs.vars["compute"]; len(vars) != 1 || vars[0].Key != "vpc_id" || vars[0].Value != `"vpc-123"` {
+		t.Errorf("expected compute to receive the networking vpc_id output but received %v", vars)
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"failed_count": "2"`, `not run, upstream workspace \"compute\" did not succeed`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestCreateBatchRunCommand_DependencyCycle(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "workspaces.yaml")
+	err := os.WriteFile(manifest, []byte("workspaces:\n  - name: a\n    depends_on: [b]\n  - name: b\n    depends_on: [a]\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := &testBatchRunService{messages: map[string]string{}}
+	ui, cmd := testCreateBatchRunCommand(t, runs)
+	if code := cmd.Run([]string{"-manifest=" + manifest}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if len(runs.created) != 0 {
+		t.Errorf("expected no runs but received %v", runs.created)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "dependency cycle detected: a -> b -> a") {
+		t.Errorf("unexpected error output: %s", ui.ErrorWriter.String())
+	}
+}
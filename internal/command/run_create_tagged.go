@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// parses key:value tag selectors, a selector without a value matches any value of the key
+func parseTagSelectors(tags []string) ([]*cloud.TagBindingDetails, error) {
+	bindings := make([]*cloud.TagBindingDetails, 0, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, ":")
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid workspace tag %q, must be formatted as key:value", tag)
+		}
+		bindings = append(bindings, &cloud.TagBindingDetails{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+	return bindings, nil
+}
+
+// creates runs in every workspace with the -workspace-tag tag bindings, returning aggregated results.
+// a -configuration_version is uploaded to each workspace, as configuration versions belong to a single workspace
+func (c *CreateRunCommand) runTagged(opts cloud.CreateRunOptions) int {
+	bindings, err := parseTagSelectors(c.WorkspaceTags)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	names, err := c.cloud.ListTaggedWorkspaces(c.appCtx, c.organization, bindings)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing workspaces with tags %s in HCP Terraform: %s", strings.Join(c.WorkspaceTags, ","), err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+	if len(names) == 0 {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("no workspaces found with tags %s", strings.Join(c.WorkspaceTags, ",")))
+		return 1
+	}
+
+	var archive []byte
+	if opts.ConfigurationVersionID != "" {
+		archive, err = c.cloud.DownloadConfig(c.appCtx, opts.ConfigurationVersionID)
+		if err != nil {
+			status := c.resolveStatus(err)
+			c.addOutput("status", string(status))
+			c.writer.ErrorResult(fmt.Sprintf("error downloading configuration version %s in HCP Terraform: %s", opts.ConfigurationVersionID, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+	}
+
+	targets := make([]*batchTarget, 0, len(names))
+	for _, name := range names {
+		targets = append(targets, &batchTarget{Name: name})
+	}
+
+	c.writer.Output(fmt.Sprintf("Creating runs in %d workspaces with tags %s, concurrency: %d", len(targets), strings.Join(c.WorkspaceTags, ","), c.Concurrency))
+	results, err := runDAG(c.appCtx, targets, c.Concurrency, func(ctx context.Context, target *batchTarget) *batchResult {
+		result := &batchResult{Workspace: target.Name, Status: Success}
+		workspaceOpts := opts
+		workspaceOpts.Workspace = target.Name
+
+		if archive != nil {
+			cv, cvErr := c.cloud.UploadConfigArchive(ctx, cloud.UploadArchiveOptions{
+				Organization: opts.Organization,
+				Workspace:    target.Name,
+				Archive:      archive,
+				Speculative:  opts.PlanOnly,
+			})
+			if cvErr != nil {
+				result.Status = statusForError(cvErr)
+				result.Error = cvErr.Error()
+				c.writer.Output(fmt.Sprintf("Workspace: %q, Status: %s", result.Workspace, result.Status))
+				return result
+			}
+			workspaceOpts.ConfigurationVersionID = cv.ID
+		}
+
+		run, runErr := c.cloud.CreateRun(ctx, workspaceOpts)
+		if run != nil {
+			result.RunID = run.ID
+			result.RunStatus = string(run.Status)
+			result.RunLink, _ = c.cloud.RunLink(ctx, c.organization, run)
+		}
+		switch {
+		case errors.Is(runErr, cloud.ErrRunUnchanged):
+			result.Status = Noop
+		case runErr != nil:
+			result.Status = statusForError(runErr)
+			result.Error = runErr.Error()
+		}
+		c.writer.Output(fmt.Sprintf("Workspace: %q, Run ID: %q, Status: %s", result.Workspace, result.RunID, result.Status))
+		return result
+	})
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+	return c.batchResults(results)
+}
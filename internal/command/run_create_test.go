@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testRetryRunService struct {
+	testPipelineRunService
+
+	// statuses of each created run, in order
+	statuses []tfe.RunStatus
+	reason   string
+	retries  []cloud.RetryRunOptions
+}
+
+func (s *testRetryRunService) createdRun() (*tfe.Run, error) {
+	attempt := len(s.retries)
+	run := testPipelineRun(s.statuses[attempt], false)
+	run.ID = "run-" + string(rune('1'+attempt))
+	run.ConfigurationVersion = &tfe.ConfigurationVersion{ID: "cv-1"}
+	if run.Status == tfe.RunErrored {
+		return run, &cloud.RunStatusError{Status: run.Status}
+	}
+	return run, nil
+}
+
+func (s *testRetryRunService) CreateRun(context.Context, cloud.CreateRunOptions) (*tfe.Run, error) {
+	return s.createdRun()
+}
+
+func (s *testRetryRunService) RetryRun(_ context.Context, options cloud.RetryRunOptions) (*tfe.Run, error) {
+	s.retries = append(s.retries, options)
+	return s.createdRun()
+}
+
+func (s *testRetryRunService) TransientRunError(context.Context, *tfe.Run) (string, error) {
+	return s.reason, nil
+}
+
+func testCreateRunCommand(runs cloud.RunService) (*cli.MockUi, *CreateRunCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &CreateRunCommand{Meta: meta}
+}
+
+func TestCreateRunCommand_AutoRetry(t *testing.T) {
+	cases := map[string]struct {
+		statuses []tfe.RunStatus
+		reason   string
+		code     int
+		retries  int
+		expected []string
+	}{
+		"succeeds after retry": {
+			statuses: []tfe.RunStatus{tfe.RunErrored, tfe.RunErrored, tfe.RunPlanned},
+			reason:   "cloud provider throttling",
+			retries:  2,
+			expected: []string{`"status": "Success"`, `"run_id": "run-3"`, `"retry_count": "2"`, `"retried_run_ids": "run-1,run-2"`},
+		},
+		"retry limit": {
+			statuses: []tfe.RunStatus{tfe.RunErrored, tfe.RunErrored, tfe.RunErrored, tfe.RunErrored},
+			reason:   "agent disconnected",
+			code:     1,
+			retries:  3,
+			expected: []string{`"status": "Error"`, `"run_id": "run-4"`, `"retry_count": "3"`},
+		},
+		"not transient": {
+			statuses: []tfe.RunStatus{tfe.RunErrored},
+			code:     1,
+			expected: []string{`"status": "Error"`, `"run_id": "run-1"`, `"retry_count": "0"`},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			runs := &testRetryRunService{statuses: tc.statuses, reason: tc.reason}
+			ui, cmd := testCreateRunCommand(runs)
+
+			if code := cmd.Run([]string{"-workspace=ws", "-auto-retry=3"}); code != tc.code {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.code, code, ui.ErrorWriter.String())
+			}
+			if len(runs.retries) != tc.retries {
+				t.Errorf("expected %d retries but received %d", tc.retries, len(runs.retries))
+			}
+			for i, retry := range runs.retries {
+				if retry.Attempt != i+1 {
+					t.Errorf("expected attempt %d but received %d", i+1, retry.Attempt)
+				}
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRunCommand_Diagnostics(t *testing.T) {
+	runs := &testRetryRunService{statuses: []tfe.RunStatus{tfe.RunErrored}}
+	runs.diagnostics = []*cloud.Diagnostic{{
+		Severity: "error",
+		Summary:  "Unsupported argument",
+		Address:  "module.vpc.aws_vpc.main",
+		Module:   "module.vpc",
+		Filename: "main.tf",
+		Line:     3,
+	}}
+	ui, cmd := testCreateRunCommand(runs)
+
+	if code := cmd.Run([]string{"-workspace=ws"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"diagnostics": [`, `"summary": "Unsupported argument"`, `"module": "module.vpc"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Terraform error: Unsupported argument, with module.vpc.aws_vpc.main, on main.tf line 3") {
+		t.Errorf("expected error diagnostic on stderr but received %s", ui.ErrorWriter.String())
+	}
+}
+
+type testTagSelectorService struct {
+	cloud.TagBindingService
+	names    []string
+	bindings []*cloud.TagBindingDetails
+}
+
+func (s *testTagSelectorService) ListTaggedWorkspaces(_ context.Context, _ string, bindings []*cloud.TagBindingDetails) ([]string, error) {
+	s.bindings = bindings
+	return s.names, nil
+}
+
+type testArchiveUploader struct {
+	cloud.ConfigVersionService
+	mu       sync.Mutex
+	uploaded []string
+}
+
+func (u *testArchiveUploader) DownloadConfig(_ context.Context, configVersionID string) ([]byte, error) {
+	return []byte("archive-" + configVersionID), nil
+}
+
+func (u *testArchiveUploader) UploadConfigArchive(_ context.Context, options cloud.UploadArchiveOptions) (*tfe.ConfigurationVersion, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.uploaded = append(u.uploaded, options.Workspace)
+	return &tfe.ConfigurationVersion{ID: "cv-" + options.Workspace}, nil
+}
+
+func TestCreateRunCommand_WorkspaceTag(t *testing.T) {
+	runs := &testBatchRunService{messages: map[string]string{}, configVersions: map[string]string{}}
+	ui, cmd := testCreateRunCommand(runs)
+	tags := &testTagSelectorService{names: []string{"payments-api", "payments-db"}}
+	uploader := &testArchiveUploader{}
+	cmd.cloud.TagBindingService = tags
+	cmd.cloud.ConfigVersionService = uploader
+
+	code := cmd.Run([]string{"-workspace-tag=team:payments", "-configuration_version=cv-source", "-plan-only"})
+	if code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(tags.bindings) != 1 || tags.bindings[0].Key != "team" || tags.bindings[0].Value != "payments" {
+		t.Errorf("expected team:payments tag binding but received %v", tags.bindings)
+	}
+	if len(uploader.uploaded) != 2 {
+		t.Errorf("expected configuration uploaded to 2 workspaces but received %v", uploader.uploaded)
+	}
+	if runs.configVersions["payments-db"] != "cv-payments-db" {
+		t.Errorf("expected run to use the configuration version of its workspace but received %v", runs.configVersions)
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"run_count": "2"`, `"failed_count": "0"`, `"status": "Success"`, `"run_id": "run-payments-api"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestCreateRunCommand_WorkspaceTagValidation(t *testing.T) {
+	ui, cmd := testCreateRunCommand(&testBatchRunService{messages: map[string]string{}})
+
+	code := cmd.Run([]string{"-workspace-tag=team:payments", "-workspace=payments-api"})
+	if code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-workspace-tag cannot be used with -workspace") {
+		t.Errorf("expected validation error but received %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestParseTagSelectors(t *testing.T) {
+	bindings, err := parseTagSelectors([]string{"team:payments", "critical"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if bindings[0].Key != "team" || bindings[0].Value != "payments" || bindings[1].Key != "critical" || bindings[1].Value != "" {
+		t.Errorf("unexpected tag bindings: %v", bindings)
+	}
+	if _, err := parseTagSelectors([]string{":payments"}); err == nil {
+		t.Error("expected error for selector without a key")
+	}
+}
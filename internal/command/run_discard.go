@@ -6,6 +6,7 @@ package command
 import (
 	"flag"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-tfe"
@@ -17,12 +18,14 @@ type DiscardRunCommand struct {
 
 	RunID   string
 	Comment string
+	NoopOk  bool
 }
 
 func (c *DiscardRunCommand) flags() *flag.FlagSet {
 	f := c.flagSet("run discard")
 	f.StringVar(&c.RunID, "run", "", "HCP Terraform Run ID to Discard")
 	f.StringVar(&c.Comment, "comment", "", "An optional comment about the run.")
+	f.BoolVar(&c.NoopOk, "noop-ok", false, "Reports a Noop status instead of failing when the run is already discarded, canceled, errored or applied.")
 
 	return f
 }
@@ -44,7 +47,7 @@ func (c *DiscardRunCommand) Run(args []string) int {
 		RunID: c.RunID,
 	})
 	if runErr != nil {
-		c.addOutput("status", string(Error))
+		c.addOutput("status", string(c.resolveStatus(runErr)))
 		c.closeOutput()
 		c.writer.ErrorResult(fmt.Sprintf("unable to read run: %s, with: %s", c.RunID, runErr.Error()))
 		return 1
@@ -52,6 +55,13 @@ func (c *DiscardRunCommand) Run(args []string) int {
 
 	// first check if not able to discard run
 	if !run.Actions.IsDiscardable {
+		if c.NoopOk && slices.Contains(cloud.DiscardNoopStatus, run.Status) {
+			c.addOutput("status", string(Noop))
+			c.addRunDetails(run)
+			c.writer.ErrorResult(fmt.Sprintf("run %s, is %s. There is nothing to discard.", c.RunID, run.Status))
+			c.writer.OutputResult(c.closeOutput())
+			return 0
+		}
 		c.addOutput("status", string(Error))
 		c.addRunDetails(run)
 		c.writer.ErrorResult(fmt.Sprintf("run: %s cannot be discarded", c.RunID))
@@ -88,9 +98,7 @@ func (c *DiscardRunCommand) addRunDetails(run *tfe.Run) {
 		return
 	}
 	link, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
-	if link != "" {
-		c.addOutput("run_link", link)
-	}
+	c.addRunLinks(run, link)
 	c.addOutput("run_id", run.ID)
 	c.addOutput("run_status", string(run.Status))
 }
@@ -101,19 +109,14 @@ Usage: tfci [global options] run discard [options]
 
 	Skips any remaining work on runs that are paused waiting for confirmation or priority.
 
-Global Options:
-
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
-
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
-
-	-organization   HCP Terraform Organization Name.
-
+` + globalOptionsHelp + `
 Options:
 
 	-run         Existing HCP Terraform Run ID to Discard.
 
 	-comment     An optional comment about the run.
+
+	-noop-ok     Reports a "Noop" status instead of failing when the run is already discarded, canceled, errored or applied, so re-running a pipeline does not fail.
 	`
 	return strings.TrimSpace(helpText)
 }
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+// a run that has already ended and cannot be discarded or canceled
+type testEndedRunService struct {
+	cloud.RunService
+	status  tfe.RunStatus
+	changed bool
+}
+
+func (s *testEndedRunService) GetRun(_ context.Context, options cloud.GetRunOptions) (*tfe.Run, error) {
+	return &tfe.Run{ID: options.RunID, Status: s.status, Actions: &tfe.RunActions{}}, nil
+}
+
+func (s *testEndedRunService) DiscardRun(_ context.Context, options cloud.DiscardRunOptions) (*tfe.Run, error) {
+	s.changed = true
+	return &tfe.Run{ID: options.RunID, Status: tfe.RunDiscarded}, nil
+}
+
+func (s *testEndedRunService) CancelRun(_ context.Context, options cloud.CancelRunOptions) (*tfe.Run, error) {
+	s.changed = true
+	return &tfe.Run{ID: options.RunID, Status: tfe.RunCanceled}, nil
+}
+
+func (s *testEndedRunService) RunLink(context.Context, string, *tfe.Run) (string, error) {
+	return "", nil
+}
+
+func testEndedRunMeta(runs cloud.RunService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestDiscardRunCommand_NoopOk(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		status       tfe.RunStatus
+		expectedCode int
+		expected     string
+	}{
+		{name: "discarded", args: []string{"-noop-ok"}, status: tfe.RunDiscarded, expectedCode: 0, expected: `"status": "Noop"`},
+		{name: "applied", args: []string{"-noop-ok"}, status: tfe.RunApplied, expectedCode: 0, expected: `"status": "Noop"`},
+		{name: "without-noop-ok", status: tfe.RunDiscarded, expectedCode: 1, expected: `"status": "Error"`},
+		{name: "applying", args: []string{"-noop-ok"}, status: tfe.RunApplying, expectedCode: 1, expected: `"status": "Error"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := &testEndedRunService{status: tc.status}
+			ui, meta := testEndedRunMeta(runs)
+			cmd := &DiscardRunCommand{Meta: meta}
+
+			if code := cmd.Run(append([]string{"-run=run-1"}, tc.args...)); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if runs.changed {
+				t.Error("expected the run not to be discarded")
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range []string{tc.expected, `"run_status": "` + string(tc.status) + `"`} {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
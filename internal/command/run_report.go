@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type RunReportFormat string
+
+const (
+	RunReportJSON     RunReportFormat = "json"
+	RunReportMarkdown RunReportFormat = "markdown"
+	RunReportHTML     RunReportFormat = "html"
+)
+
+func parseRunReportFormat(format string) (RunReportFormat, error) {
+	switch RunReportFormat(format) {
+	case "", RunReportJSON:
+		return RunReportJSON, nil
+	case RunReportMarkdown, RunReportHTML:
+		return RunReportFormat(format), nil
+	default:
+		return "", fmt.Errorf("unsupported report format %q, must be one of: json, markdown, html", format)
+	}
+}
+
+type RunReportCommand struct {
+	*Meta
+
+	RunID        string
+	ReportFile   string
+	ReportFormat string
+}
+
+func (c *RunReportCommand) flags() *flag.FlagSet {
+	f := c.flagSet("run report")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to report on.")
+	f.StringVar(&c.ReportFile, "report-file", "", "Writes the report to the provided file path, to archive as deploy evidence.")
+	f.StringVar(&c.ReportFormat, "report-format", "json", "Format of the file written to -report-file: json, markdown or html.")
+	return f
+}
+
+func (c *RunReportCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("reporting on a run requires a run id")
+		return 1
+	}
+
+	format, err := parseRunReportFormat(c.ReportFormat)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	report, err := c.cloud.GetRunReport(c.appCtx, c.RunID)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error reporting on run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if c.ReportFile != "" {
+		if err := writeRunReport(c.ReportFile, format, report); err != nil {
+			c.addOutput("status", string(Error))
+			c.writer.ErrorResult(fmt.Sprintf("error writing report to %q: %s", c.ReportFile, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+		c.addOutput("report_file", c.ReportFile)
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_id", report.RunID)
+	c.addOutput("run_status", report.RunStatus)
+	c.addOutput("policy_status", report.Policies.Status)
+	c.addOutputWithOpts("report", report, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		artifact:    "run_report.json",
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func writeRunReport(path string, format RunReportFormat, report *cloud.RunReport) error {
+	data, err := renderRunReport(format, report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func renderRunReport(format RunReportFormat, report *cloud.RunReport) ([]byte, error) {
+	switch format {
+	case RunReportMarkdown:
+		return []byte(markdownRunReport(report)), nil
+	case RunReportHTML:
+		var b bytes.Buffer
+		if err := htmlRunReportTemplate.Execute(&b, report); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	default:
+		return json.MarshalIndent(report, "", "  ")
+	}
+}
+
+func markdownRunReport(report *cloud.RunReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Run Report: %s\n\n", report.RunID)
+	fmt.Fprintf(&b, "Status: `%s`, Created: %s, Message: %s\n", report.RunStatus, report.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"), report.Message)
+
+	if plan := report.Plan; plan != nil {
+		b.WriteString("\n## Plan\n\n")
+		b.WriteString("| Status | Has Changes | Additions | Changes | Destructions | Imports |\n")
+		b.WriteString("| ------ | ----------- | --------- | ------- | ------------ | ------- |\n")
+		fmt.Fprintf(&b, "| %s | %t | %d | %d | %d | %d |\n", plan.Status, plan.HasChanges, plan.Additions, plan.Changes, plan.Destructions, plan.Imports)
+	}
+
+	if cost := report.CostEstimate; cost != nil {
+		b.WriteString("\n## Cost Estimation\n\n")
+		b.WriteString("| Status | Prior Monthly Cost | Proposed Monthly Cost | Delta Monthly Cost |\n")
+		b.WriteString("| ------ | ------------------ | --------------------- | ------------------ |\n")
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", cost.Status, cost.PriorMonthlyCost, cost.ProposedMonthlyCost, cost.DeltaMonthlyCost)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(markdownPolicyReport(report.Policies))
+
+	if len(report.TaskStages) > 0 {
+		b.WriteString("\n## Task Stages\n\n")
+		b.WriteString("| Stage | Stage Status | Task | Task Status | Enforcement Level | Message |\n")
+		b.WriteString("| ----- | ------------ | ---- | ----------- | ----------------- | ------- |\n")
+		for _, stage := range report.TaskStages {
+			if len(stage.TaskResults) == 0 {
+				fmt.Fprintf(&b, "| %s | %s | | | | |\n", stage.Stage, stage.Status)
+			}
+			for _, result := range stage.TaskResults {
+				fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", stage.Stage, stage.Status, result.TaskName, result.Status, result.EnforcementLevel, strings.ReplaceAll(result.Message, "|", "\\|"))
+			}
+		}
+	}
+	return b.String()
+}
+
+var htmlRunReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Run Report: {{ .RunID }}</title>
+</head>
+<body>
+<h1>Run Report: {{ .RunID }}</h1>
+<p>Status: <code>{{ .RunStatus }}</code>, Created: {{ .CreatedAt.UTC.Format "2006-01-02T15:04:05Z" }}, Message: {{ .Message }}</p>
+{{- with .Plan }}
+<h2>Plan</h2>
+<table>
+<tr><th>Status</th><th>Has Changes</th><th>Additions</th><th>Changes</th><th>Destructions</th><th>Imports</th></tr>
+<tr><td>{{ .Status }}</td><td>{{ .HasChanges }}</td><td>{{ .Additions }}</td><td>{{ .Changes }}</td><td>{{ .Destructions }}</td><td>{{ .Imports }}</td></tr>
+</table>
+{{- end }}
+{{- with .CostEstimate }}
+<h2>Cost Estimation</h2>
+<table>
+<tr><th>Status</th><th>Prior Monthly Cost</th><th>Proposed Monthly Cost</th><th>Delta Monthly Cost</th></tr>
+<tr><td>{{ .Status }}</td><td>{{ .PriorMonthlyCost }}</td><td>{{ .ProposedMonthlyCost }}</td><td>{{ .DeltaMonthlyCost }}</td></tr>
+</table>
+{{- end }}
+{{- with .Policies }}
+<h2>Policy Results: {{ .Status }}</h2>
+<p>Passed: {{ .Passed }}, Advisory failed: {{ .AdvisoryFailed }}, Mandatory failed: {{ .MandatoryFailed }}, Errored: {{ .Errored }}, Requires override: {{ .RequiresOverride }}</p>
+<table>
+<tr><th>Stage</th><th>Status</th><th>Passed</th><th>Advisory Failed</th><th>Mandatory Failed</th><th>Errored</th></tr>
+{{- range .Stages }}
+<tr><td>{{ .Stage }}</td><td>{{ .Status }}</td><td>{{ .Passed }}</td><td>{{ .AdvisoryFailed }}</td><td>{{ .MandatoryFailed }}</td><td>{{ .Errored }}</td></tr>
+{{- end }}
+</table>
+{{- $policies := .Policies true }}
+{{- if $policies }}
+<table>
+<tr><th>Stage</th><th>Policy Set</th><th>Policy</th><th>Enforcement Level</th><th>Status</th><th>Description</th></tr>
+{{- range $policies }}
+<tr><td>{{ .Stage }}</td><td>{{ .PolicySet }}</td><td>{{ .Name }}</td><td>{{ .EnforcementLevel }}</td><td>{{ .Status }}</td><td>{{ .Description }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+{{- if .TaskStages }}
+<h2>Task Stages</h2>
+<table>
+<tr><th>Stage</th><th>Stage Status</th><th>Task</th><th>Task Status</th><th>Enforcement Level</th><th>Message</th></tr>
+{{- range $stage := .TaskStages }}
+{{- range .TaskResults }}
+<tr><td>{{ $stage.Stage }}</td><td>{{ $stage.Status }}</td><td>{{ .TaskName }}</td><td>{{ .Status }}</td><td>{{ .EnforcementLevel }}</td><td>{{ .Message }}</td></tr>
+{{- else }}
+<tr><td>{{ $stage.Stage }}</td><td>{{ $stage.Status }}</td><td></td><td></td><td></td><td></td></tr>
+{{- end }}
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
+
+func (c *RunReportCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] run report [options]
+
+	Reports the plan changes, cost estimation, policy results and task stage
+	outcomes of a run in a single document, to archive as deploy evidence.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run            Existing HCP Terraform Run ID to report on.
+
+	-report-file    Writes the report to the provided file path.
+
+	-report-format  Format of the file written to -report-file: json (default),
+	                markdown or html.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunReportCommand) Synopsis() string {
+	return "Reports the governance evidence of a run"
+}
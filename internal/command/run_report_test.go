@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testRunReportService struct {
+	cloud.RunService
+}
+
+func (s *testRunReportService) GetRunReport(_ context.Context, runID string) (*cloud.RunReport, error) {
+	return &cloud.RunReport{
+		RunID:        runID,
+		RunStatus:    "planned",
+		Message:      "Deploy <networking>",
+		Plan:         &cloud.PlanChanges{ID: "plan-1", Status: "finished", HasChanges: true, Additions: 2},
+		CostEstimate: &cloud.CostEstimateDetails{ID: "ce-1", Status: "finished", DeltaMonthlyCost: "12.50"},
+		Policies:     testPolicyEvaluation(),
+		TaskStages: []*cloud.TaskStageDetails{{
+			ID:          "ts-1",
+			Stage:       "post_plan",
+			Status:      "passed",
+			TaskResults: []*cloud.TaskResultDetails{{ID: "taskrs-1", TaskName: "security-scanner", Status: "passed", EnforcementLevel: "mandatory"}},
+		}},
+	}, nil
+}
+
+func testRunReportCommand() (*cli.MockUi, *RunReportCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = &testRunReportService{}
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &RunReportCommand{Meta: meta}
+}
+
+func TestRunReportCommand(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected []string
+	}{
+		{
+			format:   "json",
+			expected: []string{`"run_id": "run-1"`, `"delta_monthly_cost": "12.50"`},
+		},
+		{
+			format: "markdown",
+			expected: []string{
+				"# Run Report: run-1",
+				"| finished | true | 2 | 0 | 0 | 0 |",
+				"## Policy Results: failed",
+				"| post_plan | passed | security-scanner | passed | mandatory |  |",
+			},
+		},
+		{
+			format: "html",
+			expected: []string{
+				"<h1>Run Report: run-1</h1>",
+				"Message: Deploy &lt;networking&gt;",
+				"<td>baseline</td><td>require-tags</td>",
+				"<td>security-scanner</td>",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "report")
+			ui, cmd := testRunReportCommand()
+			if code := cmd.Run([]string{"-run=run-1", "-report-file=" + path, "-report-format=" + tc.format}); code != 0 {
+				t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+			}
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("expected report file to be written: %s", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(string(contents), expected) {
+					t.Errorf("expected report to contain %q but received %s", expected, contents)
+				}
+			}
+			if !strings.Contains(ui.OutputWriter.String(), `"policy_status": "failed"`) {
+				t.Errorf("expected policy status in output but received %s", ui.OutputWriter.String())
+			}
+		})
+	}
+}
+
+func TestRunReportCommand_InvalidFormat(t *testing.T) {
+	ui, cmd := testRunReportCommand()
+	if code := cmd.Run([]string{"-run=run-1", "-report-format=pdf"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `unsupported report format "pdf"`) {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
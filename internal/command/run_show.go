@@ -4,23 +4,41 @@
 package command
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/tfci/internal/cloud"
 )
 
+// related resources of the -include option, comments cannot be included in the run read
+var runShowIncludes = map[string]tfe.RunIncludeOpt{
+	"apply":       tfe.RunApply,
+	"task_stages": tfe.RunTaskStages,
+	"created_by":  tfe.RunCreatedBy,
+	"comments":    "",
+}
+
 type ShowRunCommand struct {
 	*Meta
 
-	RunID string
+	RunID     string
+	Workspace string
+	Latest    bool
+	Current   bool
+	Include   []string
 }
 
 func (c *ShowRunCommand) flags() *flag.FlagSet {
 	f := c.flagSet("run show")
 	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to show.")
+	f.StringVar(&c.Workspace, "workspace", "", "HCP Terraform Workspace to show the latest or current run of, instead of a run ID.")
+	f.BoolVar(&c.Latest, "latest", false, "Shows the most recent run of the workspace.")
+	f.BoolVar(&c.Current, "current", false, "Shows the current run of the workspace.")
+	f.Var((*flagStringSlice)(&c.Include), "include", "Related resources to add to the result: apply, task_stages, comments or created_by. This option accepts multiple values.")
 
 	return f
 }
@@ -30,23 +48,40 @@ func (c *ShowRunCommand) Run(args []string) int {
 		return 1
 	}
 
-	if c.RunID == "" {
+	// the workspace from tfci.yaml only applies when a run id is not provided
+	if c.RunID == "" && c.Workspace == "" && c.config != nil {
+		c.Workspace = c.config.Workspace
+	}
+
+	if err := c.validate(); err != nil {
 		c.addOutput("status", string(Error))
 		c.closeOutput()
-		c.writer.ErrorResult("showing a run requires a valid run id")
+		c.writer.ErrorResult(err.Error())
 		return 1
 	}
 
 	// fetch run
-	run, err := c.cloud.GetRun(c.appCtx, cloud.GetRunOptions{
-		RunID: c.RunID,
-	})
+	var run *tfe.Run
+	var err error
+	if c.Workspace != "" {
+		run, err = c.cloud.GetWorkspaceRun(c.appCtx, cloud.WorkspaceRunOptions{
+			Organization: c.organization,
+			Workspace:    c.Workspace,
+			Current:      c.Current,
+			Include:      c.runIncludes(),
+		})
+	} else {
+		run, err = c.cloud.GetRun(c.appCtx, cloud.GetRunOptions{
+			RunID:   c.RunID,
+			Include: c.runIncludes(),
+		})
+	}
 
 	if err != nil {
 		status := c.resolveStatus(err)
 		c.addOutput("status", string(status))
 		c.addRunDetails(run)
-		c.writer.ErrorResult(fmt.Sprintf("error showing run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.ErrorResult(fmt.Sprintf("error showing run, '%s' in HCP Terraform: %s", c.runRef(), err.Error()))
 		c.writer.OutputResult(c.closeOutput())
 		return 1
 	}
@@ -57,54 +92,141 @@ func (c *ShowRunCommand) Run(args []string) int {
 	return 0
 }
 
+func (c *ShowRunCommand) validate() error {
+	switch {
+	case c.RunID != "" && c.Workspace != "":
+		return errors.New("showing a run accepts either a run id or a workspace, not both")
+	case c.Workspace != "" && c.Latest == c.Current:
+		return errors.New("showing a run of a workspace requires either -latest or -current")
+	case c.Workspace == "" && (c.Latest || c.Current):
+		return errors.New("-latest and -current require a workspace")
+	case c.RunID == "" && c.Workspace == "":
+		return errors.New("showing a run requires a valid run id")
+	}
+	for _, include := range c.Include {
+		if _, ok := runShowIncludes[include]; !ok {
+			return fmt.Errorf("invalid include %q, expected apply, task_stages, comments or created_by", include)
+		}
+	}
+	return nil
+}
+
+func (c *ShowRunCommand) runIncludes() []tfe.RunIncludeOpt {
+	opts := []tfe.RunIncludeOpt{}
+	for _, include := range c.Include {
+		if opt := runShowIncludes[include]; opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+// the run id, or the workspace the run is resolved from, for error messages
+func (c *ShowRunCommand) runRef() string {
+	if c.Workspace == "" {
+		return c.RunID
+	}
+	if c.Current {
+		return fmt.Sprintf("current run of workspace %s", c.Workspace)
+	}
+	return fmt.Sprintf("latest run of workspace %s", c.Workspace)
+}
+
 func (c *ShowRunCommand) addRunDetails(run *tfe.Run) {
 	if run == nil {
 		return
 	}
 
 	runLink, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
-	if runLink != "" {
-		c.addOutput("run_link", runLink)
-	}
+	c.addRunLinks(run, runLink)
 	c.addOutput("run_id", run.ID)
 	c.addOutput("run_status", string(run.Status))
 	c.addOutput("run_message", run.Message)
 	c.addOutput("plan_id", run.Plan.ID)
 	c.addOutput("plan_status", string(run.Plan.Status))
 	c.addOutput("configuration_version_id", run.ConfigurationVersion.ID)
+	c.addRunDurations(run)
 
 	if run.CostEstimate != nil {
 		c.addOutput("cost_estimation_id", run.CostEstimate.ID)
 		c.addOutput("cost_estimation_status", string(run.CostEstimate.Status))
 		if run.CostEstimate.ErrorMessage != "" {
-			c.writer.ErrorResult(fmt.Sprintf("Cost Estimation errored: %s", run.CostEstimate.ErrorMessage))
+			c.softFailure(fmt.Sprintf("Cost Estimation errored: %s", run.CostEstimate.ErrorMessage))
 		}
 	}
 
+	c.addIncludes(run)
+
 	c.addOutputWithOpts("payload", run, &outputOpts{
 		stdOut:      false,
 		multiLine:   true,
 		platformOut: true,
+		artifact:    "run.json",
 	})
 }
 
+// adds the related resources requested with -include
+func (c *ShowRunCommand) addIncludes(run *tfe.Run) {
+	if slices.Contains(c.Include, "apply") && run.Apply != nil {
+		c.addOutput("apply_id", run.Apply.ID)
+		c.addOutput("apply_status", string(run.Apply.Status))
+		c.addApplySummary(run)
+	}
+	if slices.Contains(c.Include, "created_by") && run.CreatedBy != nil {
+		c.addOutput("created_by", run.CreatedBy.Username)
+	}
+	if slices.Contains(c.Include, "task_stages") {
+		stages := []*cloud.TaskStageDetails{}
+		for _, stage := range run.TaskStages {
+			stages = append(stages, &cloud.TaskStageDetails{
+				ID:          stage.ID,
+				Stage:       string(stage.Stage),
+				Status:      string(stage.Status),
+				CanOverride: stage.Actions != nil && stage.Actions.IsOverridable != nil && *stage.Actions.IsOverridable,
+			})
+		}
+		c.addOutputWithOpts("task_stages", stages, &outputOpts{
+			stdOut:      true,
+			multiLine:   true,
+			platformOut: true,
+		})
+	}
+	if slices.Contains(c.Include, "comments") {
+		comments, err := c.cloud.ListComments(c.appCtx, run.ID)
+		if err != nil {
+			c.softFailure(fmt.Sprintf("failed to read the comments of run %s: %s", run.ID, err.Error()))
+			return
+		}
+		details := []*commentDetails{}
+		for _, comment := range comments {
+			details = append(details, &commentDetails{ID: comment.ID, Body: comment.Body})
+		}
+		c.addOutputWithOpts("comments", details, &outputOpts{
+			stdOut:      true,
+			multiLine:   true,
+			platformOut: true,
+		})
+	}
+}
+
 func (c *ShowRunCommand) Help() string {
 	helpText := `
 Usage: tfci [global options] run show [options]
 
-	Returns run details for the provided HCP Terraform run ID.
+	Returns run details for the provided HCP Terraform run ID, or the latest or current run of a workspace.
 
-Global Options:
+` + globalOptionsHelp + `
+Options:
 
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
+	-run            Existing HCP Terraform Run ID to show.
 
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
+	-workspace      HCP Terraform Workspace to show the latest or current run of, instead of a run ID.
 
-	-organization   HCP Terraform Organization Name.
+	-latest         Shows the most recent run of the workspace.
 
-Options:
+	-current        Shows the current run of the workspace.
 
-	-run            Existing HCP Terraform Run ID to show.
+	-include        Related resources to add to the result: apply, task_stages, comments or created_by. This option accepts multiple values.
 	`
 	return strings.TrimSpace(helpText)
 }
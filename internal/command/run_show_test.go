@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/config"
+)
+
+type testWorkspaceRunService struct {
+	cloud.RunService
+	options *cloud.WorkspaceRunOptions
+	include []tfe.RunIncludeOpt
+}
+
+func (s *testWorkspaceRunService) GetRun(_ context.Context, options cloud.GetRunOptions) (*tfe.Run, error) {
+	s.include = options.Include
+	overridable := true
+	return &tfe.Run{
+		ID:                   options.RunID,
+		Status:               tfe.RunApplied,
+		Plan:                 &tfe.Plan{ID: "plan-abc123"},
+		Apply:                &tfe.Apply{ID: "apply-abc123", Status: tfe.ApplyFinished},
+		ConfigurationVersion: &tfe.ConfigurationVersion{ID: "cv-abc123"},
+		CreatedBy:            &tfe.User{Username: "octocat"},
+		TaskStages: []*tfe.TaskStage{
+			{ID: "ts-abc123", Stage: tfe.PostPlan, Status: tfe.TaskStagePassed, Actions: &tfe.Actions{IsOverridable: &overridable}},
+		},
+	}, nil
+}
+
+func (s *testWorkspaceRunService) GetApplySummary(context.Context, *tfe.Run) (*cloud.ApplySummary, error) {
+	return &cloud.ApplySummary{ApplyID: "apply-abc123", Added: 2}, nil
+}
+
+func (s *testWorkspaceRunService) GetWorkspaceRun(_ context.Context, options cloud.WorkspaceRunOptions) (*tfe.Run, error) {
+	s.options = &options
+	if options.Workspace == "empty" {
+		return nil, cloud.ErrNoWorkspaceRun
+	}
+	runID := "run-latest"
+	if options.Current {
+		runID = "run-current"
+	}
+	return &tfe.Run{
+		ID:                   runID,
+		Status:               tfe.RunPlanned,
+		Plan:                 &tfe.Plan{ID: "plan-abc123"},
+		ConfigurationVersion: &tfe.ConfigurationVersion{ID: "cv-abc123"},
+	}, nil
+}
+
+func (s *testWorkspaceRunService) RunLink(context.Context, string, *tfe.Run) (string, error) {
+	return "", nil
+}
+
+func TestShowRunCommand_Workspace(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expected     string
+	}{
+		{name: "latest", args: []string{"-workspace=networking", "-latest"}, expectedCode: 0, expected: `"run_id": "run-latest"`},
+		{name: "current", args: []string{"-workspace=networking", "-current"}, expectedCode: 0, expected: `"run_id": "run-current"`},
+		{name: "no-runs", args: []string{"-workspace=empty", "-latest"}, expectedCode: 1, expected: "error showing run, 'latest run of workspace empty' in HCP Terraform: workspace has no runs"},
+		{name: "latest-and-current", args: []string{"-workspace=networking", "-latest", "-current"}, expectedCode: 1, expected: "requires either -latest or -current"},
+		{name: "run-and-workspace", args: []string{"-run=run-abc123", "-workspace=networking", "-latest"}, expectedCode: 1, expected: "either a run id or a workspace"},
+		{name: "latest-without-workspace", args: []string{"-latest"}, expectedCode: 1, expected: "-latest and -current require a workspace"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := &testWorkspaceRunService{}
+			ui, meta := testEndedRunMeta(runs)
+			c := &ShowRunCommand{Meta: meta}
+
+			if code := c.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if output := ui.OutputWriter.String() + ui.ErrorWriter.String(); !strings.Contains(output, tc.expected) {
+				t.Errorf("expected %q but received %q", tc.expected, output)
+			}
+			if tc.expectedCode == 0 && (runs.options.Organization != "abc-company" || runs.options.Workspace != "networking") {
+				t.Errorf("expected run of workspace abc-company/networking but received %+v", runs.options)
+			}
+		})
+	}
+}
+
+func TestShowRunCommand_RunWithConfigWorkspace(t *testing.T) {
+	runs := &testWorkspaceRunService{}
+	ui, meta := testEndedRunMeta(runs)
+	meta.config = &config.Config{Workspace: "networking"}
+	c := &ShowRunCommand{Meta: meta}
+
+	if code := c.Run([]string{"-run=run-abc123"}); code != 0 {
+		t.Fatalf("expected the run id to win over the config workspace but received exit code %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.options != nil {
+		t.Errorf("expected run to be read by id but received workspace run options %+v", runs.options)
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, `"run_id": "run-abc123"`) {
+		t.Errorf("expected run run-abc123 but received %q", output)
+	}
+
+	// without a run id, the config workspace applies
+	runs = &testWorkspaceRunService{}
+	ui, meta = testEndedRunMeta(runs)
+	meta.config = &config.Config{Workspace: "networking"}
+	c = &ShowRunCommand{Meta: meta}
+
+	if code := c.Run([]string{"-latest"}); code != 0 {
+		t.Fatalf("expected workspace from config file but received exit code %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.options == nil || runs.options.Workspace != "networking" {
+		t.Errorf("expected run of workspace networking but received %+v", runs.options)
+	}
+}
+
+func TestShowRunCommand_Include(t *testing.T) {
+	runs := &testWorkspaceRunService{}
+	ui, meta := testEndedRunMeta(runs)
+	meta.cloud.CommentService = &testCommentService{comments: []*tfe.Comment{{ID: "wsc-abc123", Body: "Approved by the platform team"}}}
+	c := &ShowRunCommand{Meta: meta}
+
+	if code := c.Run([]string{"-run=run-abc123", "-include=apply,task_stages,comments,created_by"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !slices.Equal(runs.include, []tfe.RunIncludeOpt{tfe.RunApply, tfe.RunTaskStages, tfe.RunCreatedBy}) {
+		t.Errorf("expected apply, task_stages and created_by to be included but received %v", runs.include)
+	}
+
+	var result struct {
+		ApplyID        string                    `json:"apply_id"`
+		ApplyStatus    string                    `json:"apply_status"`
+		ResourcesAdded string                    `json:"resources_added"`
+		CreatedBy      string                    `json:"created_by"`
+		TaskStages     []*cloud.TaskStageDetails `json:"task_stages"`
+		Comments       []*commentDetails         `json:"comments"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &result); err != nil {
+		t.Fatalf("expected json result but received %q: %s", ui.OutputWriter.String(), err)
+	}
+	if result.ApplyID != "apply-abc123" || result.ApplyStatus != "finished" || result.ResourcesAdded != "2" {
+		t.Errorf("expected apply outputs but received %+v", result)
+	}
+	if result.CreatedBy != "octocat" {
+		t.Errorf("expected created_by %q but received %q", "octocat", result.CreatedBy)
+	}
+	if len(result.TaskStages) != 1 || result.TaskStages[0].Stage != "post_plan" || !result.TaskStages[0].CanOverride {
+		t.Errorf("expected post_plan task stage but received %+v", result.TaskStages)
+	}
+	if len(result.Comments) != 1 || result.Comments[0].Body != "Approved by the platform team" {
+		t.Errorf("expected comment but received %+v", result.Comments)
+	}
+}
+
+func TestShowRunCommand_InvalidInclude(t *testing.T) {
+	ui, meta := testEndedRunMeta(&testWorkspaceRunService{})
+	c := &ShowRunCommand{Meta: meta}
+
+	if code := c.Run([]string{"-run=run-abc123", "-include=policies"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if expected := `invalid include "policies"`; !strings.Contains(ui.ErrorWriter.String(), expected) {
+		t.Errorf("expected %q but received %q", expected, ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// environment variable holding the HMAC key of created run tasks, kept out of the command line
+const runTaskHMACKeyEnv = "TFCI_RUN_TASK_HMAC_KEY"
+
+type RunTaskListCommand struct {
+	*Meta
+}
+
+func (c *RunTaskListCommand) flags() *flag.FlagSet {
+	return c.flagSet("runtask list")
+}
+
+func (c *RunTaskListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	tasks, err := c.cloud.ListRunTasks(c.appCtx, c.organization)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing run tasks in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_task_count", fmt.Sprintf("%d", len(tasks)))
+	c.addOutputWithOpts("run_tasks", tasks, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"id", "name", "url", "enabled"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *RunTaskListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] runtask list
+
+	Lists the run tasks registered in the organization.
+
+` + globalOptionsHelp
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunTaskListCommand) Synopsis() string {
+	return "Lists the run tasks of the organization"
+}
+
+type RunTaskCreateCommand struct {
+	*Meta
+
+	Name        string
+	URL         string
+	Description string
+	Enabled     bool
+}
+
+func (c *RunTaskCreateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("runtask create")
+	f.StringVar(&c.Name, "name", "", "Name of the run task.")
+	f.StringVar(&c.URL, "url", "", "URL the run task requests are sent to.")
+	f.StringVar(&c.Description, "description", "", "Description of the run task.")
+	f.BoolVar(&c.Enabled, "enabled", true, "When false, the run task is registered but not sent requests.")
+	return f
+}
+
+func (c *RunTaskCreateCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" || c.URL == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("creating a run task requires a name and url")
+		return 1
+	}
+
+	task, err := c.cloud.CreateRunTask(c.appCtx, cloud.CreateRunTaskOptions{
+		Organization: c.organization,
+		Name:         c.Name,
+		URL:          c.URL,
+		Description:  c.Description,
+		HMACKey:      os.Getenv(runTaskHMACKeyEnv),
+		Enabled:      c.Enabled,
+	})
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error creating run task, '%s' in HCP Terraform: %s", c.Name, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_task_id", task.ID)
+	c.addOutput("run_task_name", task.Name)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *RunTaskCreateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] runtask create [options]
+
+	Registers a run task in the organization. The HMAC key signing run task requests is read from the
+	"TFCI_RUN_TASK_HMAC_KEY" environment variable.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name         Name of the run task.
+
+	-url          URL the run task requests are sent to.
+
+	-description  Description of the run task.
+
+	-enabled      When false, the run task is registered but not sent requests. Defaults to true.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunTaskCreateCommand) Synopsis() string {
+	return "Registers a run task in the organization"
+}
+
+type RunTaskAttachCommand struct {
+	*Meta
+
+	Workspace        string
+	RunTask          string
+	EnforcementLevel string
+	Stage            string
+}
+
+func (c *RunTaskAttachCommand) flags() *flag.FlagSet {
+	f := c.flagSet("runtask attach")
+	f.StringVar(&c.Workspace, "workspace", "", "Name of the workspace to attach the run task to.")
+	f.StringVar(&c.RunTask, "task", "", "Name or ID of the organization run task.")
+	f.StringVar(&c.EnforcementLevel, "enforcement-level", string(tfe.Advisory), "Enforcement level of the run task: advisory or mandatory.")
+	f.StringVar(&c.Stage, "stage", string(tfe.PostPlan), "Stage the run task runs in: pre_plan, post_plan, pre_apply or post_apply.")
+	return f
+}
+
+func (c *RunTaskAttachCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Workspace == "" || c.RunTask == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("attaching a run task requires a workspace and task")
+		return 1
+	}
+	level := tfe.TaskEnforcementLevel(c.EnforcementLevel)
+	if level != tfe.Advisory && level != tfe.Mandatory {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("unsupported enforcement level %q, must be one of: advisory, mandatory", c.EnforcementLevel))
+		return 1
+	}
+	stage := tfe.Stage(c.Stage)
+	if !slices.Contains(taskStageNames, stage) {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("invalid stage '%s', expected one of: pre_plan, post_plan, pre_apply, post_apply", c.Stage))
+		return 1
+	}
+
+	wrt, err := c.cloud.AttachRunTask(c.appCtx, cloud.AttachRunTaskOptions{
+		Organization:     c.organization,
+		Workspace:        c.Workspace,
+		RunTask:          c.RunTask,
+		EnforcementLevel: level,
+		Stage:            stage,
+	})
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error attaching run task, '%s' to workspace, '%s' in HCP Terraform: %s", c.RunTask, c.Workspace, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("workspace_run_task_id", wrt.ID)
+	c.addOutput("enforcement_level", string(wrt.EnforcementLevel))
+	c.addOutput("stage", string(wrt.Stage))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *RunTaskAttachCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] runtask attach [options]
+
+	Attaches an organization run task to a workspace. When the run task is already attached, its enforcement level and stage are updated.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace          Name of the workspace to attach the run task to.
+
+	-task               Name or ID of the organization run task.
+
+	-enforcement-level  Enforcement level of the run task: advisory or mandatory. Defaults to "advisory".
+
+	-stage              Stage the run task runs in: pre_plan, post_plan, pre_apply or post_apply. Defaults to "post_plan".
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunTaskAttachCommand) Synopsis() string {
+	return "Attaches a run task to a workspace"
+}
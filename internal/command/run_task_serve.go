@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/tfci/internal/runtask"
+)
+
+type RunTaskServeCommand struct {
+	*Meta
+
+	Port               int
+	Handler            string
+	Timeout            time.Duration
+	InsecureSkipVerify bool
+}
+
+func (c *RunTaskServeCommand) flags() *flag.FlagSet {
+	f := c.flagSet("runtask serve")
+	f.IntVar(&c.Port, "port", 8080, "Port to listen for run task requests on.")
+	f.StringVar(&c.Handler, "handler", "", "Executable run for each run task request.")
+	f.DurationVar(&c.Timeout, "timeout", runtask.DefaultTimeout, "Maximum duration of a handler run.")
+	f.BoolVar(&c.InsecureSkipVerify, "insecure-skip-verify", false, "Accepts run task requests without verifying their signature.")
+	return f
+}
+
+func (c *RunTaskServeCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Handler == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("serving run tasks requires a handler")
+		return 1
+	}
+	if _, err := os.Stat(c.Handler); err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("error reading run task handler: %s", err.Error()))
+		return 1
+	}
+	hmacKey := os.Getenv(runTaskHMACKeyEnv)
+	switch {
+	case c.InsecureSkipVerify:
+		c.softFailure("-insecure-skip-verify is set, run task request signatures are not verified")
+	case hmacKey == "":
+		// the handler runs with the request input, unsigned requests must not reach it
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("serving run tasks requires %s to verify request signatures, or -insecure-skip-verify", runTaskHMACKeyEnv))
+		return 1
+	}
+
+	tasks := runtask.NewServer(runtask.Options{
+		HMACKey:            hmacKey,
+		Handler:            &runtask.ScriptHandler{Path: c.Handler},
+		Callbacks:          c.cloud.RunTaskCallbacks(),
+		Timeout:            c.Timeout,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	})
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", c.Port),
+		Handler:           tasks,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(c.appCtx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("[INFO] listening for run task requests on: %q", server.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		log.Printf("[INFO] shutting down, waiting for run tasks in progress")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err = server.Shutdown(shutdownCtx)
+		tasks.Wait()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		c.addOutput("status", string(Error))
+		c.writer.ErrorResult(fmt.Sprintf("error serving run tasks: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *RunTaskServeCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] runtask serve [options]
+
+	Serves run task requests, running the handler for each request and reporting its result to HCP Terraform.
+	Requests are verified with the HMAC key read from the "TFCI_RUN_TASK_HMAC_KEY" environment variable,
+	which is required unless -insecure-skip-verify is set.
+
+	The handler receives the run details in the TFCI_RUN_ID, TFCI_RUN_STAGE, TFCI_ORGANIZATION_NAME,
+	TFCI_WORKSPACE_NAME and TFCI_TASK_RESULT_ID environment variables, and the paths of the run task request
+	and plan JSON in TFCI_RUN_TASK_REQUEST and TFCI_PLAN_JSON. Exiting with 0 passes the run task, any
+	other exit code fails it. The handler output is reported as the run task message.
+
+` + globalOptionsHelp + `
+Options:
+
+	-port                  Port to listen for run task requests on. Defaults to 8080.
+
+	-handler               Executable run for each run task request.
+
+	-timeout               Maximum duration of a handler run. Defaults to "10m".
+
+	-insecure-skip-verify  Accepts run task requests without verifying their signature, only for local testing.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RunTaskServeCommand) Synopsis() string {
+	return "Serves run task requests with a handler script"
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testRunTaskService struct {
+	cloud.RunTaskService
+	createOptions cloud.CreateRunTaskOptions
+	attachOptions cloud.AttachRunTaskOptions
+}
+
+func (s *testRunTaskService) ListRunTasks(context.Context, string) ([]*cloud.RunTaskDetails, error) {
+	return []*cloud.RunTaskDetails{{ID: "task-1", Name: "scanner", Enabled: true, HMACKeySet: true}}, nil
+}
+
+func (s *testRunTaskService) CreateRunTask(_ context.Context, options cloud.CreateRunTaskOptions) (*cloud.RunTaskDetails, error) {
+	s.createOptions = options
+	return &cloud.RunTaskDetails{ID: "task-1", Name: options.Name}, nil
+}
+
+func (s *testRunTaskService) AttachRunTask(_ context.Context, options cloud.AttachRunTaskOptions) (*tfe.WorkspaceRunTask, error) {
+	s.attachOptions = options
+	return &tfe.WorkspaceRunTask{ID: "wstask-1", EnforcementLevel: options.EnforcementLevel, Stage: options.Stage}, nil
+}
+
+func testRunTaskCommandMeta(tasks cloud.RunTaskService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunTaskService = tasks
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestRunTaskListCommand(t *testing.T) {
+	ui, meta := testRunTaskCommandMeta(&testRunTaskService{})
+	cmd := &RunTaskListCommand{Meta: meta}
+	if code := cmd.Run([]string{}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"run_task_count": "1"`, `"hmac_key_set": true`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestRunTaskCreateCommand(t *testing.T) {
+	t.Setenv(runTaskHMACKeyEnv, "secret")
+	tasks := &testRunTaskService{}
+	ui, meta := testRunTaskCommandMeta(tasks)
+	cmd := &RunTaskCreateCommand{Meta: meta}
+	if code := cmd.Run([]string{"-name=scanner", "-url=https://scanner.example.com/hook"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if tasks.createOptions.HMACKey != "secret" || !tasks.createOptions.Enabled || tasks.createOptions.Organization != "abc-company" {
+		t.Errorf("unexpected create options %+v", tasks.createOptions)
+	}
+	if strings.Contains(ui.OutputWriter.String(), "secret") {
+		t.Errorf("expected the hmac key to not be printed but received %s", ui.OutputWriter.String())
+	}
+}
+
+func TestRunTaskAttachCommand(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expectedOut  []string
+	}{
+		{
+			name:         "defaults",
+			args:         []string{"-workspace=networking", "-task=scanner"},
+			expectedCode: 0,
+			expectedOut:  []string{`"enforcement_level": "advisory"`, `"stage": "post_plan"`},
+		},
+		{
+			name:         "mandatory-pre-plan",
+			args:         []string{"-workspace=networking", "-task=scanner", "-enforcement-level=mandatory", "-stage=pre_plan"},
+			expectedCode: 0,
+			expectedOut:  []string{`"enforcement_level": "mandatory"`, `"stage": "pre_plan"`},
+		},
+		{
+			name:         "invalid-enforcement-level",
+			args:         []string{"-workspace=networking", "-task=scanner", "-enforcement-level=hard"},
+			expectedCode: 1,
+		},
+		{
+			name:         "invalid-stage",
+			args:         []string{"-workspace=networking", "-task=scanner", "-stage=post_destroy"},
+			expectedCode: 1,
+		},
+		{
+			name:         "missing-task",
+			args:         []string{"-workspace=networking"},
+			expectedCode: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testRunTaskCommandMeta(&testRunTaskService{})
+			cmd := &RunTaskAttachCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRunTaskServeCommand_Validation(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "missing-handler",
+			args: []string{"-port=0"},
+		},
+		{
+			name: "handler-not-found",
+			args: []string{"-port=0", "-handler=./does-not-exist.sh"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testRunTaskCommandMeta(&testRunTaskService{})
+			cmd := &RunTaskServeCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != 1 {
+				t.Fatalf("expected exit code 1 but received %d", code)
+			}
+			if ui.ErrorWriter.String() == "" {
+				t.Error("expected an error message")
+			}
+		})
+	}
+}
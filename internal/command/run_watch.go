@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// how often the terminal UI is redrawn, for phase timers and new log lines
+const watchRefreshInterval = 500 * time.Millisecond
+
+type WatchRunCommand struct {
+	*Meta
+
+	RunID   string
+	TUI     bool
+	Comment string
+}
+
+func (c *WatchRunCommand) flags() *flag.FlagSet {
+	f := c.flagSet("run watch")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to watch.")
+	f.BoolVar(&c.TUI, "tui", false, "Shows an interactive terminal UI with live status, phase timers, scrolling logs, and keybindings to apply, discard or cancel the run.")
+	f.StringVar(&c.Comment, "comment", "", "An optional comment when applying, discarding or cancelling the run from the terminal UI.")
+	return f
+}
+
+func (c *WatchRunCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("watching a run requires a valid run id")
+		return 1
+	}
+
+	var run *tfe.Run
+	var err error
+	if c.TUI {
+		run, err = c.watchTUI()
+	} else {
+		run, err = c.watch()
+	}
+
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.addRunDetails(run)
+		c.writer.ErrorResult(fmt.Sprintf("error watching run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addRunDetails(run)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+// writes each status change until the run has finished
+func (c *WatchRunCommand) watch() (*tfe.Run, error) {
+	var lastStatus tfe.RunStatus
+	return c.cloud.WatchRun(c.appCtx, c.RunID, func(run *tfe.Run) {
+		if run.Status != lastStatus {
+			lastStatus = run.Status
+			c.writer.Output(fmt.Sprintf("Run Status: %q", run.Status))
+		}
+	})
+}
+
+// shows the terminal UI until it is quit, the run may still be in progress
+func (c *WatchRunCommand) watchTUI() (*tfe.Run, error) {
+	term, err := openTerminal()
+	if err != nil {
+		return nil, err
+	}
+	defer term.close()
+
+	ctx, cancel := context.WithCancel(c.appCtx)
+	defer cancel()
+
+	screen := newWatchScreen(c.RunID)
+	runs := make(chan *tfe.Run, 1)
+	watched := make(chan error, 1)
+	go func() {
+		run, err := c.cloud.WatchRun(ctx, c.RunID, func(run *tfe.Run) {
+			screen.update(run)
+			select {
+			case runs <- run:
+			default:
+			}
+		})
+		screen.update(run)
+		watched <- err
+	}()
+
+	keys := make(chan byte)
+	go readKeys(os.Stdin, keys)
+
+	ticker := time.NewTicker(watchRefreshInterval)
+	defer ticker.Stop()
+
+	var run *tfe.Run
+	var watchErr error
+	logsStarted := false
+	for {
+		width, height := term.size()
+		term.draw(screen.render(width, height, time.Now()))
+
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+		case r := <-runs:
+			run = r
+			if !logsStarted {
+				logsStarted = true
+				go c.readLogs(ctx, r, screen)
+			}
+		case watchErr = <-watched:
+			if watchErr != nil {
+				screen.finish(fmt.Sprintf("Stopped watching: %s, press q to exit", watchErr))
+			} else {
+				screen.finish("Run finished, press q to exit")
+			}
+		case k, ok := <-keys:
+			if !ok {
+				return run, watchErr
+			}
+			switch action := screen.key(k); action {
+			case "":
+			case actionQuit:
+				return c.latestRun(run, screen), watchErr
+			default:
+				go func() {
+					if err := c.cloud.RunAction(ctx, c.RunID, action, c.Comment); err != nil {
+						screen.setMessage(fmt.Sprintf("Failed to %s the run: %s", action, err))
+					}
+				}()
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *WatchRunCommand) latestRun(run *tfe.Run, screen *watchScreen) *tfe.Run {
+	screen.mu.Lock()
+	defer screen.mu.Unlock()
+	if screen.run != nil {
+		return screen.run
+	}
+	return run
+}
+
+// streams the plan and apply logs into the screen
+func (c *WatchRunCommand) readLogs(ctx context.Context, run *tfe.Run, screen *watchScreen) {
+	logs, err := c.cloud.RunLogs(ctx, run)
+	if err != nil {
+		screen.setMessage(fmt.Sprintf("Failed to read logs: %s", err))
+		return
+	}
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		screen.addLine(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		log.Printf("[ERROR] error reading logs of run: %q error: %s", run.ID, err)
+		screen.setMessage(fmt.Sprintf("Failed to read logs: %s", err))
+	}
+}
+
+func readKeys(in *os.File, keys chan<- byte) {
+	defer close(keys)
+	buf := make([]byte, 1)
+	for {
+		if _, err := in.Read(buf); err != nil {
+			return
+		}
+		keys <- buf[0]
+	}
+}
+
+func (c *WatchRunCommand) addRunDetails(run *tfe.Run) {
+	if run == nil {
+		return
+	}
+	runLink, _ := c.cloud.RunLink(c.appCtx, c.organization, run)
+	c.addRunLinks(run, runLink)
+	c.addOutput("run_id", run.ID)
+	c.addOutput("run_status", string(run.Status))
+	c.addRunDurations(run)
+	c.addRunDiagnostics(run)
+}
+
+func (c *WatchRunCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] run watch [options]
+
+	Watches a run until it has finished, primarily for operators debugging runs created by pipelines.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run      Existing HCP Terraform Run ID to watch.
+
+	-tui      Shows an interactive terminal UI with live status, phase timers, scrolling logs, and keybindings to apply, discard or cancel the run. Actions are taken when their key is pressed twice.
+
+	-comment  An optional comment when applying, discarding or cancelling the run from the terminal UI.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WatchRunCommand) Synopsis() string {
+	return "Watches a run until it has finished, optionally in an interactive terminal UI"
+}
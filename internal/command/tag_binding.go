@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// workspace or project flags shared by the tag binding commands
+type tagBindingTargetFlags struct {
+	Workspace string
+	Project   string
+}
+
+func (t *tagBindingTargetFlags) addFlags(f *flag.FlagSet) {
+	f.StringVar(&t.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.StringVar(&t.Project, "project", "", "The name of the HCP Terraform Project.")
+}
+
+func (t *tagBindingTargetFlags) target(organization string) (cloud.TagBindingTarget, error) {
+	if (t.Workspace == "") == (t.Project == "") {
+		return cloud.TagBindingTarget{}, fmt.Errorf("tag bindings require either a workspace or a project")
+	}
+	return cloud.TagBindingTarget{Organization: organization, Workspace: t.Workspace, Project: t.Project}, nil
+}
+
+func (t *tagBindingTargetFlags) name() string {
+	if t.Workspace != "" {
+		return fmt.Sprintf("workspace, '%s'", t.Workspace)
+	}
+	return fmt.Sprintf("project, '%s'", t.Project)
+}
+
+// parses key=value tag bindings, the value may be empty
+func parseTagBindings(tags []string) ([]*cloud.TagBindingDetails, error) {
+	bindings := make([]*cloud.TagBindingDetails, 0, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid tag binding %q, must be formatted as key=value", tag)
+		}
+		bindings = append(bindings, &cloud.TagBindingDetails{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+	return bindings, nil
+}
+
+type TagBindingListCommand struct {
+	*Meta
+	tagBindingTargetFlags
+
+	Effective bool
+	Require   []string
+}
+
+func (c *TagBindingListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("tagbinding list")
+	c.addFlags(f)
+	f.BoolVar(&c.Effective, "effective", false, "Includes the tag bindings a workspace inherits from its project.")
+	f.Var((*flagStringSlice)(&c.Require), "require", "Tag binding key that must be set. This option accepts multiple values.")
+	return f
+}
+
+func (c *TagBindingListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	target, err := c.target(c.organization)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	bindings, err := c.cloud.ListTagBindings(c.appCtx, target, c.Effective)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing tag bindings of %s in HCP Terraform: %s", c.name(), err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("tag_binding_count", fmt.Sprintf("%d", len(bindings)))
+	if len(c.Require) > 0 {
+		set := map[string]bool{}
+		for _, b := range bindings {
+			set[b.Key] = true
+		}
+		missing := []string{}
+		for _, key := range c.Require {
+			if !set[key] {
+				missing = append(missing, key)
+			}
+		}
+		c.addOutput("missing_tags", strings.Join(missing, ","))
+		if len(missing) > 0 {
+			c.softFailure(fmt.Sprintf("%s is missing required tag bindings: %s", c.name(), strings.Join(missing, ", ")))
+		}
+	}
+	c.addOutputWithOpts("tag_bindings", bindings, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"key", "value", "inherited"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TagBindingListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] tagbinding list [options]
+
+	Lists the key/value tag bindings of a workspace or project.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  Existing HCP Terraform Workspace.
+
+	-project    Existing HCP Terraform Project.
+
+	-effective  Includes the tag bindings a workspace inherits from its project, marked as inherited.
+
+	-require    Tag binding key that must be set, missing keys are reported as a warning that fails the command with --strict. This option accepts multiple values.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TagBindingListCommand) Synopsis() string {
+	return "Lists the tag bindings of a workspace or project"
+}
+
+type TagBindingSetCommand struct {
+	*Meta
+	tagBindingTargetFlags
+
+	Tags []string
+}
+
+func (c *TagBindingSetCommand) flags() *flag.FlagSet {
+	f := c.flagSet("tagbinding set")
+	c.addFlags(f)
+	f.Var((*flagStringSlice)(&c.Tags), "tag", "Tag binding formatted as key=value. This option accepts multiple values.")
+	return f
+}
+
+func (c *TagBindingSetCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	target, err := c.target(c.organization)
+	if err == nil && len(c.Tags) == 0 {
+		err = fmt.Errorf("setting tag bindings requires at least one tag")
+	}
+	var bindings []*cloud.TagBindingDetails
+	if err == nil {
+		bindings, err = parseTagBindings(c.Tags)
+	}
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	updated, err := c.cloud.AddTagBindings(c.appCtx, target, bindings)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error setting tag bindings of %s in HCP Terraform: %s", c.name(), err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("tag_binding_count", fmt.Sprintf("%d", len(updated)))
+	c.addOutputWithOpts("tag_bindings", updated, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"key", "value", "inherited"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TagBindingSetCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] tagbinding set [options]
+
+	Sets key/value tag bindings on a workspace or project. Existing keys are updated with the new value,
+	and tag bindings that are not set are left unchanged.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  Existing HCP Terraform Workspace.
+
+	-project    Existing HCP Terraform Project.
+
+	-tag        Tag binding formatted as key=value. This option accepts multiple values.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TagBindingSetCommand) Synopsis() string {
+	return "Sets tag bindings on a workspace or project"
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testTagBindingService struct {
+	cloud.TagBindingService
+	target    cloud.TagBindingTarget
+	effective bool
+	added     []*cloud.TagBindingDetails
+}
+
+func (s *testTagBindingService) ListTagBindings(_ context.Context, target cloud.TagBindingTarget, effective bool) ([]*cloud.TagBindingDetails, error) {
+	s.target = target
+	s.effective = effective
+	return []*cloud.TagBindingDetails{
+		{Key: "cost-center", Value: "1234", Inherited: true},
+		{Key: "team", Value: "platform"},
+	}, nil
+}
+
+func (s *testTagBindingService) AddTagBindings(_ context.Context, target cloud.TagBindingTarget, bindings []*cloud.TagBindingDetails) ([]*cloud.TagBindingDetails, error) {
+	s.target = target
+	s.added = bindings
+	return bindings, nil
+}
+
+func testTagBindingCommandMeta(tags cloud.TagBindingService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.TagBindingService = tags
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestTagBindingListCommand(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedCode  int
+		expectedOut   []string
+		expectedError string
+	}{
+		{
+			name:         "effective",
+			args:         []string{"-workspace=networking", "-effective"},
+			expectedCode: 0,
+			expectedOut:  []string{`"tag_binding_count": "2"`, `"inherited": true`},
+		},
+		{
+			name:         "required-tags-set",
+			args:         []string{"-project=payments", "-require=team", "-require=cost-center"},
+			expectedCode: 0,
+			expectedOut:  []string{`"missing_tags": ""`},
+		},
+		{
+			name:          "required-tags-missing",
+			args:          []string{"-workspace=networking", "-require=team", "-require=owner"},
+			expectedCode:  0,
+			expectedOut:   []string{`"missing_tags": "owner"`},
+			expectedError: "missing required tag bindings: owner",
+		},
+		{
+			name:         "missing-target",
+			args:         []string{},
+			expectedCode: 1,
+		},
+		{
+			name:         "workspace-and-project",
+			args:         []string{"-workspace=networking", "-project=payments"},
+			expectedCode: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testTagBindingCommandMeta(&testTagBindingService{})
+			cmd := &TagBindingListCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			if !strings.Contains(ui.ErrorWriter.String(), tc.expectedError) {
+				t.Errorf("expected error to contain %q but received %s", tc.expectedError, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
+
+func TestTagBindingSetCommand(t *testing.T) {
+	tags := &testTagBindingService{}
+	ui, meta := testTagBindingCommandMeta(tags)
+	cmd := &TagBindingSetCommand{Meta: meta}
+	if code := cmd.Run([]string{"-project=payments", "-tag=team=platform", "-tag=cost-center = 1234", "-tag=pci"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	expected := []*cloud.TagBindingDetails{
+		{Key: "team", Value: "platform"},
+		{Key: "cost-center", Value: "1234"},
+		{Key: "pci", Value: ""},
+	}
+	if !reflect.DeepEqual(tags.added, expected) {
+		t.Errorf("expected tag bindings %+v, got %+v", expected, tags.added)
+	}
+	if tags.target.Project != "payments" || tags.target.Organization != "abc-company" {
+		t.Errorf("unexpected target %+v", tags.target)
+	}
+
+	for _, args := range [][]string{
+		{"-workspace=networking"},
+		{"-workspace=networking", "-tag==platform"},
+	} {
+		ui, meta := testTagBindingCommandMeta(&testTagBindingService{})
+		cmd := &TagBindingSetCommand{Meta: meta}
+		if code := cmd.Run(args); code != 1 {
+			t.Errorf("%v: expected exit code 1 but received %d: %s", args, code, ui.OutputWriter.String())
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+var taskStageNames = []tfe.Stage{tfe.PrePlan, tfe.PostPlan, tfe.PreApply, tfe.PostApply}
+
+type TaskStageShowCommand struct {
+	*Meta
+
+	RunID string
+	Stage string
+}
+
+func (c *TaskStageShowCommand) flags() *flag.FlagSet {
+	f := c.flagSet("taskstage show")
+	f.StringVar(&c.RunID, "run", "", "Existing HCP Terraform Run ID to show task stages of.")
+	f.StringVar(&c.Stage, "stage", "", "Only show the given stage: pre_plan, post_plan, pre_apply or post_apply.")
+	return f
+}
+
+func (c *TaskStageShowCommand) validStage() bool {
+	if c.Stage == "" {
+		return true
+	}
+	for _, stage := range taskStageNames {
+		if c.Stage == string(stage) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *TaskStageShowCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.RunID == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("showing task stages requires a run id")
+		return 1
+	}
+
+	if !c.validStage() {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("invalid stage '%s', expected one of: pre_plan, post_plan, pre_apply, post_apply", c.Stage))
+		return 1
+	}
+
+	stages, err := c.cloud.ReadTaskStages(c.appCtx, c.RunID, tfe.Stage(c.Stage))
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error reading task stages of run, '%s' in HCP Terraform: %s", c.RunID, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	failed := 0
+	for _, stage := range stages {
+		for _, result := range stage.TaskResults {
+			switch tfe.TaskResultStatus(result.Status) {
+			case tfe.TaskFailed, tfe.TaskErrored, tfe.TaskUnreachable:
+				failed++
+			}
+		}
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("run_id", c.RunID)
+	c.addOutput("task_stage_count", fmt.Sprintf("%d", len(stages)))
+	c.addOutput("failed_task_count", fmt.Sprintf("%d", failed))
+	c.addOutputWithOpts("task_stages", stages, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TaskStageShowCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] taskstage show [options]
+
+	Shows the task stages of a run with their run task results and policy evaluations.
+
+` + globalOptionsHelp + `
+Options:
+
+	-run    Existing HCP Terraform Run ID to show task stages of.
+
+	-stage  Only show the given stage: pre_plan, post_plan, pre_apply or post_apply.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TaskStageShowCommand) Synopsis() string {
+	return "Shows the task stages and run task results of a run"
+}
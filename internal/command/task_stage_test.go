@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testTaskStageRunService struct {
+	cloud.RunService
+	stages []*cloud.TaskStageDetails
+	stage  tfe.Stage
+}
+
+func (s *testTaskStageRunService) ReadTaskStages(_ context.Context, _ string, stage tfe.Stage) ([]*cloud.TaskStageDetails, error) {
+	s.stage = stage
+	return s.stages, nil
+}
+
+func testTaskStageShowCommand(runs cloud.RunService) (*cli.MockUi, *TaskStageShowCommand) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.RunService = runs
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &TaskStageShowCommand{Meta: meta}
+}
+
+func TestTaskStageShowCommand(t *testing.T) {
+	runs := &testTaskStageRunService{stages: []*cloud.TaskStageDetails{{
+		ID:     "ts-1",
+		Stage:  "post_plan",
+		Status: "failed",
+		TaskResults: []*cloud.TaskResultDetails{
+			{ID: "taskrs-1", TaskName: "security-scanner", Status: "failed", EnforcementLevel: "mandatory"},
+			{ID: "taskrs-2", TaskName: "cost-check", Status: "passed", EnforcementLevel: "advisory"},
+		},
+	}}}
+
+	ui, cmd := testTaskStageShowCommand(runs)
+	if code := cmd.Run([]string{"-run=run-1", "-stage=post_plan"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.stage != tfe.PostPlan {
+		t.Errorf("expected stage post_plan to be requested but received %q", runs.stage)
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"task_stage_count": "1"`, `"failed_task_count": "1"`, `"task_name": "security-scanner"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestTaskStageShowCommand_InvalidStage(t *testing.T) {
+	ui, cmd := testTaskStageShowCommand(&testTaskStageRunService{})
+	if code := cmd.Run([]string{"-run=run-1", "-stage=after_apply"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "invalid stage 'after_apply'") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
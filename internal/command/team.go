@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type TeamListCommand struct {
+	*Meta
+}
+
+func (c *TeamListCommand) flags() *flag.FlagSet {
+	return c.flagSet("team list")
+}
+
+func (c *TeamListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	teams, err := c.cloud.ListTeams(c.appCtx, c.organization)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing teams in HCP Terraform: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("team_count", fmt.Sprintf("%d", len(teams)))
+	c.addOutputWithOpts("teams", teams, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"id", "name", "visibility", "user_count"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TeamListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] team list
+
+	Lists the teams of the organization.
+
+` + globalOptionsHelp
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TeamListCommand) Synopsis() string {
+	return "Lists the teams of the organization"
+}
+
+type TeamCreateCommand struct {
+	*Meta
+
+	Name       string
+	Visibility string
+}
+
+func (c *TeamCreateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("team create")
+	f.StringVar(&c.Name, "name", "", "Name of the team.")
+	f.StringVar(&c.Visibility, "visibility", "", "Visibility of the team: secret or organization.")
+	return f
+}
+
+func (c *TeamCreateCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Name == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("creating a team requires a name")
+		return 1
+	}
+	if c.Visibility != "" && c.Visibility != "secret" && c.Visibility != "organization" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("unsupported team visibility %q, must be one of: secret, organization", c.Visibility))
+		return 1
+	}
+
+	team, err := c.cloud.CreateTeam(c.appCtx, c.organization, c.Name, c.Visibility)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error creating team, '%s' in HCP Terraform: %s", c.Name, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("team_id", team.ID)
+	c.addOutput("team_name", team.Name)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TeamCreateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] team create [options]
+
+	Creates a team in the organization.
+
+` + globalOptionsHelp + `
+Options:
+
+	-name        Name of the team.
+
+	-visibility  Visibility of the team: secret or organization. Defaults to the organization setting.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TeamCreateCommand) Synopsis() string {
+	return "Creates a team in the organization"
+}
+
+func parseAccessType(access string) (tfe.AccessType, error) {
+	switch tfe.AccessType(access) {
+	case tfe.AccessRead, tfe.AccessPlan, tfe.AccessWrite, tfe.AccessAdmin:
+		return tfe.AccessType(access), nil
+	default:
+		return "", fmt.Errorf("unsupported access %q, must be one of: read, plan, write, admin", access)
+	}
+}
+
+type TeamAccessAddCommand struct {
+	*Meta
+
+	Team      string
+	Workspace string
+	Access    string
+}
+
+func (c *TeamAccessAddCommand) flags() *flag.FlagSet {
+	f := c.flagSet("team access add")
+	f.StringVar(&c.Team, "team", "", "Name of the team to grant access to.")
+	f.StringVar(&c.Workspace, "workspace", "", "Name of the workspace the team is granted access to.")
+	f.StringVar(&c.Access, "access", "", "Access granted to the team: read, plan, write or admin.")
+	return f
+}
+
+func (c *TeamAccessAddCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Team == "" || c.Workspace == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("adding team access requires a team and workspace")
+		return 1
+	}
+	access, err := parseAccessType(c.Access)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	teamAccess, err := c.cloud.AddTeamAccess(c.appCtx, cloud.TeamAccessOptions{
+		Organization: c.organization,
+		Team:         c.Team,
+		Workspace:    c.Workspace,
+		Access:       access,
+	})
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error adding team, '%s' access to workspace, '%s' in HCP Terraform: %s", c.Team, c.Workspace, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("team_access_id", teamAccess.ID)
+	c.addOutput("access", string(teamAccess.Access))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TeamAccessAddCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] team access add [options]
+
+	Grants a team access to a workspace. When the team already has access, its access is updated.
+
+` + globalOptionsHelp + `
+Options:
+
+	-team       Name of the team to grant access to.
+
+	-workspace  Name of the workspace the team is granted access to.
+
+	-access     Access granted to the team: read, plan, write or admin.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TeamAccessAddCommand) Synopsis() string {
+	return "Grants a team access to a workspace"
+}
+
+type TeamTokenRegenerateCommand struct {
+	*Meta
+
+	Team      string
+	TokenFile string
+}
+
+func (c *TeamTokenRegenerateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("team token regenerate")
+	f.StringVar(&c.Team, "team", "", "Name of the team to regenerate the token of.")
+	f.StringVar(&c.TokenFile, "token-file", "", "Writes the secret token value to the provided file path, readable only by the current user.")
+	return f
+}
+
+func (c *TeamTokenRegenerateCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Team == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("regenerating a team token requires a team")
+		return 1
+	}
+
+	token, err := c.cloud.RegenerateTeamToken(c.appCtx, c.organization, c.Team)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error regenerating token of team, '%s' in HCP Terraform: %s", c.Team, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if c.TokenFile != "" {
+		if err := os.WriteFile(c.TokenFile, []byte(token.Token), 0600); err != nil {
+			status := c.resolveStatus(err)
+			c.addOutput("status", string(status))
+			c.addOutput("team_token_id", token.ID)
+			c.writer.ErrorResult(fmt.Sprintf("error writing team token to file, '%s': %s", c.TokenFile, err.Error()))
+			c.writer.OutputResult(c.closeOutput())
+			return 1
+		}
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("team_token_id", token.ID)
+	// the secret is only passed to the platform outputs, never printed to stdout
+	c.addOutputWithOpts("team_token", token.Token, &outputOpts{
+		stdOut:      false,
+		multiLine:   false,
+		platformOut: true,
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TeamTokenRegenerateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] team token regenerate [options]
+
+	Regenerates the token of a team, the previous token stops working. The secret token value is not printed,
+	it is passed to the "team_token" platform output, and written to -token-file when provided.
+
+` + globalOptionsHelp + `
+Options:
+
+	-team        Name of the team to regenerate the token of.
+
+	-token-file  Writes the secret token value to the provided file path, readable only by the current user.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TeamTokenRegenerateCommand) Synopsis() string {
+	return "Regenerates the token of a team"
+}
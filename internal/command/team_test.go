@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testTeamService struct {
+	cloud.TeamService
+	accessOptions cloud.TeamAccessOptions
+}
+
+func (s *testTeamService) ListTeams(context.Context, string) ([]*cloud.TeamDetails, error) {
+	return []*cloud.TeamDetails{{ID: "team-1", Name: "platform", Visibility: "organization", UserCount: 4}}, nil
+}
+
+func (s *testTeamService) CreateTeam(_ context.Context, _, name, visibility string) (*cloud.TeamDetails, error) {
+	return &cloud.TeamDetails{ID: "team-2", Name: name, Visibility: visibility}, nil
+}
+
+func (s *testTeamService) AddTeamAccess(_ context.Context, options cloud.TeamAccessOptions) (*tfe.TeamAccess, error) {
+	s.accessOptions = options
+	return &tfe.TeamAccess{ID: "tws-1", Access: options.Access}, nil
+}
+
+func (s *testTeamService) RegenerateTeamToken(context.Context, string, string) (*tfe.TeamToken, error) {
+	return &tfe.TeamToken{ID: "at-1", Token: "secret-team-token"}, nil
+}
+
+func testTeamCommandMeta(teams cloud.TeamService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.TeamService = teams
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestTeamListCommand(t *testing.T) {
+	ui, meta := testTeamCommandMeta(&testTeamService{})
+	cmd := &TeamListCommand{Meta: meta}
+	if code := cmd.Run([]string{}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"team_count": "1"`, `"user_count": 4`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestTeamCreateCommand(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		code int
+	}{
+		"creates a team":     {args: []string{"-name=platform", "-visibility=secret"}, code: 0},
+		"requires a name":    {args: []string{}, code: 1},
+		"invalid visibility": {args: []string{"-name=platform", "-visibility=public"}, code: 1},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui, meta := testTeamCommandMeta(&testTeamService{})
+			cmd := &TeamCreateCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.code {
+				t.Errorf("expected exit code %d but received %d: %s", tc.code, code, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
+
+func TestTeamAccessAddCommand(t *testing.T) {
+	teams := &testTeamService{}
+	ui, meta := testTeamCommandMeta(teams)
+	cmd := &TeamAccessAddCommand{Meta: meta}
+	if code := cmd.Run([]string{"-team=platform", "-workspace=networking", "-access=write"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	if teams.accessOptions.Organization != "abc-company" || teams.accessOptions.Access != tfe.AccessWrite {
+		t.Errorf("unexpected team access options %+v", teams.accessOptions)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"access": "write"`) {
+		t.Errorf("expected access in output but received %s", ui.OutputWriter.String())
+	}
+
+	ui, meta = testTeamCommandMeta(&testTeamService{})
+	cmd = &TeamAccessAddCommand{Meta: meta}
+	if code := cmd.Run([]string{"-team=platform", "-workspace=networking", "-access=owner"}); code != 1 {
+		t.Errorf("expected exit code 1 for an invalid access but received %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "unsupported access") {
+		t.Errorf("unexpected error %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestTeamTokenRegenerateCommand(t *testing.T) {
+	ui, meta := testTeamCommandMeta(&testTeamService{})
+	cmd := &TeamTokenRegenerateCommand{Meta: meta}
+	if code := cmd.Run([]string{"-team=platform"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, `"team_token_id": "at-1"`) {
+		t.Errorf("expected team token id in output but received %s", output)
+	}
+	if strings.Contains(output, "secret-team-token") {
+		t.Errorf("expected the secret token to not be printed but received %s", output)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// an interactive terminal, reading key presses without waiting for a line ending
+type terminal struct {
+	// stty settings restored on close
+	saved string
+}
+
+// configures stdin to read key presses without echoing them, using stty so no platform specific
+// system calls are needed
+func openTerminal() (*terminal, error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, errors.New("the terminal UI requires an interactive unix terminal")
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("unable to configure the terminal: %w", err)
+	}
+	fmt.Fprint(os.Stdout, ansiHideCursor)
+	return &terminal{saved: strings.TrimSpace(saved)}, nil
+}
+
+// the number of columns and rows of the terminal
+func (t *terminal) size() (int, int) {
+	out, err := stty("size")
+	if err != nil {
+		return 80, 24
+	}
+	var rows, cols int
+	if _, err := fmt.Sscan(out, &rows, &cols); err != nil || rows == 0 || cols == 0 {
+		return 80, 24
+	}
+	return cols, rows
+}
+
+func (t *terminal) draw(screen string) {
+	fmt.Fprint(os.Stdout, ansiClear+screen)
+}
+
+func (t *terminal) close() {
+	fmt.Fprint(os.Stdout, ansiShowCursor+"\r\n")
+	if _, err := stty(t.saved); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to restore the terminal, run `stty sane`: %s\n", err)
+	}
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
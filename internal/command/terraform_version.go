@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+type TerraformVersionListCommand struct {
+	*Meta
+
+	Search string
+}
+
+func (c *TerraformVersionListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("terraform-versions list")
+	f.StringVar(&c.Search, "search", "", "Only lists Terraform versions containing the search string, such as 1.9.")
+	return f
+}
+
+func (c *TerraformVersionListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	versions, err := c.cloud.ListAvailableTerraformVersions(c.appCtx, c.Search)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing available Terraform versions: %s", err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("terraform_version_count", fmt.Sprintf("%d", len(versions)))
+	if len(versions) > 0 {
+		c.addOutput("latest_version", versions[0].Version)
+	}
+	c.addOutputWithOpts("terraform_versions", versions, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"version", "enabled", "deprecated", "official"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *TerraformVersionListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] terraform-versions list [options]
+
+	Lists the Terraform versions workspaces can select. HCP Terraform offers every Terraform release,
+	Terraform Enterprise versions are read from the admin API and require a site admin token.
+
+` + globalOptionsHelp + `
+Options:
+
+	-search  Only lists Terraform versions containing the search string, such as 1.9.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *TerraformVersionListCommand) Synopsis() string {
+	return "Lists the available Terraform versions"
+}
+
+type WorkspaceSetVersionCommand struct {
+	*Meta
+
+	Workspace string
+	Version   string
+}
+
+func (c *WorkspaceSetVersionCommand) flags() *flag.FlagSet {
+	f := c.flagSet("workspace set-version")
+	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.StringVar(&c.Version, "version", "", "Terraform version or version constraint of the workspace, such as 1.9.5 or ~> 1.9.0.")
+	return f
+}
+
+func (c *WorkspaceSetVersionCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Workspace == "" || c.Version == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("setting the Terraform version requires a workspace and version")
+		return 1
+	}
+
+	change, err := c.cloud.SetWorkspaceTerraformVersion(c.appCtx, c.organization, c.Workspace, c.Version)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error setting Terraform version of workspace, '%s' in HCP Terraform: %s", c.Workspace, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if change.Deprecated {
+		c.softFailure(fmt.Sprintf("Terraform version %s is deprecated", change.Version))
+	}
+	c.addOutput("status", string(Success))
+	c.addOutput("previous_version", change.PreviousVersion)
+	c.addOutput("terraform_version", change.Version)
+	c.addOutput("changed", fmt.Sprintf("%t", change.PreviousVersion != change.Version))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *WorkspaceSetVersionCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] workspace set-version [options]
+
+	Sets the Terraform version of a workspace. Exact versions are validated to be available before the
+	workspace is updated, deprecated versions are reported as a warning that fails the command with --strict.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  Existing HCP Terraform Workspace.
+
+	-version    Terraform version or version constraint of the workspace, such as 1.9.5 or ~> 1.9.0.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceSetVersionCommand) Synopsis() string {
+	return "Sets the Terraform version of a workspace"
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testTerraformVersionService struct {
+	cloud.TerraformVersionService
+	deprecated bool
+}
+
+func (s *testTerraformVersionService) ListAvailableTerraformVersions(context.Context, string) ([]*cloud.TerraformVersionDetails, error) {
+	return []*cloud.TerraformVersionDetails{{Version: "1.9.5", Enabled: true}, {Version: "1.9.4", Enabled: true}}, nil
+}
+
+func (s *testTerraformVersionService) SetWorkspaceTerraformVersion(_ context.Context, _, workspace, version string) (*cloud.WorkspaceVersionChange, error) {
+	if version == "1.99.0" {
+		return nil, fmt.Errorf("%w: %s", cloud.ErrTerraformVersionUnavailable, version)
+	}
+	return &cloud.WorkspaceVersionChange{Workspace: workspace, PreviousVersion: "1.5.7", Version: version, Deprecated: s.deprecated}, nil
+}
+
+func testTerraformVersionCommandMeta(versions cloud.TerraformVersionService) (*cli.MockUi, *Meta) {
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.TerraformVersionService = versions
+	return ui, NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+}
+
+func TestTerraformVersionListCommand(t *testing.T) {
+	ui, meta := testTerraformVersionCommandMeta(&testTerraformVersionService{})
+	cmd := &TerraformVersionListCommand{Meta: meta}
+	if code := cmd.Run([]string{"-search=1.9"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"terraform_version_count": "2"`, `"latest_version": "1.9.5"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+func TestWorkspaceSetVersionCommand(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		deprecated    bool
+		expectedCode  int
+		expectedOut   []string
+		expectedError string
+	}{
+		{
+			name:         "set-version",
+			args:         []string{"-workspace=networking", "-version=1.9.5"},
+			expectedCode: 0,
+			expectedOut:  []string{`"previous_version": "1.5.7"`, `"terraform_version": "1.9.5"`, `"changed": "true"`},
+		},
+		{
+			name:          "deprecated-version",
+			args:          []string{"-workspace=networking", "-version=1.6.0"},
+			deprecated:    true,
+			expectedCode:  0,
+			expectedError: "Terraform version 1.6.0 is deprecated",
+		},
+		{
+			name:          "unavailable-version",
+			args:          []string{"-workspace=networking", "-version=1.99.0"},
+			expectedCode:  1,
+			expectedError: "terraform version is not available",
+		},
+		{
+			name:         "missing-version",
+			args:         []string{"-workspace=networking"},
+			expectedCode: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, meta := testTerraformVersionCommandMeta(&testTerraformVersionService{deprecated: tc.deprecated})
+			cmd := &WorkspaceSetVersionCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			if !strings.Contains(ui.ErrorWriter.String(), tc.expectedError) {
+				t.Errorf("expected error to contain %q but received %s", tc.expectedError, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"log"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/tfci/internal/cloud"
@@ -20,6 +21,7 @@ type UploadConfigurationCommand struct {
 	Directory   string
 	Speculative bool
 	Provisional bool
+	SlugCache   string
 }
 
 func (c *UploadConfigurationCommand) flags() *flag.FlagSet {
@@ -29,6 +31,7 @@ func (c *UploadConfigurationCommand) flags() *flag.FlagSet {
 	f.StringVar(&c.Directory, "directory", "", "Path to the configuration files on disk.")
 	f.BoolVar(&c.Speculative, "speculative", false, "When true, this configuration version may only be used to create runs which are speculative, that is, can neither be confirmed nor applied.")
 	f.BoolVar(&c.Provisional, "provisional", false, "When true, this configuration version does not immediately become the workspace's current configuration until a run referencing it is ultimately applied.")
+	f.StringVar(&c.SlugCache, "slug-cache", "", "Path to a file caching the hash of previously uploaded configurations. An unchanged configuration reuses the previous configuration version instead of uploading again.")
 	return f
 }
 
@@ -49,13 +52,16 @@ func (c *UploadConfigurationCommand) Run(args []string) int {
 
 	log.Printf("[DEBUG] target directory for configuration upload: %s", dirPath)
 
+	start := time.Now()
 	configVersion, cvError := c.cloud.UploadConfig(c.appCtx, cloud.UploadOptions{
 		Workspace:              c.Workspace,
 		Organization:           c.organization,
 		ConfigurationDirectory: dirPath,
 		Speculative:            c.Speculative,
 		Provisional:            c.Provisional,
+		SlugCacheFile:          c.SlugCache,
 	})
+	c.addDuration(durationUpload, time.Since(start))
 
 	if cvError != nil {
 		status := c.resolveStatus(cvError)
@@ -82,6 +88,7 @@ func (c *UploadConfigurationCommand) addConfigurationDetails(config *tfe.Configu
 		stdOut:      false,
 		multiLine:   true,
 		platformOut: true,
+		artifact:    "configuration_version.json",
 	})
 }
 
@@ -91,14 +98,7 @@ Usage: tfci [global options] upload [options]
 
 	Creates and uploads a new configuration version for the provided workspace.
 
-Global Options:
-
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
-
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
-
-	-organization   HCP Terraform Organization Name.
-
+` + globalOptionsHelp + `
 Options:
 
 	-workspace      The name of the HCP Terraform Workspace to create and upload the terraform configuration version in.
@@ -108,6 +108,8 @@ Options:
 	-speculative    When true, this configuration version may only be used to create runs which are speculative, that is, can neither be confirmed nor applied.
 
 	-provisional    When true, this configuration version does not immediately become the workspace's current configuration until a run referencing it is ultimately applied.
+
+	-slug-cache     Path to a file caching the hash of previously uploaded configurations. When the configuration is unchanged, the previous configuration version is reused instead of uploading again.
 	`
 	return strings.TrimSpace(helpText)
 }
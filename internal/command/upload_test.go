@@ -15,6 +15,7 @@ import (
 )
 
 type SuccessfulUploader struct {
+	cloud.ConfigVersionService
 	configurationVersion *tfe.ConfigurationVersion
 }
 
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/environment"
+)
+
+type checkStatus string
+
+const (
+	checkPass checkStatus = "pass"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+	checkSkip checkStatus = "skip"
+)
+
+type validationCheck struct {
+	Name        string      `json:"name"`
+	Status      checkStatus `json:"status"`
+	Message     string      `json:"message"`
+	Remediation string      `json:"remediation,omitempty"`
+}
+
+type ValidateCommand struct {
+	*Meta
+
+	Workspace string
+	Directory string
+}
+
+func (c *ValidateCommand) flags() *flag.FlagSet {
+	f := c.flagSet("validate")
+	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace to check access to.")
+	f.StringVar(&c.Directory, "directory", "", "Path to the configuration files on disk to check.")
+	return f
+}
+
+func (c *ValidateCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	checks := []*validationCheck{
+		c.checkPlatform(),
+		c.checkHostname(),
+	}
+	token := c.checkToken()
+	checks = append(checks, token)
+
+	org := c.checkOrganization(token.Status == checkPass)
+	checks = append(checks, org, c.checkWorkspace(org.Status == checkPass), c.checkDirectory())
+
+	failed := 0
+	for _, check := range checks {
+		msg := fmt.Sprintf("[%s] %s: %s", check.Status, check.Name, check.Message)
+		switch check.Status {
+		case checkFail:
+			failed++
+			c.writer.Error(fmt.Sprintf("%s\n  remediation: %s", msg, check.Remediation))
+		case checkWarn:
+			c.writer.Error(fmt.Sprintf("%s\n  remediation: %s", msg, check.Remediation))
+		default:
+			c.writer.Output(msg)
+		}
+	}
+
+	c.addOutputWithOpts("checks", checks, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+	})
+
+	if failed > 0 {
+		c.addOutput("status", string(Error))
+		c.writer.ErrorResult(fmt.Sprintf("validation failed, %d of %d checks did not pass", failed, len(checks)))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *ValidateCommand) checkPlatform() *validationCheck {
+	check := &validationCheck{Name: "platform"}
+	if c.env == nil || c.env.Context == nil || c.env.PlatformType == environment.Other {
+		check.Status = checkWarn
+		check.Message = "no supported CI platform detected, outputs will only be written to stdout"
+		check.Remediation = "run within a supported CI platform or set the TFCI_CONTEXT_* environment variables"
+		return check
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("detected %s", c.env.PlatformType)
+	return check
+}
+
+// the client pings the instance on initialization, so the command only runs when the hostname is reachable
+func (c *ValidateCommand) checkHostname() *validationCheck {
+	check := &validationCheck{Name: "hostname", Status: checkPass, Message: "reachable"}
+	caps := c.cloud.Capabilities()
+	switch {
+	case caps == nil:
+	case caps.IsCloud:
+		check.Message = fmt.Sprintf("reachable, HCP Terraform, API version: %s", caps.APIVersion)
+	case caps.TFEVersion != "":
+		check.Message = fmt.Sprintf("reachable, Terraform Enterprise %s, API version: %s", caps.TFEVersion, caps.APIVersion)
+	}
+	return check
+}
+
+func (c *ValidateCommand) checkToken() *validationCheck {
+	check := &validationCheck{Name: "token"}
+	user, err := c.cloud.ReadCurrentUser(c.appCtx)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		check.Remediation = "verify TF_API_TOKEN or --token is set to a valid user or team token"
+		if errors.Is(err, tfe.ErrResourceNotFound) {
+			check.Remediation = "organization tokens cannot be used, use a user or team token instead"
+		}
+		return check
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("authenticated as %q", user.Username)
+	return check
+}
+
+func (c *ValidateCommand) checkOrganization(tokenValid bool) *validationCheck {
+	check := &validationCheck{Name: "organization"}
+	switch {
+	case c.organization == "":
+		check.Status = checkFail
+		check.Message = "organization is not set"
+		check.Remediation = "set TF_CLOUD_ORGANIZATION or --organization"
+		return check
+	case !tokenValid:
+		check.Status = checkSkip
+		check.Message = "skipped, token is not valid"
+		return check
+	}
+
+	if _, err := c.cloud.ReadEntitlements(c.appCtx, c.organization); err != nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("unable to read organization %q: %s", c.organization, err.Error())
+		check.Remediation = "verify the organization name and that the token belongs to a member of the organization"
+		return check
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("organization %q exists", c.organization)
+	return check
+}
+
+func (c *ValidateCommand) checkWorkspace(orgValid bool) *validationCheck {
+	check := &validationCheck{Name: "workspace"}
+	switch {
+	case c.Workspace == "":
+		check.Status = checkSkip
+		check.Message = "skipped, no workspace provided"
+		return check
+	case !orgValid:
+		check.Status = checkSkip
+		check.Message = "skipped, organization is not valid"
+		return check
+	}
+
+	permissions, err := c.cloud.ReadWorkspacePermissions(c.appCtx, c.organization, c.Workspace)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("unable to read workspace %q: %s", c.Workspace, err.Error())
+		check.Remediation = "verify the workspace name and that the token has at least read access to the workspace"
+		return check
+	}
+	if !permissions["can-queue-run"] {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("workspace %q exists, but the token cannot queue runs", c.Workspace)
+		check.Remediation = "grant the team plan or write access to the workspace"
+		return check
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("workspace %q is accessible", c.Workspace)
+	return check
+}
+
+func (c *ValidateCommand) checkDirectory() *validationCheck {
+	check := &validationCheck{Name: "directory"}
+	if c.Directory == "" {
+		check.Status = checkSkip
+		check.Message = "skipped, no directory provided"
+		return check
+	}
+
+	dirPath, err := filepath.Abs(c.Directory)
+	if err == nil {
+		err = hasConfigurationFiles(dirPath)
+	}
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		check.Remediation = "verify -directory points to the root of the Terraform configuration, relative to the working directory"
+		return check
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("%s contains Terraform configuration files", dirPath)
+	return check
+}
+
+func hasConfigurationFiles(dirPath string) error {
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dirPath)
+	}
+
+	found := errors.New("found")
+	walkErr := filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == ".terraform" || d.Name() == ".git") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && (strings.HasSuffix(d.Name(), ".tf") || strings.HasSuffix(d.Name(), ".tf.json")) {
+			return found
+		}
+		return nil
+	})
+	if walkErr == found {
+		return nil
+	}
+	if walkErr != nil {
+		return walkErr
+	}
+	return fmt.Errorf("no Terraform configuration files (.tf, .tf.json) found in %s", dirPath)
+}
+
+func (c *ValidateCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] validate [options]
+
+	Validates the execution context before running other commands: CI platform detection, hostname reachability, token, organization, workspace access and configuration directory.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  The name of the HCP Terraform Workspace to check access to.
+
+	-directory  Path to the configuration files on disk to check.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ValidateCommand) Synopsis() string {
+	return "Validates the token, organization, workspace and configuration before running other commands"
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+func testValidateCommand(t *testing.T, account *testAccountReader, org string) (*cli.MockUi, *ValidateCommand) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.AccountService = account
+
+	env := &environment.CI{PlatformType: environment.GitHub, Context: &testPlatformContext{}}
+	meta := NewMetaOpts(context.Background(), cloudMockService, env, WithWriter(writer), WithOrg(org))
+	return ui, &ValidateCommand{Meta: meta}
+}
+
+func TestValidateCommand(t *testing.T) {
+	configDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(configDir, "main.tf"), []byte(`terraform {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	emptyDir := t.TempDir()
+
+	testCases := []struct {
+		name         string
+		org          string
+		args         []string
+		account      *testAccountReader
+		expectedCode int
+		expectedOut  []string
+	}{
+		{
+			name: "valid",
+			org:  "abc-company",
+			args: []string{"-workspace=my-workspace", "-directory=" + configDir},
+			account: &testAccountReader{
+				user:        &tfe.User{Username: "octocat"},
+				permissions: map[string]bool{"can-queue-run": true},
+			},
+			expectedCode: 0,
+			expectedOut:  []string{`"status": "Success"`},
+		},
+		{
+			name: "invalid-token-skips-remote-checks",
+			org:  "abc-company",
+			args: []string{"-workspace=my-workspace"},
+			account: &testAccountReader{
+				userErr: tfe.ErrUnauthorized,
+			},
+			expectedCode: 1,
+			expectedOut:  []string{`"message": "unauthorized"`, `"message": "skipped, token is not valid"`, `"status": "Error"`},
+		},
+		{
+			name: "missing-organization-and-configuration",
+			args: []string{"-directory=" + emptyDir},
+			account: &testAccountReader{
+				user: &tfe.User{Username: "octocat"},
+			},
+			expectedCode: 1,
+			expectedOut:  []string{`"message": "organization is not set"`, `"message": "no Terraform configuration files`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, cmd := testValidateCommand(t, tc.account, tc.org)
+
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+		})
+	}
+}
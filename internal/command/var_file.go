@@ -4,15 +4,30 @@
 package command
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
 )
 
 const VarEnvPrefix = "TF_VAR_"
 
+// run variables with values that are not logged, taking precedence over a TF_VAR_ with the same name
+const SensitiveVarEnvPrefix = "TF_VAR_SENSITIVE_"
+
+// environment category variables set on the workspace before the run is created
+const EnvVarEnvPrefix = "TFCI_ENV_"
+
+// environment category variables created as sensitive, taking precedence over a TFCI_ENV_ with the same name
+const SensitiveEnvVarEnvPrefix = "TFCI_ENV_SENSITIVE_"
+
 func collectVariables() []*tfe.RunVariable {
 	var tfVars []*tfe.RunVariable
 	// get vars from env
@@ -25,6 +40,7 @@ func collectVariables() []*tfe.RunVariable {
 
 func collectEnvVariables() map[string]*tfe.RunVariable {
 	tfRunMap := make(map[string]*tfe.RunVariable)
+	sensitive := make(map[string]bool)
 
 	env := os.Environ()
 	for _, v := range env {
@@ -39,8 +55,17 @@ func collectEnvVariables() map[string]*tfe.RunVariable {
 		key := v[len(VarEnvPrefix):eq]
 		value := v[eq+1:]
 
-		log.Printf("[DEBUG] adding variable: '%s', with: '%s'", key, value)
+		isSensitive := strings.HasPrefix(v, SensitiveVarEnvPrefix) && eq > len(SensitiveVarEnvPrefix)
+		if isSensitive {
+			key = v[len(SensitiveVarEnvPrefix):eq]
+			log.Printf("[DEBUG] adding sensitive variable: '%s'", key)
+		} else if sensitive[key] {
+			continue
+		} else {
+			log.Printf("[DEBUG] adding variable: '%s', with: '%s'", key, value)
+		}
 
+		sensitive[key] = isSensitive
 		tfRunMap[key] = &tfe.RunVariable{
 			Key:   key,
 			Value: value,
@@ -48,3 +73,124 @@ func collectEnvVariables() map[string]*tfe.RunVariable {
 	}
 	return tfRunMap
 }
+
+// collects environment category variables from TFCI_ENV_ environment variables,
+// and -env-var flags formatted as KEY=VALUE which take precedence
+func collectEnvCategoryVariables(flagVars []string) ([]*cloud.EnvVariable, error) {
+	envVars := make(map[string]*cloud.EnvVariable)
+
+	for _, v := range os.Environ() {
+		if !strings.HasPrefix(v, EnvVarEnvPrefix) {
+			continue
+		}
+		eq := strings.Index(v, "=")
+		if eq <= len(EnvVarEnvPrefix) {
+			continue
+		}
+
+		key := v[len(EnvVarEnvPrefix):eq]
+		isSensitive := strings.HasPrefix(v, SensitiveEnvVarEnvPrefix) && eq > len(SensitiveEnvVarEnvPrefix)
+		if isSensitive {
+			key = v[len(SensitiveEnvVarEnvPrefix):eq]
+			log.Printf("[DEBUG] adding sensitive environment variable: '%s'", key)
+		} else if existing, ok := envVars[key]; ok && existing.Sensitive {
+			continue
+		} else {
+			log.Printf("[DEBUG] adding environment variable: '%s', with: '%s'", key, v[eq+1:])
+		}
+		envVars[key] = &cloud.EnvVariable{Key: key, Value: v[eq+1:], Sensitive: isSensitive}
+	}
+
+	for _, v := range flagVars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid environment variable %q, must be formatted as KEY=VALUE", v)
+		}
+		envVars[key] = &cloud.EnvVariable{Key: key, Value: value}
+	}
+
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	vars := make([]*cloud.EnvVariable, 0, len(keys))
+	for _, key := range keys {
+		vars = append(vars, envVars[key])
+	}
+	return vars, nil
+}
+
+// variable of a -vars-json document
+type jsonVariable struct {
+	Value json.RawMessage `json:"value"`
+	// value is a string containing an HCL expression
+	HCL       bool `json:"hcl"`
+	Sensitive bool `json:"sensitive"`
+}
+
+// reads run variables from a json document keyed by variable name, from stdin when the path is "-"
+func readVariablesJSON(path string, stdin io.Reader) ([]*tfe.RunVariable, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading variables json: %w", err)
+	}
+
+	var doc map[string]*jsonVariable
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing variables json: %w", err)
+	}
+
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vars := make([]*tfe.RunVariable, 0, len(keys))
+	for _, key := range keys {
+		v := doc[key]
+		if v == nil || len(v.Value) == 0 {
+			return nil, fmt.Errorf("variable %q in variables json has no value", key)
+		}
+		value, err := v.hclLiteral()
+		if err != nil {
+			return nil, fmt.Errorf("variable %q in variables json: %w", key, err)
+		}
+		if v.Sensitive {
+			log.Printf("[DEBUG] adding sensitive variable: '%s'", key)
+		} else {
+			log.Printf("[DEBUG] adding variable: '%s', with: '%s'", key, value)
+		}
+		vars = append(vars, &tfe.RunVariable{Key: key, Value: value})
+	}
+	return vars, nil
+}
+
+// run variable values are HCL literals, json values are valid HCL once template sequences in strings are escaped
+func (v *jsonVariable) hclLiteral() (string, error) {
+	if v.HCL {
+		var expr string
+		if err := json.Unmarshal(v.Value, &expr); err != nil {
+			return "", errors.New("value must be a string when hcl is true")
+		}
+		return expr, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(v.Value, &value); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	literal := strings.ReplaceAll(string(b), "${", "$${")
+	return strings.ReplaceAll(literal, "%{", "%%{"), nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+func TestCollectEnvVariables(t *testing.T) {
+	t.Setenv("TF_VAR_region", `"us-east-1"`)
+	t.Setenv("TF_VAR_SENSITIVE_db_password", `"hunter2"`)
+	t.Setenv("TF_VAR_db_password", `"overridden"`)
+
+	var logs bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	vars := collectEnvVariables()
+
+	if v := vars["region"]; v == nil || v.Value != `"us-east-1"` {
+		t.Errorf("expected region variable but received %v", v)
+	}
+	if v := vars["db_password"]; v == nil || v.Value != `"hunter2"` {
+		t.Errorf("expected sensitive db_password variable to take precedence but received %v", v)
+	}
+	if _, ok := vars["SENSITIVE_db_password"]; ok {
+		t.Error("expected sensitive prefix to be removed from the variable key")
+	}
+	if strings.Contains(logs.String(), "hunter2") {
+		t.Errorf("expected sensitive value not to be logged but received:\n%s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "adding sensitive variable: 'db_password'") {
+		t.Errorf("expected sensitive variable key to be logged but received:\n%s", logs.String())
+	}
+}
+
+func TestCollectEnvCategoryVariables(t *testing.T) {
+	t.Setenv("TFCI_ENV_AWS_REGION", "us-west-2")
+	t.Setenv("TFCI_ENV_LOG_LEVEL", "info")
+	t.Setenv("TFCI_ENV_SENSITIVE_AWS_SECRET_ACCESS_KEY", "secret")
+
+	vars, err := collectEnvCategoryVariables([]string{"AWS_REGION=us-east-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []cloud.EnvVariable{
+		{Key: "AWS_REGION", Value: "us-east-1"},
+		{Key: "AWS_SECRET_ACCESS_KEY", Value: "secret", Sensitive: true},
+		{Key: "LOG_LEVEL", Value: "info"},
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("expected %d variables but received %d", len(expected), len(vars))
+	}
+	for i, v := range vars {
+		if *v != expected[i] {
+			t.Errorf("expected %+v but received %+v", expected[i], *v)
+		}
+	}
+}
+
+func TestCollectEnvCategoryVariables_Invalid(t *testing.T) {
+	if _, err := collectEnvCategoryVariables([]string{"AWS_REGION"}); err == nil {
+		t.Error("expected error for variable without a value")
+	}
+}
+
+func TestReadVariablesJSON(t *testing.T) {
+	doc := `{
+		"region": {"value": "us-east-1"},
+		"instance_count": {"value": 3},
+		"tags": {"value": {"team": "platform", "env": "${prod}"}},
+		"subnets": {"value": "[\"a\", \"b\"]", "hcl": true},
+		"db_password": {"value": "hunter2", "sensitive": true}
+	}`
+
+	var logs bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	vars, err := readVariablesJSON("-", strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"db_password":    `"hunter2"`,
+		"instance_count": `3`,
+		"region":         `"us-east-1"`,
+		"subnets":        `["a", "b"]`,
+		"tags":           `{"env":"$${prod}","team":"platform"}`,
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("expected %d variables but received %d", len(expected), len(vars))
+	}
+	for _, v := range vars {
+		if v.Value != expected[v.Key] {
+			t.Errorf("expected %s value %s but received %s", v.Key, expected[v.Key], v.Value)
+		}
+	}
+	if strings.Contains(logs.String(), "hunter2") {
+		t.Errorf("expected sensitive value not to be logged but received:\n%s", logs.String())
+	}
+}
+
+func TestReadVariablesJSON_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vars.json")
+	if err := os.WriteFile(path, []byte(`{"region": {"value": "us-east-1"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	vars, err := readVariablesJSON(path, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(vars) != 1 || vars[0].Key != "region" || vars[0].Value != `"us-east-1"` {
+		t.Errorf("expected region variable but received %v", vars)
+	}
+}
+
+func TestReadVariablesJSON_Invalid(t *testing.T) {
+	testCases := map[string]string{
+		"invalid-json":   `{"region": `,
+		"missing-value":  `{"region": {"hcl": false}}`,
+		"hcl-not-string": `{"count": {"value": 3, "hcl": true}}`,
+	}
+	for name, doc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := readVariablesJSON("-", strings.NewReader(doc)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
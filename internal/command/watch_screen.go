@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+// log lines kept for scrolling
+const watchScreenMaxLines = 5000
+
+// ansi escape sequences
+const (
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiBold       = "\x1b[1m"
+	ansiReset      = "\x1b[0m"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+)
+
+// keybindings of the watch terminal UI
+const (
+	keyApply   = 'a'
+	keyDiscard = 'd'
+	keyCancel  = 'c'
+	keyQuit    = 'q'
+	keyUp      = 'k'
+	keyDown    = 'j'
+	keyFollow  = 'f'
+	keyCtrlC   = 3
+)
+
+const (
+	actionQuit = "quit"
+	// shown for phases the run has not reached
+	phaseNotRun = "-"
+)
+
+// state of the watch terminal UI, updated by the run poller, log reader and keyboard
+type watchScreen struct {
+	mu sync.Mutex
+
+	runID string
+	run   *tfe.Run
+	lines []string
+	// lines scrolled up from the end of the logs, 0 follows new lines
+	scroll int
+	// action waiting for the key to be pressed again to confirm
+	pending string
+	message string
+	done    bool
+}
+
+func newWatchScreen(runID string) *watchScreen {
+	return &watchScreen{runID: runID}
+}
+
+func (s *watchScreen) update(run *tfe.Run) {
+	if run == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.run = run
+}
+
+func (s *watchScreen) addLine(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lines = append(s.lines, line)
+	if len(s.lines) > watchScreenMaxLines {
+		s.lines = s.lines[len(s.lines)-watchScreenMaxLines:]
+	}
+	if s.scroll > 0 {
+		// keep the scrolled position while new lines arrive
+		s.scroll++
+	}
+}
+
+func (s *watchScreen) setMessage(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = msg
+}
+
+func (s *watchScreen) finish(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.message = msg
+}
+
+// handles a key press, returning the run action to take or quit.
+// actions are only taken when the key is pressed twice, to avoid applying a run by accident.
+func (s *watchScreen) key(k byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch k {
+	case keyQuit, keyCtrlC:
+		return actionQuit
+	case keyUp:
+		s.scroll = min(s.scroll+1, max(len(s.lines)-1, 0))
+		return ""
+	case keyDown:
+		s.scroll = max(s.scroll-1, 0)
+		return ""
+	case keyFollow:
+		s.scroll = 0
+		return ""
+	}
+
+	action := map[byte]string{keyApply: cloud.RunActionApply, keyDiscard: cloud.RunActionDiscard, keyCancel: cloud.RunActionCancel}[k]
+	if action == "" {
+		return ""
+	}
+	if s.done || !s.allowed(action) {
+		s.pending = ""
+		s.message = fmt.Sprintf("The run cannot be %s", actionPastTense(action))
+		return ""
+	}
+	if s.pending != action {
+		s.pending = action
+		s.message = fmt.Sprintf("Press %c again to %s the run", k, action)
+		return ""
+	}
+	s.pending = ""
+	s.message = fmt.Sprintf("Requested to %s the run", action)
+	return action
+}
+
+func (s *watchScreen) allowed(action string) bool {
+	if s.run == nil || s.run.Actions == nil {
+		return false
+	}
+	switch action {
+	case cloud.RunActionApply:
+		return s.run.Actions.IsConfirmable
+	case cloud.RunActionDiscard:
+		return s.run.Actions.IsDiscardable
+	case cloud.RunActionCancel:
+		return s.run.Actions.IsCancelable
+	}
+	return false
+}
+
+func actionPastTense(action string) string {
+	switch action {
+	case cloud.RunActionApply:
+		return "applied"
+	case cloud.RunActionDiscard:
+		return "discarded"
+	}
+	return "cancelled"
+}
+
+// renders the screen for a terminal of the given size
+func (s *watchScreen) render(width int, height int, now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b strings.Builder
+	status := "pending"
+	workspace := ""
+	if s.run != nil {
+		status = string(s.run.Status)
+		if s.run.Workspace != nil && s.run.Workspace.Name != "" {
+			workspace = fmt.Sprintf("  Workspace: %s", s.run.Workspace.Name)
+		}
+	}
+	header := []string{
+		fmt.Sprintf("%sRun %s%s%s  Status: %s", ansiBold, s.runID, ansiReset, workspace, status),
+		s.phases(now),
+		strings.Repeat("─", width),
+	}
+	footer := []string{
+		strings.Repeat("─", width),
+		fmt.Sprintf("[a] apply  [d] discard  [c] cancel  [j/k] scroll  [f] follow  [q] quit  %s", s.message),
+	}
+
+	// fill the space between the header and footer with the end of the logs
+	size := max(height-len(header)-len(footer), 1)
+	end := max(len(s.lines)-s.scroll, 0)
+	start := max(end-size, 0)
+	visible := s.lines[start:end]
+
+	for _, line := range header {
+		b.WriteString(truncate(line, width, strings.HasPrefix(line, ansiBold)) + "\r\n")
+	}
+	for _, line := range visible {
+		b.WriteString(truncate(line, width, false) + "\r\n")
+	}
+	for i := len(visible); i < size; i++ {
+		b.WriteString("\r\n")
+	}
+	for i, line := range footer {
+		b.WriteString(truncate(line, width, false))
+		if i < len(footer)-1 {
+			b.WriteString("\r\n")
+		}
+	}
+	return b.String()
+}
+
+// phase timers, completed phases from the status timestamps and the running phase from its start
+func (s *watchScreen) phases(now time.Time) string {
+	durations := runDurations(s.run)
+	running, started := "", time.Time{}
+	if s.run != nil && s.run.StatusTimestamps != nil {
+		ts := s.run.StatusTimestamps
+		switch s.run.Status {
+		case tfe.RunPending, tfe.RunPlanQueued:
+			running, started = durationQueue, firstTime(ts.PlanQueuedAt, s.run.CreatedAt)
+		case tfe.RunPlanning:
+			running, started = durationPlan, ts.PlanningAt
+		case tfe.RunPostPlanRunning, tfe.RunPolicyChecking:
+			running, started = durationPolicy, firstTime(ts.PostPlanRunningAt, ts.PlannedAt)
+		case tfe.RunApplying:
+			running, started = durationApply, ts.ApplyingAt
+		}
+	}
+
+	parts := []string{}
+	for _, phase := range []string{durationQueue, durationPlan, durationPolicy, durationApply} {
+		value := phaseNotRun
+		if d, ok := durations[phase]; ok {
+			value = d.Round(100 * time.Millisecond).String()
+		} else if phase == running && !started.IsZero() {
+			value = fmt.Sprintf("%s (running)", now.Sub(started).Round(time.Second))
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", phase, value))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// shortens a line to the terminal width, keeping escape sequences of styled lines intact
+func truncate(line string, width int, styled bool) string {
+	if styled || width <= 0 {
+		return line
+	}
+	runes := []rune(line)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return line
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+func TestWatchScreen_Key(t *testing.T) {
+	screen := newWatchScreen("run-1")
+	screen.update(&tfe.Run{ID: "run-1", Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true, IsDiscardable: true}})
+
+	if action := screen.key('a'); action != "" {
+		t.Fatalf("expected the first key press to ask for confirmation but received %q", action)
+	}
+	if screen.message != "Press a again to apply the run" {
+		t.Errorf("unexpected message %q", screen.message)
+	}
+	if action := screen.key('a'); action != cloud.RunActionApply {
+		t.Errorf("expected apply action but received %q", action)
+	}
+
+	// pressing another key resets the confirmation
+	screen.key('d')
+	screen.key('a')
+	if action := screen.key('d'); action != "" {
+		t.Errorf("expected discard to require confirmation again but received %q", action)
+	}
+
+	if action := screen.key('c'); action != "" || screen.message != "The run cannot be cancelled" {
+		t.Errorf("expected cancel to be rejected but received %q: %q", action, screen.message)
+	}
+	if action := screen.key('q'); action != actionQuit {
+		t.Errorf("expected quit but received %q", action)
+	}
+}
+
+func TestWatchScreen_Render(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	screen := newWatchScreen("run-1")
+	screen.update(&tfe.Run{
+		ID:        "run-1",
+		Status:    tfe.RunPlanning,
+		CreatedAt: created,
+		Workspace: &tfe.Workspace{Name: "networking"},
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			PlanQueuedAt: created,
+			PlanningAt:   created.Add(2 * time.Second),
+		},
+	})
+	for i := 1; i <= 10; i++ {
+		screen.addLine(fmt.Sprintf("line %d", i))
+	}
+
+	lines := strings.Split(screen.render(60, 9, created.Add(32*time.Second)), "\r\n")
+	if len(lines) != 9 {
+		t.Fatalf("expected 9 lines but received %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "networking") || !strings.Contains(lines[0], "Status: planning") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if lines[1] != "queue 2s | plan 30s (running) | policy - | apply -" {
+		t.Errorf("unexpected phase timers %q", lines[1])
+	}
+	// 9 rows leaves room for the last 4 log lines
+	if strings.Join(lines[3:7], ",") != "line 7,line 8,line 9,line 10" {
+		t.Errorf("expected the end of the logs but received %q", lines[3:7])
+	}
+	if len([]rune(lines[8])) != 60 {
+		t.Errorf("expected the footer to be truncated to the terminal width but received %q", lines[8])
+	}
+
+	screen.key('k')
+	screen.key('k')
+	lines = strings.Split(screen.render(60, 9, created), "\r\n")
+	if strings.Join(lines[3:7], ",") != "line 5,line 6,line 7,line 8" {
+		t.Errorf("expected scrolled logs but received %q", lines[3:7])
+	}
+}
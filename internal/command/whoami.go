@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type WhoamiCommand struct {
+	*Meta
+
+	Workspace   string
+	Permissions []string
+}
+
+func (c *WhoamiCommand) flags() *flag.FlagSet {
+	f := c.flagSet("whoami")
+	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace to report effective permissions for.")
+	f.Var((*flagStringSlice)(&c.Permissions), "require", "Workspace permission the token must have, ex: -require=can-queue-run. This option accepts multiple values.")
+	return f
+}
+
+func (c *WhoamiCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Workspace != "" && c.organization == "" {
+		return c.failed(nil, "reporting workspace permissions requires an organization")
+	}
+	if len(c.Permissions) > 0 && c.Workspace == "" {
+		return c.failed(nil, "requiring permissions requires a workspace name")
+	}
+
+	user, err := c.cloud.ReadCurrentUser(c.appCtx)
+	if err != nil {
+		return c.failed(err, fmt.Sprintf("error validating token with HCP Terraform: %s", err.Error()))
+	}
+
+	accountType := "user"
+	if user.IsServiceAccount {
+		accountType = "service_account"
+	}
+	c.writer.Output(fmt.Sprintf("Authenticated as: %q (%s)", user.Username, accountType))
+	c.addOutput("account_id", user.ID)
+	c.addOutput("account", user.Username)
+	c.addOutput("account_type", accountType)
+
+	if c.organization != "" {
+		entitlements, err := c.cloud.ReadEntitlements(c.appCtx, c.organization)
+		if err != nil {
+			return c.failed(err, fmt.Sprintf("error reading organization %q: %s", c.organization, err.Error()))
+		}
+		c.addOutput("organization", c.organization)
+		c.addOutputWithOpts("entitlements", entitlements, &outputOpts{
+			stdOut:      true,
+			multiLine:   true,
+			platformOut: true,
+		})
+	}
+
+	if c.Workspace != "" {
+		permissions, err := c.cloud.ReadWorkspacePermissions(c.appCtx, c.organization, c.Workspace)
+		if err != nil {
+			return c.failed(err, fmt.Sprintf("error reading workspace %q: %s", c.Workspace, err.Error()))
+		}
+		c.addOutputWithOpts("workspace_permissions", permissions, &outputOpts{
+			stdOut:      true,
+			multiLine:   true,
+			platformOut: true,
+		})
+
+		if missing := missingPermissions(permissions, c.Permissions); len(missing) > 0 {
+			c.addOutput("missing_permissions", strings.Join(missing, ","))
+			return c.failed(nil, fmt.Sprintf("token is missing required permissions on workspace %q: %s", c.Workspace, strings.Join(missing, ", ")))
+		}
+	}
+
+	c.addOutput("status", string(Success))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *WhoamiCommand) failed(err error, msg string) int {
+	status := Error
+	if err != nil {
+		status = c.resolveStatus(err)
+	}
+	c.addOutput("status", string(status))
+	c.writer.ErrorResult(msg)
+	c.writer.OutputResult(c.closeOutput())
+	return 1
+}
+
+// unknown permission names are reported as missing
+func missingPermissions(permissions map[string]bool, required []string) []string {
+	missing := []string{}
+	for _, p := range required {
+		if !permissions[p] {
+			missing = append(missing, p)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func (c *WhoamiCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] whoami [options]
+
+	Validates the token and reports the authenticated account, organization entitlements and effective permissions on a workspace.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  The name of the HCP Terraform Workspace to report effective permissions for.
+
+	-require    Workspace permission the token must have, ex: "can-queue-run", "can-queue-apply". This option accepts multiple values.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WhoamiCommand) Synopsis() string {
+	return "Validates the token and reports the authenticated account and its permissions"
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testAccountReader struct {
+	user        *tfe.User
+	userErr     error
+	permissions map[string]bool
+}
+
+func (a *testAccountReader) ReadCurrentUser(_ context.Context) (*tfe.User, error) {
+	return a.user, a.userErr
+}
+
+func (a *testAccountReader) ReadEntitlements(_ context.Context, _ string) (map[string]bool, error) {
+	return map[string]bool{"operations": true}, nil
+}
+
+func (a *testAccountReader) ReadWorkspacePermissions(_ context.Context, _ string, _ string) (map[string]bool, error) {
+	return a.permissions, nil
+}
+
+func testWhoamiCommand(t *testing.T, account *testAccountReader) (*cli.MockUi, *WhoamiCommand) {
+	t.Helper()
+
+	ui := cli.NewMockUi()
+	writer := writer.NewWriter(ui)
+	cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+	cloudMockService.AccountService = account
+
+	meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+	return ui, &WhoamiCommand{Meta: meta}
+}
+
+func TestWhoamiCommand(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		account      *testAccountReader
+		expectedCode int
+		expectedOut  []string
+		expectedErr  string
+	}{
+		{
+			name: "service-account",
+			args: []string{"-workspace=my-workspace", "-require=can-queue-run"},
+			account: &testAccountReader{
+				user:        &tfe.User{ID: "user-123", Username: "api-team_123", IsServiceAccount: true},
+				permissions: map[string]bool{"can-queue-run": true},
+			},
+			expectedCode: 0,
+			expectedOut:  []string{`"account": "api-team_123"`, `"account_type": "service_account"`, `"status": "Success"`},
+		},
+		{
+			name: "missing-permissions",
+			args: []string{"-workspace=my-workspace", "-require=can-queue-run,can-queue-apply"},
+			account: &testAccountReader{
+				user:        &tfe.User{ID: "user-123", Username: "octocat"},
+				permissions: map[string]bool{"can-queue-run": true},
+			},
+			expectedCode: 1,
+			expectedOut:  []string{`"missing_permissions": "can-queue-apply"`, `"status": "Error"`},
+			expectedErr:  "missing required permissions",
+		},
+		{
+			name: "invalid-token",
+			args: []string{},
+			account: &testAccountReader{
+				userErr: tfe.ErrUnauthorized,
+			},
+			expectedCode: 1,
+			expectedOut:  []string{`"status": "Unauthorized"`},
+			expectedErr:  "error validating token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, cmd := testWhoamiCommand(t, tc.account)
+
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			if tc.expectedErr != "" && !strings.Contains(ui.ErrorWriter.String(), tc.expectedErr) {
+				t.Errorf("expected error to contain %q but received %s", tc.expectedErr, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
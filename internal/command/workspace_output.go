@@ -7,12 +7,22 @@ import (
 	"flag"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"golang.org/x/sync/errgroup"
 )
 
 type WorkspaceOutputCommand struct {
 	*Meta
 
-	Workspace string
+	Workspace   string
+	Workspaces  []string
+	Tags        []string
+	Concurrency int
+	WaitFor     []string
+	Timeout     time.Duration
 }
 
 type WorkspaceOutput struct {
@@ -21,8 +31,13 @@ type WorkspaceOutput struct {
 }
 
 func (c *WorkspaceOutputCommand) flags() *flag.FlagSet {
-	f := c.flagSet("state output")
+	f := c.flagSet("workspace output list")
 	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.Var((*flagStringSlice)(&c.Workspaces), "workspaces", "Names of HCP Terraform Workspaces to merge the outputs of, keyed by workspace name. This option accepts multiple values.")
+	f.Var((*flagStringSlice)(&c.Tags), "tag", "Merges the outputs of all workspaces with the tag, keyed by workspace name. This option accepts multiple values, workspaces must have all tags.")
+	f.IntVar(&c.Concurrency, "concurrency", defaultBatchConcurrency, "Maximum number of workspaces to read outputs from at once.")
+	f.Var((*flagStringSlice)(&c.WaitFor), "wait-for", "Names of outputs to wait for, polling the current state version until they exist. This option accepts multiple values.")
+	f.DurationVar(&c.Timeout, "timeout", cloud.StateVersionOutputMaxDuration, "Maximum duration to wait for the -wait-for outputs.")
 
 	return f
 }
@@ -32,6 +47,10 @@ func (c *WorkspaceOutputCommand) Run(args []string) int {
 		return 1
 	}
 
+	if len(c.Workspaces) > 0 || len(c.Tags) > 0 {
+		return c.runMultiple()
+	}
+
 	// validate workspace name was supplied as argument
 	if c.Workspace == "" {
 		c.addOutput("status", string(Error))
@@ -40,7 +59,7 @@ func (c *WorkspaceOutputCommand) Run(args []string) int {
 		return 1
 	}
 
-	svoList, svoErr := c.cloud.ReadStateOutputs(c.appCtx, c.organization, c.Workspace)
+	svoList, svoErr := c.readStateOutputs(c.Workspace)
 	if svoErr != nil {
 		status := c.resolveStatus(svoErr)
 		c.addOutput("status", string(status))
@@ -61,29 +80,140 @@ func (c *WorkspaceOutputCommand) Run(args []string) int {
 		stdOut:      true,
 		multiLine:   true,
 		platformOut: true,
+		table:       true,
+		columns:     []string{"name", "value"},
+	})
+	c.addOutput("total_outputs", fmt.Sprintf("%d", len(workspaceOutputs)))
+	c.addOutput("status", string(Success))
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+// reads outputs of multiple workspaces concurrently, merged into a single document keyed by workspace name
+func (c *WorkspaceOutputCommand) runMultiple() int {
+	names, err := c.workspaceNames()
+	if err != nil {
+		c.addOutput("status", string(c.resolveStatus(err)))
+		c.closeOutput()
+		c.writer.ErrorResult(err.Error())
+		return 1
+	}
+
+	type workspaceOutputs struct {
+		outputs map[string]interface{}
+		err     error
+	}
+	results := make([]workspaceOutputs, len(names))
+
+	var g errgroup.Group
+	g.SetLimit(max(c.Concurrency, 1))
+	for i, name := range names {
+		// failed workspaces are reported after every workspace is read
+		g.Go(func() error {
+			svoList, err := c.readStateOutputs(name)
+			if err != nil {
+				results[i].err = err
+				return nil
+			}
+			results[i].outputs = map[string]interface{}{}
+			for _, svo := range svoList.Items {
+				results[i].outputs[svo.Name] = svo.Value
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	merged := map[string]map[string]interface{}{}
+	failed := []string{}
+	total := 0
+	var lastErr error
+	for i, name := range names {
+		if results[i].err != nil {
+			lastErr = results[i].err
+			failed = append(failed, name)
+			c.writer.ErrorResult(fmt.Sprintf("error retrieving workspace %q state version outputs: %s", name, results[i].err.Error()))
+			continue
+		}
+		merged[name] = results[i].outputs
+		total += len(results[i].outputs)
+	}
+
+	c.addOutputWithOpts("outputs", merged, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
 	})
+	c.addOutput("workspace_count", fmt.Sprintf("%d", len(names)))
+	c.addOutput("total_outputs", fmt.Sprintf("%d", total))
+	if lastErr != nil {
+		c.addOutput("status", string(c.resolveStatus(lastErr)))
+		c.addOutput("failed_workspaces", strings.Join(failed, ","))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
 	c.addOutput("status", string(Success))
 	c.writer.OutputResult(c.closeOutput())
 	return 0
 }
 
+func (c *WorkspaceOutputCommand) readStateOutputs(workspace string) (*tfe.StateVersionOutputsList, error) {
+	if len(c.WaitFor) > 0 {
+		return c.cloud.WaitForStateOutputs(c.appCtx, c.organization, workspace, c.WaitFor, c.Timeout)
+	}
+	return c.cloud.ReadStateOutputs(c.appCtx, c.organization, workspace)
+}
+
+// workspaces from -workspace, -workspaces and -tag, in that order without duplicates
+func (c *WorkspaceOutputCommand) workspaceNames() ([]string, error) {
+	names := []string{}
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	add(c.Workspace)
+	for _, name := range c.Workspaces {
+		add(name)
+	}
+	if len(c.Tags) > 0 {
+		tagged, err := c.cloud.ListWorkspaceNames(c.appCtx, c.organization, c.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("error listing workspaces with tags %s: %w", strings.Join(c.Tags, ","), err)
+		}
+		for _, name := range tagged {
+			add(name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no workspaces have the tags %s", strings.Join(c.Tags, ","))
+	}
+	return names, nil
+}
+
 func (c *WorkspaceOutputCommand) Help() string {
 	helpText := `
 Usage: tfci [global options] workspace outputs [options]
 
 	Returns current state version outputs for a workspace.
 
-Global Options:
+` + globalOptionsHelp + `
+Options:
 
-	-hostname       The hostname of a Terraform Enterprise installation, if using Terraform Enterprise. Defaults to "app.terraform.io".
+	-workspace            Existing HCP Terraform Workspace.
 
-	-token          The token used to authenticate with HCP Terraform. Defaults to reading "TF_API_TOKEN" environment variable.
+	-workspaces           Existing HCP Terraform Workspaces to merge the outputs of, keyed by workspace name. This option accepts multiple values.
 
-	-organization   HCP Terraform Organization Name.
+	-tag                  Merges the outputs of all workspaces with the tag, keyed by workspace name. This option accepts multiple values, workspaces must have all tags.
 
-Options:
+	-concurrency          Maximum number of workspaces to read outputs from at once. Defaults to 5.
 
-	-workspace            Existing HCP Terraform Workspace.
+	-wait-for             Names of outputs to wait for, polling the current state version until they exist. This option accepts multiple values.
+
+	-timeout              Maximum duration to wait for the -wait-for outputs. Defaults to 5m.
 	`
 	return strings.TrimSpace(helpText)
 }
@@ -6,8 +6,10 @@ package command
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/tfci/internal/cloud"
@@ -24,6 +26,14 @@ func (w *WorkspaceOutputReader) ReadStateOutputs(_ context.Context, orgName stri
 	return w.svo, nil
 }
 
+func (w *WorkspaceOutputReader) WaitForStateOutputs(_ context.Context, orgName string, wName string, names []string, timeout time.Duration) (*tfe.StateVersionOutputsList, error) {
+	return w.svo, nil
+}
+
+func (w *WorkspaceOutputReader) ListWorkspaceNames(_ context.Context, orgName string, tags []string) ([]string, error) {
+	return nil, nil
+}
+
 type testWorkspaceOutputCommandOpts struct {
 	items []*tfe.StateVersionOutput
 }
@@ -103,11 +113,16 @@ func TestWorkspaceOutputListCommand_Output(t *testing.T) {
 			stdout := ui.OutputWriter.String()
 
 			var outputVal struct {
-				Outputs []WorkspaceOutput `json:"outputs"`
-				Status  string            `json:"status"`
+				Outputs      []WorkspaceOutput `json:"outputs"`
+				TotalOutputs string            `json:"total_outputs"`
+				Status       string            `json:"status"`
 			}
 			json.Unmarshal([]byte(stdout), &outputVal)
 
+			if outputVal.TotalOutputs != fmt.Sprint(len(tc.svoList)) {
+				t.Errorf("expected total_outputs %d but received %q", len(tc.svoList), outputVal.TotalOutputs)
+			}
+
 			for i, o := range outputVal.Outputs {
 				actualVal, _ := json.Marshal(o.Value)
 				expectVal, _ := json.Marshal(tc.svoList[i].Value)
@@ -187,3 +202,123 @@ func TestWorkspaceOutputListCommand_ErrorArgs(t *testing.T) {
 		})
 	}
 }
+
+type testWorkspaceOutputs struct {
+	WorkspaceOutputReader
+	outputs map[string][]*tfe.StateVersionOutput
+	tagged  []string
+}
+
+func (w *testWorkspaceOutputs) ReadStateOutputs(_ context.Context, _ string, wName string) (*tfe.StateVersionOutputsList, error) {
+	items, ok := w.outputs[wName]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return &tfe.StateVersionOutputsList{Items: items}, nil
+}
+
+func (w *testWorkspaceOutputs) ListWorkspaceNames(_ context.Context, _ string, _ []string) ([]string, error) {
+	return w.tagged, nil
+}
+
+func TestWorkspaceOutputListCommand_MultipleWorkspaces(t *testing.T) {
+	ui, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+	cmd.cloud.WorkspaceService = &testWorkspaceOutputs{
+		outputs: map[string][]*tfe.StateVersionOutput{
+			"networking": {{Name: "vpc_id", Value: "vpc-123"}},
+			"compute":    {{Name: "instance_ids", Value: []interface{}{"i-1", "i-2"}}},
+			"dns":        {{Name: "zone_id", Value: "Z123"}},
+		},
+		tagged: []string{"compute", "dns"},
+	}
+
+	if code := cmd.Run([]string{"-workspaces=networking,compute", "-tag=production", "-concurrency=2"}); code != 0 {
+		t.Fatalf("expected exit code 0 but received %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var result struct {
+		Outputs        map[string]map[string]interface{} `json:"outputs"`
+		WorkspaceCount string                            `json:"workspace_count"`
+		TotalOutputs   string                            `json:"total_outputs"`
+	}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error parsing output: %s", err)
+	}
+	if result.WorkspaceCount != "3" {
+		t.Errorf("expected 3 workspaces but received %s", result.WorkspaceCount)
+	}
+	if result.TotalOutputs != "3" {
+		t.Errorf("expected 3 outputs but received %s", result.TotalOutputs)
+	}
+	if result.Outputs["networking"]["vpc_id"] != "vpc-123" || result.Outputs["dns"]["zone_id"] != "Z123" {
+		t.Errorf("unexpected merged outputs: %v", result.Outputs)
+	}
+	if ids, ok := result.Outputs["compute"]["instance_ids"].([]interface{}); !ok || len(ids) != 2 {
+		t.Errorf("unexpected compute outputs: %v", result.Outputs["compute"])
+	}
+}
+
+func TestWorkspaceOutputListCommand_MultipleWorkspacesError(t *testing.T) {
+	ui, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+	cmd.cloud.WorkspaceService = &testWorkspaceOutputs{
+		outputs: map[string][]*tfe.StateVersionOutput{"networking": {{Name: "vpc_id", Value: "vpc-123"}}},
+	}
+
+	if code := cmd.Run([]string{"-workspaces=networking,missing"}); code != 1 {
+		t.Fatalf("expected exit code 1 but received %d", code)
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{`"failed_workspaces": "missing"`, `"vpc_id": "vpc-123"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %s but received %s", expected, output)
+		}
+	}
+}
+
+type testWaitingWorkspaceOutputs struct {
+	WorkspaceOutputReader
+	names   []string
+	timeout time.Duration
+	err     error
+}
+
+func (w *testWaitingWorkspaceOutputs) WaitForStateOutputs(_ context.Context, _ string, _ string, names []string, timeout time.Duration) (*tfe.StateVersionOutputsList, error) {
+	w.names, w.timeout = names, timeout
+	if w.err != nil {
+		return nil, w.err
+	}
+	return w.svo, nil
+}
+
+func TestWorkspaceOutputListCommand_WaitFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		code     int
+		expected Status
+	}{
+		{name: "outputs-exist", code: 0, expected: Success},
+		{name: "timeout", err: fmt.Errorf("outputs cluster_endpoint of workspace %q: %w", "consumer", &cloud.RetryTimeoutError{}), code: 1, expected: Timeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui, cmd := testWorkspaceOutputCommand(t, &testWorkspaceOutputCommandOpts{})
+			waiting := &testWaitingWorkspaceOutputs{err: tc.err}
+			waiting.svo = &tfe.StateVersionOutputsList{Items: []*tfe.StateVersionOutput{{Name: "image_id", Value: "ami-123"}, {Name: "cluster_endpoint", Value: "https://cluster.example.com"}}}
+			cmd.cloud.WorkspaceService = waiting
+
+			if code := cmd.Run([]string{"-workspace=consumer", "-wait-for=image_id,cluster_endpoint", "-timeout=10m"}); code != tc.code {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.code, code, ui.ErrorWriter.String())
+			}
+			if strings.Join(waiting.names, ",") != "image_id,cluster_endpoint" || waiting.timeout != 10*time.Minute {
+				t.Errorf("expected to wait 10m for image_id,cluster_endpoint but received %v for %v", waiting.timeout, waiting.names)
+			}
+			for _, m := range cmd.messages {
+				if m.name == "status" && m.value != string(tc.expected) {
+					t.Errorf("expected status %q but received %q", tc.expected, m.value)
+				}
+			}
+		})
+	}
+}
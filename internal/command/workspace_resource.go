@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type WorkspaceResourceListCommand struct {
+	*Meta
+
+	Workspace string
+	Mode      string
+}
+
+func (c *WorkspaceResourceListCommand) flags() *flag.FlagSet {
+	f := c.flagSet("workspace resources list")
+	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.StringVar(&c.Mode, "mode", "", "Only lists resources of the mode, managed or data.")
+	return f
+}
+
+func (c *WorkspaceResourceListCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Workspace == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("listing workspace resources requires a workspace name")
+		return 1
+	}
+	if c.Mode != "" && c.Mode != cloud.ResourceModeManaged && c.Mode != cloud.ResourceModeData {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("invalid mode %q, must be %s or %s", c.Mode, cloud.ResourceModeManaged, cloud.ResourceModeData))
+		return 1
+	}
+
+	resources, err := c.cloud.ListWorkspaceResources(c.appCtx, c.organization, c.Workspace)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		c.writer.ErrorResult(fmt.Sprintf("error listing resources of workspace, '%s' in HCP Terraform: %s", c.Workspace, err.Error()))
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	if c.Mode != "" {
+		filtered := []*cloud.WorkspaceResourceDetails{}
+		for _, r := range resources {
+			if r.Mode == c.Mode {
+				filtered = append(filtered, r)
+			}
+		}
+		resources = filtered
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("resource_count", fmt.Sprintf("%d", len(resources)))
+	c.addOutputWithOpts("resources", resources, &outputOpts{
+		stdOut:      true,
+		multiLine:   true,
+		platformOut: true,
+		table:       true,
+		columns:     []string{"address", "provider", "module", "mode"},
+	})
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *WorkspaceResourceListCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] workspace resources list [options]
+
+	Lists the resources tracked in the current state of a workspace, with their address, module, provider
+	and mode, without downloading the state.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  Existing HCP Terraform Workspace.
+
+	-mode       Only lists resources of the mode, managed or data.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceResourceListCommand) Synopsis() string {
+	return "Lists the resources tracked in a workspace"
+}
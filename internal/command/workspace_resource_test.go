@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testWorkspaceResourceService struct {
+	cloud.WorkspaceResourceService
+}
+
+func (s *testWorkspaceResourceService) ListWorkspaceResources(context.Context, string, string) ([]*cloud.WorkspaceResourceDetails, error) {
+	return []*cloud.WorkspaceResourceDetails{
+		{Address: "aws_instance.web", Module: "root", Provider: "hashicorp/aws", Mode: cloud.ResourceModeManaged},
+		{Address: "data.aws_ami.ubuntu", Module: "root", Provider: "hashicorp/aws", Mode: cloud.ResourceModeData},
+	}, nil
+}
+
+func TestWorkspaceResourceListCommand(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedCode  int
+		expectedOut   []string
+		unexpectedOut []string
+	}{
+		{
+			name:         "list",
+			args:         []string{"-workspace=networking"},
+			expectedCode: 0,
+			expectedOut:  []string{`"resource_count": "2"`, `"address": "aws_instance.web"`, `"mode": "data"`},
+		},
+		{
+			name:          "filter-mode",
+			args:          []string{"-workspace=networking", "-mode=managed"},
+			expectedCode:  0,
+			expectedOut:   []string{`"resource_count": "1"`, `"address": "aws_instance.web"`},
+			unexpectedOut: []string{"data.aws_ami.ubuntu"},
+		},
+		{
+			name:         "invalid-mode",
+			args:         []string{"-workspace=networking", "-mode=ephemeral"},
+			expectedCode: 1,
+		},
+		{
+			name:         "missing-workspace",
+			args:         []string{},
+			expectedCode: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			writer := writer.NewWriter(ui)
+			cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+			cloudMockService.WorkspaceResourceService = &testWorkspaceResourceService{}
+			meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+
+			cmd := &WorkspaceResourceListCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			for _, unexpected := range tc.unexpectedOut {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("expected output not to contain %s but received %s", unexpected, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+)
+
+type WorkspaceStatePushCommand struct {
+	*Meta
+
+	Workspace string
+	File      string
+	Serial    int64
+	Force     bool
+}
+
+func (c *WorkspaceStatePushCommand) flags() *flag.FlagSet {
+	f := c.flagSet("workspace state push")
+	f.StringVar(&c.Workspace, "workspace", "", "The name of the HCP Terraform Workspace.")
+	f.StringVar(&c.File, "file", "", "Path of the Terraform state file to push.")
+	f.Int64Var(&c.Serial, "serial", 0, "Serial of the created state version, overriding the serial of the state file.")
+	f.BoolVar(&c.Force, "force", false, "Pushes the state even when the lineage differs or the serial is not greater than the current state version.")
+	return f
+}
+
+func (c *WorkspaceStatePushCommand) Run(args []string) int {
+	if err := c.setupCmd(args, c.flags()); err != nil {
+		return 1
+	}
+
+	if c.Workspace == "" || c.File == "" {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult("pushing state requires a workspace and state file")
+		return 1
+	}
+
+	state, err := os.ReadFile(c.File)
+	if err != nil {
+		c.addOutput("status", string(Error))
+		c.closeOutput()
+		c.writer.ErrorResult(fmt.Sprintf("error reading state file: %s", err.Error()))
+		return 1
+	}
+
+	options := cloud.PushStateOptions{
+		Organization: c.organization,
+		Workspace:    c.Workspace,
+		State:        state,
+		Force:        c.Force,
+	}
+	if c.Serial > 0 {
+		options.Serial = tfe.Int64(c.Serial)
+	}
+	sv, err := c.cloud.PushState(c.appCtx, options)
+	if err != nil {
+		status := c.resolveStatus(err)
+		c.addOutput("status", string(status))
+		msg := fmt.Sprintf("error pushing state to workspace, '%s' in HCP Terraform: %s", c.Workspace, err.Error())
+		switch {
+		case errors.Is(err, cloud.ErrStateSerialConflict):
+			msg += ". Use -serial to push the state with a greater serial"
+		case errors.Is(err, cloud.ErrStateLineageConflict):
+			msg += ". Use -force to replace state of a different lineage"
+		}
+		c.writer.ErrorResult(msg)
+		c.writer.OutputResult(c.closeOutput())
+		return 1
+	}
+
+	c.addOutput("status", string(Success))
+	c.addOutput("state_version_id", sv.ID)
+	c.addOutput("serial", fmt.Sprintf("%d", sv.Serial))
+	c.addOutput("lineage", sv.Lineage)
+	c.writer.OutputResult(c.closeOutput())
+	return 0
+}
+
+func (c *WorkspaceStatePushCommand) Help() string {
+	helpText := `
+Usage: tfci [global options] workspace state push [options]
+
+	Creates a state version in a workspace from a Terraform state file, to migrate existing state into
+	HCP Terraform. The workspace is locked while the state version is created, and unless -force is
+	provided, the lineage must match and the serial must be greater than the current state version.
+
+` + globalOptionsHelp + `
+Options:
+
+	-workspace  Existing HCP Terraform Workspace.
+
+	-file       Path of the Terraform state file to push.
+
+	-serial     Serial of the created state version, overriding the serial of the state file.
+
+	-force      Pushes the state even when the lineage differs or the serial is not greater than the current
+	            state version, like terraform state push -force.
+	`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceStatePushCommand) Synopsis() string {
+	return "Pushes a Terraform state file to a workspace"
+}
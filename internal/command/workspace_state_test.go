@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/tfci/internal/cloud"
+	"github.com/hashicorp/tfci/internal/environment"
+	"github.com/hashicorp/tfci/internal/writer"
+	"github.com/mitchellh/cli"
+)
+
+type testStateVersionService struct {
+	cloud.StateVersionService
+	options cloud.PushStateOptions
+	err     error
+}
+
+func (s *testStateVersionService) PushState(_ context.Context, options cloud.PushStateOptions) (*cloud.StateVersionDetails, error) {
+	s.options = options
+	if s.err != nil {
+		return nil, s.err
+	}
+	serial := int64(7)
+	if options.Serial != nil {
+		serial = *options.Serial
+	}
+	return &cloud.StateVersionDetails{ID: "sv-1", Serial: serial, Lineage: "abc-123"}, nil
+}
+
+func TestWorkspaceStatePushCommand(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(stateFile, []byte(`{"version":4,"serial":7,"lineage":"abc-123"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		args          []string
+		err           error
+		expectedCode  int
+		expectedOut   []string
+		expectedError string
+	}{
+		{
+			name:         "push",
+			args:         []string{"-workspace=networking", "-file=" + stateFile},
+			expectedCode: 0,
+			expectedOut:  []string{`"state_version_id": "sv-1"`, `"serial": "7"`},
+		},
+		{
+			name:         "override-serial",
+			args:         []string{"-workspace=networking", "-file=" + stateFile, "-serial=12"},
+			expectedCode: 0,
+			expectedOut:  []string{`"serial": "12"`},
+		},
+		{
+			name:          "serial-conflict",
+			args:          []string{"-workspace=networking", "-file=" + stateFile},
+			err:           fmt.Errorf("%w, serial: 7 current serial: 9", cloud.ErrStateSerialConflict),
+			expectedCode:  1,
+			expectedError: "Use -serial",
+		},
+		{
+			name:          "lineage-conflict",
+			args:          []string{"-workspace=networking", "-file=" + stateFile},
+			err:           fmt.Errorf("%w, lineage: abc-123 current lineage: def-456", cloud.ErrStateLineageConflict),
+			expectedCode:  1,
+			expectedError: "Use -force",
+		},
+		{
+			name:         "force",
+			args:         []string{"-workspace=networking", "-file=" + stateFile, "-force"},
+			expectedCode: 0,
+			expectedOut:  []string{`"state_version_id": "sv-1"`},
+		},
+		{
+			name:          "locked",
+			args:          []string{"-workspace=networking", "-file=" + stateFile},
+			err:           tfe.ErrWorkspaceLocked,
+			expectedCode:  1,
+			expectedError: "workspace already locked",
+		},
+		{
+			name:         "missing-file",
+			args:         []string{"-workspace=networking"},
+			expectedCode: 1,
+		},
+		{
+			name:         "unreadable-file",
+			args:         []string{"-workspace=networking", "-file=does-not-exist.tfstate"},
+			expectedCode: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			writer := writer.NewWriter(ui)
+			cloudMockService := cloud.NewCloud(&tfe.Client{}, writer)
+			service := &testStateVersionService{err: tc.err}
+			cloudMockService.StateVersionService = service
+			meta := NewMetaOpts(context.Background(), cloudMockService, &environment.CI{}, WithWriter(writer), WithOrg("abc-company"))
+
+			cmd := &WorkspaceStatePushCommand{Meta: meta}
+			if code := cmd.Run(tc.args); code != tc.expectedCode {
+				t.Fatalf("expected exit code %d but received %d: %s", tc.expectedCode, code, ui.ErrorWriter.String())
+			}
+			if force := slices.Contains(tc.args, "-force"); tc.expectedCode == 0 && service.options.Force != force {
+				t.Errorf("expected force %t but received %t", force, service.options.Force)
+			}
+			output := ui.OutputWriter.String()
+			for _, expected := range tc.expectedOut {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %s but received %s", expected, output)
+				}
+			}
+			if !strings.Contains(ui.ErrorWriter.String(), tc.expectedError) {
+				t.Errorf("expected error to contain %q but received %s", tc.expectedError, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// loaded from the working directory when --config is not provided
+	DefaultFile = "tfci.yaml"
+	envConfig   = "TFCI_CONFIG"
+)
+
+// environment variable references in option values, only the ${VAR} form is expanded
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Config holds project level defaults, values are applied with precedence: flags > environment > file
+type Config struct {
+	Hostname     string `yaml:"hostname"`
+	Organization string `yaml:"organization"`
+	// default for commands with a -workspace option
+	Workspace string `yaml:"workspace"`
+	// max wait for operations, same as TF_MAX_TIMEOUT
+	Timeout string `yaml:"timeout"`
+	// global options, keyed by flag name
+	Global map[string]string `yaml:"global"`
+	// command options keyed by command name, then flag name. eg. "run create": {"message": "..."}
+	Commands map[string]map[string]string `yaml:"commands"`
+	// named targets selected with --profile
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// Load reads the config file at path, falling back to TFCI_CONFIG and then ./tfci.yaml.
+// A missing default file is not an error and returns an empty config.
+func Load(path string) (*Config, error) {
+	explicit := true
+	if path == "" {
+		path = os.Getenv(envConfig)
+	}
+	if path == "" {
+		path = DefaultFile
+		explicit = false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	cfg.expandEnv()
+	log.Printf("[DEBUG] loaded config file: %s", path)
+	return cfg, nil
+}
+
+// expands environment variable references in the option values, after parsing so values cannot change the yaml structure
+func (c *Config) expandEnv() {
+	for _, value := range []*string{&c.Hostname, &c.Organization, &c.Workspace, &c.Timeout} {
+		*value = expandEnv(*value)
+	}
+	for name, value := range c.Global {
+		c.Global[name] = expandEnv(value)
+	}
+	for _, options := range c.Commands {
+		for name, value := range options {
+			options[name] = expandEnv(value)
+		}
+	}
+}
+
+func expandEnv(value string) string {
+	return envReferencePattern.ReplaceAllStringFunc(value, func(ref string) string {
+		return os.Getenv(envReferencePattern.FindStringSubmatch(ref)[1])
+	})
+}
+
+// Env returns environment variables to default from the file, existing environment variables take precedence
+func (c *Config) Env() map[string]string {
+	env := map[string]string{}
+	if c.Hostname != "" {
+		env["TF_HOSTNAME"] = c.Hostname
+	}
+	if c.Organization != "" {
+		env["TF_CLOUD_ORGANIZATION"] = c.Organization
+	}
+	if c.Timeout != "" {
+		env["TF_MAX_TIMEOUT"] = c.Timeout
+	}
+	return env
+}
+
+// commands where -workspace is an alternative to another option, the command applies the shared workspace itself
+var workspaceAlternativeCommands = map[string]bool{
+	"run show": true,
+}
+
+// CommandDefaults returns option defaults for the command, command specific values override the shared workspace
+func (c *Config) CommandDefaults(command string) map[string]string {
+	defaults := map[string]string{}
+	if c.Workspace != "" && !workspaceAlternativeCommands[command] {
+		defaults["workspace"] = c.Workspace
+	}
+	for k, v := range c.Commands[command] {
+		defaults[k] = v
+	}
+	return defaults
+}
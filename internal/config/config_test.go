@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfig = `
+organization: abc-company
+workspace: my-workspace
+timeout: 30m
+global:
+  max-retries: "5"
+commands:
+  run create:
+    message: "Triggered by ${TEST_CONFIG_USER} for $TEST_CONFIG_USER"
+  workspace output list:
+    workspace: other-workspace
+`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tfci.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_CONFIG_USER", "octocat")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if env := cfg.Env(); env["TF_CLOUD_ORGANIZATION"] != "abc-company" || env["TF_MAX_TIMEOUT"] != "30m" {
+		t.Errorf("unexpected environment defaults: %v", env)
+	}
+
+	runCreate := cfg.CommandDefaults("run create")
+	if runCreate["message"] != "Triggered by octocat for $TEST_CONFIG_USER" {
+		t.Errorf("expected environment variables to be expanded but received %q", runCreate["message"])
+	}
+	if runCreate["workspace"] != "my-workspace" {
+		t.Errorf("expected shared workspace default but received %q", runCreate["workspace"])
+	}
+	if ws := cfg.CommandDefaults("workspace output list")["workspace"]; ws != "other-workspace" {
+		t.Errorf("expected command workspace to override shared workspace but received %q", ws)
+	}
+	if ws, ok := cfg.CommandDefaults("run show")["workspace"]; ok {
+		t.Errorf("expected run show to apply the shared workspace itself but received %q", ws)
+	}
+}
+
+func TestLoad_ExpandEnvAfterParsing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tfci.yaml")
+	if err := os.WriteFile(path, []byte("workspace: ${TEST_CONFIG_WORKSPACE}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// a value that would change the yaml structure if expanded before parsing
+	t.Setenv("TEST_CONFIG_WORKSPACE", "my-workspace\norganization: other-org")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cfg.Organization != "" {
+		t.Errorf("expected environment variable not to add options but received organization %q", cfg.Organization)
+	}
+	if cfg.Workspace != "my-workspace\norganization: other-org" {
+		t.Errorf("expected expanded workspace but received %q", cfg.Workspace)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("expected missing default config file to be ignored: %s", err)
+	}
+	if cfg.Organization != "" {
+		t.Errorf("expected empty config")
+	}
+
+	if _, err := Load("does-not-exist.yaml"); err == nil {
+		t.Errorf("expected error for missing explicit config file")
+	}
+}
+
+func TestApplyFlags(t *testing.T) {
+	var workspace, message string
+	f := flag.NewFlagSet("run create", flag.ContinueOnError)
+	f.StringVar(&workspace, "workspace", "", "")
+	f.StringVar(&message, "message", "", "")
+
+	if err := f.Parse([]string{"-workspace=flag-workspace"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ApplyFlags(f, map[string]string{
+		"workspace": "config-workspace",
+		"message":   "config message",
+		"unknown":   "ignored",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if workspace != "flag-workspace" {
+		t.Errorf("expected command-line flag to take precedence but received %q", workspace)
+	}
+	if message != "config message" {
+		t.Errorf("expected config default but received %q", message)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"log"
+)
+
+// ApplyFlags sets defaults on flags not provided on the command-line, options the flag set does not define are ignored
+func ApplyFlags(f *flag.FlagSet, defaults map[string]string) error {
+	provided := map[string]bool{}
+	f.Visit(func(fl *flag.Flag) {
+		provided[fl.Name] = true
+	})
+
+	for name, value := range defaults {
+		if provided[name] {
+			continue
+		}
+		if f.Lookup(name) == nil {
+			log.Printf("[DEBUG] ignoring config option %q, not an option of %q", name, f.Name())
+			continue
+		}
+		if err := f.Set(name, value); err != nil {
+			return fmt.Errorf("invalid config value for option %q: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const envProfilePrefix = "TFCI_PROFILE_"
+
+var profileEnvPattern = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// Profile bundles the target of a HCP Terraform or Terraform Enterprise installation
+type Profile struct {
+	Hostname     string `yaml:"hostname"`
+	Organization string `yaml:"organization"`
+	// name of the environment variable holding the api token, tokens are not read from the file
+	TokenEnv string `yaml:"token_env"`
+}
+
+// Profile returns the named profile from the config file, with TFCI_PROFILE_<NAME>_* environment variables
+// taking precedence over values from the file
+func (c *Config) Profile(name string, getenv func(string) string) (*Profile, error) {
+	profile := &Profile{}
+	found := false
+	if p, ok := c.Profiles[name]; ok && p != nil {
+		*profile = *p
+		found = true
+	}
+
+	prefix := profileEnvPrefix(name)
+	for suffix, value := range map[string]*string{
+		"HOSTNAME":     &profile.Hostname,
+		"ORGANIZATION": &profile.Organization,
+		"TOKEN_ENV":    &profile.TokenEnv,
+	} {
+		if v := getenv(prefix + suffix); v != "" {
+			*value = v
+			found = true
+		}
+	}
+	if getenv(prefix+"TOKEN") != "" {
+		found = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("profile %q is not defined in the config file or with %s* environment variables", name, prefix)
+	}
+	return profile, nil
+}
+
+// Token resolves the api token of the named profile, TFCI_PROFILE_<NAME>_TOKEN takes precedence over token_env
+func (p *Profile) Token(name string, getenv func(string) string) string {
+	if token := getenv(profileEnvPrefix(name) + "TOKEN"); token != "" {
+		return token
+	}
+	if p.TokenEnv != "" {
+		return getenv(p.TokenEnv)
+	}
+	return ""
+}
+
+// eg. "prod-us" reads TFCI_PROFILE_PROD_US_*
+func profileEnvPrefix(name string) string {
+	return envProfilePrefix + profileEnvPattern.ReplaceAllString(strings.ToUpper(name), "_") + "_"
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"testing"
+)
+
+func TestConfig_Profile(t *testing.T) {
+	cfg := &Config{
+		Profiles: map[string]*Profile{
+			"staging": {Hostname: "tfe.example.com", Organization: "staging-org", TokenEnv: "STAGING_TOKEN"},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		profile       string
+		env           map[string]string
+		expectedHost  string
+		expectedOrg   string
+		expectedToken string
+		expectErr     bool
+	}{
+		{
+			name:          "file",
+			profile:       "staging",
+			env:           map[string]string{"STAGING_TOKEN": "staging-token"},
+			expectedHost:  "tfe.example.com",
+			expectedOrg:   "staging-org",
+			expectedToken: "staging-token",
+		},
+		{
+			name:    "environment-overrides-file",
+			profile: "staging",
+			env: map[string]string{
+				"TFCI_PROFILE_STAGING_ORGANIZATION": "other-org",
+				"TFCI_PROFILE_STAGING_TOKEN":        "env-token",
+				"STAGING_TOKEN":                     "staging-token",
+			},
+			expectedHost:  "tfe.example.com",
+			expectedOrg:   "other-org",
+			expectedToken: "env-token",
+		},
+		{
+			name:    "environment-only",
+			profile: "prod-us",
+			env: map[string]string{
+				"TFCI_PROFILE_PROD_US_HOSTNAME":     "app.terraform.io",
+				"TFCI_PROFILE_PROD_US_ORGANIZATION": "prod-org",
+			},
+			expectedHost: "app.terraform.io",
+			expectedOrg:  "prod-org",
+		},
+		{
+			name:      "undefined",
+			profile:   "missing",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(k string) string { return tc.env[k] }
+
+			profile, err := cfg.Profile(tc.profile, getenv)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error for undefined profile")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if profile.Hostname != tc.expectedHost || profile.Organization != tc.expectedOrg {
+				t.Errorf("expected %s/%s but received %s/%s", tc.expectedHost, tc.expectedOrg, profile.Hostname, profile.Organization)
+			}
+			if token := profile.Token(tc.profile, getenv); token != tc.expectedToken {
+				t.Errorf("expected token %q but received %q", tc.expectedToken, token)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+)
+
+const (
+	// working directory shared between all Cloud Build steps
+	cloudBuildWorkspace  = "/workspace"
+	cloudBuildOutputFile = "tfci-outputs.json"
+)
+
+// Sourced from: https://cloud.google.com/build/docs/configuring-builds/substitute-variable-values#using_default_substitutions
+type CloudBuildContext struct {
+	// The ID of your Cloud project
+	projectId string
+	// The autogenerated ID of the build
+	buildId string
+	// The commit ID associated with your build
+	commitSHA string
+	// The first seven characters of COMMIT_SHA
+	shortSHA string
+	// The name of your branch
+	branchName string
+	// The name of the trigger associated with the build
+	triggerName string
+	// directory that output file is written to, defaults to /workspace
+	workspace string
+	// The map containing output data
+	output OutputMap
+}
+
+func (cb *CloudBuildContext) ID() string {
+	return fmt.Sprintf("gcb-%s-%s", cb.projectId, cb.buildId)
+}
+
+func (cb *CloudBuildContext) SHA() string {
+	return cb.commitSHA
+}
+
+func (cb *CloudBuildContext) SHAShort() string {
+	if cb.shortSHA != "" {
+		return cb.shortSHA
+	}
+	if len(cb.commitSHA) > 7 {
+		return cb.commitSHA[:7]
+	}
+	return cb.commitSHA
+}
+
+// Cloud Build does not expose the author of a commit
+func (cb *CloudBuildContext) Author() string {
+	return ""
+}
+
+func (cb *CloudBuildContext) WriteDir() string {
+	return cb.workspace
+}
+
+func (cb *CloudBuildContext) SetOutput(output OutputMap) {
+	cb.output = output
+}
+
+func (cb *CloudBuildContext) PreviewOutput() ([]*OutputFile, error) {
+	file, err := jsonOutputFile(filepath.Join(cb.workspace, cloudBuildOutputFile), cb.output)
+	if err != nil {
+		return nil, err
+	}
+	return []*OutputFile{file}, nil
+}
+
+func (cb *CloudBuildContext) CloseOutput() error {
+	log.Printf("[DEBUG] Cloud Build flushing output")
+
+	files, err := cb.PreviewOutput()
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutputFiles(files); err != nil {
+		return err
+	}
+
+	// reset output
+	cb.output = make(map[string]OutputWriter)
+
+	return nil
+}
+
+func newCloudBuildContext(getenv GetEnv) *CloudBuildContext {
+	return &CloudBuildContext{
+		projectId:   getenv("PROJECT_ID"),
+		buildId:     getenv("BUILD_ID"),
+		commitSHA:   getenv("COMMIT_SHA"),
+		shortSHA:    getenv("SHORT_SHA"),
+		branchName:  getenv("BRANCH_NAME"),
+		triggerName: getenv("TRIGGER_NAME"),
+		workspace:   cloudBuildWorkspace,
+		output:      make(map[string]OutputWriter),
+	}
+}
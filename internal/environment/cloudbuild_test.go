@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCloudBuildContext(t *testing.T) {
+	env := map[string]string{
+		"PROJECT_ID": "my-project",
+		"BUILD_ID":   "b1e6661e",
+		"COMMIT_SHA": "13c988d4f15e06bcdd0b0af290086a3079cdadb0",
+	}
+	getenv := func(k string) string {
+		return env[k]
+	}
+
+	cloudbuild := newCloudBuildContext(getenv)
+
+	if expected, actual := "gcb-my-project-b1e6661e", cloudbuild.ID(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+	if expected, actual := "13c988d", cloudbuild.SHAShort(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+	if expected, actual := cloudBuildWorkspace, cloudbuild.WriteDir(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+}
+
+func TestCloudBuildCloseOutput(t *testing.T) {
+	cloudbuild := newCloudBuildContext(func(k string) string { return "something" })
+	cloudbuild.workspace = t.TempDir()
+
+	cloudbuild.SetOutput(OutputMap{
+		"run_id":  &testOutput{val: "run-123"},
+		"payload": &testOutput{val: `{"pk": "pv"}`, multiLine: true},
+	})
+
+	if err := cloudbuild.CloseOutput(); err != nil {
+		t.Fatalf("close output error: %v\n", err)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(cloudbuild.workspace, cloudBuildOutputFile))
+	if err != nil {
+		t.Fatalf("file read error: %v\n", err)
+	}
+
+	var outputs map[string]string
+	if err := json.Unmarshal(contents, &outputs); err != nil {
+		t.Fatalf("outputs were not valid json: %v\n", err)
+	}
+
+	if actual := outputs["run_id"]; actual != "run-123" {
+		t.Errorf("value %s for %s expected, but found %s", "run-123", "run_id", actual)
+	}
+	if actual := outputs["payload"]; actual != `{"pk": "pv"}` {
+		t.Errorf("value %s for %s expected, but found %s", `{"pk": "pv"}`, "payload", actual)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// env-file written to the source directory, sourced in the buildspec so outputs become shell variables
+// that CodePipeline reads through env.exported-variables
+const codeBuildOutputFile = "tfci-exported-variables.env"
+
+// Sourced from: https://docs.aws.amazon.com/codebuild/latest/userguide/build-env-ref-env-vars.html
+type CodeBuildContext struct {
+	// The CodeBuild ID of the build. For example, codebuild-demo-project:b1e6661e-e4f2-4156-9ab9-82a19EXAMPLE
+	buildId string
+	// The build number of the current build.
+	buildNumber string
+	// An identifier for the version of a build's source code. For CodePipeline, this is the commit SHA.
+	resolvedSourceVersion string
+	// The entity that started the build. If CodePipeline started the build, this is the pipeline's name.
+	initiator string
+	// The directory path that CodeBuild uses for the build's source code.
+	srcDir string
+	// The map containing output data
+	output OutputMap
+}
+
+func (cb *CodeBuildContext) ID() string {
+	return fmt.Sprintf("cb-%s-%s", cb.buildId, cb.buildNumber)
+}
+
+func (cb *CodeBuildContext) SHA() string {
+	return cb.resolvedSourceVersion
+}
+
+func (cb *CodeBuildContext) SHAShort() string {
+	if len(cb.resolvedSourceVersion) > 7 {
+		return cb.resolvedSourceVersion[:7]
+	}
+	return cb.resolvedSourceVersion
+}
+
+func (cb *CodeBuildContext) Author() string {
+	return cb.initiator
+}
+
+func (cb *CodeBuildContext) WriteDir() string {
+	return cb.srcDir
+}
+
+func (cb *CodeBuildContext) SetOutput(output OutputMap) {
+	cb.output = output
+}
+
+// CodePipeline exported variables are restricted to alphanumeric characters and underscores
+// https://docs.aws.amazon.com/codepipeline/latest/userguide/reference-variables.html
+func codeBuildVariableName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (cb *CodeBuildContext) PreviewOutput() ([]*OutputFile, error) {
+	lines := []string{}
+	for _, k := range slices.Sorted(maps.Keys(cb.output)) {
+		lines = append(lines, fmt.Sprintf("export %s=%s", codeBuildVariableName(k), shellQuote(cb.output[k].String())))
+	}
+
+	return []*OutputFile{{
+		Path:    filepath.Join(cb.srcDir, codeBuildOutputFile),
+		Content: strings.Join(lines, "\n") + "\n",
+	}}, nil
+}
+
+// single quotes a value for the shell, values are not expanded when the file is sourced
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func (cb *CodeBuildContext) CloseOutput() error {
+	log.Printf("[DEBUG] CodeBuild flushing output")
+
+	files, err := cb.PreviewOutput()
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutputFiles(files); err != nil {
+		return err
+	}
+
+	// reset output
+	cb.output = make(map[string]OutputWriter)
+
+	return nil
+}
+
+func newCodeBuildContext(getenv GetEnv) *CodeBuildContext {
+	return &CodeBuildContext{
+		buildId:               getenv("CODEBUILD_BUILD_ID"),
+		buildNumber:           getenv("CODEBUILD_BUILD_NUMBER"),
+		resolvedSourceVersion: getenv("CODEBUILD_RESOLVED_SOURCE_VERSION"),
+		initiator:             getenv("CODEBUILD_INITIATOR"),
+		srcDir:                getenv("CODEBUILD_SRC_DIR"),
+		output:                make(map[string]OutputWriter),
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCodeBuildContext(t *testing.T) {
+	env := map[string]string{
+		"CODEBUILD_BUILD_ID":                "my-project:b1e6661e",
+		"CODEBUILD_BUILD_NUMBER":            "4",
+		"CODEBUILD_RESOLVED_SOURCE_VERSION": "13c988d4f15e06bcdd0b0af290086a3079cdadb0",
+		"CODEBUILD_INITIATOR":               "codepipeline/my-pipeline",
+	}
+	getenv := func(k string) string {
+		return env[k]
+	}
+
+	codebuild := newCodeBuildContext(getenv)
+
+	if expected, actual := "cb-my-project:b1e6661e-4", codebuild.ID(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+	if expected, actual := "13c988d", codebuild.SHAShort(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+	if expected, actual := "codepipeline/my-pipeline", codebuild.Author(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+}
+
+func TestCodeBuildCloseOutput(t *testing.T) {
+	dir := t.TempDir()
+	getenv := func(k string) string {
+		if k == "CODEBUILD_SRC_DIR" {
+			return dir
+		}
+		return "something"
+	}
+
+	codebuild := newCodeBuildContext(getenv)
+	codebuild.SetOutput(OutputMap{
+		"run_id":  &testOutput{val: "run-123"},
+		"payload": &testOutput{val: `{"pk": "pv"}`, multiLine: true},
+		"plan-id": &testOutput{val: "plan-123"},
+		"quoted":  &testOutput{val: "it's $HOME"},
+	})
+
+	if err := codebuild.CloseOutput(); err != nil {
+		t.Fatalf("close output error: %v\n", err)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(dir, codeBuildOutputFile))
+	if err != nil {
+		t.Fatalf("file read error: %v\n", err)
+	}
+
+	expected := "export payload='{\"pk\": \"pv\"}'\n" +
+		"export plan_id='plan-123'\n" +
+		"export quoted='it'\\''s $HOME'\n" +
+		"export run_id='run-123'\n"
+	if actual := string(contents); actual != expected {
+		t.Errorf("expected exported variables %q, but received: %q", expected, actual)
+	}
+}
@@ -12,9 +12,13 @@ import (
 type PlatformType string
 
 const (
-	GitLab PlatformType = "GitLab"
-	GitHub PlatformType = "GitHub"
-	Other  PlatformType = "Other"
+	GitLab     PlatformType = "GitLab"
+	GitHub     PlatformType = "GitHub"
+	CodeBuild  PlatformType = "CodeBuild"
+	CloudBuild PlatformType = "CloudBuild"
+	Tekton     PlatformType = "Tekton"
+	Generic    PlatformType = "Generic"
+	Other      PlatformType = "Other"
 )
 
 var (
@@ -75,6 +79,8 @@ type Common interface {
 	Author() string
 	WriteDir() string // where to store tmp files
 	SetOutput(output OutputMap)
+	// renders output without writing, allows inspecting exactly what CloseOutput would write
+	PreviewOutput() ([]*OutputFile, error)
 	CloseOutput() error
 }
 
@@ -93,6 +99,32 @@ func (c *CI) initialize() {
 		return
 	}
 
+	if c.getenv("CODEBUILD_BUILD_ID") != "" {
+		c.PlatformType = CodeBuild
+		c.Context = newCodeBuildContext(c.getenv)
+		return
+	}
+
+	// BUILD_ID alone is too generic, Cloud Build always provides PROJECT_ID alongside it
+	if c.getenv("BUILD_ID") != "" && c.getenv("PROJECT_ID") != "" {
+		c.PlatformType = CloudBuild
+		c.Context = newCloudBuildContext(c.getenv)
+		return
+	}
+
+	if c.getenv("TEKTON_RESULTS_DIR") != "" {
+		c.PlatformType = Tekton
+		c.Context = newTektonContext(c.getenv)
+		return
+	}
+
+	// fallback for unsupported platforms, configured with TFCI_CONTEXT_* values
+	if hasGenericContext(c.getenv) {
+		c.PlatformType = Generic
+		c.Context = newGenericContext(c.getenv)
+		return
+	}
+
 	c.PlatformType = Other
 }
 
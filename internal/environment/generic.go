@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"log"
+)
+
+// prefix for user supplied context values, for CI platforms without a dedicated context
+const genericContextPrefix = "TFCI_CONTEXT_"
+
+// Sourced from user supplied TFCI_CONTEXT_* environment variables
+type GenericContext struct {
+	// unique identifier of the pipeline execution. TFCI_CONTEXT_ID
+	id string
+	// commit SHA the pipeline is running for. TFCI_CONTEXT_SHA
+	commitSHA string
+	// author of the commit or initiator of the pipeline. TFCI_CONTEXT_AUTHOR
+	author string
+	// directory to store tmp files. TFCI_CONTEXT_WRITE_DIR
+	writeDir string
+	// path to json file that outputs are written to. TFCI_CONTEXT_OUTPUT_FILE
+	outputFile string
+	// The map containing output data
+	output OutputMap
+}
+
+func (g *GenericContext) ID() string {
+	return g.id
+}
+
+func (g *GenericContext) SHA() string {
+	return g.commitSHA
+}
+
+func (g *GenericContext) SHAShort() string {
+	if len(g.commitSHA) > 7 {
+		return g.commitSHA[:7]
+	}
+	return g.commitSHA
+}
+
+func (g *GenericContext) Author() string {
+	return g.author
+}
+
+func (g *GenericContext) WriteDir() string {
+	return g.writeDir
+}
+
+func (g *GenericContext) SetOutput(output OutputMap) {
+	g.output = output
+}
+
+func (g *GenericContext) PreviewOutput() ([]*OutputFile, error) {
+	// outputs are optional for the generic context
+	if g.outputFile == "" {
+		return nil, nil
+	}
+
+	file, err := jsonOutputFile(g.outputFile, g.output)
+	if err != nil {
+		return nil, err
+	}
+	return []*OutputFile{file}, nil
+}
+
+func (g *GenericContext) CloseOutput() error {
+	log.Printf("[DEBUG] Generic context flushing output to: %q", g.outputFile)
+
+	files, err := g.PreviewOutput()
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutputFiles(files); err != nil {
+		return err
+	}
+
+	// reset output
+	g.output = make(map[string]OutputWriter)
+
+	return nil
+}
+
+var genericContextKeys = []string{"ID", "SHA", "AUTHOR", "WRITE_DIR", "OUTPUT_FILE"}
+
+// determines if any TFCI_CONTEXT_* value has been supplied
+func hasGenericContext(getenv GetEnv) bool {
+	for _, k := range genericContextKeys {
+		if getenv(genericContextPrefix+k) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func newGenericContext(getenv GetEnv) *GenericContext {
+	return &GenericContext{
+		id:         getenv(genericContextPrefix + "ID"),
+		commitSHA:  getenv(genericContextPrefix + "SHA"),
+		author:     getenv(genericContextPrefix + "AUTHOR"),
+		writeDir:   getenv(genericContextPrefix + "WRITE_DIR"),
+		outputFile: getenv(genericContextPrefix + "OUTPUT_FILE"),
+		output:     make(map[string]OutputWriter),
+	}
+}
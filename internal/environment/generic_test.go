@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenericContext_Detection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		platform PlatformType
+	}{
+		{
+			name:     "no-context",
+			env:      map[string]string{},
+			platform: Other,
+		},
+		{
+			name:     "generic-context",
+			env:      map[string]string{"TFCI_CONTEXT_SHA": "13c988d4f15e06bcdd0b0af290086a3079cdadb0"},
+			platform: Generic,
+		},
+		{
+			name: "platform-takes-precedence",
+			env: map[string]string{
+				"GITHUB_ACTIONS":   "true",
+				"TFCI_CONTEXT_SHA": "13c988d4f15e06bcdd0b0af290086a3079cdadb0",
+			},
+			platform: GitHub,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ci := &CI{getenv: func(k string) string { return tc.env[k] }}
+			ci.initialize()
+
+			if ci.PlatformType != tc.platform {
+				t.Errorf("expected %s, but received: %s", tc.platform, ci.PlatformType)
+			}
+		})
+	}
+}
+
+func TestGenericContext_CloseOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outputs.json")
+	env := map[string]string{
+		"TFCI_CONTEXT_ID":          "build-42",
+		"TFCI_CONTEXT_SHA":         "13c988d4f15e06bcdd0b0af290086a3079cdadb0",
+		"TFCI_CONTEXT_AUTHOR":      "octocat",
+		"TFCI_CONTEXT_OUTPUT_FILE": path,
+	}
+
+	generic := newGenericContext(func(k string) string { return env[k] })
+
+	if expected, actual := "13c988d", generic.SHAShort(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+	if expected, actual := "octocat", generic.Author(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+
+	generic.SetOutput(OutputMap{
+		"run_id": &testOutput{val: "run-123"},
+	})
+	if err := generic.CloseOutput(); err != nil {
+		t.Fatalf("close output error: %v\n", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("file read error: %v\n", err)
+	}
+
+	var outputs map[string]string
+	if err := json.Unmarshal(contents, &outputs); err != nil {
+		t.Fatalf("outputs were not valid json: %v\n", err)
+	}
+	if actual := outputs["run_id"]; actual != "run-123" {
+		t.Errorf("value %s for %s expected, but found %s", "run-123", "run_id", actual)
+	}
+}
@@ -5,7 +5,6 @@ package environment
 
 import (
 	"fmt"
-	"os"
 	"strings"
 )
 
@@ -63,35 +62,31 @@ func (gh *GitHubContext) SetOutput(output OutputMap) {
 	gh.output = output
 }
 
-func (gh *GitHubContext) CloseOutput() (retErr error) {
-	filepath := gh.githubOutput
-	file, err := os.OpenFile(filepath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		retErr = err
-		return
-	}
-
+func (gh *GitHubContext) PreviewOutput() ([]*OutputFile, error) {
 	data := []string{}
 	for k, v := range gh.output {
 		data = append(data, multiLineStrVal(gh.fileDelimeter, k, v.String()))
 	}
-	out := []byte(strings.Join(data, EOF))
 
-	defer func() {
-		if err := file.Close(); err != nil {
-			retErr = err
-		}
-	}()
+	return []*OutputFile{
+		{Path: gh.githubOutput, Content: strings.Join(data, EOF), Append: true},
+	}, nil
+}
+
+func (gh *GitHubContext) CloseOutput() error {
+	files, err := gh.PreviewOutput()
+	if err != nil {
+		return err
+	}
 
-	if _, err := file.Write(out); err != nil {
-		retErr = err
-		return
+	if err := writeOutputFiles(files); err != nil {
+		return err
 	}
 
 	// reset output
 	gh.output = make(map[string]OutputWriter)
 
-	return
+	return nil
 }
 
 func newGitHubContext(getenv GetEnv) *GitHubContext {
@@ -6,7 +6,6 @@ package environment
 import (
 	"fmt"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -32,20 +31,6 @@ type GitLabContext struct {
 	output OutputMap
 }
 
-func writeArtifact(prefix string, name string, data string) (err error) {
-	file, err := os.Create(generateArtifactFileName("json", prefix, name))
-	if err != nil {
-		return
-	}
-	defer func() {
-		err = file.Close()
-	}()
-
-	_, err = file.WriteString(data)
-
-	return err
-}
-
 func (gl *GitLabContext) ID() string {
 	return fmt.Sprintf("gl-%s-%s", gl.concurrentId, gl.concurrentProjectId)
 }
@@ -72,24 +57,16 @@ func (gl *GitLabContext) SetOutput(output OutputMap) {
 	gl.output = output
 }
 
-func (gl *GitLabContext) CloseOutput() (err error) {
-	log.Printf("Gitlab flushing output")
-
-	// Create output file
-	file, err := os.Create(".env")
-	if err != nil {
-		return
-	}
-	defer func() {
-		err = file.Close()
-	}()
-
+func (gl *GitLabContext) PreviewOutput() ([]*OutputFile, error) {
+	var files []*OutputFile
 	var lines []string
 	for k, v := range gl.output {
+		// gitlab does not support multiline values in `.env`, write to an artifact instead
 		if v.MultiLine() {
-			if err = writeArtifact(gl.jobName, k, v.String()); err != nil {
-				return
-			}
+			files = append(files, &OutputFile{
+				Path:    generateArtifactFileName("json", gl.jobName, k),
+				Content: v.String(),
+			})
 			continue
 		}
 
@@ -97,12 +74,19 @@ func (gl *GitLabContext) CloseOutput() (err error) {
 		lines = append(lines, line)
 	}
 
-	content := strings.Join(lines, "\n")
-	if _, err := file.WriteString(content); err != nil {
+	files = append(files, &OutputFile{Path: ".env", Content: strings.Join(lines, "\n")})
+	return files, nil
+}
+
+func (gl *GitLabContext) CloseOutput() error {
+	log.Printf("Gitlab flushing output")
+
+	files, err := gl.PreviewOutput()
+	if err != nil {
 		return err
 	}
 
-	return
+	return writeOutputFiles(files)
 }
 
 func generateArtifactFileName(ext string, parts ...string) string {
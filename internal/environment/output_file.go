@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// rendered platform output, describes a single file and its contents
+type OutputFile struct {
+	// destination of the platform output
+	Path string
+	// exact contents written to the destination
+	Content string
+	// when true, content is appended to an existing file. eg. GITHUB_OUTPUT
+	Append bool
+}
+
+// renders output as a flat json object of string values, for platforms that consume outputs from a file
+func jsonOutputFile(path string, output OutputMap) (*OutputFile, error) {
+	data := make(map[string]string)
+	for k, v := range output {
+		data[k] = v.String()
+	}
+
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputFile{Path: path, Content: string(b)}, nil
+}
+
+func writeOutputFiles(files []*OutputFile) error {
+	for _, f := range files {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if f.Append {
+			flags = os.O_APPEND | os.O_CREATE | os.O_WRONLY
+		}
+
+		file, err := os.OpenFile(f.Path, flags, 0644)
+		if err != nil {
+			return err
+		}
+
+		_, err = file.WriteString(f.Content)
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// Tekton does not export context variables to steps by default,
+// values are expected to be mapped to the step's env from $(context.*) and $(results.*) variables
+// https://tekton.dev/docs/pipelines/variables/
+type TektonContext struct {
+	// directory that contains result files, ex: /tekton/results. TEKTON_RESULTS_DIR
+	resultsDir string
+	// $(context.taskRun.name). TEKTON_TASKRUN_NAME
+	taskRunName string
+	// commit SHA, Tekton has no native value. TFCI_CONTEXT_SHA
+	commitSHA string
+	// commit author, Tekton has no native value. TFCI_CONTEXT_AUTHOR
+	author string
+	// The map containing output data
+	output OutputMap
+}
+
+func (tk *TektonContext) ID() string {
+	return fmt.Sprintf("tekton-%s", tk.taskRunName)
+}
+
+func (tk *TektonContext) SHA() string {
+	return tk.commitSHA
+}
+
+func (tk *TektonContext) SHAShort() string {
+	if len(tk.commitSHA) > 7 {
+		return tk.commitSHA[:7]
+	}
+	return tk.commitSHA
+}
+
+func (tk *TektonContext) Author() string {
+	return tk.author
+}
+
+func (tk *TektonContext) WriteDir() string {
+	return ""
+}
+
+func (tk *TektonContext) SetOutput(output OutputMap) {
+	tk.output = output
+}
+
+// Tekton result names may only contain alphanumeric characters, '-', '_' and '.'
+func tektonResultName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// each output is written to its own file, matching $(results.<name>.path)
+func (tk *TektonContext) PreviewOutput() ([]*OutputFile, error) {
+	var files []*OutputFile
+	for k, v := range tk.output {
+		files = append(files, &OutputFile{
+			Path:    filepath.Join(tk.resultsDir, tektonResultName(k)),
+			Content: v.String(),
+		})
+	}
+	return files, nil
+}
+
+func (tk *TektonContext) CloseOutput() error {
+	log.Printf("[DEBUG] Tekton flushing output to: %s", tk.resultsDir)
+
+	files, err := tk.PreviewOutput()
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutputFiles(files); err != nil {
+		return err
+	}
+
+	// reset output
+	tk.output = make(map[string]OutputWriter)
+
+	return nil
+}
+
+func newTektonContext(getenv GetEnv) *TektonContext {
+	return &TektonContext{
+		resultsDir:  getenv("TEKTON_RESULTS_DIR"),
+		taskRunName: getenv("TEKTON_TASKRUN_NAME"),
+		commitSHA:   getenv(genericContextPrefix + "SHA"),
+		author:      getenv(genericContextPrefix + "AUTHOR"),
+		output:      make(map[string]OutputWriter),
+	}
+}
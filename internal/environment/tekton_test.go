@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTektonCloseOutput(t *testing.T) {
+	dir := t.TempDir()
+	env := map[string]string{
+		"TEKTON_RESULTS_DIR":  dir,
+		"TEKTON_TASKRUN_NAME": "tfci-run-abc12",
+		"TFCI_CONTEXT_SHA":    "13c988d4f15e06bcdd0b0af290086a3079cdadb0",
+	}
+
+	ci := &CI{getenv: func(k string) string { return env[k] }}
+	ci.initialize()
+	if ci.PlatformType != Tekton {
+		t.Fatalf("expected %s, but received: %s", Tekton, ci.PlatformType)
+	}
+
+	tekton := ci.Context.(*TektonContext)
+	if expected, actual := "tekton-tfci-run-abc12", tekton.ID(); expected != actual {
+		t.Errorf("expected %s, but received: %s", expected, actual)
+	}
+
+	tekton.SetOutput(OutputMap{
+		"run_id":  &testOutput{val: "run-123"},
+		"payload": &testOutput{val: `{"pk": "pv"}`, multiLine: true},
+	})
+	if err := tekton.CloseOutput(); err != nil {
+		t.Fatalf("close output error: %v\n", err)
+	}
+
+	expected := map[string]string{
+		"run_id":  "run-123",
+		"payload": `{"pk": "pv"}`,
+	}
+	for k, v := range expected {
+		contents, err := os.ReadFile(filepath.Join(dir, k))
+		if err != nil {
+			t.Fatalf("result file %s, read error: %v\n", k, err)
+		}
+		if string(contents) != v {
+			t.Errorf("value %s for %s expected, but found %s", v, k, string(contents))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package github waits for approvals of GitHub deployments, so applies can be gated by GitHub environment protection rules.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultAPIURL       = "https://api.github.com"
+	DefaultPollInterval = 15 * time.Second
+	requestTimeout      = 10 * time.Second
+)
+
+var ErrDeploymentRejected = errors.New("deployment was rejected")
+
+type Client struct {
+	apiURL     string
+	token      string
+	httpClient *http.Client
+	// time between checks of the deployment status
+	PollInterval time.Duration
+}
+
+func NewClient(apiURL, token string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &Client{
+		apiURL:       strings.TrimSuffix(apiURL, "/"),
+		token:        token,
+		httpClient:   &http.Client{Timeout: requestTimeout},
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+type ApprovalOptions struct {
+	// owner/name of the repository
+	Repository  string
+	Environment string
+	// commit SHA the deployment was created for
+	SHA string
+}
+
+type Approval struct {
+	DeploymentID int64  `json:"deployment_id"`
+	State        string `json:"state"`
+	Creator      string `json:"creator"`
+}
+
+type deployment struct {
+	ID int64 `json:"id"`
+}
+
+type deploymentStatus struct {
+	State       string `json:"state"`
+	Description string `json:"description"`
+	Creator     struct {
+		Login string `json:"login"`
+	} `json:"creator"`
+}
+
+// waits until the newest deployment of the commit to the environment is approved, which GitHub reports by
+// moving the deployment to in_progress or success once the environment protection rules pass.
+// Failed, errored and inactive deployments are rejected.
+func (c *Client) WaitForApproval(ctx context.Context, opts ApprovalOptions) (*Approval, error) {
+	log.Printf("[INFO] waiting for approval of deployment to environment: %q repository: %q", opts.Environment, opts.Repository)
+	for {
+		approval, err := c.readApproval(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if approval != nil {
+			switch approval.State {
+			case "in_progress", "success":
+				log.Printf("[INFO] deployment: %d to environment: %q was approved", approval.DeploymentID, opts.Environment)
+				return approval, nil
+			case "failure", "error", "inactive":
+				return approval, fmt.Errorf("%w, deployment: %d state: %s", ErrDeploymentRejected, approval.DeploymentID, approval.State)
+			}
+			log.Printf("[DEBUG] deployment: %d state: %q, waiting for approval", approval.DeploymentID, approval.State)
+		} else {
+			log.Printf("[DEBUG] no deployment to environment: %q for commit: %q yet", opts.Environment, opts.SHA)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.PollInterval):
+		}
+	}
+}
+
+// returns nil while no deployment exists
+func (c *Client) readApproval(ctx context.Context, opts ApprovalOptions) (*Approval, error) {
+	deployments := []*deployment{}
+	params := url.Values{"environment": {opts.Environment}, "per_page": {"1"}}
+	if opts.SHA != "" {
+		params.Set("sha", opts.SHA)
+	}
+	if err := c.get(ctx, fmt.Sprintf("repos/%s/deployments?%s", opts.Repository, params.Encode()), &deployments); err != nil {
+		return nil, fmt.Errorf("error listing deployments: %w", err)
+	}
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+
+	approval := &Approval{DeploymentID: deployments[0].ID, State: "pending"}
+	statuses := []*deploymentStatus{}
+	if err := c.get(ctx, fmt.Sprintf("repos/%s/deployments/%d/statuses?per_page=1", opts.Repository, approval.DeploymentID), &statuses); err != nil {
+		return nil, fmt.Errorf("error listing deployment statuses: %w", err)
+	}
+	if len(statuses) > 0 {
+		approval.State = statuses[0].State
+		approval.Creator = statuses[0].Creator.Login
+	}
+	return approval, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s", c.apiURL, path), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
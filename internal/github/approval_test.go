@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serves a deployment whose statuses progress through the states, one state per poll
+func testDeploymentServer(t *testing.T, states []string) *httptest.Server {
+	var polls atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/acme/infra/deployments":
+			if r.URL.Query().Get("environment") != "production" || r.URL.Query().Get("sha") != "abc123" {
+				t.Errorf("unexpected deployments query: %s", r.URL.RawQuery)
+			}
+			if polls.Load() == 0 {
+				fmt.Fprint(w, `[]`)
+				polls.Add(1)
+				return
+			}
+			fmt.Fprint(w, `[{"id": 42}]`)
+		case "/repos/acme/infra/deployments/42/statuses":
+			i := int(polls.Add(1)) - 2
+			if i >= len(states) {
+				i = len(states) - 1
+			}
+			fmt.Fprintf(w, `[{"state": %q, "creator": {"login": "github-actions[bot]"}}]`, states[i])
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_WaitForApproval(t *testing.T) {
+	testCases := []struct {
+		name          string
+		states        []string
+		expectedState string
+		expectedErr   error
+	}{
+		{
+			name:          "approved",
+			states:        []string{"waiting", "queued", "in_progress"},
+			expectedState: "in_progress",
+		},
+		{
+			name:          "rejected",
+			states:        []string{"waiting", "failure"},
+			expectedState: "failure",
+			expectedErr:   ErrDeploymentRejected,
+		},
+		{
+			name:        "timeout",
+			states:      []string{"waiting"},
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := testDeploymentServer(t, tc.states)
+			defer server.Close()
+
+			client := NewClient(server.URL, "gh-token")
+			client.PollInterval = time.Millisecond
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			approval, err := client.WaitForApproval(ctx, ApprovalOptions{Repository: "acme/infra", Environment: "production", SHA: "abc123"})
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.expectedState != "" && (approval == nil || approval.State != tc.expectedState || approval.DeploymentID != 42) {
+				t.Errorf("expected deployment 42 in state %q, got %+v", tc.expectedState, approval)
+			}
+		})
+	}
+}
+
+func TestClient_WaitForApprovalUnauthorized(t *testing.T) {
+	server := testDeploymentServer(t, []string{"waiting"})
+	defer server.Close()
+
+	client := NewClient(server.URL, "wrong-token")
+	if _, err := client.WaitForApproval(context.Background(), ApprovalOptions{Repository: "acme/infra", Environment: "production", SHA: "abc123"}); err == nil {
+		t.Fatal("expected error for unauthorized request")
+	}
+}
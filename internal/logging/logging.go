@@ -4,38 +4,102 @@
 package logging
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/tfci/internal/environment"
 )
 
-const envLogLevel = "TF_LOG"
+const (
+	envLogLevel  = "TF_LOG"
+	envLogFormat = "TF_LOG_FORMAT"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
 
 var (
-	ValidLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
-	logger      hclog.Logger
-	logWriter   io.Writer
+	ValidLevels  = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
+	ValidFormats = []string{TextFormat, JSONFormat}
+	logger       hclog.Logger
+	logWriter    io.Writer
+	logOutput    io.Writer = os.Stderr
+	// fields included with every log line
+	logFields []interface{}
+	fieldsMu  sync.Mutex
 )
 
+// TRACE includes http request and response logging
+func IsTrace() bool {
+	return strings.EqualFold(os.Getenv(envLogLevel), "TRACE")
+}
+
 type LoggerOptions struct {
 	PlatformType environment.PlatformType
+	// text (default) or json, defaults to reading TF_LOG_FORMAT environment variable
+	Format string
 }
 
-func SetupLogger(options *LoggerOptions) {
+func SetupLogger(options *LoggerOptions) error {
 	logLevel := os.Getenv(envLogLevel)
 	// default to off
 	if logLevel == "" {
 		logLevel = "OFF"
 	}
+
+	format := options.Format
+	if format == "" {
+		format = os.Getenv(envLogFormat)
+	}
+	format = strings.ToLower(format)
+	if format == "" {
+		format = TextFormat
+	}
+	if format != TextFormat && format != JSONFormat {
+		return fmt.Errorf("invalid log format %q, expected one of: %s", format, strings.Join(ValidFormats, ", "))
+	}
+
 	logger = hclog.NewInterceptLogger(&hclog.LoggerOptions{
-		Name:  "tfci",
-		Level: hclog.LevelFromString(logLevel),
+		Name:       "tfci",
+		Level:      hclog.LevelFromString(logLevel),
+		JSONFormat: format == JSONFormat,
+		Output:     logOutput,
 	})
-	logger.With("platform", options.PlatformType)
-	logWriter = logger.StandardWriter(&hclog.StandardLoggerOptions{InferLevels: true})
+	logFields = []interface{}{"platform", string(options.PlatformType)}
+	useLogger()
+	return nil
+}
+
+// With adds a field to all subsequent log lines, eg. command or run_id
+func With(key string, value interface{}) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	if logger == nil {
+		return
+	}
+	for i := 0; i < len(logFields); i += 2 {
+		if logFields[i] == key {
+			if logFields[i+1] == value {
+				return
+			}
+			logFields[i+1] = value
+			useLogger()
+			return
+		}
+	}
+	logFields = append(logFields, key, value)
+	useLogger()
+}
+
+func useLogger() {
+	logWriter = logger.With(logFields...).StandardWriter(&hclog.StandardLoggerOptions{InferLevels: true})
 
 	// set up the default std library logger to use our output
 	log.SetFlags(0)
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/tfci/internal/environment"
+)
+
+func TestSetupLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logOutput = &buf
+	t.Setenv(envLogLevel, "DEBUG")
+	defer func() {
+		logOutput = os.Stderr
+		log.SetOutput(io.Discard)
+	}()
+
+	if err := SetupLogger(&LoggerOptions{PlatformType: environment.GitHub, Format: JSONFormat}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	With("command", "run show")
+	With("run_id", "run-123")
+	log.Printf("[DEBUG] reading run")
+
+	line := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected a JSON log line but received %q: %s", buf.String(), err)
+	}
+
+	expected := map[string]string{
+		"@level":   "debug",
+		"@message": "reading run",
+		"platform": string(environment.GitHub),
+		"command":  "run show",
+		"run_id":   "run-123",
+	}
+	for k, v := range expected {
+		if line[k] != v {
+			t.Errorf("expected %s to be %q but received %v", k, v, line[k])
+		}
+	}
+	if _, ok := line["@timestamp"]; !ok {
+		t.Errorf("expected log line to include a timestamp")
+	}
+}
+
+func TestSetupLogger_InvalidFormat(t *testing.T) {
+	if err := SetupLogger(&LoggerOptions{Format: "xml"}); err == nil {
+		t.Fatalf("expected error for invalid log format")
+	}
+}
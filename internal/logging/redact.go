@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const redacted = "[REDACTED]"
+
+// keys whose values are always redacted
+var sensitiveKeys = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"id_token":      true,
+	"subject_token": true,
+	"secret":        true,
+	"password":      true,
+	"hmac-key":      true,
+}
+
+// RedactJSON returns the JSON document with tokens and sensitive values redacted,
+// values of variables (objects with a "key") and objects marked "sensitive" are redacted.
+// Documents that are not valid JSON are returned redacted entirely.
+func RedactJSON(body []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return []byte(redacted)
+	}
+	out, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return out
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		_, isVariable := val["key"]
+		sensitive, _ := val["sensitive"].(bool)
+		for k, child := range val {
+			switch {
+			case sensitiveKeys[strings.ToLower(k)]:
+				val[k] = redacted
+			case k == "value" && (isVariable || sensitive):
+				val[k] = redacted
+			default:
+				val[k] = redactValue(child)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// values shorter than the minimum are not redacted, as they are unlikely to be secrets
+// and replacing them would make logs unreadable
+const minRedactLength = 4
+
+// Redactor replaces sensitive values and user configured patterns in log lines
+type Redactor struct {
+	mu       sync.RWMutex
+	values   []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor returns a Redactor for the regular expression patterns
+func NewRedactor(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// AddValues redacts the values in subsequent lines, quoted HCL strings are also redacted unquoted
+func (r *Redactor) AddValues(values ...string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range values {
+		if unquoted, err := strconv.Unquote(v); err == nil {
+			r.addValue(unquoted)
+		}
+		r.addValue(v)
+	}
+	// replace longer values first, so values containing another value are redacted entirely
+	sort.SliceStable(r.values, func(i, j int) bool { return len(r.values[i]) > len(r.values[j]) })
+}
+
+func (r *Redactor) addValue(v string) {
+	if len(v) < minRedactLength || slices.Contains(r.values, v) {
+		return
+	}
+	r.values = append(r.values, v)
+}
+
+// Redact returns the line with sensitive values and pattern matches replaced
+func (r *Redactor) Redact(line string) string {
+	if r == nil {
+		return line
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, v := range r.values {
+		line = strings.ReplaceAll(line, v, redacted)
+	}
+	for _, re := range r.patterns {
+		line = re.ReplaceAllString(line, redacted)
+	}
+	return line
+}
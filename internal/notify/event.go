@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type EventType string
+
+const (
+	RunCreated          EventType = "run_created"
+	RunStatusChanged    EventType = "run_status_changed"
+	RunCompleted        EventType = "run_completed"
+	PolicyResult        EventType = "policy_result"
+	ConfigStatusChanged EventType = "configuration_version_status_changed"
+)
+
+// lifecycle event emitted while monitoring HCP Terraform resources
+type Event struct {
+	Type      EventType `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	// id of the run, configuration version or policy the event belongs to
+	ID      string `json:"id,omitempty"`
+	RunID   string `json:"run_id,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func NewEvent(eventType EventType, id string, status string) *Event {
+	return &Event{
+		Type:      eventType,
+		Timestamp: time.Now().UTC(),
+		ID:        id,
+		Status:    status,
+	}
+}
+
+// posts each event as a single line of newline delimited json (NDJSON)
+type EventWebhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewEventWebhook(url string) *EventWebhook {
+	return &EventWebhook{
+		url:    url,
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+func (w *EventWebhook) Emit(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	body = append(body, '\n')
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("event request to %s failed with: %s", redactURL(w.url), resp.Status)
+	}
+	return nil
+}
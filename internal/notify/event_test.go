@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEventWebhook_Emit(t *testing.T) {
+	var body, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer server.Close()
+
+	webhook := NewEventWebhook(server.URL)
+	if err := webhook.Emit(context.Background(), NewEvent(RunStatusChanged, "run-123", "planning")); err != nil {
+		t.Fatalf("unexpected emit error: %s", err)
+	}
+
+	if contentType != "application/x-ndjson" {
+		t.Errorf("expected %q but received %q", "application/x-ndjson", contentType)
+	}
+	if !strings.HasSuffix(body, "\n") || strings.Count(body, "\n") != 1 {
+		t.Fatalf("expected a single newline delimited json line, received %q", body)
+	}
+
+	var e Event
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		t.Fatalf("event was not valid json: %s", err)
+	}
+	if e.Type != RunStatusChanged || e.ID != "run-123" || e.Status != "planning" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+}
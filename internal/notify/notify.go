@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const requestTimeout = 10 * time.Second
+
+// summary of a completed command sent to each notifier
+type Notification struct {
+	Command string `json:"command"`
+	Status  string `json:"status"`
+	RunID   string `json:"run_id,omitempty"`
+	RunLink string `json:"run_link,omitempty"`
+	Add     string `json:"add,omitempty"`
+	Change  string `json:"change,omitempty"`
+	Destroy string `json:"destroy,omitempty"`
+}
+
+// human readable summary, used for chat based notifiers
+func (n *Notification) Text() string {
+	text := fmt.Sprintf("tfci %s finished with status: %s", n.Command, n.Status)
+	if n.RunID != "" {
+		text = fmt.Sprintf("%s, run: %s", text, n.RunID)
+	}
+	if n.Add != "" || n.Change != "" || n.Destroy != "" {
+		text = fmt.Sprintf("%s (add: %s, change: %s, destroy: %s)", text, n.Add, n.Change, n.Destroy)
+	}
+	if n.RunLink != "" {
+		text = fmt.Sprintf("%s\n%s", text, n.RunLink)
+	}
+	return text
+}
+
+type Notifier interface {
+	Notify(context.Context, *Notification) error
+}
+
+type Options struct {
+	// Slack incoming webhook url
+	SlackWebhook string
+	// generic webhook url, receives the notification as json
+	WebhookURL string
+}
+
+func NewNotifiers(opts Options) []Notifier {
+	client := &http.Client{Timeout: requestTimeout}
+
+	notifiers := []Notifier{}
+	if opts.SlackWebhook != "" {
+		notifiers = append(notifiers, &slackNotifier{url: opts.SlackWebhook, client: client})
+	}
+	if opts.WebhookURL != "" {
+		notifiers = append(notifiers, &webhookNotifier{url: opts.WebhookURL, client: client})
+	}
+	return notifiers
+}
+
+type slackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, n *Notification) error {
+	log.Printf("[DEBUG] sending slack notification for: %s", n.Command)
+	return postJSON(ctx, s.client, s.url, map[string]string{"text": n.Text()})
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n *Notification) error {
+	log.Printf("[DEBUG] sending webhook notification for: %s", n.Command)
+	return postJSON(ctx, w.client, w.url, n)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification request to %s failed with: %s", redactURL(url), resp.Status)
+	}
+	return nil
+}
+
+// webhook urls commonly embed secrets in the path, only keep the host for errors
+func redactURL(url string) string {
+	parts := strings.SplitN(url, "/", 4)
+	if len(parts) < 3 {
+		return url
+	}
+	return strings.Join(parts[:3], "/")
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotifiers(t *testing.T) {
+	received := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received[r.URL.Path] = string(body)
+	}))
+	defer server.Close()
+
+	notifiers := NewNotifiers(Options{
+		SlackWebhook: server.URL + "/slack",
+		WebhookURL:   server.URL + "/webhook",
+	})
+	if len(notifiers) != 2 {
+		t.Fatalf("expected %d notifiers but received %d", 2, len(notifiers))
+	}
+
+	n := &Notification{
+		Command: "run apply",
+		Status:  "Success",
+		RunID:   "run-123",
+		RunLink: "https://app.terraform.io/app/org/workspaces/ws/runs/run-123",
+	}
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(context.Background(), n); err != nil {
+			t.Fatalf("unexpected notify error: %s", err)
+		}
+	}
+
+	var slack map[string]string
+	if err := json.Unmarshal([]byte(received["/slack"]), &slack); err != nil {
+		t.Fatalf("slack payload was not valid json: %s", err)
+	}
+	if !strings.Contains(slack["text"], "run apply finished with status: Success") {
+		t.Errorf("unexpected slack text: %q", slack["text"])
+	}
+
+	var webhook Notification
+	if err := json.Unmarshal([]byte(received["/webhook"]), &webhook); err != nil {
+		t.Fatalf("webhook payload was not valid json: %s", err)
+	}
+	if webhook.RunID != "run-123" {
+		t.Errorf("expected %q but received %q", "run-123", webhook.RunID)
+	}
+}
+
+func TestNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	notifiers := NewNotifiers(Options{WebhookURL: server.URL + "/secret-token"})
+	err := notifiers[0].Notify(context.Background(), &Notification{Command: "run show"})
+	if err == nil {
+		t.Fatalf("expected error for non successful status")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("expected webhook path to be redacted: %s", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package runtask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// task result messages longer than this are truncated
+const maxMessageLength = 1000
+
+// credentials of tfci, not passed to the handler since it runs with the request input
+var credentialEnvPrefixes = []string{
+	"TF_API_TOKEN=",
+	"TF_TOKEN_",
+	"TF_OIDC_TOKEN=",
+	"ACTIONS_ID_TOKEN_REQUEST_",
+	"TFCI_RUN_TASK_HMAC_KEY=",
+}
+
+// runs an executable for each run task request. The run details are passed as environment variables,
+// the request (without its access token) and plan json as files. The credentials of tfci are removed
+// from the environment. Exiting with 0 passes the task,
+// any other exit code fails it, and the output of the script is the task result message.
+type ScriptHandler struct {
+	Path string
+}
+
+func (h *ScriptHandler) Handle(ctx context.Context, req *tfe.RunTaskRequest, planJSON []byte) (*Result, error) {
+	dir, err := os.MkdirTemp("", "tfci-runtask-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// the access token must not leak to the handler
+	redacted := *req
+	redacted.AccessToken = ""
+	requestFile := filepath.Join(dir, "request.json")
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(requestFile, data, 0600); err != nil {
+		return nil, err
+	}
+	env := append(handlerEnviron(),
+		"TFCI_RUN_TASK_REQUEST="+requestFile,
+		"TFCI_RUN_ID="+req.RunID,
+		"TFCI_RUN_STAGE="+req.Stage,
+		"TFCI_ORGANIZATION_NAME="+req.OrganizationName,
+		"TFCI_WORKSPACE_NAME="+req.WorkspaceName,
+		"TFCI_TASK_RESULT_ID="+req.TaskResultID,
+	)
+	if len(planJSON) > 0 {
+		planFile := filepath.Join(dir, "plan.json")
+		if err := os.WriteFile(planFile, planJSON, 0600); err != nil {
+			return nil, err
+		}
+		env = append(env, "TFCI_PLAN_JSON="+planFile)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Path)
+	cmd.Env = env
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	runErr := cmd.Run()
+
+	result := &Result{Status: tfe.TaskPassed, Message: truncateMessage(strings.TrimSpace(output.String()))}
+	var exitErr *exec.ExitError
+	switch {
+	case runErr == nil:
+	case errors.As(runErr, &exitErr):
+		result.Status = tfe.TaskFailed
+	default:
+		return nil, runErr
+	}
+	return result, nil
+}
+
+// the environment of tfci, without its credentials
+func handlerEnviron() []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		isCredential := slices.ContainsFunc(credentialEnvPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(kv, prefix)
+		})
+		if !isCredential {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
+
+func truncateMessage(msg string) string {
+	if len(msg) <= maxMessageLength {
+		return msg
+	}
+	return msg[:maxMessageLength-3] + "..."
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package runtask
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func writeScript(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "handler.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+content), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScriptHandler_Handle(t *testing.T) {
+	req := &tfe.RunTaskRequest{AccessToken: "secret-token", RunID: "run-1", WorkspaceName: "networking"}
+
+	testCases := []struct {
+		name            string
+		script          string
+		expectedStatus  tfe.TaskResultStatus
+		expectedMessage string
+	}{
+		{
+			name:            "passes on zero exit code",
+			script:          `echo "checked $TFCI_WORKSPACE_NAME: $(cat "$TFCI_PLAN_JSON")"`,
+			expectedStatus:  tfe.TaskPassed,
+			expectedMessage: `checked networking: {"resource_changes":[]}`,
+		},
+		{
+			name:            "fails on nonzero exit code",
+			script:          "echo 'policy violated' >&2\nexit 3",
+			expectedStatus:  tfe.TaskFailed,
+			expectedMessage: "policy violated",
+		},
+		{
+			name:            "does not pass the access token",
+			script:          `grep -c secret-token "$TFCI_RUN_TASK_REQUEST" || true`,
+			expectedStatus:  tfe.TaskPassed,
+			expectedMessage: "0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &ScriptHandler{Path: writeScript(t, tc.script)}
+			result, err := handler.Handle(context.Background(), req, []byte(`{"resource_changes":[]}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result.Status != tc.expectedStatus {
+				t.Errorf("expected status %q, got %q", tc.expectedStatus, result.Status)
+			}
+			if result.Message != tc.expectedMessage {
+				t.Errorf("expected message %q, got %q", tc.expectedMessage, result.Message)
+			}
+		})
+	}
+}
+
+func TestScriptHandler_HandleCredentials(t *testing.T) {
+	t.Setenv("TF_API_TOKEN", "api-token")
+	t.Setenv("TF_TOKEN_app_terraform_io", "host-token")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "id-token")
+	t.Setenv("TFCI_RUN_TASK_HMAC_KEY", "hmac-key")
+	t.Setenv("TFCI_SCANNER_MODE", "strict")
+
+	handler := &ScriptHandler{Path: writeScript(t, `env | grep -c -e api-token -e host-token -e id-token -e hmac-key; echo "$TFCI_SCANNER_MODE"`)}
+	result, err := handler.Handle(context.Background(), &tfe.RunTaskRequest{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Message != "0\nstrict" {
+		t.Errorf("expected the handler to receive the environment without credentials, got %q", result.Message)
+	}
+}
+
+func TestScriptHandler_HandleMissingScript(t *testing.T) {
+	handler := &ScriptHandler{Path: filepath.Join(t.TempDir(), "missing.sh")}
+	if _, err := handler.Handle(context.Background(), &tfe.RunTaskRequest{}, nil); err == nil {
+		t.Fatal("expected error running missing script")
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	msg := truncateMessage(strings.Repeat("a", maxMessageLength+10))
+	if len(msg) != maxMessageLength || !strings.HasSuffix(msg, "...") {
+		t.Errorf("expected message truncated to %d characters, got %d", maxMessageLength, len(msg))
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package runtask implements the receiving side of the run task protocol, so custom run task
+// integrations can be written as a handler that inspects a run and returns a result.
+package runtask
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+const (
+	signatureHeader = "X-Tfc-Task-Signature"
+	// access token sent with the verification request when a run task is registered
+	verificationToken = "test-token"
+	maxRequestBytes   = 1 << 20
+	// HCP Terraform fails a task result that is not reported within 10 minutes
+	DefaultTimeout = 10 * time.Minute
+)
+
+// outcome of a run task, reported to the task result callback
+type Result struct {
+	Status  tfe.TaskResultStatus
+	Message string
+	URL     string
+}
+
+type Handler interface {
+	// planJSON is empty in the pre_plan stage
+	Handle(ctx context.Context, req *tfe.RunTaskRequest, planJSON []byte) (*Result, error)
+}
+
+type Options struct {
+	// key the request signatures are verified with, requests are rejected without a key
+	HMACKey string
+	Handler Handler
+	// reports task results, the access token of each request authenticates the callback
+	Callbacks tfe.RunTasksIntegration
+	Timeout   time.Duration
+	// accepts requests without verifying their signature, only for local testing
+	InsecureSkipVerify bool
+}
+
+type Server struct {
+	hmacKey    []byte
+	skipVerify bool
+	handler    Handler
+	callbacks  tfe.RunTasksIntegration
+	timeout    time.Duration
+	httpClient *http.Client
+	wg         sync.WaitGroup
+}
+
+func NewServer(opts Options) *Server {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Server{
+		hmacKey:    []byte(opts.HMACKey),
+		skipVerify: opts.InsecureSkipVerify,
+		handler:    opts.Handler,
+		callbacks:  opts.Callbacks,
+		timeout:    timeout,
+		httpClient: &http.Client{Timeout: time.Minute},
+	}
+}
+
+// acknowledges the run task request, then handles it and reports the result in the background
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !s.verified(body, r.Header.Get(signatureHeader)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	req := &tfe.RunTaskRequest{}
+	if err := json.Unmarshal(body, req); err != nil {
+		log.Printf("[WARN] rejected invalid run task request: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if req.AccessToken == verificationToken {
+		log.Printf("[INFO] received run task verification request from organization: %q", req.OrganizationName)
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		s.handle(ctx, req)
+	}()
+}
+
+// requests run the handler with attacker controlled input, so they are rejected unless signed with the key
+func (s *Server) verified(body []byte, signature string) bool {
+	switch {
+	case s.skipVerify:
+		return true
+	case len(s.hmacKey) == 0:
+		log.Printf("[WARN] rejected run task request, no HMAC key is configured to verify its signature")
+		return false
+	case !VerifySignature(s.hmacKey, body, signature):
+		log.Printf("[WARN] rejected run task request with an invalid signature")
+		return false
+	}
+	return true
+}
+
+// waits for the requests being handled to report their results
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Server) handle(ctx context.Context, req *tfe.RunTaskRequest) {
+	log.Printf("[INFO] handling run task result: %q for run: %q stage: %q", req.TaskResultID, req.RunID, req.Stage)
+
+	var planJSON []byte
+	if req.PlanJSONAPIURL != "" {
+		var err error
+		planJSON, err = s.readPlanJSON(ctx, req)
+		if err != nil {
+			log.Printf("[ERROR] error reading plan json of run: %q error: %s", req.RunID, err)
+			s.callback(ctx, req, &Result{Status: tfe.TaskFailed, Message: fmt.Sprintf("error reading plan json: %s", err)})
+			return
+		}
+	}
+
+	result, err := s.handler.Handle(ctx, req, planJSON)
+	if err != nil {
+		log.Printf("[ERROR] error handling run task result: %q error: %s", req.TaskResultID, err)
+		result = &Result{Status: tfe.TaskFailed, Message: fmt.Sprintf("error running handler: %s", err)}
+	}
+	s.callback(ctx, req, result)
+}
+
+func (s *Server) readPlanJSON(ctx context.Context, req *tfe.RunTaskRequest) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.PlanJSONAPIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+req.AccessToken)
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s *Server) callback(ctx context.Context, req *tfe.RunTaskRequest, result *Result) {
+	err := s.callbacks.Callback(ctx, req.TaskResultCallbackURL, req.AccessToken, tfe.TaskResultCallbackRequestOptions{
+		Status:  result.Status,
+		Message: result.Message,
+		URL:     result.URL,
+	})
+	if err != nil {
+		log.Printf("[ERROR] error reporting run task result: %q error: %s", req.TaskResultID, err)
+		return
+	}
+	log.Printf("[INFO] reported run task result: %q status: %q", req.TaskResultID, result.Status)
+}
+
+// signatures are the hex encoded HMAC-SHA512 of the request body
+func VerifySignature(key []byte, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+	mac := hmac.New(sha512.New, key)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
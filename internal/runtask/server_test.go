@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package runtask
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type testHandler struct {
+	result   *Result
+	err      error
+	planJSON []byte
+}
+
+func (h *testHandler) Handle(_ context.Context, _ *tfe.RunTaskRequest, planJSON []byte) (*Result, error) {
+	h.planJSON = planJSON
+	return h.result, h.err
+}
+
+type testCallbacks struct {
+	mu          sync.Mutex
+	callbackURL string
+	accessToken string
+	options     *tfe.TaskResultCallbackRequestOptions
+}
+
+func (c *testCallbacks) Callback(_ context.Context, callbackURL, accessToken string, options tfe.TaskResultCallbackRequestOptions) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callbackURL = callbackURL
+	c.accessToken = accessToken
+	c.options = &options
+	return nil
+}
+
+func sign(key string, body []byte) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"payload_version":1}`)
+	key := []byte("secret")
+
+	if !VerifySignature(key, body, sign("secret", body)) {
+		t.Error("expected signature to be valid")
+	}
+	for name, signature := range map[string]string{
+		"wrong key": sign("other", body),
+		"not hex":   "not-a-signature",
+		"missing":   "",
+	} {
+		if VerifySignature(key, body, signature) {
+			t.Errorf("%s: expected signature to be invalid", name)
+		}
+	}
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	plans := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer run-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"format_version":"1.2"}`))
+	}))
+	defer plans.Close()
+
+	newRequest := func(accessToken string) []byte {
+		body, _ := json.Marshal(tfe.RunTaskRequest{
+			AccessToken:           accessToken,
+			PlanJSONAPIURL:        plans.URL,
+			RunID:                 "run-1",
+			Stage:                 "post_plan",
+			TaskResultCallbackURL: "https://app.terraform.io/api/v2/task-results/taskrs-1/callback",
+			TaskResultID:          "taskrs-1",
+		})
+		return body
+	}
+
+	testCases := []struct {
+		name           string
+		key            string
+		skipVerify     bool
+		body           []byte
+		signature      string
+		handler        *testHandler
+		expectedCode   int
+		expectedStatus tfe.TaskResultStatus
+		expectedPlan   string
+	}{
+		{
+			name:           "passes signed request",
+			key:            "secret",
+			body:           newRequest("run-token"),
+			signature:      sign("secret", newRequest("run-token")),
+			handler:        &testHandler{result: &Result{Status: tfe.TaskPassed, Message: "ok"}},
+			expectedCode:   http.StatusOK,
+			expectedStatus: tfe.TaskPassed,
+			expectedPlan:   `{"format_version":"1.2"}`,
+		},
+		{
+			name:         "rejects invalid signature",
+			key:          "secret",
+			body:         newRequest("run-token"),
+			signature:    sign("other", newRequest("run-token")),
+			handler:      &testHandler{},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "rejects unsigned request without a key",
+			body:         newRequest("run-token"),
+			handler:      &testHandler{},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "rejects request signed with an empty key",
+			body:         newRequest("run-token"),
+			signature:    sign("", newRequest("run-token")),
+			handler:      &testHandler{},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "skips verification when insecure",
+			skipVerify:     true,
+			body:           newRequest("run-token"),
+			handler:        &testHandler{result: &Result{Status: tfe.TaskPassed, Message: "ok"}},
+			expectedCode:   http.StatusOK,
+			expectedStatus: tfe.TaskPassed,
+			expectedPlan:   `{"format_version":"1.2"}`,
+		},
+		{
+			name:           "fails when handler errors",
+			skipVerify:     true,
+			body:           newRequest("run-token"),
+			handler:        &testHandler{err: errors.New("boom")},
+			expectedCode:   http.StatusOK,
+			expectedStatus: tfe.TaskFailed,
+			expectedPlan:   `{"format_version":"1.2"}`,
+		},
+		{
+			name:           "fails when plan json cannot be read",
+			skipVerify:     true,
+			body:           newRequest("expired-token"),
+			handler:        &testHandler{},
+			expectedCode:   http.StatusOK,
+			expectedStatus: tfe.TaskFailed,
+		},
+		{
+			name:         "acknowledges verification request",
+			skipVerify:   true,
+			body:         newRequest(verificationToken),
+			handler:      &testHandler{},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "rejects invalid body",
+			skipVerify:   true,
+			body:         []byte("{"),
+			handler:      &testHandler{},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			callbacks := &testCallbacks{}
+			server := NewServer(Options{HMACKey: tc.key, InsecureSkipVerify: tc.skipVerify, Handler: tc.handler, Callbacks: callbacks})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.body))
+			req.Header.Set(signatureHeader, tc.signature)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			server.Wait()
+
+			if rec.Code != tc.expectedCode {
+				t.Fatalf("expected response code %d, got %d", tc.expectedCode, rec.Code)
+			}
+			if tc.expectedStatus == "" {
+				if callbacks.options != nil {
+					t.Fatalf("expected no task result to be reported, got %q", callbacks.options.Status)
+				}
+				return
+			}
+			if callbacks.options == nil {
+				t.Fatal("expected task result to be reported")
+			}
+			if callbacks.options.Status != tc.expectedStatus {
+				t.Errorf("expected status %q, got %q", tc.expectedStatus, callbacks.options.Status)
+			}
+			if callbacks.callbackURL != "https://app.terraform.io/api/v2/task-results/taskrs-1/callback" {
+				t.Errorf("unexpected callback url: %q", callbacks.callbackURL)
+			}
+			if string(tc.handler.planJSON) != tc.expectedPlan {
+				t.Errorf("expected plan json %q, got %q", tc.expectedPlan, tc.handler.planJSON)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"runtime"
@@ -26,7 +27,7 @@ func main() {
 	env = environment.NewCIContext()
 
 	// setup logging
-	logging.SetupLogger(&logging.LoggerOptions{
+	logErr := logging.SetupLogger(&logging.LoggerOptions{
 		PlatformType: env.PlatformType,
 	})
 
@@ -41,6 +42,11 @@ func main() {
 		},
 	}
 
+	if logErr != nil {
+		Ui.Error(logErr.Error())
+		os.Exit(1)
+	}
+
 	appCtx = context.Background()
 
 	os.Exit(realMain())
@@ -52,6 +58,12 @@ func realMain() int {
 
 	log.Printf("[DEBUG] Preparing runner")
 	cliRunner, runError := newCliRunner()
+	if profile != nil {
+		defer stopDebugProfile(cliRunner)
+	}
+	if recorder != nil {
+		defer saveRecording(cliRunner)
+	}
 	if runError != nil {
 		Ui.Error(runError.Error())
 		return 1
@@ -66,3 +78,28 @@ func realMain() int {
 
 	return exitCode
 }
+
+func stopDebugProfile(cliRunner *cli.CLI) {
+	command := ""
+	if cliRunner != nil {
+		command = cliRunner.Subcommand()
+	}
+	dir, err := profile.stop(command)
+	if err != nil {
+		Ui.Warn(fmt.Sprintf("error writing debug profiles to %s: %s", dir, err))
+		return
+	}
+	Ui.Warn(fmt.Sprintf("Debug profiles written to: %s", dir))
+}
+
+func saveRecording(cliRunner *cli.CLI) {
+	command := ""
+	if cliRunner != nil {
+		command = cliRunner.Subcommand()
+	}
+	if err := recorder.Save(*recordFlag, command); err != nil {
+		Ui.Warn(fmt.Sprintf("error writing recording to %s: %s", *recordFlag, err))
+		return
+	}
+	Ui.Warn(fmt.Sprintf("Recording written to: %s", *recordFlag))
+}